@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -33,6 +34,45 @@ func main() {
 	}
 
 	app := server.New(cfg, pool)
+	if err := app.EnsureEventTrashSchema(ctx); err != nil {
+		log.Fatalf("event schema update failed: %v", err)
+	}
+	if err := app.EnsureUsageLogPricingSchema(ctx); err != nil {
+		log.Fatalf("usage log schema update failed: %v", err)
+	}
+	if err := app.EnsureVoiceClipResolutionSchema(ctx); err != nil {
+		log.Fatalf("voice clip schema update failed: %v", err)
+	}
+
+	jobCtx, stopJobs := context.WithCancel(ctx)
+	jobsDone := make(chan struct{})
+	go func() {
+		defer close(jobsDone)
+		var jobs sync.WaitGroup
+		if cfg.WeeklyReportJobEnabled {
+			jobs.Add(1)
+			go func() {
+				defer jobs.Done()
+				app.RunWeeklyReportScheduler(jobCtx)
+			}()
+		}
+		if cfg.FeedingReminderJobEnabled {
+			jobs.Add(1)
+			go func() {
+				defer jobs.Done()
+				app.RunFeedingReminderScheduler(jobCtx)
+			}()
+		}
+		if cfg.EventTrashPurgeJobEnabled {
+			jobs.Add(1)
+			go func() {
+				defer jobs.Done()
+				app.RunEventTrashPurgeScheduler(jobCtx)
+			}()
+		}
+		jobs.Wait()
+	}()
+
 	httpServer := &http.Server{
 		Addr:              ":" + cfg.AppPort,
 		Handler:           app.Router(),
@@ -55,4 +95,10 @@ func main() {
 	if err := httpServer.Shutdown(shutdownCtx); err != nil {
 		log.Printf("graceful shutdown failed: %v", err)
 	}
+	stopJobs()
+	select {
+	case <-jobsDone:
+	case <-shutdownCtx.Done():
+		log.Printf("background jobs did not stop before shutdown timeout")
+	}
 }
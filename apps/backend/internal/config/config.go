@@ -36,6 +36,31 @@ type Config struct {
 	OpenAIBaseURL              string
 	AIMaxOutputTokens          int
 	AITimeoutSeconds           int
+	STTProvider                string
+	STTModel                   string
+	VoiceAudioBaseURL          string
+	AIModelPricing             []string
+	AIModelAllowlist           []string
+	AIIntentMaxOutputTokens    []string
+	AIAnswerJargonTerms        []string
+	ChatDebugEndpointsEnabled  bool
+	ChatMemoryCompressEvery    int
+	ChatMemoryAISummary        bool
+	ChatMonthlyMinEvents       int
+	ChatMonthlyMinHistoryDays  int
+	ChatRawContextMaxLines     int
+	SleepZeroDurationMode      string
+	AILowBalanceThreshold      int
+	AdminUserIDs               []string
+	WeeklyReportJobEnabled     bool
+	WeeklyReportJobIntervalMin int
+	PushWebhookURL             string
+	FeedingReminderJobEnabled  bool
+	FeedingReminderIntervalMin int
+	EventTrashPurgeJobEnabled  bool
+	EventTrashPurgeIntervalMin int
+	EventDuplicateWindows      []string
+	BillingWebhookSecret       string
 }
 
 func Load() Config {
@@ -66,11 +91,36 @@ func Load() Config {
 			"CORS_ALLOW_ORIGINS",
 			[]string{"http://localhost:5173", "http://127.0.0.1:5173", "http://localhost:3000"},
 		),
-		OpenAIAPIKey:      getEnv("OPENAI_API_KEY", ""),
-		OpenAIModel:       getEnv("OPENAI_MODEL", "gpt-5-mini"),
-		OpenAIBaseURL:     getEnv("OPENAI_BASE_URL", "https://api.openai.com/v1"),
-		AIMaxOutputTokens: getEnvInt("AI_MAX_OUTPUT_TOKENS", 1200),
-		AITimeoutSeconds:  getEnvInt("AI_TIMEOUT_SECONDS", 60),
+		OpenAIAPIKey:               getEnv("OPENAI_API_KEY", ""),
+		OpenAIModel:                getEnv("OPENAI_MODEL", "gpt-5-mini"),
+		OpenAIBaseURL:              getEnv("OPENAI_BASE_URL", "https://api.openai.com/v1"),
+		AIMaxOutputTokens:          getEnvInt("AI_MAX_OUTPUT_TOKENS", 1200),
+		AITimeoutSeconds:           getEnvInt("AI_TIMEOUT_SECONDS", 60),
+		STTProvider:                getEnv("STT_PROVIDER", "openai"),
+		STTModel:                   getEnv("STT_MODEL", "gpt-4o-mini-transcribe"),
+		VoiceAudioBaseURL:          getEnv("VOICE_AUDIO_BASE_URL", ""),
+		AIModelPricing:             getEnvCSV("AI_MODEL_PRICING", nil),
+		AIModelAllowlist:           getEnvCSV("AI_MODEL_ALLOWLIST", nil),
+		AIIntentMaxOutputTokens:    getEnvCSV("AI_INTENT_MAX_OUTPUT_TOKENS", nil),
+		AIAnswerJargonTerms:        getEnvCSV("AI_ANSWER_JARGON_TERMS", nil),
+		ChatDebugEndpointsEnabled:  getEnvBool("CHAT_DEBUG_ENDPOINTS_ENABLED", false),
+		ChatMemoryCompressEvery:    getEnvInt("CHAT_MEMORY_COMPRESS_EVERY_TURNS", 10),
+		ChatMemoryAISummary:        getEnvBool("CHAT_MEMORY_AI_SUMMARY", false),
+		ChatMonthlyMinEvents:       getEnvInt("CHAT_MONTHLY_ROLLUP_MIN_EVENTS", 20),
+		ChatMonthlyMinHistoryDays:  getEnvInt("CHAT_MONTHLY_ROLLUP_MIN_HISTORY_DAYS", 7),
+		ChatRawContextMaxLines:     getEnvInt("CHAT_RAW_CONTEXT_MAX_LINES", 120),
+		SleepZeroDurationMode:      getEnv("SLEEP_ZERO_DURATION_MODE", "reject"),
+		AILowBalanceThreshold:      getEnvInt("AI_LOW_BALANCE_THRESHOLD", 50),
+		AdminUserIDs:               getEnvCSV("ADMIN_USER_IDS", nil),
+		WeeklyReportJobEnabled:     getEnvBool("WEEKLY_REPORT_JOB_ENABLED", false),
+		WeeklyReportJobIntervalMin: getEnvInt("WEEKLY_REPORT_JOB_INTERVAL_MIN", 360),
+		PushWebhookURL:             getEnv("PUSH_WEBHOOK_URL", ""),
+		FeedingReminderJobEnabled:  getEnvBool("FEEDING_REMINDER_JOB_ENABLED", false),
+		FeedingReminderIntervalMin: getEnvInt("FEEDING_REMINDER_JOB_INTERVAL_MIN", 5),
+		EventTrashPurgeJobEnabled:  getEnvBool("EVENT_TRASH_PURGE_JOB_ENABLED", false),
+		EventTrashPurgeIntervalMin: getEnvInt("EVENT_TRASH_PURGE_JOB_INTERVAL_MIN", 1440),
+		EventDuplicateWindows:      getEnvCSV("EVENT_DUPLICATE_WINDOW_SEC", nil),
+		BillingWebhookSecret:       getEnv("BILLING_WEBHOOK_SECRET", ""),
 	}
 }
 
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -32,6 +33,8 @@ type AIModelRequest struct {
 	SystemPrompt string
 	Conversation []ChatTurn
 	UserPrompt   string
+	// MaxOutputTokens overrides the client's configured cap when positive.
+	MaxOutputTokens int
 }
 
 type AIModelResponse struct {
@@ -44,6 +47,13 @@ type AIClient interface {
 	Query(ctx context.Context, req AIModelRequest) (AIModelResponse, error)
 }
 
+// AIStreamingClient is implemented by clients that can hand out the answer
+// while it is generated. onDelta receives raw answer fragments in order; the
+// returned response carries the full answer and usage exactly like Query.
+type AIStreamingClient interface {
+	QueryStream(ctx context.Context, req AIModelRequest, onDelta func(string)) (AIModelResponse, error)
+}
+
 const (
 	defaultAITimeoutSeconds = 60
 	defaultAIMaxOutputToken = 1200
@@ -102,6 +112,24 @@ func (m MockAIClient) Query(_ context.Context, req AIModelRequest) (AIModelRespo
 	}, nil
 }
 
+// QueryStream emits the mock answer word by word so streaming handlers can be
+// exercised without a provider.
+func (m MockAIClient) QueryStream(ctx context.Context, req AIModelRequest, onDelta func(string)) (AIModelResponse, error) {
+	response, err := m.Query(ctx, req)
+	if err != nil {
+		return AIModelResponse{}, err
+	}
+	for _, chunk := range strings.SplitAfter(response.Answer, " ") {
+		if err := ctx.Err(); err != nil {
+			return AIModelResponse{}, err
+		}
+		if chunk != "" && onDelta != nil {
+			onDelta(chunk)
+		}
+	}
+	return response, nil
+}
+
 func NewOpenAIResponsesClient(cfg config.Config) *OpenAIResponsesClient {
 	timeoutSeconds := cfg.AITimeoutSeconds
 	if timeoutSeconds <= 0 {
@@ -134,14 +162,6 @@ func (c *OpenAIResponsesClient) Query(ctx context.Context, req AIModelRequest) (
 		requestModel = defaultModel
 	}
 
-	type inputText struct {
-		Type string `json:"type"`
-		Text string `json:"text"`
-	}
-	type inputBlock struct {
-		Role    string      `json:"role"`
-		Content []inputText `json:"content"`
-	}
 	hasAssistantTurn := false
 	for _, turn := range req.Conversation {
 		if strings.EqualFold(strings.TrimSpace(turn.Role), "assistant") {
@@ -150,51 +170,15 @@ func (c *OpenAIResponsesClient) Query(ctx context.Context, req AIModelRequest) (
 		}
 	}
 
-	buildInput := func(includeAssistantTurns bool) []inputBlock {
-		input := make([]inputBlock, 0, len(req.Conversation)+2)
-		if strings.TrimSpace(req.SystemPrompt) != "" {
-			input = append(input, inputBlock{
-				Role:    "system",
-				Content: []inputText{{Type: "input_text", Text: strings.TrimSpace(req.SystemPrompt)}},
-			})
-		}
-		for _, turn := range req.Conversation {
-			role := strings.ToLower(strings.TrimSpace(turn.Role))
-			if role != "user" && role != "assistant" {
-				continue
-			}
-			if role == "assistant" && !includeAssistantTurns {
-				continue
-			}
-			content := strings.TrimSpace(turn.Content)
-			if content == "" {
-				continue
-			}
-			contentType := "input_text"
-			if role == "assistant" {
-				contentType = "output_text"
-			}
-			input = append(input, inputBlock{
-				Role:    role,
-				Content: []inputText{{Type: contentType, Text: content}},
-			})
-		}
-		userPrompt := strings.TrimSpace(req.UserPrompt)
-		if userPrompt != "" {
-			input = append(input, inputBlock{
-				Role:    "user",
-				Content: []inputText{{Type: "input_text", Text: userPrompt}},
-			})
-		}
-		return input
-	}
-
 	maxTokens := c.maxOutputTokens
+	if req.MaxOutputTokens > 0 {
+		maxTokens = req.MaxOutputTokens
+	}
 	if maxTokens <= 0 {
 		maxTokens = defaultAIMaxOutputToken
 	}
 
-	callResponses := func(input []inputBlock, outputTokens int) (int, []byte, error) {
+	callResponses := func(input []responsesInputBlock, outputTokens int) (int, []byte, error) {
 		if len(input) == 0 {
 			return 0, nil, errors.New("AI request input is empty")
 		}
@@ -239,7 +223,7 @@ func (c *OpenAIResponsesClient) Query(ctx context.Context, req AIModelRequest) (
 		return response.StatusCode, responseBody, nil
 	}
 
-	callResponsesWithRetry := func(input []inputBlock, outputTokens int) (int, []byte, error) {
+	callResponsesWithRetry := func(input []responsesInputBlock, outputTokens int) (int, []byte, error) {
 		maxAttempts := openAIRequestMaxRetries + 1
 		for attempt := 1; attempt <= maxAttempts; attempt++ {
 			statusCode, responseBody, err := callResponses(input, outputTokens)
@@ -268,7 +252,7 @@ func (c *OpenAIResponsesClient) Query(ctx context.Context, req AIModelRequest) (
 		return 0, nil, errors.New("openai request exhausted retries")
 	}
 
-	input := buildInput(true)
+	input := buildResponsesInput(req, true)
 	statusCode, responseBody, err := callResponsesWithRetry(input, maxTokens)
 	if err != nil {
 		return AIModelResponse{}, err
@@ -280,7 +264,7 @@ func (c *OpenAIResponsesClient) Query(ctx context.Context, req AIModelRequest) (
 			strings.Contains(bodyText, "Invalid value: 'input_text'") &&
 			strings.Contains(bodyText, "Supported values are: 'output_text' and 'refusal'")
 		if shouldRetryWithoutAssistant {
-			retryInput := buildInput(false)
+			retryInput := buildResponsesInput(req, false)
 			retryStatusCode, retryResponseBody, retryErr := callResponsesWithRetry(retryInput, maxTokens)
 			if retryErr == nil && retryStatusCode >= 200 && retryStatusCode < 300 {
 				statusCode = retryStatusCode
@@ -354,6 +338,199 @@ func (c *OpenAIResponsesClient) Query(ctx context.Context, req AIModelRequest) (
 	}, nil
 }
 
+// QueryStream sends the request with stream=true and forwards each
+// response.output_text.delta to onDelta. Unlike Query it does not retry:
+// once fragments reach the caller a second attempt would repeat them.
+func (c *OpenAIResponsesClient) QueryStream(ctx context.Context, req AIModelRequest, onDelta func(string)) (AIModelResponse, error) {
+	if strings.TrimSpace(c.apiKey) == "" {
+		return AIModelResponse{}, errors.New("OPENAI_API_KEY is not configured")
+	}
+	if strings.TrimSpace(c.baseURL) == "" {
+		return AIModelResponse{}, errors.New("OPENAI_BASE_URL is not configured")
+	}
+	requestModel := strings.TrimSpace(req.Model)
+	if requestModel == "" {
+		requestModel = strings.TrimSpace(c.model)
+	}
+	if requestModel == "" {
+		return AIModelResponse{}, errors.New("OPENAI_MODEL is not configured")
+	}
+	maxTokens := c.maxOutputTokens
+	if req.MaxOutputTokens > 0 {
+		maxTokens = req.MaxOutputTokens
+	}
+	if maxTokens <= 0 {
+		maxTokens = defaultAIMaxOutputToken
+	}
+	input := buildResponsesInput(req, true)
+	if len(input) == 0 {
+		return AIModelResponse{}, errors.New("AI request input is empty")
+	}
+
+	bodyRaw, err := json.Marshal(map[string]any{
+		"model":             requestModel,
+		"input":             input,
+		"max_output_tokens": maxTokens,
+		"stream":            true,
+		"reasoning": map[string]any{
+			"effort": "low",
+		},
+		"text": map[string]any{
+			"verbosity": "low",
+		},
+	})
+	if err != nil {
+		return AIModelResponse{}, err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/responses", bytes.NewReader(bodyRaw))
+	if err != nil {
+		return AIModelResponse{}, err
+	}
+	request.Header.Set("Authorization", "Bearer "+c.apiKey)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "text/event-stream")
+
+	// The client timeout covers the whole body read, which for a stream is
+	// the whole answer; it still bounds a stalled provider.
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return AIModelResponse{}, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		responseBody, _ := io.ReadAll(response.Body)
+		return AIModelResponse{}, fmt.Errorf("openai responses error (%d): %s", response.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+
+	var answer strings.Builder
+	var final map[string]any
+	scanner := bufio.NewScanner(response.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "" || data == "[DONE]" {
+			continue
+		}
+		event := parseJSONStringMap([]byte(data))
+		switch toString(event["type"]) {
+		case "response.output_text.delta":
+			delta := toString(event["delta"])
+			if delta == "" {
+				continue
+			}
+			answer.WriteString(delta)
+			if onDelta != nil {
+				onDelta(delta)
+			}
+		case "response.completed", "response.incomplete":
+			final, _ = event["response"].(map[string]any)
+		case "response.failed":
+			failed, _ := event["response"].(map[string]any)
+			return AIModelResponse{}, fmt.Errorf("openai responses error (stream): %s", truncateForLog(mustMarshalJSON(failed["error"]), 600))
+		case "error":
+			return AIModelResponse{}, fmt.Errorf("openai responses error (stream): %s", truncateForLog(data, 600))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return AIModelResponse{}, err
+	}
+	if final == nil {
+		if err := ctx.Err(); err != nil {
+			return AIModelResponse{}, err
+		}
+		return AIModelResponse{}, errors.New("openai responses error (stream): ended without a final response")
+	}
+
+	answerText := answer.String()
+	if strings.TrimSpace(answerText) == "" {
+		if isMaxOutputTokenIncomplete(final) {
+			return AIModelResponse{}, errors.New("openai response incomplete due max_output_tokens")
+		}
+		answerText = extractResponseAnswer(final)
+		if strings.TrimSpace(answerText) == "" {
+			return AIModelResponse{}, errors.New("openai response answer is empty")
+		}
+		if onDelta != nil {
+			onDelta(answerText)
+		}
+	}
+
+	usageMap, _ := final["usage"].(map[string]any)
+	totalTokens := int(extractNumberFromMap(usageMap, "total_tokens"))
+	if totalTokens <= 0 {
+		return AIModelResponse{}, errors.New("openai response missing token usage")
+	}
+	modelName := strings.TrimSpace(toString(final["model"]))
+	if modelName == "" {
+		modelName = requestModel
+	}
+	return AIModelResponse{
+		Answer: answerText,
+		Model:  modelName,
+		Usage: AIUsage{
+			PromptTokens:     int(extractNumberFromMap(usageMap, "input_tokens", "prompt_tokens")),
+			CompletionTokens: int(extractNumberFromMap(usageMap, "output_tokens", "completion_tokens")),
+			TotalTokens:      totalTokens,
+		},
+	}, nil
+}
+
+type responsesInputText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type responsesInputBlock struct {
+	Role    string               `json:"role"`
+	Content []responsesInputText `json:"content"`
+}
+
+// buildResponsesInput turns a request into Responses API input blocks.
+// Assistant turns are sent as output_text; includeAssistantTurns=false drops
+// them for models that reject prior assistant content.
+func buildResponsesInput(req AIModelRequest, includeAssistantTurns bool) []responsesInputBlock {
+	input := make([]responsesInputBlock, 0, len(req.Conversation)+2)
+	if strings.TrimSpace(req.SystemPrompt) != "" {
+		input = append(input, responsesInputBlock{
+			Role:    "system",
+			Content: []responsesInputText{{Type: "input_text", Text: strings.TrimSpace(req.SystemPrompt)}},
+		})
+	}
+	for _, turn := range req.Conversation {
+		role := strings.ToLower(strings.TrimSpace(turn.Role))
+		if role != "user" && role != "assistant" {
+			continue
+		}
+		if role == "assistant" && !includeAssistantTurns {
+			continue
+		}
+		content := strings.TrimSpace(turn.Content)
+		if content == "" {
+			continue
+		}
+		contentType := "input_text"
+		if role == "assistant" {
+			contentType = "output_text"
+		}
+		input = append(input, responsesInputBlock{
+			Role:    role,
+			Content: []responsesInputText{{Type: contentType, Text: content}},
+		})
+	}
+	userPrompt := strings.TrimSpace(req.UserPrompt)
+	if userPrompt != "" {
+		input = append(input, responsesInputBlock{
+			Role:    "user",
+			Content: []responsesInputText{{Type: "input_text", Text: userPrompt}},
+		})
+	}
+	return input
+}
+
 func extractResponseAnswer(data map[string]any) string {
 	direct := strings.TrimSpace(toString(data["output_text"]))
 	if direct != "" {
@@ -97,6 +97,49 @@ func TestOpenAIResponsesClientHonorsConfiguredMaxOutputTokens(t *testing.T) {
 	}
 }
 
+func TestOpenAIResponsesClientPrefersRequestMaxOutputTokens(t *testing.T) {
+	t.Parallel()
+
+	var receivedMaxTokens int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode request payload: %v", err)
+		}
+		receivedMaxTokens = int(extractNumber(payload["max_output_tokens"]))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"model":"gpt-5-mini",
+			"output":[{"content":[{"type":"output_text","text":"ok"}]}],
+			"usage":{"input_tokens":8,"output_tokens":3,"total_tokens":11}
+		}`))
+	}))
+	defer server.Close()
+
+	client := &OpenAIResponsesClient{
+		apiKey:          "test",
+		baseURL:         server.URL,
+		model:           "gpt-5-mini",
+		maxOutputTokens: 1200,
+		httpClient: &http.Client{
+			Timeout: 2 * time.Second,
+		},
+	}
+
+	_, err := client.Query(context.Background(), AIModelRequest{
+		Model:           "gpt-5-mini",
+		UserPrompt:      "token test",
+		MaxOutputTokens: 400,
+	})
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if receivedMaxTokens != 400 {
+		t.Fatalf("expected max_output_tokens=400, got %d", receivedMaxTokens)
+	}
+}
+
 func TestOpenAIResponsesClientRetriesWithHigherTokenBudgetOnIncomplete(t *testing.T) {
 	t.Parallel()
 
@@ -175,3 +218,44 @@ func extractNumber(value any) float64 {
 		return 0
 	}
 }
+
+func TestOpenAIResponsesClientQueryStreamForwardsDeltas(t *testing.T) {
+	t.Parallel()
+
+	var streamRequested bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		streamRequested, _ = payload["stream"].(bool)
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("event: response.created\ndata: {\"type\":\"response.created\"}\n\n"))
+		_, _ = w.Write([]byte("event: response.output_text.delta\ndata: {\"type\":\"response.output_text.delta\",\"delta\":\"Slept \"}\n\n"))
+		_, _ = w.Write([]byte("event: response.output_text.delta\ndata: {\"type\":\"response.output_text.delta\",\"delta\":\"11 hours.\"}\n\n"))
+		_, _ = w.Write([]byte("event: response.completed\ndata: {\"type\":\"response.completed\",\"response\":{\"model\":\"gpt-5-mini\",\"usage\":{\"input_tokens\":10,\"output_tokens\":4,\"total_tokens\":14}}}\n\n"))
+	}))
+	defer server.Close()
+
+	client := &OpenAIResponsesClient{
+		apiKey:     "test",
+		baseURL:    server.URL,
+		model:      "gpt-5-mini",
+		httpClient: &http.Client{Timeout: 2 * time.Second},
+	}
+
+	deltas := []string{}
+	resp, err := client.QueryStream(context.Background(), AIModelRequest{UserPrompt: "hello"}, func(delta string) {
+		deltas = append(deltas, delta)
+	})
+	if err != nil {
+		t.Fatalf("expected stream to succeed, got err=%v", err)
+	}
+	if !streamRequested {
+		t.Fatalf("expected stream=true in the request payload")
+	}
+	if len(deltas) != 2 || resp.Answer != "Slept 11 hours." {
+		t.Fatalf("expected two deltas joined into the answer, got %q / %q", deltas, resp.Answer)
+	}
+	if resp.Usage.TotalTokens != 14 {
+		t.Fatalf("expected usage from the completed event, got %+v", resp.Usage)
+	}
+}
@@ -55,9 +55,22 @@ type dbQuerier interface {
 }
 
 type App struct {
-	cfg config.Config
-	db  *pgxpool.Pool
-	ai  AIClient
+	cfg      config.Config
+	db       *pgxpool.Pool
+	ai       AIClient
+	aiHealth *aiHealthCounters
+	// stt transcribes voice uploads; nil when STT_PROVIDER=none.
+	stt SpeechToTextClient
+	// tokenEstimator prices chat queries for the estimate endpoint; nil uses
+	// charRatioTokenEstimator.
+	tokenEstimator chatTokenEstimator
+	// push delivers feeding reminders; nil disables sending.
+	push PushNotifier
+	// modelPricing is AI_MODEL_PRICING parsed once at startup.
+	modelPricing map[string]modelPricing
+	// jargonTerms is AI_ANSWER_JARGON_TERMS compiled once at startup; empty
+	// uses defaultJargonTerms.
+	jargonTerms []jargonTerm
 }
 
 type AuthUser struct {
@@ -70,12 +83,27 @@ type AuthUser struct {
 
 func New(cfg config.Config, db *pgxpool.Pool) *App {
 	var aiClient AIClient
+	var sttClient SpeechToTextClient
+	var pushNotifier PushNotifier
 	if strings.EqualFold(cfg.AppEnv, "test") {
 		aiClient = MockAIClient{Model: cfg.OpenAIModel}
+		sttClient = MockSpeechToTextClient{}
+		pushNotifier = MockPushNotifier{}
 	} else {
 		aiClient = NewOpenAIResponsesClient(cfg)
+		sttClient = newSpeechToTextClient(cfg)
+		pushNotifier = NewWebhookPushNotifier(cfg)
+	}
+	return &App{
+		cfg:          cfg,
+		db:           db,
+		ai:           aiClient,
+		aiHealth:     newAIHealthCounters(),
+		stt:          sttClient,
+		push:         pushNotifier,
+		modelPricing: parseModelPricingTable(cfg.AIModelPricing),
+		jargonTerms:  parseJargonTerms(cfg.AIAnswerJargonTerms),
 	}
-	return &App{cfg: cfg, db: db, ai: aiClient}
 }
 
 func (a *App) Router() *gin.Engine {
@@ -84,8 +112,8 @@ func (a *App) Router() *gin.Engine {
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     a.cfg.CORSAllowOrigins,
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Authorization", "Content-Type"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Authorization", "Content-Type", idempotencyKeyHeader},
+		ExposeHeaders:    []string{"Content-Length", idempotencyReplayHeader},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
@@ -94,6 +122,8 @@ func (a *App) Router() *gin.Engine {
 	router.GET("/dev/local-token", a.issueLocalDevToken)
 	router.POST("/dev/local-token", a.issueLocalDevToken)
 	router.POST("/auth/test-login", a.testLogin)
+	// Payment providers authenticate with a signature, not a bearer token.
+	router.POST(a.cfg.APIPrefix+"/billing/webhook", a.handleBillingWebhook)
 
 	api := router.Group(a.cfg.APIPrefix)
 	api.Use(a.authMiddleware())
@@ -101,38 +131,95 @@ func (a *App) Router() *gin.Engine {
 	api.POST("/onboarding/parent", a.onboardingParent)
 	api.POST("/events/voice", a.parseVoiceEvent)
 	api.POST("/events/confirm", a.confirmEvents)
+	api.GET("/voice/clips", a.listVoiceClips)
+	api.POST("/voice/clips/:clip_id/reparse", a.reparseVoiceClip)
 	api.POST("/events/manual", a.createManualEvent)
+	api.POST("/events/bulk", a.createManualEventsBulk)
+	api.POST("/events/validate", a.validateEvent)
 	api.POST("/events/start", a.startManualEvent)
+	api.POST("/events/merge", a.mergeEvents)
 	api.PATCH("/events/:event_id", a.updateManualEvent)
 	api.PATCH("/events/:event_id/complete", a.completeManualEvent)
 	api.PATCH("/events/:event_id/cancel", a.cancelManualEvent)
+	api.DELETE("/events/:event_id", a.deleteManualEvent)
+	api.POST("/events/:event_id/restore", a.restoreManualEvent)
+	api.GET("/events/:event_id/history", a.getEventHistory)
+	api.GET("/events", a.listEvents)
 	api.GET("/events/open", a.listOpenEvents)
+	api.GET("/events/open/stale", a.getStaleOpenEvents)
 	api.GET("/settings/me", a.getMySettings)
 	api.PATCH("/settings/me", a.upsertMySettings)
 	api.GET("/data/export.csv", a.exportBabyDataCSV)
+	api.GET("/events/export", a.exportEventsCSV)
+	api.GET("/households/:household_id/dashboard", a.getHouseholdDashboard)
+	api.GET("/households/:household_id/open-events", a.getHouseholdOpenEvents)
+	api.POST("/households/:household_id/invites", a.createHouseholdInvite)
+	api.POST("/households/invites/:token/accept", a.acceptHouseholdInvite)
 	api.GET("/babies/profile", a.getBabyProfile)
 	api.PATCH("/babies/profile", a.upsertBabyProfile)
+	api.GET("/babies/:baby_id/recommendation-audit", a.getRecommendationAudit)
+	api.GET("/babies/:baby_id/remaining-formula", a.getRemainingFormula)
+	api.GET("/babies/:baby_id/formula-prep", a.getFormulaPrep)
+	api.GET("/babies/:baby_id/completeness", a.getDataCompleteness)
+	api.GET("/babies/:baby_id/timeline", a.getTimeline)
+	api.GET("/babies/:baby_id/field-series", a.getFieldSeries)
+	api.GET("/babies/:baby_id/recent", a.getRecentByType)
+	api.GET("/babies/:baby_id/feeding-efficiency", a.getFeedingEfficiency)
+	api.GET("/babies/:baby_id/nap-night-ratio", a.getNapNightRatio)
+	api.GET("/babies/:baby_id/schedule-shift", a.getScheduleShift)
+	api.GET("/babies/:baby_id/low-confidence", a.getLowConfidenceEvents)
+	api.GET("/babies/:baby_id/milestones", a.getMilestones)
+	api.GET("/babies/:baby_id/next-nap", a.getNextNapWindow)
+	api.POST("/babies/:baby_id/events/shift", a.shiftEventTimes)
+	api.POST("/babies/:baby_id/stats/dates", a.getStatsForDates)
 	api.GET("/quick/last-poo-time", a.quickLastPooTime)
+	api.GET("/quick/last-feeding", a.quickLastFeeding)
+	api.GET("/quick/last-symptom", a.quickLastSymptom)
 	api.GET("/quick/next-feeding-eta", a.quickNextFeedingETA)
+	api.GET("/quick/feeding-intervals", a.quickFeedingIntervals)
 	api.GET("/quick/today-summary", a.quickTodaySummary)
 	api.GET("/quick/landing-snapshot", a.quickLandingSnapshot)
+	api.GET("/quick/household-snapshot", a.quickHouseholdSnapshot)
 	api.POST("/ai/query", a.aiQuery)
+	api.GET("/ai/capabilities", a.getAICapabilities)
 	api.POST("/chat/sessions", a.createChatSession)
 	api.GET("/chat/sessions", a.listChatSessions)
 	api.POST("/chat/sessions/:session_id/messages", a.createChatMessage)
 	api.GET("/chat/sessions/:session_id/messages", a.getChatMessages)
+	api.GET("/chat/sessions/:session_id/export", a.exportChatSession)
+	api.PATCH("/chat/sessions/:session_id", a.updateChatSession)
+	api.DELETE("/chat/sessions/:session_id", a.deleteChatSession)
+	api.POST("/chat/sessions/:session_id/archive", a.archiveChatSession)
+	api.POST("/chat/sessions/:session_id/unarchive", a.unarchiveChatSession)
+	api.POST("/chat/sessions/:session_id/fork", a.forkChatSession)
+	api.POST("/chat/sessions/:session_id/reclassify", a.reclassifyChatSession)
+	api.POST("/chat/sessions/:session_id/regenerate", a.regenerateChatAnswer)
+	api.GET("/chat/sessions/:session_id/style-hint", a.getSessionStyleHint)
+	api.POST("/chat/classify", a.classifyChatQuestion)
+	api.GET("/chat/search", a.searchChatMessages)
 	api.POST("/chat/query", a.chatQuery)
+	api.POST("/chat/query/stream", a.chatQueryStream)
+	api.POST("/chat/query/estimate", a.estimateChatQuery)
 	api.GET("/reports/daily", a.getDailyReport)
 	api.GET("/reports/weekly", a.getWeeklyReport)
+	api.GET("/reports/monthly", a.getMonthlyReport)
+	api.GET("/reports/growth", a.getGrowthReport)
+	api.GET("/reports/growth-series", a.getGrowthSeries)
 	api.POST("/photos/upload-url", a.createPhotoUploadURL)
 	api.POST("/photos/complete", a.completePhotoUpload)
 	api.GET("/subscription/me", a.getMySubscription)
 	api.POST("/subscription/checkout", a.checkoutSubscription)
+	api.PATCH("/subscription/ai-model", a.updateSubscriptionAIModel)
+	api.GET("/billing/usage", a.getBillingUsage)
+	api.POST("/reminders/feeding/subscribe", a.subscribeFeedingReminders)
+	api.POST("/reminders/feeding/unsubscribe", a.unsubscribeFeedingReminders)
 	api.POST("/assistants/siri/GetLastPooTime", a.siriLastPoo)
 	api.POST("/assistants/siri/GetNextFeedingEta", a.siriNextFeeding)
 	api.POST("/assistants/siri/GetTodaySummary", a.siriTodaySummary)
 	api.POST("/assistants/siri/:intent_name", a.siriDynamic)
 	api.POST("/assistants/bixby/query", a.bixbyQuery)
+	api.POST("/assistants/alexa", a.alexaQuery)
+	api.GET("/admin/ai-health", a.getAIHealth)
 
 	return router
 }
@@ -698,34 +785,17 @@ type etaCalculation struct {
 	Unstable               bool
 }
 
+const (
+	feedingETAModeMean     = "mean"
+	feedingETAModeWeighted = "weighted"
+	// feedingETAWeightDecay is the weight of each interval relative to the
+	// next, more recent one in weighted mode.
+	feedingETAWeightDecay = 0.7
+)
+
 func calculateNextFeedingETA(feedings []time.Time, now time.Time) etaCalculation {
 	normalizedNow := now.UTC()
-	// Ignore future feedings so ETA is always anchored to "current" time.
-	candidates := make([]time.Time, 0, len(feedings))
-	for _, feedingAt := range feedings {
-		ts := feedingAt.UTC()
-		if ts.After(normalizedNow) {
-			continue
-		}
-		candidates = append(candidates, ts)
-	}
-	if len(candidates) < 2 {
-		return etaCalculation{Unstable: true}
-	}
-	ordered := make([]time.Time, len(candidates))
-	copy(ordered, candidates)
-	sort.Slice(ordered, func(i, j int) bool {
-		return ordered[i].Before(ordered[j])
-	})
-
-	intervals := make([]float64, 0, len(ordered)-1)
-	for idx := 1; idx < len(ordered); idx++ {
-		gapMinutes := ordered[idx].Sub(ordered[idx-1]).Minutes()
-		if gapMinutes <= 0 {
-			continue
-		}
-		intervals = append(intervals, gapMinutes)
-	}
+	ordered, intervals := feedingIntervals(feedings, normalizedNow)
 	if len(intervals) == 0 {
 		return etaCalculation{Unstable: true}
 	}
@@ -750,20 +820,87 @@ func calculateNextFeedingETA(feedings []time.Time, now time.Time) etaCalculation
 		total += interval
 	}
 	avg := int(math.Round(total / float64(len(intervals))))
-	if avg <= 0 {
+	return projectFeedingETA(ordered[len(ordered)-1], avg, normalizedNow)
+}
+
+// calculateWeightedNextFeedingETA weights recent intervals more heavily, each
+// older one by feedingETAWeightDecay, so a daytime estimate follows the
+// current rhythm. With three or more intervals the single longest is dropped
+// as a likely overnight gap.
+func calculateWeightedNextFeedingETA(feedings []time.Time, now time.Time) etaCalculation {
+	normalizedNow := now.UTC()
+	ordered, intervals := feedingIntervals(feedings, normalizedNow)
+	if len(intervals) == 0 {
 		return etaCalculation{Unstable: true}
 	}
 
-	lastFeeding := ordered[len(ordered)-1]
+	if len(intervals) >= 3 {
+		longest := 0
+		for idx, interval := range intervals {
+			if interval > intervals[longest] {
+				longest = idx
+			}
+		}
+		trimmed := make([]float64, 0, len(intervals)-1)
+		trimmed = append(trimmed, intervals[:longest]...)
+		intervals = append(trimmed, intervals[longest+1:]...)
+	}
+
+	weightedTotal := 0.0
+	weightTotal := 0.0
+	weight := 1.0
+	for idx := len(intervals) - 1; idx >= 0; idx-- {
+		weightedTotal += intervals[idx] * weight
+		weightTotal += weight
+		weight *= feedingETAWeightDecay
+	}
+	avg := int(math.Round(weightedTotal / weightTotal))
+	return projectFeedingETA(ordered[len(ordered)-1], avg, normalizedNow)
+}
+
+// feedingIntervals returns past feedings oldest first and the positive gaps
+// between them in minutes, in the same order.
+func feedingIntervals(feedings []time.Time, now time.Time) ([]time.Time, []float64) {
+	// Ignore future feedings so ETA is always anchored to "current" time.
+	ordered := make([]time.Time, 0, len(feedings))
+	for _, feedingAt := range feedings {
+		ts := feedingAt.UTC()
+		if ts.After(now) {
+			continue
+		}
+		ordered = append(ordered, ts)
+	}
+	if len(ordered) < 2 {
+		return ordered, nil
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].Before(ordered[j])
+	})
+
+	intervals := make([]float64, 0, len(ordered)-1)
+	for idx := 1; idx < len(ordered); idx++ {
+		gapMinutes := ordered[idx].Sub(ordered[idx-1]).Minutes()
+		if gapMinutes <= 0 {
+			continue
+		}
+		intervals = append(intervals, gapMinutes)
+	}
+	return ordered, intervals
+}
+
+func projectFeedingETA(lastFeeding time.Time, avg int, now time.Time) etaCalculation {
+	if avg <= 0 {
+		return etaCalculation{Unstable: true}
+	}
 	expected := lastFeeding.Add(time.Duration(avg) * time.Minute)
 	// Keep projecting by average interval until expected is in the future.
-	if expected.Before(normalizedNow) {
-		overdueMinutes := normalizedNow.Sub(expected).Minutes()
+	if expected.Before(now) {
+		overdueMinutes := now.Sub(expected).Minutes()
 		cycles := int(overdueMinutes/float64(avg)) + 1
 		expected = expected.Add(time.Duration(cycles*avg) * time.Minute)
 	}
 
-	eta := int(math.Ceil(expected.Sub(normalizedNow).Minutes()))
+	eta := int(math.Ceil(expected.Sub(now).Minutes()))
 	if eta < 0 {
 		eta = 0
 	}
@@ -63,3 +63,58 @@ func TestBabyProfileUpsertAndGet(t *testing.T) {
 		t.Fatalf("expected formula_catalog in response")
 	}
 }
+
+func TestRecommendationAuditExposesInputsAndSteps(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	seedEvent(t, "", fixture.BabyID, "FORMULA", time.Now().UTC().Add(-2*time.Hour), nil, map[string]any{"ml": 120}, fixture.UserID)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/babies/"+fixture.BabyID+"/recommendation-audit",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	inputs, ok := body["inputs"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected inputs object, got %T", body["inputs"])
+	}
+	if inputs["weight_source"] != "age_fallback" {
+		t.Fatalf("expected weight_source=age_fallback without saved weight, got %v", inputs["weight_source"])
+	}
+	if inputs["last_feeding_time"] == nil {
+		t.Fatalf("expected last_feeding_time from seeded formula event")
+	}
+	if steps := decodeStringList(t, body["steps"]); len(steps) == 0 {
+		t.Fatalf("expected calculation steps")
+	}
+
+	profileRec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/babies/profile?baby_id="+fixture.BabyID,
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if profileRec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", profileRec.Code, profileRec.Body.String())
+	}
+	profileBody := decodeJSONMap(t, profileRec)
+	result, _ := body["result"].(map[string]any)
+	if result["recommended_formula_per_feed_ml"] != profileBody["recommended_formula_per_feed_ml"] {
+		t.Fatalf(
+			"expected audit result to match profile recommendation, audit=%v profile=%v",
+			result["recommended_formula_per_feed_ml"],
+			profileBody["recommended_formula_per_feed_ml"],
+		)
+	}
+}
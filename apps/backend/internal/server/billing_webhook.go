@@ -0,0 +1,322 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	billingWebhookSignatureHeader = "X-Billing-Signature"
+	// billingWebhookTolerance is how far the signed timestamp may drift from
+	// now. Older deliveries are treated as replays.
+	billingWebhookTolerance = 5 * time.Minute
+	billingWebhookMaxBody   = 1 << 20
+)
+
+var (
+	errBillingWebhookSignature = errors.New("Invalid webhook signature")
+	errBillingWebhookStale     = errors.New("Webhook timestamp is outside the allowed window")
+)
+
+// billingWebhookStatuses are the subscription states a provider event may
+// move a subscription to. TRIALING is only ever set by checkout.
+var billingWebhookStatuses = map[string]struct{}{
+	"ACTIVE":   {},
+	"PAST_DUE": {},
+	"CANCELED": {},
+}
+
+type billingWebhookEvent struct {
+	ID         string     `json:"id"`
+	Type       string     `json:"type"`
+	ExternalID string     `json:"external_id"`
+	Status     string     `json:"status"`
+	RenewAt    *time.Time `json:"renew_at"`
+	// CreatedAt is when the provider raised the event. Deliveries without it
+	// are ordered by their signed timestamp instead.
+	CreatedAt  *time.Time `json:"created_at"`
+	OccurredAt time.Time  `json:"-"`
+}
+
+type billingWebhookResult struct {
+	SubscriptionID string
+	HouseholdID    string
+	PreviousStatus string
+	Status         string
+	Duplicate      bool
+	Stale          bool
+}
+
+// signBillingWebhookPayload returns the hex HMAC-SHA256 of "<timestamp>.<body>".
+func signBillingWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyBillingWebhookSignature checks a "t=<unix seconds>,v1=<hex>" header
+// and returns the signed time. Several v1 entries are accepted so the
+// provider can rotate secrets.
+func verifyBillingWebhookSignature(secret, header string, body []byte, now time.Time) (time.Time, error) {
+	var timestamp int64
+	signatures := make([]string, 0, 1)
+	for _, part := range strings.Split(header, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "t":
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return time.Time{}, errBillingWebhookSignature
+			}
+			timestamp = parsed
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == 0 || len(signatures) == 0 {
+		return time.Time{}, errBillingWebhookSignature
+	}
+	expected := signBillingWebhookPayload(secret, timestamp, body)
+	matched := false
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return time.Time{}, errBillingWebhookSignature
+	}
+	signedAt := time.Unix(timestamp, 0)
+	if now.Sub(signedAt) > billingWebhookTolerance || signedAt.Sub(now) > billingWebhookTolerance {
+		return time.Time{}, errBillingWebhookStale
+	}
+	return signedAt, nil
+}
+
+// handleBillingWebhook applies a payment provider event to the Subscription
+// whose externalId it names. It sits outside the bearer-token group; the
+// shared-secret signature is the only authentication.
+func (a *App) handleBillingWebhook(c *gin.Context) {
+	secret := strings.TrimSpace(a.cfg.BillingWebhookSecret)
+	if secret == "" {
+		writeError(c, http.StatusServiceUnavailable, "Billing webhook is not configured")
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, billingWebhookMaxBody))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, "Failed to read webhook body")
+		return
+	}
+	signedAt, err := verifyBillingWebhookSignature(secret, c.GetHeader(billingWebhookSignatureHeader), body, time.Now())
+	if err != nil {
+		writeError(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var event billingWebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid webhook payload")
+		return
+	}
+	event.ID = strings.TrimSpace(event.ID)
+	event.ExternalID = strings.TrimSpace(event.ExternalID)
+	event.Status = strings.ToUpper(strings.TrimSpace(event.Status))
+	if event.ID == "" || event.ExternalID == "" {
+		writeError(c, http.StatusBadRequest, "id and external_id are required")
+		return
+	}
+	if _, ok := billingWebhookStatuses[event.Status]; !ok {
+		writeError(c, http.StatusBadRequest, "status must be one of: ACTIVE, PAST_DUE, CANCELED")
+		return
+	}
+	event.OccurredAt = signedAt.UTC()
+	if event.CreatedAt != nil {
+		event.OccurredAt = event.CreatedAt.UTC()
+	}
+
+	result, err := a.applyBillingWebhookEvent(c.Request.Context(), event)
+	if err != nil && isMissingBillingWebhookSchemaErr(err) {
+		if ensureErr := a.ensureBillingWebhookSchema(c.Request.Context()); ensureErr != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to prepare billing webhook storage")
+			return
+		}
+		result, err = a.applyBillingWebhookEvent(c.Request.Context(), event)
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		writeError(c, http.StatusNotFound, "Subscription not found")
+		return
+	}
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to apply billing webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"received":        true,
+		"duplicate":       result.Duplicate,
+		"stale":           result.Stale,
+		"subscription_id": result.SubscriptionID,
+		"status":          result.Status,
+	})
+}
+
+// applyBillingWebhookEvent records the event id and moves the subscription
+// to the event's status in one transaction. An event id seen before changes
+// nothing, so provider retries are safe, and an event older than the last one
+// applied is recorded but leaves the subscription alone, so a delayed
+// PAST_DUE cannot undo a newer ACTIVE.
+func (a *App) applyBillingWebhookEvent(ctx context.Context, event billingWebhookEvent) (billingWebhookResult, error) {
+	tx, err := a.db.Begin(ctx)
+	if err != nil {
+		return billingWebhookResult{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	var result billingWebhookResult
+	var lastEventAt *time.Time
+	if err := tx.QueryRow(
+		ctx,
+		`SELECT id, "householdId", status::text, "lastEventAt"
+		 FROM "Subscription"
+		 WHERE "externalId" = $1
+		 FOR UPDATE`,
+		event.ExternalID,
+	).Scan(&result.SubscriptionID, &result.HouseholdID, &result.PreviousStatus, &lastEventAt); err != nil {
+		return billingWebhookResult{}, err
+	}
+
+	tag, err := tx.Exec(
+		ctx,
+		`INSERT INTO "BillingWebhookEvent" (id, "subscriptionId", type, status, "receivedAt")
+		 VALUES ($1, $2, $3, $4, NOW())
+		 ON CONFLICT (id) DO NOTHING`,
+		event.ID,
+		result.SubscriptionID,
+		strings.TrimSpace(event.Type),
+		event.Status,
+	)
+	if err != nil {
+		return billingWebhookResult{}, err
+	}
+	if tag.RowsAffected() == 0 {
+		result.Duplicate = true
+		result.Status = normalizeSubscriptionStatus(result.PreviousStatus)
+		return result, nil
+	}
+	if lastEventAt != nil && event.OccurredAt.Before(*lastEventAt) {
+		result.Stale = true
+		result.Status = normalizeSubscriptionStatus(result.PreviousStatus)
+		if err := tx.Commit(ctx); err != nil {
+			return billingWebhookResult{}, err
+		}
+		return result, nil
+	}
+
+	result.Status = event.Status
+	changed := normalizeSubscriptionStatus(result.PreviousStatus) != event.Status
+	if _, err := tx.Exec(
+		ctx,
+		`UPDATE "Subscription"
+		 SET status = $2::"SubscriptionStatus", "renewAt" = COALESCE($3, "renewAt"), "lastEventAt" = $4
+		 WHERE id = $1`,
+		result.SubscriptionID,
+		event.Status,
+		event.RenewAt,
+		event.OccurredAt,
+	); err != nil {
+		return billingWebhookResult{}, err
+	}
+
+	if err := recordAuditLog(
+		ctx,
+		tx,
+		result.HouseholdID,
+		"",
+		"SUBSCRIPTION_STATUS_WEBHOOK",
+		"Subscription",
+		&result.SubscriptionID,
+		gin.H{
+			"event_id":    event.ID,
+			"event_type":  strings.TrimSpace(event.Type),
+			"occurred_at": event.OccurredAt.Format(time.RFC3339),
+			"from_status": normalizeSubscriptionStatus(result.PreviousStatus),
+			"to_status":   event.Status,
+			"changed":     changed,
+		},
+	); err != nil {
+		return billingWebhookResult{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return billingWebhookResult{}, err
+	}
+	return result, nil
+}
+
+// lookupSubscriptionExternalID returns the household subscription's external
+// id, or "" when there is none yet. It runs before checkout's transaction so
+// the column can be added on databases that predate it.
+func (a *App) lookupSubscriptionExternalID(ctx context.Context, householdID string) (string, error) {
+	query := `SELECT COALESCE("externalId", '') FROM "Subscription" WHERE "householdId" = $1 LIMIT 1`
+	var externalID string
+	err := a.db.QueryRow(ctx, query, householdID).Scan(&externalID)
+	if err != nil && isMissingBillingWebhookSchemaErr(err) {
+		if ensureErr := a.ensureBillingWebhookSchema(ctx); ensureErr != nil {
+			return "", ensureErr
+		}
+		err = a.db.QueryRow(ctx, query, householdID).Scan(&externalID)
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	return externalID, err
+}
+
+func (a *App) ensureBillingWebhookSchema(ctx context.Context) error {
+	statements := []string{
+		`ALTER TABLE "Subscription" ADD COLUMN IF NOT EXISTS "externalId" TEXT`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS "Subscription_externalId_key" ON "Subscription"("externalId")`,
+		`ALTER TABLE "Subscription" ADD COLUMN IF NOT EXISTS "lastEventAt" TIMESTAMP(3)`,
+		`CREATE TABLE IF NOT EXISTS "BillingWebhookEvent" (
+			id TEXT PRIMARY KEY,
+			"subscriptionId" TEXT NOT NULL REFERENCES "Subscription"(id) ON DELETE CASCADE ON UPDATE CASCADE,
+			type TEXT NOT NULL DEFAULT '',
+			status TEXT NOT NULL,
+			"receivedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+	}
+	for _, stmt := range statements {
+		if _, err := a.db.Exec(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isMissingBillingWebhookSchemaErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	lowered := strings.ToLower(err.Error())
+	return (strings.Contains(lowered, "column") &&
+		(strings.Contains(lowered, "externalid") || strings.Contains(lowered, "lasteventat"))) ||
+		(strings.Contains(lowered, "relation") && strings.Contains(lowered, "billingwebhookevent"))
+}
@@ -2,6 +2,8 @@ package server
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 	"testing"
@@ -67,6 +69,110 @@ func TestChatQueryCreatesUsageLogAndChargesWallet(t *testing.T) {
 	}
 }
 
+func TestChatQueryTranslateToReturnsBothAnswers(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	seedSubscription(t, "", fixture.HouseholdID, "AI_ONLY", "ACTIVE")
+
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/chat/query",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"session_id":        sessionID,
+			"child_id":          fixture.BabyID,
+			"query":             "How was sleep today?",
+			"tone":              "neutral",
+			"use_personal_data": true,
+			"translate_to":      "en",
+		},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	body := decodeJSONMap(t, rec)
+	answer, _ := body["answer"].(string)
+	translated, _ := body["answer_translated"].(string)
+	if strings.TrimSpace(answer) == "" || strings.TrimSpace(translated) == "" {
+		t.Fatalf("expected answer and answer_translated, got %v", body)
+	}
+	if body["translate_to"] != "en" {
+		t.Fatalf("expected translate_to=en, got %v", body["translate_to"])
+	}
+	usage, _ := body["usage"].(map[string]any)
+	// The mock AI reports 200 tokens per call; both calls are billed.
+	if total, _ := usage["total_tokens"].(float64); total != 400 {
+		t.Fatalf("expected combined usage of 400 tokens, got %v", usage)
+	}
+
+	invalid := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/chat/query",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"session_id":   sessionID,
+			"child_id":     fixture.BabyID,
+			"query":        "How was sleep today?",
+			"translate_to": "klingon",
+		},
+		nil,
+	)
+	if invalid.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unsupported translate_to, got %d body=%s", invalid.Code, invalid.Body.String())
+	}
+}
+
+func TestGetChatMessagesExposesAssistantUsage(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	seedSubscription(t, "", fixture.HouseholdID, "AI_ONLY", "ACTIVE")
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+	token := signToken(t, fixture.UserID, nil)
+
+	rec := performRequest(t, newTestRouter(t), http.MethodPost, "/api/v1/chat/query", token, map[string]any{
+		"session_id":        sessionID,
+		"child_id":          fixture.BabyID,
+		"query":             "How was sleep today?",
+		"use_personal_data": true,
+	}, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = performRequest(t, newTestRouter(t), http.MethodGet, "/api/v1/chat/sessions/"+sessionID+"/messages", token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	messages, _ := decodeJSONMap(t, rec)["messages"].([]any)
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(messages))
+	}
+	for _, raw := range messages {
+		message, _ := raw.(map[string]any)
+		if message["context_json"] == nil {
+			t.Fatalf("expected context_json to be kept, got %v", message)
+		}
+		if message["role"] == "user" {
+			if _, found := message["usage"]; found {
+				t.Fatalf("expected no usage on user messages, got %v", message)
+			}
+			continue
+		}
+		usage, _ := message["usage"].(map[string]any)
+		if usage["total_tokens"] != float64(200) || message["model"] == nil || message["credit"] == nil {
+			t.Fatalf("expected usage, model and credit on the assistant message, got %v", message)
+		}
+	}
+}
+
 func TestChatQueryGraceThenPaymentRequired(t *testing.T) {
 	resetDatabase(t)
 	fixture := seedOwnerFixture(t)
@@ -284,3 +390,490 @@ func createSessionForTest(t *testing.T, userID, babyID string) string {
 	}
 	return sessionID
 }
+
+func TestChatQueryWarnsWhenBalanceFallsBelowThreshold(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	seedSubscription(t, "", fixture.HouseholdID, "AI_ONLY", "ACTIVE")
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+
+	query := func(threshold int) map[string]any {
+		cfg := baseTestConfig
+		cfg.AILowBalanceThreshold = threshold
+		rec := performRequest(
+			t,
+			newTestRouterWithConfig(t, cfg),
+			http.MethodPost,
+			"/api/v1/chat/query",
+			signToken(t, fixture.UserID, nil),
+			map[string]any{
+				"session_id":        sessionID,
+				"child_id":          fixture.BabyID,
+				"query":             "How was sleep today?",
+				"use_personal_data": true,
+			},
+			nil,
+		)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+		}
+		return decodeJSONMap(t, rec)
+	}
+
+	above := query(1)
+	if above["low_balance_warning"] != false {
+		t.Fatalf("expected no warning above the threshold, got %v", above["low_balance_warning"])
+	}
+
+	below := query(1_000_000)
+	if below["low_balance_warning"] != true {
+		t.Fatalf("expected a warning below the threshold, got %v", below["low_balance_warning"])
+	}
+	credit, _ := below["credit"].(map[string]any)
+	if below["remaining_balance"] == nil || below["remaining_balance"] != credit["balance_after"] {
+		t.Fatalf("expected remaining_balance to match balance_after, got %v vs %v", below["remaining_balance"], credit["balance_after"])
+	}
+}
+
+// emptyAnswerStubAIClient reports token usage but an answer that sanitizes to
+// nothing, like a provider reply that only repeats a timezone label.
+type emptyAnswerStubAIClient struct{}
+
+func (emptyAnswerStubAIClient) Query(_ context.Context, req AIModelRequest) (AIModelResponse, error) {
+	return AIModelResponse{
+		Answer: " (UTC) ",
+		Model:  req.Model,
+		Usage:  AIUsage{PromptTokens: 150, CompletionTokens: 50, TotalTokens: 200},
+	}, nil
+}
+
+func TestChatQueryEmptyAnswerDoesNotChargeOrPersist(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	seedSubscription(t, "", fixture.HouseholdID, "AI_ONLY", "ACTIVE")
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+	token := signToken(t, fixture.UserID, nil)
+	payload := map[string]any{
+		"session_id":        sessionID,
+		"child_id":          fixture.BabyID,
+		"query":             "How was sleep today?",
+		"use_personal_data": true,
+	}
+
+	rec := performRequest(t, newTestRouter(t), http.MethodPost, "/api/v1/chat/query", token, payload, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	credit, _ := decodeJSONMap(t, rec)["credit"].(map[string]any)
+	balanceAfter, ok := credit["balance_after"].(float64)
+	if !ok {
+		t.Fatalf("expected balance_after in credit, got %v", credit)
+	}
+
+	app := New(baseTestConfig, testPool)
+	app.ai = emptyAnswerStubAIClient{}
+	rec = performRequest(t, app.Router(), http.MethodPost, "/api/v1/chat/query", token, payload, nil)
+	if rec.Code != http.StatusBadGateway {
+		t.Fatalf("expected 502, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if detail := responseDetail(t, rec); !strings.Contains(detail, "no credits were charged") {
+		t.Fatalf("expected empty-answer detail, got %q", detail)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var walletBalance, usageLogCount, messageCount int
+	if err := testPool.QueryRow(ctx, `SELECT "balanceCredits" FROM "UserCreditWallet" WHERE "userId" = $1`, fixture.UserID).Scan(&walletBalance); err != nil {
+		t.Fatalf("query wallet balance: %v", err)
+	}
+	if walletBalance != int(balanceAfter) {
+		t.Fatalf("expected balance to stay at %v, got %d", balanceAfter, walletBalance)
+	}
+	if err := testPool.QueryRow(ctx, `SELECT COUNT(*)::int FROM "AiUsageLog" WHERE "userId" = $1`, fixture.UserID).Scan(&usageLogCount); err != nil {
+		t.Fatalf("query usage log count: %v", err)
+	}
+	if usageLogCount != 1 {
+		t.Fatalf("expected only the first query to be logged, got %d", usageLogCount)
+	}
+	if err := testPool.QueryRow(ctx, `SELECT COUNT(*)::int FROM "ChatMessage" WHERE "sessionId" = $1`, sessionID).Scan(&messageCount); err != nil {
+		t.Fatalf("query message count: %v", err)
+	}
+	if messageCount != 2 {
+		t.Fatalf("expected no messages from the empty answer, got %d total", messageCount)
+	}
+}
+
+// factsStubAIClient answers with prose followed by a facts block, as the
+// response_format=facts prompt asks.
+type factsStubAIClient struct{}
+
+func (factsStubAIClient) Query(_ context.Context, req AIModelRequest) (AIModelResponse, error) {
+	if strings.Contains(req.SystemPrompt, "You classify childcare chat intent") {
+		return AIModelResponse{Answer: `{"intent":"data_query","confidence":0.9}`, Model: req.Model}, nil
+	}
+	return AIModelResponse{
+		Answer: "## 답변\n오늘 분유는 **480ml** 먹었어요.\n\n```json\n{\"facts\":[{\"metric\":\"formula_total\",\"value\":480,\"unit\":\"ml\",\"period\":\"today\"}]}\n```",
+		Model:  req.Model,
+		Usage:  AIUsage{PromptTokens: 150, CompletionTokens: 50, TotalTokens: 200},
+	}, nil
+}
+
+func TestChatQueryFactsFormatReturnsParsedFacts(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	seedSubscription(t, "", fixture.HouseholdID, "AI_ONLY", "ACTIVE")
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+	token := signToken(t, fixture.UserID, nil)
+
+	app := New(baseTestConfig, testPool)
+	app.ai = factsStubAIClient{}
+	rec := performRequest(t, app.Router(), http.MethodPost, "/api/v1/chat/query", token, map[string]any{
+		"session_id":        sessionID,
+		"child_id":          fixture.BabyID,
+		"query":             "오늘 분유 몇 ml 먹었어?",
+		"use_personal_data": true,
+		"response_format":   "facts",
+	}, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	if answer, _ := body["answer"].(string); strings.Contains(answer, "facts") || !strings.Contains(answer, "480ml") {
+		t.Fatalf("expected prose answer without the facts block, got %q", answer)
+	}
+	facts, _ := body["facts"].([]any)
+	if len(facts) != 1 {
+		t.Fatalf("expected one fact, got %v", body["facts"])
+	}
+	fact, _ := facts[0].(map[string]any)
+	if fact["metric"] != "formula_total" || fact["value"] != float64(480) || fact["unit"] != "ml" {
+		t.Fatalf("unexpected fact: %v", fact)
+	}
+
+	invalid := performRequest(t, app.Router(), http.MethodPost, "/api/v1/chat/query", token, map[string]any{
+		"session_id":      sessionID,
+		"query":           "오늘 분유 몇 ml 먹었어?",
+		"response_format": "xml",
+	}, nil)
+	if invalid.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unsupported response_format, got %d body=%s", invalid.Code, invalid.Body.String())
+	}
+}
+
+func TestChatQueryProviderTimeoutMovesAIHealthCounters(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	seedSubscription(t, "", fixture.HouseholdID, "AI_ONLY", "ACTIVE")
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+
+	cfg := baseTestConfig
+	cfg.AdminUserIDs = []string{fixture.UserID}
+	app := New(cfg, testPool)
+	app.ai = intentRouterStubAIClient{err: errors.New("openai request failed: context deadline exceeded")}
+	router := app.Router()
+	token := signToken(t, fixture.UserID, nil)
+
+	rec := performRequest(
+		t,
+		router,
+		http.MethodPost,
+		"/api/v1/chat/query",
+		token,
+		map[string]any{
+			"session_id":        sessionID,
+			"child_id":          fixture.BabyID,
+			"query":             "How was sleep today?",
+			"use_personal_data": true,
+		},
+		nil,
+	)
+	if rec.Code != http.StatusBadGateway {
+		t.Fatalf("expected 502, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = performRequest(t, router, http.MethodGet, "/api/v1/admin/ai-health?window_min=5", token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	counts, _ := body["counts"].(map[string]any)
+	if counts["timeout"] != float64(1) || counts["success"] != float64(0) {
+		t.Fatalf("expected one timeout and no successes, got %v", counts)
+	}
+	if body["failure_rate"] != float64(1) {
+		t.Fatalf("expected a failure rate of 1, got %v", body["failure_rate"])
+	}
+
+	outsider := seedUser(t, "")
+	rec = performRequest(t, router, http.MethodGet, "/api/v1/admin/ai-health", signToken(t, outsider, nil), nil, nil)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for a non-admin user, got %d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestChatQueryStreamSendsDeltasThenDoneAndPersistsAnswer(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	seedSubscription(t, "", fixture.HouseholdID, "AI_ONLY", "ACTIVE")
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/chat/query/stream",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"session_id":        sessionID,
+			"child_id":          fixture.BabyID,
+			"query":             "Was the nap at 2026-01-01T09:00:00Z (UTC) short? Should I move it?",
+			"use_personal_data": true,
+		},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if contentType := rec.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/event-stream") {
+		t.Fatalf("expected an event stream, got %q", contentType)
+	}
+
+	deltas := make([]string, 0, 4)
+	var done map[string]any
+	for _, frame := range strings.Split(strings.TrimSpace(rec.Body.String()), "\n\n") {
+		event, data := "", ""
+		for _, line := range strings.Split(frame, "\n") {
+			if value, ok := strings.CutPrefix(line, "event:"); ok {
+				event = strings.TrimSpace(value)
+			}
+			if value, ok := strings.CutPrefix(line, "data:"); ok {
+				data = strings.TrimSpace(value)
+			}
+		}
+		switch event {
+		case "delta":
+			delta := map[string]any{}
+			if err := json.Unmarshal([]byte(data), &delta); err != nil {
+				t.Fatalf("unmarshal delta frame: %v", err)
+			}
+			deltas = append(deltas, toString(delta["text"]))
+		case "done":
+			done = map[string]any{}
+			if err := json.Unmarshal([]byte(data), &done); err != nil {
+				t.Fatalf("unmarshal done frame: %v", err)
+			}
+		}
+	}
+	if len(deltas) < 2 {
+		t.Fatalf("expected several delta frames, got %d body=%s", len(deltas), rec.Body.String())
+	}
+	if streamed := strings.Join(deltas, ""); strings.Contains(streamed, "T09:00:00Z") || strings.Contains(streamed, "UTC") {
+		t.Fatalf("expected deltas cleaned like the final answer, got %q", streamed)
+	}
+	if done == nil || done["session_id"] != sessionID || done["message_id"] == nil || done["usage"] == nil || done["credit"] == nil {
+		t.Fatalf("expected done frame with session, message, usage and credit, got %v", done)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var content string
+	if err := testPool.QueryRow(
+		ctx,
+		`SELECT content FROM "ChatMessage" WHERE id = $1 AND role = 'assistant'`,
+		done["message_id"],
+	).Scan(&content); err != nil {
+		t.Fatalf("query assistant message: %v", err)
+	}
+	if content != done["answer"] {
+		t.Fatalf("expected the persisted answer to match the done frame, got %q vs %v", content, done["answer"])
+	}
+}
+
+func TestChatQueryEstimateDoesNotChargeOrStoreMessages(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/chat/query/estimate",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"session_id":        sessionID,
+			"child_id":          fixture.BabyID,
+			"query":             "how much did she eat yesterday?",
+			"use_personal_data": true,
+		},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	if credits, _ := body["estimated_credits"].(float64); credits <= 0 {
+		t.Fatalf("expected a positive credit estimate, got %v", body["estimated_credits"])
+	}
+	if _, ok := body["balance"].(float64); !ok {
+		t.Fatalf("expected balance in estimate, got %v", body)
+	}
+
+	ctx := context.Background()
+	var usageLogCount, messageCount int
+	if err := testPool.QueryRow(ctx, `SELECT COUNT(*)::int FROM "AiUsageLog" WHERE "userId" = $1`, fixture.UserID).Scan(&usageLogCount); err != nil {
+		t.Fatalf("count usage logs: %v", err)
+	}
+	if err := testPool.QueryRow(ctx, `SELECT COUNT(*)::int FROM "ChatMessage" WHERE "sessionId" = $1`, sessionID).Scan(&messageCount); err != nil {
+		t.Fatalf("count chat messages: %v", err)
+	}
+	if usageLogCount != 0 || messageCount != 0 {
+		t.Fatalf("expected no usage logs or messages, got %d and %d", usageLogCount, messageCount)
+	}
+}
+
+func TestBillingUsageReturnsDailySeriesAndTotals(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	seedSubscription(t, "", fixture.HouseholdID, "AI_ONLY", "ACTIVE")
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+
+	rec := performRequest(
+		t,
+		router,
+		http.MethodPost,
+		"/api/v1/chat/query",
+		token,
+		map[string]any{
+			"session_id":        sessionID,
+			"child_id":          fixture.BabyID,
+			"query":             "How was sleep today?",
+			"use_personal_data": true,
+		},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	credit, _ := decodeJSONMap(t, rec)["credit"].(map[string]any)
+
+	today := time.Now().UTC().Format("2006-01-02")
+	from := time.Now().UTC().AddDate(0, 0, -6).Format("2006-01-02")
+	rec = performRequest(t, router, http.MethodGet, "/api/v1/billing/usage?household_id="+fixture.HouseholdID+"&from="+from+"&to="+today, token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	days, _ := body["days"].([]any)
+	if len(days) != 7 {
+		t.Fatalf("expected 7 zero-filled days, got %d", len(days))
+	}
+	last, _ := days[len(days)-1].(map[string]any)
+	if last["date"] != today || last["queries"] != float64(1) || last["charged_credits"] != credit["charged"] {
+		t.Fatalf("expected today's query in the last day, got %v (credit=%v)", last, credit)
+	}
+	totals, _ := body["totals"].(map[string]any)
+	byMode, _ := totals["by_billing_mode"].(map[string]any)
+	if totals["total_tokens"] != float64(200) || byMode["paid"] != float64(1) || totals["grace_used"] != float64(0) {
+		t.Fatalf("unexpected totals: %v", totals)
+	}
+
+	rec = performRequest(t, router, http.MethodGet, "/api/v1/billing/usage?household_id="+fixture.HouseholdID+"&from=2026-01-01&to=2026-06-01", token, nil, nil)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a window over 92 days, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	outsider := seedUser(t, "")
+	rec = performRequest(t, router, http.MethodGet, "/api/v1/billing/usage?household_id="+fixture.HouseholdID, signToken(t, outsider, nil), nil, nil)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for a non-member, got %d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestChatQueryCreditReportsPlanLimits(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	seedSubscription(t, "", fixture.HouseholdID, "AI_PHOTO", "ACTIVE")
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/chat/query",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"session_id":        sessionID,
+			"child_id":          fixture.BabyID,
+			"query":             "How was sleep today?",
+			"use_personal_data": true,
+		},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	credit, _ := decodeJSONMap(t, rec)["credit"].(map[string]any)
+	expected := limitsForPlan("AI_PHOTO", "ACTIVE")
+	if credit["grace_limit"] != float64(expected.GraceLimitPerDay) || credit["turn_limit"] != float64(expected.ConversationTurnLimit) {
+		t.Fatalf("expected AI_PHOTO limits %+v, got %v", expected, credit)
+	}
+}
+
+func TestChatQueryUsesHouseholdAIModel(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	seedSubscription(t, "", fixture.HouseholdID, "AI_ONLY", "ACTIVE")
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+	token := signToken(t, fixture.UserID, nil)
+
+	cfg := baseTestConfig
+	cfg.AIModelPricing = []string{"model-premium=2:8"}
+	router := newTestRouterWithConfig(t, cfg)
+
+	rejected := performRequest(t, router, http.MethodPatch, "/api/v1/subscription/ai-model", token, map[string]any{
+		"household_id": fixture.HouseholdID,
+		"model":        "model-unpriced",
+	}, nil)
+	if rejected.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unpriced model, got %d body=%s", rejected.Code, rejected.Body.String())
+	}
+
+	rec := performRequest(t, router, http.MethodPatch, "/api/v1/subscription/ai-model", token, map[string]any{
+		"household_id": fixture.HouseholdID,
+		"model":        "model-premium",
+	}, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = performRequest(t, router, http.MethodPost, "/api/v1/chat/query", token, map[string]any{
+		"session_id":        sessionID,
+		"child_id":          fixture.BabyID,
+		"query":             "How was sleep today?",
+		"use_personal_data": true,
+	}, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if model := decodeJSONMap(t, rec)["model"]; model != "model-premium" {
+		t.Fatalf("expected household model in the answer, got %v", model)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var loggedModel string
+	if err := testPool.QueryRow(ctx, `SELECT model FROM "AiUsageLog" WHERE "userId" = $1`, fixture.UserID).Scan(&loggedModel); err != nil {
+		t.Fatalf("query usage log model: %v", err)
+	}
+	if loggedModel != "model-premium" {
+		t.Fatalf("expected usage to be billed on model-premium, got %s", loggedModel)
+	}
+
+	rec = performRequest(t, router, http.MethodGet, "/api/v1/subscription/me?household_id="+fixture.HouseholdID, token, nil, nil)
+	if body := decodeJSONMap(t, rec); body["ai_model"] != "model-premium" {
+		t.Fatalf("expected ai_model on the subscription, got %v", body["ai_model"])
+	}
+}
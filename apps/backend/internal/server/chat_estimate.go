@@ -0,0 +1,262 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+// chatTokenEstimator guesses the usage of a chat request before it is sent.
+// The estimate endpoint only depends on this interface so a tokenizer-backed
+// estimator can replace the character heuristic later.
+type chatTokenEstimator interface {
+	EstimateUsage(req AIModelRequest, maxOutputTokens int) AIUsage
+}
+
+// charRatioTokenEstimator counts roughly one token per four ASCII characters
+// and one per other rune, which is close for mixed Korean and English
+// prompts. The completion is assumed to use the whole output cap.
+type charRatioTokenEstimator struct{}
+
+func (charRatioTokenEstimator) EstimateUsage(req AIModelRequest, maxOutputTokens int) AIUsage {
+	promptTokens := estimateTextTokens(req.SystemPrompt) + estimateTextTokens(req.UserPrompt)
+	for _, turn := range req.Conversation {
+		promptTokens += estimateTextTokens(turn.Content)
+	}
+	if maxOutputTokens < 0 {
+		maxOutputTokens = 0
+	}
+	return AIUsage{
+		PromptTokens:     promptTokens,
+		CompletionTokens: maxOutputTokens,
+		TotalTokens:      promptTokens + maxOutputTokens,
+	}
+}
+
+func estimateTextTokens(text string) int {
+	ascii := 0
+	other := 0
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return (ascii+3)/4 + other
+}
+
+func (a *App) chatTokenEstimator() chatTokenEstimator {
+	if a.tokenEstimator != nil {
+		return a.tokenEstimator
+	}
+	return charRatioTokenEstimator{}
+}
+
+// estimateChatQuery prepares a chat query like runChatQuery and prices it
+// without calling the AI provider, reserving credits or storing messages. The
+// intent comes from the persisted first message or the heuristics, since the
+// intent router is itself an AI call.
+func (a *App) estimateChatQuery(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var payload chatQueryRequest
+	if !mustJSON(c, &payload) {
+		return
+	}
+	ctx := c.Request.Context()
+
+	sessionID := strings.TrimSpace(payload.SessionID)
+	if sessionID == "" {
+		writeError(c, http.StatusBadRequest, "session_id is required")
+		return
+	}
+	question := strings.TrimSpace(payload.Query)
+	if question == "" {
+		writeError(c, http.StatusBadRequest, "query is required")
+		return
+	}
+	translateTo := ""
+	if raw := strings.TrimSpace(payload.TranslateTo); raw != "" {
+		translateTo = normalizeTranslateTarget(raw)
+		if translateTo == "" {
+			writeError(c, http.StatusBadRequest, "translate_to must be one of: "+strings.Join(supportedTranslateTargets(), ", "))
+			return
+		}
+	}
+
+	session, err := a.loadChatSessionForUser(ctx, user.ID, sessionID)
+	if err != nil {
+		a.writeChatExecutionError(c, err)
+		return
+	}
+	hasFeature, plan, planStatus, err := a.hasSubscriptionFeature(ctx, session.HouseholdID, subscriptionFeatureAI)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to check subscription")
+		return
+	}
+	if !hasFeature {
+		writeError(c, http.StatusPaymentRequired, subscriptionFeatureDetail(subscriptionFeatureAI))
+		return
+	}
+	limits := limitsForPlan(plan, planStatus)
+	tone := normalizeTone(payload.Tone)
+	if strings.TrimSpace(payload.Tone) == "" && session.PreferredTone != nil {
+		tone = normalizeTone(*session.PreferredTone)
+	}
+	preferredLanguage := ""
+	if session.PreferredLanguage != nil {
+		preferredLanguage = *session.PreferredLanguage
+	}
+
+	childID := strings.TrimSpace(payload.ChildID)
+	if childID == "" && session.ChildID != nil {
+		childID = strings.TrimSpace(*session.ChildID)
+	}
+	if payload.UsePersonalData && childID == "" {
+		resolvedChildID, resolveErr := a.resolvePrimaryChildForHousehold(ctx, session.HouseholdID)
+		if resolveErr != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to resolve default child profile")
+			return
+		}
+		childID = strings.TrimSpace(resolvedChildID)
+	}
+	if payload.UsePersonalData && childID == "" {
+		writeError(c, http.StatusBadRequest, "child_id is required when use_personal_data is true")
+		return
+	}
+	if childID != "" {
+		baby, statusCode, babyErr := a.getBabyWithAccess(ctx, user.ID, childID, readRoles)
+		if babyErr != nil {
+			writeError(c, statusCode, babyErr.Error())
+			return
+		}
+		if baby.HouseholdID != session.HouseholdID {
+			writeError(c, http.StatusBadRequest, "child_id does not belong to this chat session household")
+			return
+		}
+		childID = baby.ID
+	}
+
+	turns, err := a.loadSessionTurns(ctx, session.ID, limits.ConversationTurnLimit)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load chat messages")
+		return
+	}
+	_, firstUserMessage, fixedIntent, err := a.loadFirstUserMessageIntent(ctx, session.ID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load chat messages")
+		return
+	}
+	intent := resolveAIIntentWithSession(question, turns)
+	switch {
+	case fixedIntent != "":
+		intent = fixedIntent
+	default:
+		firstMessage := firstUserMessage
+		if firstMessage == "" {
+			firstMessage = question
+		}
+		if isLikelyCaregiverSelfTalk(firstMessage) {
+			intent = aiIntentSmalltalk
+		}
+	}
+
+	now := time.Now().UTC()
+	scopeOverride := resolveRequestedChatScope(payload.DateMode, payload.AnchorDate, payload.TZOffset, now)
+	scopeOverride.RawStart, scopeOverride.RawEnd, err = resolveRequestedRawWindow(payload.From, payload.To, payload.TZOffset, now)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	chatContext, err := a.buildChatContext(ctx, user.ID, childID, intent, question, now, payload.UsePersonalData, scopeOverride)
+	if err != nil {
+		a.writeChatExecutionError(c, err)
+		return
+	}
+	memorySummary := ""
+	if session.MemorySummary != nil {
+		memorySummary = *session.MemorySummary
+	}
+	smalltalkStyleHint := ""
+	if intent == aiIntentSmalltalk {
+		smalltalkStyleHint = deriveSmalltalkStyleHint(turns, question)
+	}
+
+	householdModel, err := a.householdAIModel(ctx, session.HouseholdID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load subscription")
+		return
+	}
+	model := chatModelFor(householdModel, intent)
+	maxOutputTokens := a.maxOutputTokensForIntent(intent)
+	if maxOutputTokens <= 0 {
+		maxOutputTokens = a.cfg.AIMaxOutputTokens
+	}
+	request := AIModelRequest{
+		Model:           model,
+		MaxOutputTokens: maxOutputTokens,
+		SystemPrompt:    buildChatSystemPrompt(intent, tone, chatContext, payload.UsePersonalData, memorySummary, smalltalkStyleHint, preferredLanguage),
+		Conversation:    turns,
+		UserPrompt:      question,
+	}
+	usage := a.chatTokenEstimator().EstimateUsage(request, maxOutputTokens)
+	if translateTo != "" {
+		// The translation call reads the answer and writes it back out.
+		usage = addAIUsage(usage, AIUsage{
+			PromptTokens:     usage.CompletionTokens,
+			CompletionTokens: usage.CompletionTokens,
+			TotalTokens:      usage.CompletionTokens * 2,
+		})
+	}
+	breakdown := creditBreakdownForUsage(model, a.pricingForModel(model), usage)
+
+	// Mirror preflightBilling: credits are held at the core model's rates (or
+	// the household model's), and grace turns are used once the balance cannot
+	// cover that hold.
+	reserveCredits := reserveCreditsForPricing(a.pricingForModel(chatModelFor(householdModel, aiIntentDataQuery)))
+	balance, err := a.getWalletBalance(ctx, a.db, user.ID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load credit balance")
+		return
+	}
+	graceUsed, err := a.countGraceUsedToday(ctx, a.db, user.ID, now)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load credit balance")
+		return
+	}
+	billingMode := ""
+	switch {
+	case balance >= reserveCredits:
+		billingMode = string(billingModePaid)
+	case graceUsed < limits.GraceLimitPerDay:
+		billingMode = string(billingModeGrace)
+	}
+	if forcedPlan, forcedStatus, forced := a.localForcedSubscription(); forced &&
+		isEnabledSubscriptionStatus(forcedStatus) && planSupportsFeature(forcedPlan, subscriptionFeatureAI) {
+		billingMode = string(billingModeGrace)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"session_id":        session.ID,
+		"intent":            string(intent),
+		"model":             model,
+		"translate_to":      nullableString(translateTo),
+		"estimated_usage":   usageMap(usage),
+		"estimated_credits": breakdown.Charged,
+		"reserve_credits":   reserveCredits,
+		"balance":           balance,
+		"grace_used":        graceUsed,
+		"grace_limit":       limits.GraceLimitPerDay,
+		"turn_limit":        limits.ConversationTurnLimit,
+		"billing_mode":      nullableString(billingMode),
+		"allowed":           billingMode != "",
+	})
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	chatResponseFormatProse = "prose"
+	chatResponseFormatFacts = "facts"
+
+	chatFactsMaxEntries = 12
+)
+
+// chatFact is one number the model pulled out of a data_query answer, so
+// clients can show it without parsing the markdown.
+type chatFact struct {
+	Metric string  `json:"metric"`
+	Value  float64 `json:"value"`
+	Unit   string  `json:"unit"`
+	Period string  `json:"period"`
+}
+
+func normalizeChatResponseFormat(raw string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", chatResponseFormatProse:
+		return chatResponseFormatProse, true
+	case chatResponseFormatFacts:
+		return chatResponseFormatFacts, true
+	default:
+		return "", false
+	}
+}
+
+// chatFactsPromptLines asks for a fenced JSON block after the prose answer.
+// The block is cut out by splitChatFactsBlock before the answer is shown.
+func chatFactsPromptLines() []string {
+	return []string{
+		"수치 요약 모드: 평소처럼 답변을 모두 쓴 뒤, 맨 끝에 ```json 코드 블록을 정확히 하나 덧붙인다.",
+		`수치 요약 모드: 코드 블록 형식은 {"facts":[{"metric":"formula_total","value":480,"unit":"ml","period":"2026-02-15"}]} 이다.`,
+		"수치 요약 모드: metric은 영어 snake_case, value는 숫자만, unit은 ml/회/분/kg/℃ 같은 단위, period는 값이 해당하는 날짜나 기간이다.",
+		"수치 요약 모드: 답변 본문에 나온 핵심 수치만 최대 12개 넣고, 컨텍스트에 없는 값은 만들지 않는다.",
+		"수치 요약 모드: 코드 블록은 화면에 보이지 않으므로 본문에서 코드 블록을 언급하지 않는다.",
+	}
+}
+
+// splitChatFactsBlock cuts the trailing facts block out of a model answer.
+// The prose is returned without the block even when the block does not
+// parse; ok is false when no valid facts were found.
+func splitChatFactsBlock(answer string) (string, []chatFact, bool) {
+	start := strings.LastIndex(answer, "```json")
+	if start < 0 {
+		start = strings.LastIndex(answer, `{"facts"`)
+	}
+	if start < 0 {
+		return answer, nil, false
+	}
+	prose := strings.TrimSpace(answer[:start])
+	candidate := strings.TrimSpace(answer[start:])
+	candidate = strings.TrimSpace(strings.TrimPrefix(candidate, "```json"))
+	candidate = strings.TrimSpace(strings.TrimSuffix(candidate, "```"))
+	if open, end := strings.Index(candidate, "{"), strings.LastIndex(candidate, "}"); open >= 0 && end > open {
+		candidate = candidate[open : end+1]
+	}
+
+	entries, _ := parseJSONStringMap([]byte(candidate))["facts"].([]any)
+	facts := make([]chatFact, 0, len(entries))
+	for _, entry := range entries {
+		item, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+		metric := strings.TrimSpace(toString(item["metric"]))
+		value, valueOK := chatFactValue(item["value"])
+		if metric == "" || !valueOK {
+			continue
+		}
+		facts = append(facts, chatFact{
+			Metric: metric,
+			Value:  value,
+			Unit:   strings.TrimSpace(toString(item["unit"])),
+			Period: strings.TrimSpace(toString(item["period"])),
+		})
+		if len(facts) == chatFactsMaxEntries {
+			break
+		}
+	}
+	if len(facts) == 0 {
+		return prose, nil, false
+	}
+	return prose, facts, true
+}
+
+func chatFactValue(raw any) (float64, bool) {
+	switch value := raw.(type) {
+	case float64:
+		return value, true
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return parsed, err == nil
+	default:
+		return 0, false
+	}
+}
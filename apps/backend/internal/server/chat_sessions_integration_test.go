@@ -0,0 +1,727 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func createChatMessageForTest(t *testing.T, userID, sessionID, role, content string) string {
+	t.Helper()
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/chat/sessions/"+sessionID+"/messages",
+		signToken(t, userID, nil),
+		map[string]any{
+			"role":    role,
+			"content": content,
+		},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("create chat message failed: %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	messageID, _ := body["message_id"].(string)
+	if messageID == "" {
+		t.Fatalf("missing message_id in response: %v", body)
+	}
+	return messageID
+}
+
+func loadChatMessageContentsForTest(t *testing.T, userID, sessionID string) []string {
+	t.Helper()
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/chat/sessions/"+sessionID+"/messages",
+		signToken(t, userID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("load chat messages failed: %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	rawMessages, ok := body["messages"].([]any)
+	if !ok {
+		t.Fatalf("expected messages list, got %T", body["messages"])
+	}
+	contents := make([]string, 0, len(rawMessages))
+	for _, raw := range rawMessages {
+		message, _ := raw.(map[string]any)
+		content, _ := message["content"].(string)
+		contents = append(contents, content)
+	}
+	return contents
+}
+
+func TestForkChatSessionCopiesMessagesUpToCutoff(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+
+	createChatMessageForTest(t, fixture.UserID, sessionID, "user", "first question")
+	cutoffID := createChatMessageForTest(t, fixture.UserID, sessionID, "assistant", "first answer")
+	createChatMessageForTest(t, fixture.UserID, sessionID, "user", "second question")
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/chat/sessions/"+sessionID+"/fork",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"up_to_message_id": cutoffID,
+		},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	forkID, _ := body["session_id"].(string)
+	if forkID == "" || forkID == sessionID {
+		t.Fatalf("expected a new session_id, got %v", body["session_id"])
+	}
+	if body["forked_from_session_id"] != sessionID {
+		t.Fatalf("expected forked_from_session_id=%s, got %v", sessionID, body["forked_from_session_id"])
+	}
+	if count, _ := body["message_count"].(float64); int(count) != 2 {
+		t.Fatalf("expected message_count=2, got %v", body["message_count"])
+	}
+
+	forked := loadChatMessageContentsForTest(t, fixture.UserID, forkID)
+	if len(forked) != 2 || forked[0] != "first question" || forked[1] != "first answer" {
+		t.Fatalf("unexpected forked messages: %v", forked)
+	}
+	original := loadChatMessageContentsForTest(t, fixture.UserID, sessionID)
+	if len(original) != 3 {
+		t.Fatalf("expected original session to keep 3 messages, got %v", original)
+	}
+}
+
+func TestForkChatSessionRejectsForeignCutoffMessage(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+	createChatMessageForTest(t, fixture.UserID, sessionID, "user", "hello")
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/chat/sessions/"+sessionID+"/fork",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"up_to_message_id": testID(),
+		},
+		nil,
+	)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestSessionStyleHintIsDebugGated(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+	createChatMessageForTest(t, fixture.UserID, sessionID, "user", "오늘 너무 피곤해 ㅠㅠ")
+
+	path := "/api/v1/chat/sessions/" + sessionID + "/style-hint"
+	hidden := performRequest(t, newTestRouter(t), http.MethodGet, path, signToken(t, fixture.UserID, nil), nil, nil)
+	if hidden.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 when debug endpoints are disabled, got %d", hidden.Code)
+	}
+
+	cfg := baseTestConfig
+	cfg.ChatDebugEndpointsEnabled = true
+	rec := performRequest(t, newTestRouterWithConfig(t, cfg), http.MethodGet, path, signToken(t, fixture.UserID, nil), nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	signals, _ := body["signals"].(map[string]any)
+	if signals["sample_count"] != float64(1) || signals["casual_score"] != float64(1) {
+		t.Fatalf("unexpected signals: %v", body["signals"])
+	}
+	if hint, _ := body["style_hint"].(string); hint == "" {
+		t.Fatalf("expected style_hint, got %v", body)
+	}
+}
+
+func TestReclassifyChatSessionChangesIntentOfLaterTurns(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+	createChatMessageForTest(t, fixture.UserID, sessionID, "user", "hello there")
+
+	invalid := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/chat/sessions/"+sessionID+"/reclassify",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{"intent": "weather"},
+		nil,
+	)
+	if invalid.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown intent, got %d body=%s", invalid.Code, invalid.Body.String())
+	}
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/chat/sessions/"+sessionID+"/reclassify",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{"intent": "medical_related"},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if body := decodeJSONMap(t, rec); body["intent"] != "medical_related" {
+		t.Fatalf("expected intent=medical_related, got %v", body["intent"])
+	}
+
+	query := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/chat/query",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"session_id":        sessionID,
+			"child_id":          fixture.BabyID,
+			"query":             "and now?",
+			"use_personal_data": true,
+		},
+		nil,
+	)
+	if query.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", query.Code, query.Body.String())
+	}
+	body := decodeJSONMap(t, query)
+	if body["intent"] != "medical_related" || body["intent_source"] != "persisted_first_message" {
+		t.Fatalf("expected the corrected intent to stick, got intent=%v source=%v", body["intent"], body["intent_source"])
+	}
+}
+
+func TestDeleteChatSessionRemovesMessagesAndAllowsNewSession(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+	createChatMessageForTest(t, fixture.UserID, sessionID, "user", "hello")
+	createChatMessageForTest(t, fixture.UserID, sessionID, "assistant", "hi")
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodDelete,
+		"/api/v1/chat/sessions/"+sessionID,
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if count, _ := decodeJSONMap(t, rec)["deleted_message_count"].(float64); int(count) != 2 {
+		t.Fatalf("expected deleted_message_count=2, got %s", rec.Body.String())
+	}
+
+	var remaining int
+	if err := testPool.QueryRow(context.Background(), `SELECT COUNT(*)::int FROM "ChatMessage" WHERE "sessionId" = $1`, sessionID).Scan(&remaining); err != nil {
+		t.Fatalf("count chat messages: %v", err)
+	}
+	if remaining != 0 {
+		t.Fatalf("expected messages to be deleted, got %d", remaining)
+	}
+
+	missing := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/chat/sessions/"+sessionID+"/messages",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if missing.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 after delete, got %d body=%s", missing.Code, missing.Body.String())
+	}
+
+	if newID := createSessionForTest(t, fixture.UserID, fixture.BabyID); newID == sessionID {
+		t.Fatalf("expected a new session after delete, got the deleted id %s", newID)
+	}
+}
+
+func TestRenameChatSessionOverridesDerivedTitle(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+	createChatMessageForTest(t, fixture.UserID, sessionID, "user", "how long did she sleep")
+
+	empty := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPatch,
+		"/api/v1/chat/sessions/"+sessionID,
+		signToken(t, fixture.UserID, nil),
+		map[string]any{"title": "   "},
+		nil,
+	)
+	if empty.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for blank title, got %d body=%s", empty.Code, empty.Body.String())
+	}
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPatch,
+		"/api/v1/chat/sessions/"+sessionID,
+		signToken(t, fixture.UserID, nil),
+		map[string]any{"title": "  Last night's   sleep "},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if body := decodeJSONMap(t, rec); body["title"] != "Last night's sleep" {
+		t.Fatalf("expected normalized title, got %v", body["title"])
+	}
+
+	messages := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/chat/sessions/"+sessionID+"/messages",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if body := decodeJSONMap(t, messages); body["title"] != "Last night's sleep" {
+		t.Fatalf("expected stored title on messages, got %v", body["title"])
+	}
+
+	list := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/chat/sessions",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	sessions, _ := decodeJSONMap(t, list)["sessions"].([]any)
+	if len(sessions) != 1 {
+		t.Fatalf("expected 1 session, got %v", sessions)
+	}
+	if item, _ := sessions[0].(map[string]any); item["title"] != "Last night's sleep" {
+		t.Fatalf("expected stored title in list, got %v", item["title"])
+	}
+}
+
+func TestGetChatMessagesPaginatesBackwardsWithCursor(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+	for _, content := range []string{"first question", "a1", "q2", "a2", "q3"} {
+		role := "user"
+		if content[0] == 'a' {
+			role = "assistant"
+		}
+		createChatMessageForTest(t, fixture.UserID, sessionID, role, content)
+	}
+
+	loadPage := func(query string) map[string]any {
+		rec := performRequest(
+			t,
+			newTestRouter(t),
+			http.MethodGet,
+			"/api/v1/chat/sessions/"+sessionID+"/messages?"+query,
+			signToken(t, fixture.UserID, nil),
+			nil,
+			nil,
+		)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200 for %q, got %d body=%s", query, rec.Code, rec.Body.String())
+		}
+		return decodeJSONMap(t, rec)
+	}
+	contentsOf := func(body map[string]any) []string {
+		rawMessages, _ := body["messages"].([]any)
+		contents := make([]string, 0, len(rawMessages))
+		for _, raw := range rawMessages {
+			message, _ := raw.(map[string]any)
+			content, _ := message["content"].(string)
+			contents = append(contents, content)
+		}
+		return contents
+	}
+
+	first := loadPage("limit=2")
+	if got := contentsOf(first); len(got) != 2 || got[0] != "a2" || got[1] != "q3" {
+		t.Fatalf("unexpected first page: %v", got)
+	}
+	if first["title"] != "first question" {
+		t.Fatalf("expected title from the true first user message, got %v", first["title"])
+	}
+	cursor, _ := first["next_cursor"].(string)
+	if cursor == "" {
+		t.Fatalf("expected next_cursor, got %v", first["next_cursor"])
+	}
+
+	second := loadPage("limit=2&before=" + cursor)
+	if got := contentsOf(second); len(got) != 2 || got[0] != "a1" || got[1] != "q2" {
+		t.Fatalf("unexpected second page: %v", got)
+	}
+	cursor, _ = second["next_cursor"].(string)
+
+	last := loadPage("limit=2&before=" + cursor)
+	if got := contentsOf(last); len(got) != 1 || got[0] != "first question" {
+		t.Fatalf("unexpected last page: %v", got)
+	}
+	if last["next_cursor"] != nil {
+		t.Fatalf("expected no next_cursor on the last page, got %v", last["next_cursor"])
+	}
+
+	if got := loadChatMessageContentsForTest(t, fixture.UserID, sessionID); len(got) != 5 {
+		t.Fatalf("expected unpaginated load to return all 5 messages, got %v", got)
+	}
+}
+
+func TestRegenerateChatAnswerReplacesLastAnswer(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+
+	query := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/chat/query",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"session_id":        sessionID,
+			"child_id":          fixture.BabyID,
+			"query":             "how was her sleep today?",
+			"use_personal_data": true,
+		},
+		nil,
+	)
+	if query.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", query.Code, query.Body.String())
+	}
+	originalID, _ := decodeJSONMap(t, query)["message_id"].(string)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/chat/sessions/"+sessionID+"/regenerate",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	if body["replaced_message_id"] != originalID {
+		t.Fatalf("expected replaced_message_id=%s, got %v", originalID, body["replaced_message_id"])
+	}
+	if newID, _ := body["message_id"].(string); newID == "" || newID == originalID {
+		t.Fatalf("expected a new assistant message id, got %v", body["message_id"])
+	}
+
+	contents := loadChatMessageContentsForTest(t, fixture.UserID, sessionID)
+	if len(contents) != 2 || contents[0] != "how was her sleep today?" {
+		t.Fatalf("expected the question once followed by the new answer, got %v", contents)
+	}
+
+	createChatMessageForTest(t, fixture.UserID, sessionID, "user", "and yesterday?")
+	conflict := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/chat/sessions/"+sessionID+"/regenerate",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if conflict.Code != http.StatusConflict {
+		t.Fatalf("expected 409 when the last message is a question, got %d body=%s", conflict.Code, conflict.Body.String())
+	}
+}
+
+func TestUpdateChatSessionPersistsTonePreference(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+
+	invalid := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPatch,
+		"/api/v1/chat/sessions/"+sessionID,
+		signToken(t, fixture.UserID, nil),
+		map[string]any{"language": "klingon"},
+		nil,
+	)
+	if invalid.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown language, got %d body=%s", invalid.Code, invalid.Body.String())
+	}
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPatch,
+		"/api/v1/chat/sessions/"+sessionID,
+		signToken(t, fixture.UserID, nil),
+		map[string]any{"tone": " Coach ", "language": "en-US"},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	if body["tone"] != "coach" || body["language"] != "en" {
+		t.Fatalf("expected normalized preferences, got tone=%v language=%v", body["tone"], body["language"])
+	}
+
+	query := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/chat/query",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"session_id":        sessionID,
+			"child_id":          fixture.BabyID,
+			"query":             "how was her sleep today?",
+			"use_personal_data": true,
+		},
+		nil,
+	)
+	if query.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", query.Code, query.Body.String())
+	}
+
+	var storedTone string
+	if err := testPool.QueryRow(
+		context.Background(),
+		`SELECT "contextJson"->>'tone' FROM "ChatMessage" WHERE "sessionId" = $1 AND role = 'user'`,
+		sessionID,
+	).Scan(&storedTone); err != nil {
+		t.Fatalf("load user message context: %v", err)
+	}
+	if storedTone != "coach" {
+		t.Fatalf("expected the session tone to be used, got %q", storedTone)
+	}
+}
+
+func TestClassifyChatQuestionDoesNotStoreMessages(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/chat/classify",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"question":   "I'm so tired today",
+			"session_id": sessionID,
+		},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	if body["intent"] != "smalltalk" || body["intent_source"] != "caregiver_self_talk_guard" || body["caregiver_self_talk"] != true {
+		t.Fatalf("expected the caregiver self-talk guardrail, got %v", body)
+	}
+
+	if contents := loadChatMessageContentsForTest(t, fixture.UserID, sessionID); len(contents) != 0 {
+		t.Fatalf("expected classify to store no messages, got %v", contents)
+	}
+}
+
+func TestSearchChatMessagesMatchesCaseInsensitively(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+
+	createChatMessageForTest(t, fixture.UserID, sessionID, "user", "She has a mild Fever tonight")
+	answerID := createChatMessageForTest(t, fixture.UserID, sessionID, "assistant", "For a fever under 38C, keep her hydrated.")
+	createChatMessageForTest(t, fixture.UserID, sessionID, "user", "100% sure it is teething?")
+
+	other := seedUser(t, "")
+	otherHousehold := seedHousehold(t, "", other)
+	otherBaby := seedBaby(t, "", otherHousehold, "other-baby", time.Now().UTC().AddDate(-1, 0, 0))
+	otherSession := createSessionForTest(t, other, otherBaby)
+	createChatMessageForTest(t, other, otherSession, "user", "fever again")
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/chat/search?q=FEVER",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	results, _ := decodeJSONMap(t, rec)["results"].([]any)
+	if len(results) != 2 {
+		t.Fatalf("expected the caller's two fever messages, got %v", results)
+	}
+	foundAnswer := false
+	for _, raw := range results {
+		result, _ := raw.(map[string]any)
+		if result["session_id"] != sessionID {
+			t.Fatalf("expected only the caller's session, got %v", result)
+		}
+		if result["message_id"] == answerID && result["role"] == "assistant" {
+			foundAnswer = true
+		}
+	}
+	if !foundAnswer {
+		t.Fatalf("expected the assistant answer among results, got %v", results)
+	}
+
+	rec = performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/chat/search?q=%25",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if results, _ := decodeJSONMap(t, rec)["results"].([]any); len(results) != 1 {
+		t.Fatalf("expected %% to match literally, got %v", results)
+	}
+}
+
+func TestArchiveChatSessionHidesItFromList(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+	listSessions := func(path string) []any {
+		rec := performRequest(t, router, http.MethodGet, path, token, nil, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("list sessions: expected 200, got %d body=%s", rec.Code, rec.Body.String())
+		}
+		sessions, _ := decodeJSONMap(t, rec)["sessions"].([]any)
+		return sessions
+	}
+
+	rec := performRequest(t, router, http.MethodPost, "/api/v1/chat/sessions/"+sessionID+"/archive", token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if body := decodeJSONMap(t, rec); body["archived"] != true || body["status"] != "closed" || body["ended_at"] == nil {
+		t.Fatalf("expected an archived, closed session, got %v", body)
+	}
+
+	if sessions := listSessions("/api/v1/chat/sessions"); len(sessions) != 0 {
+		t.Fatalf("expected archived session to be hidden, got %v", sessions)
+	}
+	sessions := listSessions("/api/v1/chat/sessions?include_archived=true")
+	if len(sessions) != 1 {
+		t.Fatalf("expected archived session with include_archived, got %v", sessions)
+	}
+	if item, _ := sessions[0].(map[string]any); item["archived"] != true || item["status"] != "closed" {
+		t.Fatalf("expected archived flag on the item, got %v", item)
+	}
+
+	rec = performRequest(t, router, http.MethodPost, "/api/v1/chat/sessions/"+sessionID+"/unarchive", token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	sessions = listSessions("/api/v1/chat/sessions")
+	if len(sessions) != 1 {
+		t.Fatalf("expected unarchived session to be listed, got %v", sessions)
+	}
+	if item, _ := sessions[0].(map[string]any); item["archived"] != false || item["status"] != "closed" {
+		t.Fatalf("expected unarchived session to stay closed, got %v", item)
+	}
+}
+
+func TestExportChatSessionRendersTranscript(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	sessionID := createSessionForTest(t, fixture.UserID, fixture.BabyID)
+	createChatMessageForTest(t, fixture.UserID, sessionID, "user", "Is a 38.2C fever a concern?")
+	createChatMessageForTest(t, fixture.UserID, sessionID, "assistant", "Call your pediatrician if it lasts.")
+
+	export := func(query string) *httptest.ResponseRecorder {
+		return performRequest(
+			t,
+			newTestRouter(t),
+			http.MethodGet,
+			"/api/v1/chat/sessions/"+sessionID+"/export?"+query,
+			signToken(t, fixture.UserID, nil),
+			nil,
+			nil,
+		)
+	}
+
+	rec := export("tz_offset=%2B09:00")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if contentType := rec.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/markdown") {
+		t.Fatalf("unexpected Content-Type: %q", contentType)
+	}
+	if disposition := rec.Header().Get("Content-Disposition"); !strings.Contains(disposition, ".md\"") {
+		t.Fatalf("unexpected Content-Disposition: %q", disposition)
+	}
+	body := rec.Body.String()
+	if !strings.HasPrefix(body, "# Is a 38.2C fever a concern?") || !strings.Contains(body, "(UTC+09:00)") {
+		t.Fatalf("unexpected transcript header: %q", body)
+	}
+	question := strings.Index(body, "### Parent")
+	answer := strings.Index(body, "### Assistant")
+	if question < 0 || answer < question {
+		t.Fatalf("expected parent message before assistant answer: %q", body)
+	}
+	if strings.Contains(body, "context_json") {
+		t.Fatalf("transcript leaked internal context: %q", body)
+	}
+
+	rec = export("format=txt")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 for txt, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if contentType := rec.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/plain") {
+		t.Fatalf("unexpected Content-Type: %q", contentType)
+	}
+	if !strings.Contains(rec.Body.String(), "] Assistant:\nCall your pediatrician if it lasts.") {
+		t.Fatalf("unexpected txt transcript: %q", rec.Body.String())
+	}
+
+	if rec := export("format=pdf"); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown format, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,81 @@
+package server
+
+import "strings"
+
+// chatStreamSanitizer holds streamed answer fragments until a line or
+// sentence is complete and runs the final answer's cleanup on it, so a
+// timestamp or internal term split across fragments never reaches the client
+// raw.
+type chatStreamSanitizer struct {
+	pending strings.Builder
+	terms   []jargonTerm
+	emit    func(string)
+}
+
+func newChatStreamSanitizer(terms []jargonTerm, emit func(string)) *chatStreamSanitizer {
+	return &chatStreamSanitizer{terms: terms, emit: emit}
+}
+
+// Write buffers a fragment and emits everything up to the last complete line
+// or sentence.
+func (s *chatStreamSanitizer) Write(fragment string) {
+	if fragment == "" {
+		return
+	}
+	s.pending.WriteString(fragment)
+	buffered := s.pending.String()
+	cut := lastChatStreamBoundary(buffered)
+	if cut <= 0 {
+		return
+	}
+	s.pending.Reset()
+	s.pending.WriteString(buffered[cut:])
+	s.emitSegment(buffered[:cut])
+}
+
+// Flush emits whatever is left once the model has finished.
+func (s *chatStreamSanitizer) Flush() {
+	buffered := s.pending.String()
+	s.pending.Reset()
+	s.emitSegment(buffered)
+}
+
+// emitSegment cleans the segment like the final answer but keeps the
+// whitespace around it, which sanitizeUserFacingAnswer would trim and which
+// joins this segment to the next.
+func (s *chatStreamSanitizer) emitSegment(segment string) {
+	if segment == "" {
+		return
+	}
+	core := strings.TrimSpace(segment)
+	if core == "" {
+		s.emit(segment)
+		return
+	}
+	start := strings.Index(segment, core)
+	lead, trail := segment[:start], segment[start+len(core):]
+	cleaned, _ := softenInternalJargon(sanitizeUserFacingAnswer(core), s.terms)
+	if cleaned == "" {
+		if lead+trail != "" {
+			s.emit(lead + trail)
+		}
+		return
+	}
+	s.emit(lead + cleaned + trail)
+}
+
+// lastChatStreamBoundary returns the index just past the last newline or the
+// last sentence end followed by a space, or 0 when no boundary is buffered.
+func lastChatStreamBoundary(text string) int {
+	for i := len(text) - 1; i >= 0; i-- {
+		switch text[i] {
+		case '\n':
+			return i + 1
+		case ' ', '\t':
+			if i > 0 && (strings.ContainsRune(".!?", rune(text[i-1])) || strings.HasSuffix(text[:i], "。")) {
+				return i + 1
+			}
+		}
+	}
+	return 0
+}
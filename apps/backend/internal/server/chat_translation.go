@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// translateTargetLanguages maps the accepted translate_to codes to the
+// language name used in the translation prompt.
+var translateTargetLanguages = map[string]string{
+	"en": "English",
+	"ja": "Japanese",
+	"zh": "Simplified Chinese",
+	"vi": "Vietnamese",
+	"th": "Thai",
+	"tl": "Filipino",
+	"es": "Spanish",
+	"fr": "French",
+	"de": "German",
+	"ru": "Russian",
+}
+
+func normalizeTranslateTarget(raw string) string {
+	code := strings.ToLower(strings.TrimSpace(raw))
+	code, _, _ = strings.Cut(code, "-")
+	if _, ok := translateTargetLanguages[code]; !ok {
+		return ""
+	}
+	return code
+}
+
+// normalizePreferredLanguage accepts Korean, the default answer language, in
+// addition to the translate_to codes.
+func normalizePreferredLanguage(raw string) string {
+	code := strings.ToLower(strings.TrimSpace(raw))
+	code, _, _ = strings.Cut(code, "-")
+	if code == "ko" {
+		return code
+	}
+	return normalizeTranslateTarget(code)
+}
+
+func supportedTranslateTargets() []string {
+	codes := make([]string, 0, len(translateTargetLanguages))
+	for code := range translateTargetLanguages {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func buildTranslationSystemPrompt(language string) string {
+	return strings.Join([]string{
+		"You translate a Korean baby-care answer for a bilingual household.",
+		"Translate the user message into " + language + ".",
+		"Keep every number, time, date, unit and line break exactly as written.",
+		"Do not add advice, greetings or notes. Output only the translation.",
+	}, "\n")
+}
+
+func (a *App) translateChatAnswer(ctx context.Context, model, answer, targetCode string) (string, AIUsage, error) {
+	language, ok := translateTargetLanguages[targetCode]
+	if !ok {
+		return "", AIUsage{}, errors.New("unsupported translate_to")
+	}
+	response, err := a.ai.Query(ctx, AIModelRequest{
+		Model:        model,
+		SystemPrompt: buildTranslationSystemPrompt(language),
+		UserPrompt:   answer,
+	})
+	if err != nil {
+		return "", AIUsage{}, err
+	}
+	translated := strings.TrimSpace(response.Answer)
+	if translated == "" {
+		return "", response.Usage, errors.New("empty translation")
+	}
+	return translated, response.Usage, nil
+}
+
+func addAIUsage(left, right AIUsage) AIUsage {
+	return AIUsage{
+		PromptTokens:     left.PromptTokens + right.PromptTokens,
+		CompletionTokens: left.CompletionTokens + right.CompletionTokens,
+		TotalTokens:      left.TotalTokens + right.TotalTokens,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"math"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,10 +13,41 @@ import (
 )
 
 const (
+	// graceLimitPerDay is the free-tier allowance; see limitsForPlan.
 	graceLimitPerDay = 3
-	reserveCredits   = 2
+
+	// The preflight hold is priced like a call of this size so that a model's
+	// reservation follows the same rates as its final charge.
+	reservePromptTokens     = 1000
+	reserveCompletionTokens = 1000
 )
 
+// modelPricing is the credit rate per 1k tokens for one model.
+type modelPricing struct {
+	PromptCreditsPer1K     float64
+	CompletionCreditsPer1K float64
+}
+
+var fallbackModelPricing = modelPricing{PromptCreditsPer1K: 1, CompletionCreditsPer1K: 1}
+
+// defaultModelPricing applies when AI_MODEL_PRICING does not list a model.
+// Adding a model here (or to the env table) is all that is needed to price it.
+var defaultModelPricing = map[string]modelPricing{
+	chatCoreModel:  {PromptCreditsPer1K: 1, CompletionCreditsPer1K: 1},
+	chatDailyModel: {PromptCreditsPer1K: 1, CompletionCreditsPer1K: 1},
+}
+
+type creditBreakdown struct {
+	Model                  string  `json:"model"`
+	PromptTokens           int     `json:"prompt_tokens"`
+	CompletionTokens       int     `json:"completion_tokens"`
+	PromptCreditsPer1K     float64 `json:"prompt_credits_per_1k"`
+	CompletionCreditsPer1K float64 `json:"completion_credits_per_1k"`
+	PromptCredits          float64 `json:"prompt_credits"`
+	CompletionCredits      float64 `json:"completion_credits"`
+	Charged                int     `json:"charged"`
+}
+
 type billingMode string
 
 const (
@@ -29,6 +61,7 @@ type preflightResult struct {
 	Plan          *string
 	BalanceBefore int
 	GraceUsed     int
+	Limits        planLimits
 }
 
 type billingResult struct {
@@ -37,9 +70,43 @@ type billingResult struct {
 	BillingMode  billingMode
 	GraceUsed    int
 	GraceLimit   int
+	TurnLimit    int
 	Plan         *string
 }
 
+// planLimits are the chat allowances that differ by subscription plan.
+type planLimits struct {
+	GraceLimitPerDay      int
+	ConversationTurnLimit int
+}
+
+// defaultPlanLimits applies to AI_ONLY and to households without an enabled
+// subscription.
+var defaultPlanLimits = planLimits{
+	GraceLimitPerDay:      graceLimitPerDay,
+	ConversationTurnLimit: chatConversationTurnLimit,
+}
+
+func limitsForPlan(plan, status string) planLimits {
+	if !isEnabledSubscriptionStatus(status) {
+		return defaultPlanLimits
+	}
+	switch normalizeSubscriptionPlan(plan) {
+	case "AI_PHOTO":
+		return planLimits{GraceLimitPerDay: 5, ConversationTurnLimit: 40}
+	default:
+		return defaultPlanLimits
+	}
+}
+
+func (a *App) planLimitsForHousehold(ctx context.Context, householdID string) (planLimits, error) {
+	plan, status, err := a.getLatestSubscription(ctx, householdID)
+	if err != nil {
+		return planLimits{}, err
+	}
+	return limitsForPlan(plan, status), nil
+}
+
 func creditsForPlan(plan string) int {
 	switch strings.ToUpper(strings.TrimSpace(plan)) {
 	case "AI_ONLY":
@@ -170,7 +237,73 @@ func (a *App) getWalletBalance(ctx context.Context, q dbQuerier, userID string)
 	return balance, err
 }
 
-func (a *App) preflightBilling(ctx context.Context, userID, householdID string, now time.Time) (preflightResult, error) {
+// parseModelPricingTable reads "model=prompt_per_1k:completion_per_1k" entries.
+// Malformed or negative entries are skipped.
+func parseModelPricingTable(entries []string) map[string]modelPricing {
+	table := make(map[string]modelPricing, len(entries))
+	for _, entry := range entries {
+		model, rates, ok := strings.Cut(entry, "=")
+		model = strings.TrimSpace(model)
+		if !ok || model == "" {
+			continue
+		}
+		promptRaw, completionRaw, ok := strings.Cut(rates, ":")
+		if !ok {
+			continue
+		}
+		promptRate, err := strconv.ParseFloat(strings.TrimSpace(promptRaw), 64)
+		if err != nil || promptRate < 0 {
+			continue
+		}
+		completionRate, err := strconv.ParseFloat(strings.TrimSpace(completionRaw), 64)
+		if err != nil || completionRate < 0 {
+			continue
+		}
+		table[model] = modelPricing{PromptCreditsPer1K: promptRate, CompletionCreditsPer1K: completionRate}
+	}
+	return table
+}
+
+func (a *App) pricingForModel(model string) modelPricing {
+	model = strings.TrimSpace(model)
+	if pricing, ok := a.modelPricing[model]; ok {
+		return pricing
+	}
+	if pricing, ok := defaultModelPricing[model]; ok {
+		return pricing
+	}
+	return fallbackModelPricing
+}
+
+func creditBreakdownForUsage(model string, pricing modelPricing, usage AIUsage) creditBreakdown {
+	promptTokens := usage.PromptTokens
+	completionTokens := usage.CompletionTokens
+	if promptTokens <= 0 && completionTokens <= 0 && usage.TotalTokens > 0 {
+		// Providers that only report a total are billed at the prompt rate.
+		promptTokens = usage.TotalTokens
+	}
+	promptCredits := math.Max(float64(promptTokens), 0) / 1000.0 * pricing.PromptCreditsPer1K
+	completionCredits := math.Max(float64(completionTokens), 0) / 1000.0 * pricing.CompletionCreditsPer1K
+	return creditBreakdown{
+		Model:                  model,
+		PromptTokens:           promptTokens,
+		CompletionTokens:       completionTokens,
+		PromptCreditsPer1K:     pricing.PromptCreditsPer1K,
+		CompletionCreditsPer1K: pricing.CompletionCreditsPer1K,
+		PromptCredits:          promptCredits,
+		CompletionCredits:      completionCredits,
+		Charged:                int(math.Ceil(promptCredits + completionCredits)),
+	}
+}
+
+func reserveCreditsForPricing(pricing modelPricing) int {
+	return creditBreakdownForUsage("", pricing, AIUsage{
+		PromptTokens:     reservePromptTokens,
+		CompletionTokens: reserveCompletionTokens,
+	}).Charged
+}
+
+func (a *App) preflightBilling(ctx context.Context, userID, householdID, model string, now time.Time) (preflightResult, error) {
 	if forcedPlan, forcedStatus, ok := a.localForcedSubscription(); ok {
 		if isEnabledSubscriptionStatus(forcedStatus) && planSupportsFeature(forcedPlan, subscriptionFeatureAI) {
 			plan := forcedPlan
@@ -180,10 +313,16 @@ func (a *App) preflightBilling(ctx context.Context, userID, householdID string,
 				Plan:          &plan,
 				BalanceBefore: 0,
 				GraceUsed:     0,
+				Limits:        limitsForPlan(forcedPlan, forcedStatus),
 			}, nil
 		}
 	}
 
+	limits, err := a.planLimitsForHousehold(ctx, householdID)
+	if err != nil {
+		return preflightResult{}, err
+	}
+
 	tx, err := a.db.Begin(ctx)
 	if err != nil {
 		return preflightResult{}, err
@@ -217,7 +356,9 @@ func (a *App) preflightBilling(ctx context.Context, userID, householdID string,
 		Plan:          plan,
 		BalanceBefore: balance,
 		GraceUsed:     graceUsed,
+		Limits:        limits,
 	}
+	reserveCredits := reserveCreditsForPricing(a.pricingForModel(model))
 	if balance >= reserveCredits {
 		if _, err := tx.Exec(
 			ctx,
@@ -229,7 +370,7 @@ func (a *App) preflightBilling(ctx context.Context, userID, householdID string,
 		}
 		result.Mode = billingModePaid
 		result.Reserved = reserveCredits
-	} else if graceUsed < graceLimitPerDay {
+	} else if graceUsed < limits.GraceLimitPerDay {
 		result.Mode = billingModeGrace
 		result.Reserved = 0
 	} else {
@@ -243,13 +384,6 @@ func (a *App) preflightBilling(ctx context.Context, userID, householdID string,
 	return result, nil
 }
 
-func creditsFromTokens(totalTokens int) int {
-	if totalTokens <= 0 {
-		return 0
-	}
-	return int(math.Ceil(float64(totalTokens) / 1000.0))
-}
-
 func (a *App) releaseReservedCredits(ctx context.Context, userID string, reserved int) error {
 	if reserved <= 0 {
 		return nil
@@ -279,9 +413,10 @@ func (a *App) finalizeBillingAndLog(
 	}
 	defer tx.Rollback(ctx)
 
+	breakdown := creditBreakdownForUsage(model, a.pricingForModel(model), usage)
 	charged := 0
 	if preflight.Mode == billingModePaid {
-		charged = creditsFromTokens(usage.TotalTokens)
+		charged = breakdown.Charged
 		delta := preflight.Reserved - charged
 		if delta != 0 {
 			_, err := tx.Exec(
@@ -312,14 +447,16 @@ func (a *App) finalizeBillingAndLog(
 	}
 
 	questionChars := len([]rune(strings.TrimSpace(question)))
+	usageLogID := uuid.NewString()
+	breakdown.Charged = charged
 	_, err = tx.Exec(
 		ctx,
 		`INSERT INTO "AiUsageLog" (
 			id, "userId", "householdId", "childId", model,
 			"promptTokens", "completionTokens", "totalTokens",
-			"chargedCredits", "billingMode", "questionChars", "createdAt"
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10::"AiBillingMode", $11, NOW())`,
-		uuid.NewString(),
+			"chargedCredits", "billingMode", "questionChars", "pricingJson", "createdAt"
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10::"AiBillingMode", $11, $12, NOW())`,
+		usageLogID,
 		userID,
 		householdID,
 		childID,
@@ -330,6 +467,7 @@ func (a *App) finalizeBillingAndLog(
 		charged,
 		strings.ToUpper(string(preflight.Mode)),
 		questionChars,
+		mustMarshalJSON(breakdown),
 	)
 	if err != nil {
 		return billingResult{}, err
@@ -347,12 +485,21 @@ func (a *App) finalizeBillingAndLog(
 	if err := tx.Commit(ctx); err != nil {
 		return billingResult{}, err
 	}
+
 	return billingResult{
 		Charged:      charged,
 		BalanceAfter: balanceAfter,
 		BillingMode:  preflight.Mode,
 		GraceUsed:    graceUsed,
-		GraceLimit:   graceLimitPerDay,
+		GraceLimit:   preflight.Limits.GraceLimitPerDay,
+		TurnLimit:    preflight.Limits.ConversationTurnLimit,
 		Plan:         preflight.Plan,
 	}, nil
 }
+
+// EnsureUsageLogPricingSchema adds the per-response pricing column that
+// finalizeBillingAndLog writes inside the billing transaction.
+func (a *App) EnsureUsageLogPricingSchema(ctx context.Context) error {
+	_, err := a.db.Exec(ctx, `ALTER TABLE "AiUsageLog" ADD COLUMN IF NOT EXISTS "pricingJson" JSONB`)
+	return err
+}
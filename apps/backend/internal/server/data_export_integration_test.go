@@ -66,3 +66,38 @@ func TestExportBabyDataCSVRequiresBabyID(t *testing.T) {
 		t.Fatalf("unexpected detail: %q", detail)
 	}
 }
+
+func TestExportEventsCSVUsesLocalTimesAndRange(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	start := time.Date(2026, 3, 10, 23, 30, 0, 0, time.UTC) // 08:30 on Mar 11 in Seoul
+	end := start.Add(20 * time.Minute)
+	seedEvent(t, "", fixture.BabyID, "FORMULA", start, &end, map[string]any{"ml": 140, "memo": "after nap"}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "PEE", start.AddDate(0, 0, -3), nil, map[string]any{"count": 1}, fixture.UserID)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/events/export?baby_id="+fixture.BabyID+"&from=2026-03-11&to=2026-03-11&tz_offset=%2B09:00",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if disposition := rec.Header().Get("Content-Disposition"); !strings.Contains(disposition, "test-baby_2026-03-11_2026-03-11.csv") {
+		t.Fatalf("unexpected Content-Disposition: %q", disposition)
+	}
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected header and one row, got %q", rec.Body.String())
+	}
+	if lines[0] != "type,start_local,end_local,duration_min,amount_ml,memo,source" {
+		t.Fatalf("unexpected header: %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "FORMULA,2026-03-11 08:30,2026-03-11 08:50,20,140,after nap,") {
+		t.Fatalf("unexpected row: %q", lines[1])
+	}
+}
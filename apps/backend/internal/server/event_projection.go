@@ -175,10 +175,7 @@ func (a *App) projectEventToPRDTables(
 		return err
 
 	case "MEDICATION":
-		medName := strings.TrimSpace(toString(value["name"]))
-		if medName == "" {
-			medName = strings.TrimSpace(toString(value["med_name"]))
-		}
+		medName := medicationNameFromValue(value)
 		if medName == "" {
 			medName = "unspecified"
 		}
@@ -240,6 +237,96 @@ func (a *App) projectEventToPRDTables(
 	return nil
 }
 
+// shiftProjectedEvents moves the PRD rows projected from events of one type
+// that started at startTimes by shiftMinutes. Projected rows carry no event
+// id, so they are matched on child and start time; a single UPDATE per type
+// keeps rows moved onto another event's old start from being shifted twice.
+func shiftProjectedEvents(ctx context.Context, q dbQuerier, childID, eventType string, startTimes []time.Time, shiftMinutes int) error {
+	if len(startTimes) == 0 || shiftMinutes == 0 {
+		return nil
+	}
+	var query string
+	args := []any{childID, startTimes, shiftMinutes}
+	switch strings.ToUpper(strings.TrimSpace(eventType)) {
+	case "SLEEP":
+		query = `UPDATE "SleepEvent"
+		 SET "startAt" = "startAt" + $3::int * INTERVAL '1 minute',
+		     "endAt" = "endAt" + $3::int * INTERVAL '1 minute',
+		     "updatedAt" = NOW()
+		 WHERE "childId" = $1 AND "startAt" = ANY($2)`
+	case "FORMULA", "BREASTFEED":
+		query = `UPDATE "IntakeEvent"
+		 SET "startAt" = "startAt" + $3::int * INTERVAL '1 minute',
+		     "endAt" = "endAt" + $3::int * INTERVAL '1 minute',
+		     "updatedAt" = NOW()
+		 WHERE "childId" = $1 AND "startAt" = ANY($2) AND "intakeType" = $4`
+		args = append(args, strings.ToLower(strings.TrimSpace(eventType)))
+	case "SYMPTOM":
+		query = `UPDATE "TemperatureEvent"
+		 SET "measuredAt" = "measuredAt" + $3::int * INTERVAL '1 minute', "updatedAt" = NOW()
+		 WHERE "childId" = $1 AND "measuredAt" = ANY($2)`
+	case "PEE", "POO":
+		query = `UPDATE "DiaperEvent"
+		 SET at = at + $3::int * INTERVAL '1 minute', "updatedAt" = NOW()
+		 WHERE "childId" = $1 AND at = ANY($2) AND (CASE WHEN $4 = 'PEE' THEN pee ELSE poo END)`
+		args = append(args, strings.ToUpper(strings.TrimSpace(eventType)))
+	case "MEDICATION":
+		query = `UPDATE "MedicationEvent"
+		 SET at = at + $3::int * INTERVAL '1 minute', "updatedAt" = NOW()
+		 WHERE "childId" = $1 AND at = ANY($2)`
+	case "MEMO":
+		query = `UPDATE "NoteEvent"
+		 SET at = at + $3::int * INTERVAL '1 minute', "updatedAt" = NOW()
+		 WHERE "childId" = $1 AND at = ANY($2)`
+	default:
+		return nil
+	}
+	_, err := q.Exec(ctx, query, args...)
+	return err
+}
+
+// deleteProjectedEvents removes the PRD row projected from an event of
+// eventType that started at startTime. Like shiftProjectedEvents it matches on
+// child and start time, and only one row goes even if two events share it.
+func deleteProjectedEvents(ctx context.Context, q dbQuerier, childID, eventType string, startTime time.Time) error {
+	var query string
+	args := []any{childID, startTime.UTC()}
+	switch strings.ToUpper(strings.TrimSpace(eventType)) {
+	case "SLEEP":
+		query = `DELETE FROM "SleepEvent" WHERE id IN (
+		   SELECT id FROM "SleepEvent" WHERE "childId" = $1 AND "startAt" = $2 LIMIT 1
+		 )`
+	case "FORMULA", "BREASTFEED":
+		query = `DELETE FROM "IntakeEvent" WHERE id IN (
+		   SELECT id FROM "IntakeEvent" WHERE "childId" = $1 AND "startAt" = $2 AND "intakeType" = $3 LIMIT 1
+		 )`
+		args = append(args, strings.ToLower(strings.TrimSpace(eventType)))
+	case "SYMPTOM":
+		query = `DELETE FROM "TemperatureEvent" WHERE id IN (
+		   SELECT id FROM "TemperatureEvent" WHERE "childId" = $1 AND "measuredAt" = $2 LIMIT 1
+		 )`
+	case "PEE", "POO":
+		query = `DELETE FROM "DiaperEvent" WHERE id IN (
+		   SELECT id FROM "DiaperEvent"
+		   WHERE "childId" = $1 AND at = $2 AND (CASE WHEN $3 = 'PEE' THEN pee ELSE poo END)
+		   LIMIT 1
+		 )`
+		args = append(args, strings.ToUpper(strings.TrimSpace(eventType)))
+	case "MEDICATION":
+		query = `DELETE FROM "MedicationEvent" WHERE id IN (
+		   SELECT id FROM "MedicationEvent" WHERE "childId" = $1 AND at = $2 LIMIT 1
+		 )`
+	case "MEMO":
+		query = `DELETE FROM "NoteEvent" WHERE id IN (
+		   SELECT id FROM "NoteEvent" WHERE "childId" = $1 AND at = $2 LIMIT 1
+		 )`
+	default:
+		return nil
+	}
+	_, err := q.Exec(ctx, query, args...)
+	return err
+}
+
 func (a *App) closeOpenSleepEvents(ctx context.Context, q dbQuerier, childID string, nextStart time.Time) error {
 	rows, err := q.Query(
 		ctx,
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// eventTrashRetentionDays is how long a deleted event stays restorable before
+// the purge job removes it.
+const eventTrashRetentionDays = 30
+
+func eventTrashCutoff(now time.Time) time.Time {
+	return now.UTC().AddDate(0, 0, -eventTrashRetentionDays)
+}
+
+func eventRestorable(deletedAt, now time.Time) bool {
+	return deletedAt.UTC().After(eventTrashCutoff(now))
+}
+
+// EnsureEventTrashSchema adds the soft-delete column that nearly every event
+// query filters on. It runs once at startup because those reads have no
+// missing-column retry of their own.
+func (a *App) EnsureEventTrashSchema(ctx context.Context) error {
+	statements := []string{
+		`ALTER TABLE "Event" ADD COLUMN IF NOT EXISTS "deletedAt" TIMESTAMP(3)`,
+		`CREATE INDEX IF NOT EXISTS "Event_deletedAt_idx" ON "Event"("deletedAt")`,
+	}
+	for _, stmt := range statements {
+		if _, err := a.db.Exec(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunEventTrashPurgeScheduler permanently deletes events that have been in the
+// trash longer than eventTrashRetentionDays. It runs once at start and then on
+// every interval until ctx is canceled.
+func (a *App) RunEventTrashPurgeScheduler(ctx context.Context) {
+	intervalMin := a.cfg.EventTrashPurgeIntervalMin
+	if intervalMin <= 0 {
+		intervalMin = 1440
+	}
+	ticker := time.NewTicker(time.Duration(intervalMin) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		purged, err := a.purgeTrashedEvents(ctx, time.Now().UTC())
+		if err != nil && ctx.Err() == nil {
+			log.Printf("event trash purge job failed: %v", err)
+		} else if purged > 0 {
+			log.Printf("event trash purge job deleted %d event(s)", purged)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeTrashedEvents hard-deletes events trashed before the retention cutoff.
+// Their PRD rows were already removed when they were trashed.
+func (a *App) purgeTrashedEvents(ctx context.Context, now time.Time) (int64, error) {
+	result, err := a.db.Exec(
+		ctx,
+		`DELETE FROM "Event" WHERE "deletedAt" IS NOT NULL AND "deletedAt" <= $1`,
+		eventTrashCutoff(now),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,422 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	eventFutureSkewTolerance = 5 * time.Minute
+	eventDuplicateWindow     = 2 * time.Minute
+	// durationOverrideSlackMin is how far an explicit duration_min may run
+	// past end-start, for a start that was tapped a little late.
+	durationOverrideSlackMin = 60
+	// sleepMinDurationMin is the shortest SLEEP that counts as sleep; below it
+	// the event is almost always a start and complete double tap.
+	sleepMinDurationMin = 1.0
+)
+
+// manualEventMaxDuration flags durations that are almost certainly a missed
+// end tap rather than a real session. Types not listed use the default.
+var manualEventMaxDuration = map[string]time.Duration{
+	"SLEEP":      16 * time.Hour,
+	"BREASTFEED": 2 * time.Hour,
+	"FORMULA":    2 * time.Hour,
+}
+
+const manualEventDefaultMaxDuration = 24 * time.Hour
+
+// manualEventOverlapTypes are the duration events where two rows covering
+// the same time cannot both be right.
+var manualEventOverlapTypes = map[string]struct{}{
+	"SLEEP":      {},
+	"BREASTFEED": {},
+}
+
+type eventValidationIssue struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	EventID string `json:"event_id,omitempty"`
+}
+
+type validatedManualEvent struct {
+	BabyID     string
+	Type       string
+	StartTime  time.Time
+	EndTime    *time.Time
+	Visibility string
+}
+
+// validateManualEventPayload runs the request-only checks shared by
+// createManualEvent and validateEvent. Errors block saving; the first one is
+// what createManualEvent reports.
+func validateManualEventPayload(payload manualEventCreateRequest, now time.Time) (validatedManualEvent, []eventValidationIssue, []eventValidationIssue) {
+	event := validatedManualEvent{BabyID: strings.TrimSpace(payload.BabyID)}
+	errs := make([]eventValidationIssue, 0, 2)
+	warnings := make([]eventValidationIssue, 0, 2)
+
+	if event.BabyID == "" {
+		errs = append(errs, eventValidationIssue{Field: "baby_id", Code: "required", Message: "baby_id is required"})
+	}
+	eventType, valid := normalizeEventType(payload.Type)
+	if !valid {
+		errs = append(errs, eventValidationIssue{Field: "type", Code: "invalid", Message: "type is invalid"})
+	}
+	event.Type = eventType
+	if payload.StartTime.IsZero() {
+		errs = append(errs, eventValidationIssue{Field: "start_time", Code: "required", Message: "start_time is required"})
+	}
+	if valid {
+		visibility, err := normalizeEventVisibility(payload.Visibility, eventType)
+		if err != nil {
+			errs = append(errs, eventValidationIssue{Field: "visibility", Code: "invalid", Message: err.Error()})
+		}
+		event.Visibility = visibility
+	}
+	if len(errs) > 0 {
+		return event, errs, warnings
+	}
+
+	event.StartTime = payload.StartTime.UTC()
+	if payload.EndTime != nil {
+		endTime := payload.EndTime.UTC()
+		if endTime.Before(event.StartTime) {
+			errs = append(errs, eventValidationIssue{Field: "end_time", Code: "before_start", Message: "end_time must be after start_time"})
+			return event, errs, warnings
+		}
+		event.EndTime = &endTime
+
+		maxDuration, ok := manualEventMaxDuration[eventType]
+		if !ok {
+			maxDuration = manualEventDefaultMaxDuration
+		}
+		if endTime.Sub(event.StartTime) > maxDuration {
+			warnings = append(warnings, eventValidationIssue{
+				Field:   "end_time",
+				Code:    "long_duration",
+				Message: fmt.Sprintf("duration is longer than %d hours", int(maxDuration.Hours())),
+			})
+		}
+	}
+	if isFutureEventStart(event.StartTime, now, eventFutureSkewTolerance) {
+		warnings = append(warnings, eventValidationIssue{Field: "start_time", Code: "future_start", Message: "start_time is in the future"})
+	}
+	return event, errs, warnings
+}
+
+// eventValueMaxDurationMin caps an explicit SLEEP duration_min.
+const eventValueMaxDurationMin = 24 * 60
+
+// validateEventValue checks the value map of a new event against its type so
+// downstream aggregates never see a FORMULA without an amount or a GROWTH
+// without a measurement. Types without a rule, MEMO included, accept any
+// value. Feeding amounts must already be normalized to ml.
+func validateEventValue(eventType string, value map[string]any) *eventValidationIssue {
+	switch eventType {
+	case "FORMULA":
+		if present, amount := eventValueNumber(value, "ml", "amount_ml", "volume_ml"); !present || amount <= 0 {
+			return &eventValidationIssue{Field: "value.ml", Code: "required", Message: "value.ml must be a positive number for FORMULA"}
+		}
+	case "SLEEP":
+		if present, duration := eventValueNumber(value, "duration_min"); present && (duration <= 0 || duration > eventValueMaxDurationMin) {
+			return &eventValidationIssue{
+				Field:   "value.duration_min",
+				Code:    "out_of_range",
+				Message: fmt.Sprintf("value.duration_min must be between 0 and %d minutes for SLEEP", eventValueMaxDurationMin),
+			}
+		}
+	case "GROWTH":
+		weightPresent, weight := eventValueNumber(value, "weight_kg", "weight")
+		heightPresent, height := eventValueNumber(value, "height_cm", "length_cm", "height")
+		if (weightPresent && weight <= 0) || (heightPresent && height <= 0) || (weight <= 0 && height <= 0) {
+			return &eventValidationIssue{Field: "value", Code: "invalid", Message: "GROWTH needs a positive value.weight_kg or value.height_cm"}
+		}
+	case "MEDICATION":
+		if medicationNameFromValue(value) == "" {
+			return &eventValidationIssue{Field: "value.name", Code: "required", Message: "value.name is required for MEDICATION"}
+		}
+	}
+	return nil
+}
+
+// medicationNameKeys are the value keys clients have used for a medication's
+// name, in the order writers and readers prefer them.
+var medicationNameKeys = []string{"name", "med_name", "medication_name", "medication_type"}
+
+func medicationNameFromValue(value map[string]any) string {
+	for _, key := range medicationNameKeys {
+		if text := strings.TrimSpace(toString(value[key])); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// eventValueNumber reports whether any of keys is set and the first one's
+// numeric value; a set key that does not parse reads as 0.
+func eventValueNumber(value map[string]any, keys ...string) (bool, float64) {
+	for _, key := range keys {
+		if _, ok := value[key]; ok {
+			return true, extractNumberFromMap(value, key)
+		}
+	}
+	return false, 0
+}
+
+// isFutureEventStart reports a start more than tolerance past now. Future
+// events break ETA math and "today" summaries, so writers reject them unless
+// the caller asked for a scheduled entry.
+func isFutureEventStart(start, now time.Time, tolerance time.Duration) bool {
+	return start.UTC().After(now.UTC().Add(tolerance))
+}
+
+// errFutureEventStart is returned for a start past eventFutureSkewTolerance
+// without ?allow_future=true.
+var errFutureEventStart = fmt.Errorf(
+	"start_time must not be more than %d minutes in the future; pass allow_future=true for scheduled entries",
+	int(eventFutureSkewTolerance.Minutes()),
+)
+
+// manualEventRecordWarnings compares a validated event with what is already
+// stored: a start before the birth date, a likely duplicate of the same type,
+// or a duration event that overlaps another one.
+func (a *App) manualEventRecordWarnings(ctx context.Context, q dbQuerier, userID string, event validatedManualEvent) ([]eventValidationIssue, error) {
+	warnings := make([]eventValidationIssue, 0, 2)
+
+	var birthDate time.Time
+	if err := q.QueryRow(ctx, `SELECT "birthDate" FROM "Baby" WHERE id = $1`, event.BabyID).Scan(&birthDate); err != nil {
+		return nil, err
+	}
+	if event.StartTime.Before(startOfUTCDay(birthDate).AddDate(0, 0, -1)) {
+		warnings = append(warnings, eventValidationIssue{Field: "start_time", Code: "before_birth", Message: "start_time is before the baby's birth date"})
+	}
+
+	var duplicateID string
+	err := q.QueryRow(
+		ctx,
+		`SELECT id
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND type = $2
+		   AND "startTime" BETWEEN $3 AND $4
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
+		   AND `+eventVisibleToUserSQL("$5")+`
+		 ORDER BY "startTime" ASC
+		 LIMIT 1`,
+		event.BabyID,
+		event.Type,
+		event.StartTime.Add(-eventDuplicateWindow),
+		event.StartTime.Add(eventDuplicateWindow),
+		userID,
+	).Scan(&duplicateID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	if duplicateID != "" {
+		warnings = append(warnings, eventValidationIssue{
+			Field:   "start_time",
+			Code:    "possible_duplicate",
+			Message: "an event of the same type was already logged within 2 minutes",
+			EventID: duplicateID,
+		})
+	}
+
+	if _, ok := manualEventOverlapTypes[event.Type]; ok && event.EndTime != nil {
+		var overlapID string
+		err := q.QueryRow(
+			ctx,
+			`SELECT id
+			 FROM "Event"
+			 WHERE "babyId" = $1
+			   AND "deletedAt" IS NULL
+			   AND type = $2
+			   AND "endTime" IS NOT NULL
+			   AND "startTime" < $4
+			   AND "endTime" > $3
+			   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
+			 ORDER BY "startTime" ASC
+			 LIMIT 1`,
+			event.BabyID,
+			event.Type,
+			event.StartTime,
+			*event.EndTime,
+		).Scan(&overlapID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		if overlapID != "" && overlapID != duplicateID {
+			warnings = append(warnings, eventValidationIssue{
+				Field:   "end_time",
+				Code:    "overlap",
+				Message: "overlaps another " + event.Type + " event",
+				EventID: overlapID,
+			})
+		}
+	}
+	return warnings, nil
+}
+
+// resolveDurationOverride checks an explicit duration_min against the event
+// interval. The override may be shorter than end-start (a late complete tap)
+// but not more than durationOverrideSlackMin longer.
+func resolveDurationOverride(durationMin float64, start, end time.Time) (float64, error) {
+	if durationMin <= 0 {
+		return 0, errors.New("duration_min must be positive")
+	}
+	intervalMin := end.Sub(start).Minutes()
+	if durationMin > intervalMin+durationOverrideSlackMin {
+		return 0, fmt.Errorf("duration_min must not exceed end_time - start_time by more than %d minutes", durationOverrideSlackMin)
+	}
+	return roundToOneDecimal(durationMin), nil
+}
+
+// zeroDurationSleepSQL is the SQL side of isZeroDurationSleep for queries
+// that pick sleeps without loading them: a flagged row, or a sub-minute
+// interval with no stored duration_min.
+const zeroDurationSleepSQL = `(type = 'SLEEP' AND "endTime" IS NOT NULL AND (
+		COALESCE("metadataJson"->>'zero_duration_sleep', '') = 'true'
+		OR ("endTime" - "startTime" < INTERVAL '1 minute' AND NOT ("valueJson" ? 'duration_min'))
+	))`
+
+// isZeroDurationSleep reports a closed SLEEP shorter than sleepMinDurationMin.
+// A stored duration_min wins over end-start, as in extractDurationMinutes.
+func isZeroDurationSleep(eventType string, value map[string]any, start time.Time, end *time.Time) bool {
+	if eventType != "SLEEP" || end == nil {
+		return false
+	}
+	duration := extractDurationMinutes(value, start, end)
+	return duration != nil && *duration < sleepMinDurationMin
+}
+
+// zeroDurationSleepIssue applies SLEEP_ZERO_DURATION_MODE to a closed event.
+// It returns nil for anything but a sub-minute sleep; otherwise the issue is
+// an error in reject mode (the default) and a warning in flag mode.
+func (a *App) zeroDurationSleepIssue(eventType string, value map[string]any, start time.Time, end *time.Time) (*eventValidationIssue, bool) {
+	if !isZeroDurationSleep(eventType, value, start, end) {
+		return nil, false
+	}
+	issue := &eventValidationIssue{
+		Field:   "end_time",
+		Code:    "zero_duration",
+		Message: "sleep must last at least 1 minute",
+	}
+	rejected := !strings.EqualFold(strings.TrimSpace(a.cfg.SleepZeroDurationMode), "flag")
+	return issue, rejected
+}
+
+// markZeroDurationSleep sets or clears the metadata flag that keeps a
+// sub-minute sleep saved in flag mode out of sleep totals.
+func markZeroDurationSleep(metadata map[string]any, zeroDuration bool) {
+	if zeroDuration {
+		metadata["zero_duration_sleep"] = true
+		return
+	}
+	delete(metadata, "zero_duration_sleep")
+}
+
+// defaultEventDuplicateWindow is how close two events of the same type must
+// start for createManualEvent to treat the second as a double tap. MEMO is
+// off by default since several notes in a row are normal;
+// EVENT_DUPLICATE_WINDOW_SEC overrides any type.
+const defaultEventDuplicateWindow = 60 * time.Second
+
+var defaultEventDuplicateWindowByType = map[string]time.Duration{
+	"MEMO": 0,
+}
+
+// parseEventDuplicateWindows reads EVENT_DUPLICATE_WINDOW_SEC entries of the
+// form TYPE=seconds; 0 turns the check off for that type.
+func parseEventDuplicateWindows(entries []string) map[string]time.Duration {
+	windows := make(map[string]time.Duration, len(entries))
+	for _, entry := range entries {
+		rawType, rawSeconds, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+		eventType, valid := normalizeEventType(rawType)
+		seconds, err := strconv.Atoi(strings.TrimSpace(rawSeconds))
+		if !valid || err != nil || seconds < 0 {
+			continue
+		}
+		windows[eventType] = time.Duration(seconds) * time.Second
+	}
+	return windows
+}
+
+func (a *App) eventDuplicateWindow(eventType string) time.Duration {
+	if window, ok := parseEventDuplicateWindows(a.cfg.EventDuplicateWindows)[eventType]; ok {
+		return window
+	}
+	if window, ok := defaultEventDuplicateWindowByType[eventType]; ok {
+		return window
+	}
+	return defaultEventDuplicateWindow
+}
+
+// findDuplicateEvent returns the id of a closed event of the same type that
+// started less than window away from start, or "" when there is none. Events
+// the user cannot see are skipped so their ids do not leak.
+func findDuplicateEvent(ctx context.Context, q dbQuerier, userID, babyID, eventType string, start time.Time, window time.Duration) (string, error) {
+	var duplicateID string
+	err := q.QueryRow(
+		ctx,
+		`SELECT id
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND type = $2
+		   AND "startTime" > $3
+		   AND "startTime" < $4
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') = 'CLOSED'
+		   AND `+eventVisibleToUserSQL("$5")+`
+		 ORDER BY "startTime" ASC
+		 LIMIT 1`,
+		babyID,
+		eventType,
+		start.UTC().Add(-window),
+		start.UTC().Add(window),
+		userID,
+	).Scan(&duplicateID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return "", err
+	}
+	return duplicateID, nil
+}
+
+// findOverlappingSleep returns the id of a closed SLEEP of the baby whose
+// interval intersects [start, end), or "" when there is none. Sleeps that only
+// touch at an endpoint do not overlap.
+func findOverlappingSleep(ctx context.Context, q dbQuerier, babyID, excludeEventID string, start, end time.Time) (string, error) {
+	var overlapID string
+	err := q.QueryRow(
+		ctx,
+		`SELECT id
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND type = 'SLEEP'
+		   AND id <> $2
+		   AND "endTime" IS NOT NULL
+		   AND "startTime" < $4
+		   AND "endTime" > $3
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
+		 ORDER BY "startTime" ASC
+		 LIMIT 1`,
+		babyID,
+		excludeEventID,
+		start.UTC(),
+		end.UTC(),
+	).Scan(&overlapID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return "", err
+	}
+	return overlapID, nil
+}
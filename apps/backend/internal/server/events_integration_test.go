@@ -1,8 +1,11 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"mime/multipart"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -238,6 +241,134 @@ func TestParseVoiceEventCreatesParsedClip(t *testing.T) {
 	}
 }
 
+func TestParseVoiceEventTranscribesMultipartAudio(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("baby_id", fixture.BabyID); err != nil {
+		t.Fatalf("write baby_id: %v", err)
+	}
+	part, err := writer.CreateFormFile("audio", "clip.m4a")
+	if err != nil {
+		t.Fatalf("create audio part: %v", err)
+	}
+	// The test STT client reads the uploaded bytes back as the transcript.
+	if _, err := part.Write([]byte("pee 15 minutes ago")); err != nil {
+		t.Fatalf("write audio: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close multipart: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/events/voice", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+signToken(t, fixture.UserID, nil))
+	rec := httptest.NewRecorder()
+	newTestRouter(t).ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	response := decodeJSONMap(t, rec)
+	if response["transcript"] != "pee 15 minutes ago" {
+		t.Fatalf("expected transcribed text, got %v", response["transcript"])
+	}
+	events, _ := response["parsed_events"].([]any)
+	if len(events) != 1 {
+		t.Fatalf("expected one parsed event, got %v", response["parsed_events"])
+	}
+	if first, _ := events[0].(map[string]any); first["type"] != "PEE" {
+		t.Fatalf("expected PEE event, got %v", events[0])
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var transcript string
+	if err := testPool.QueryRow(ctx, `SELECT transcript FROM "VoiceClip" WHERE id = $1`, response["clip_id"]).Scan(&transcript); err != nil {
+		t.Fatalf("query created clip: %v", err)
+	}
+	if transcript != "pee 15 minutes ago" {
+		t.Fatalf("expected persisted transcript, got %q", transcript)
+	}
+}
+
+func TestParseVoiceEventStoresEverySpokenEvent(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/events/voice",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"baby_id":         fixture.BabyID,
+			"transcript_hint": "formula 120ml then changed a poo diaper, grandma visited",
+		},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	events, _ := body["parsed_events"].([]any)
+	if len(events) != 3 {
+		t.Fatalf("expected 3 parsed events, got %v", body["parsed_events"])
+	}
+	memo, _ := events[2].(map[string]any)
+	if memo["type"] != "MEMO" || memo["needs_review"] != true {
+		t.Fatalf("expected trailing MEMO flagged for review, got %v", memo)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var storedCount int
+	if err := testPool.QueryRow(
+		ctx,
+		`SELECT jsonb_array_length("parsedEventsJson") FROM "VoiceClip" WHERE id = $1`,
+		body["clip_id"],
+	).Scan(&storedCount); err != nil {
+		t.Fatalf("query created clip: %v", err)
+	}
+	if storedCount != 3 {
+		t.Fatalf("expected 3 stored parsed events, got %d", storedCount)
+	}
+}
+
+func TestParseVoiceEventRequiresAudioOrTranscript(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/events/voice",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{"baby_id": fixture.BabyID},
+		nil,
+	)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/events/voice",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{"baby_id": fixture.BabyID, "audio_object_key": "uploads/voice/missing.m4a"},
+		nil,
+	)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 without VOICE_AUDIO_BASE_URL, got %d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
 func TestParseVoiceEventRejectsUserWithoutHouseholdAccess(t *testing.T) {
 	resetDatabase(t)
 	fixture := seedOwnerFixture(t)
@@ -259,3 +390,243 @@ func TestParseVoiceEventRejectsUserWithoutHouseholdAccess(t *testing.T) {
 		t.Fatalf("unexpected detail: %q", detail)
 	}
 }
+
+func TestListVoiceClipsReturnsNewestWithEventCount(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	seedVoiceClip(t, "", fixture.HouseholdID, fixture.BabyID, "FAILED")
+
+	parseRec := performRequest(
+		t,
+		router,
+		http.MethodPost,
+		"/api/v1/events/voice",
+		token,
+		map[string]any{"baby_id": fixture.BabyID, "transcript_hint": "pee then poo"},
+		nil,
+	)
+	if parseRec.Code != http.StatusOK {
+		t.Fatalf("expected 200 from voice parse, got %d body=%s", parseRec.Code, parseRec.Body.String())
+	}
+
+	rec := performRequest(t, router, http.MethodGet, "/api/v1/voice/clips?baby_id="+fixture.BabyID, token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	clips, _ := decodeJSONMap(t, rec)["clips"].([]any)
+	if len(clips) != 2 {
+		t.Fatalf("expected 2 clips, got %v", clips)
+	}
+	newest, _ := clips[0].(map[string]any)
+	if newest["transcript"] != "pee then poo" || newest["parsed_event_count"] != float64(2) {
+		t.Fatalf("expected newest clip with 2 parsed events, got %v", newest)
+	}
+
+	outsiderRec := performRequest(
+		t,
+		router,
+		http.MethodGet,
+		"/api/v1/voice/clips?baby_id="+fixture.BabyID,
+		signToken(t, seedUser(t, ""), nil),
+		nil,
+		nil,
+	)
+	if outsiderRec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for outsider, got %d body=%s", outsiderRec.Code, outsiderRec.Body.String())
+	}
+}
+
+func TestConfirmEventsResolvesParsedEventsPartially(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	clipID := seedVoiceClip(t, "", fixture.HouseholdID, fixture.BabyID, "PARSED")
+	startTime := time.Now().UTC().Add(-30 * time.Minute).Truncate(time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := testPool.Exec(
+		ctx,
+		`UPDATE "VoiceClip" SET "parsedEventsJson" = $2 WHERE id = $1`,
+		clipID,
+		mustJSONBytes(t, []map[string]any{
+			{"type": "FORMULA", "start_time": startTime.Format(time.RFC3339), "value": map[string]any{"ml": 120}},
+			{"type": "PEE", "start_time": startTime.Format(time.RFC3339), "value": map[string]any{"count": 1}},
+			{"type": "POO", "start_time": startTime.Format(time.RFC3339), "value": map[string]any{"count": 1}},
+		}),
+	); err != nil {
+		t.Fatalf("seed parsed events: %v", err)
+	}
+
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	confirm := func(body map[string]any) *httptest.ResponseRecorder {
+		body["clip_id"] = clipID
+		return performRequest(t, router, http.MethodPost, "/api/v1/events/confirm", token, body, nil)
+	}
+
+	rec := confirm(map[string]any{"confirm_indices": []int{0}})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	if body["status"] != "PARTIALLY_CONFIRMED" {
+		t.Fatalf("expected PARTIALLY_CONFIRMED, got %v", body["status"])
+	}
+	if pending, _ := body["pending_indices"].([]any); len(pending) != 2 {
+		t.Fatalf("expected two pending parsed events, got %v", body["pending_indices"])
+	}
+
+	if rec := confirm(map[string]any{"confirm_indices": []int{0}}); rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for an already resolved index, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if rec := confirm(map[string]any{"confirm_indices": []int{5}}); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an out-of-range index, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = confirm(map[string]any{"reject_indices": []int{1}, "confirm_indices": []int{2}})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if body := decodeJSONMap(t, rec); body["status"] != "CONFIRMED" {
+		t.Fatalf("expected CONFIRMED once every parsed event is resolved, got %v", body["status"])
+	}
+
+	var eventTypes []string
+	rows, err := testPool.Query(ctx, `SELECT type FROM "Event" WHERE "babyId" = $1 ORDER BY type`, fixture.BabyID)
+	if err != nil {
+		t.Fatalf("query events: %v", err)
+	}
+	for rows.Next() {
+		var eventType string
+		if err := rows.Scan(&eventType); err != nil {
+			t.Fatalf("scan event: %v", err)
+		}
+		eventTypes = append(eventTypes, eventType)
+	}
+	rows.Close()
+	if strings.Join(eventTypes, ",") != "FORMULA,POO" {
+		t.Fatalf("expected only the confirmed FORMULA and POO to be saved, got %v", eventTypes)
+	}
+
+	var status string
+	var resolutionCount int
+	if err := testPool.QueryRow(
+		ctx,
+		`SELECT status::text, jsonb_array_length("resolutionsJson") FROM "VoiceClip" WHERE id = $1`,
+		clipID,
+	).Scan(&status, &resolutionCount); err != nil {
+		t.Fatalf("query clip: %v", err)
+	}
+	if status != "CONFIRMED" || resolutionCount != 3 {
+		t.Fatalf("expected CONFIRMED clip with 3 resolutions, got %q count=%d", status, resolutionCount)
+	}
+}
+
+func TestReparseVoiceClipUpdatesFailedAndRejectsConfirmed(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	failedID := seedVoiceClip(t, "", fixture.HouseholdID, fixture.BabyID, "FAILED")
+	confirmedID := seedVoiceClip(t, "", fixture.HouseholdID, fixture.BabyID, "CONFIRMED")
+
+	rec := performRequest(t, router, http.MethodPost, "/api/v1/voice/clips/"+failedID+"/reparse", token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if body := decodeJSONMap(t, rec); body["status"] != "PARSED" {
+		t.Fatalf("expected PARSED, got %v", body["status"])
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var status string
+	var parsedCount, auditCount int
+	if err := testPool.QueryRow(
+		ctx,
+		`SELECT status::text, jsonb_array_length("parsedEventsJson") FROM "VoiceClip" WHERE id = $1`,
+		failedID,
+	).Scan(&status, &parsedCount); err != nil {
+		t.Fatalf("query reparsed clip: %v", err)
+	}
+	if status != "PARSED" || parsedCount == 0 {
+		t.Fatalf("expected PARSED clip with events, got %q count=%d", status, parsedCount)
+	}
+	if err := testPool.QueryRow(
+		ctx,
+		`SELECT COUNT(*) FROM "AuditLog" WHERE action = 'VOICE_CLIP_REPARSED' AND "targetId" = $1`,
+		failedID,
+	).Scan(&auditCount); err != nil {
+		t.Fatalf("query audit log: %v", err)
+	}
+	if auditCount != 1 {
+		t.Fatalf("expected 1 VOICE_CLIP_REPARSED audit row, got %d", auditCount)
+	}
+
+	conflictRec := performRequest(t, router, http.MethodPost, "/api/v1/voice/clips/"+confirmedID+"/reparse", token, nil, nil)
+	if conflictRec.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for confirmed clip, got %d body=%s", conflictRec.Code, conflictRec.Body.String())
+	}
+}
+
+func TestLowConfidenceEventsListsOnlyWeakVoiceEvents(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	clipID := seedVoiceClip(t, "", fixture.HouseholdID, fixture.BabyID, "PARSED")
+	now := time.Now().UTC().Truncate(time.Second)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+
+	confirmRec := performRequest(
+		t,
+		router,
+		http.MethodPost,
+		"/api/v1/events/confirm",
+		token,
+		map[string]any{
+			"clip_id": clipID,
+			"events": []map[string]any{
+				{
+					"type":       "FORMULA",
+					"start_time": now.Add(-2 * time.Hour).Format(time.RFC3339),
+					"value":      map[string]any{"ml": 120},
+					"confidence": map[string]float64{"type": 0.97, "ml": 0.55},
+				},
+				{
+					"type":       "POO",
+					"start_time": now.Add(-time.Hour).Format(time.RFC3339),
+					"value":      map[string]any{"count": 1},
+					"confidence": map[string]float64{"type": 0.98, "start_time": 0.95},
+				},
+			},
+		},
+		nil,
+	)
+	if confirmRec.Code != http.StatusOK {
+		t.Fatalf("confirm failed: %d body=%s", confirmRec.Code, confirmRec.Body.String())
+	}
+	seedEvent(t, "", fixture.BabyID, "PEE", now.Add(-30*time.Minute), nil, nil, fixture.UserID)
+
+	rec := performRequest(t, router, http.MethodGet, "/api/v1/babies/"+fixture.BabyID+"/low-confidence?threshold=0.8", token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	events, ok := decodeJSONMap(t, rec)["events"].([]any)
+	if !ok || len(events) != 1 {
+		t.Fatalf("expected only the weak formula event, got %v", events)
+	}
+	event := events[0].(map[string]any)
+	if event["type"] != "FORMULA" || event["min_confidence"] != 0.55 {
+		t.Fatalf("unexpected low-confidence event: %v", event)
+	}
+	if fields, _ := event["low_fields"].([]any); len(fields) != 1 || fields[0] != "ml" {
+		t.Fatalf("expected ml as the low field, got %v", event["low_fields"])
+	}
+
+	badRec := performRequest(t, router, http.MethodGet, "/api/v1/babies/"+fixture.BabyID+"/low-confidence?threshold=1.5", token, nil, nil)
+	if badRec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for threshold above 1, got %d", badRec.Code)
+	}
+}
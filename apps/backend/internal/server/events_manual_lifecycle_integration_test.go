@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -108,6 +110,73 @@ func TestStartManualEventRejectsDuplicateOpenEvent(t *testing.T) {
 	}
 }
 
+func TestStartManualEventAllowConcurrentForMemo(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	start := time.Now().UTC().Add(-20 * time.Minute).Truncate(time.Second)
+
+	startMemo := func(offset time.Duration) *httptest.ResponseRecorder {
+		return performRequest(
+			t,
+			newTestRouter(t),
+			http.MethodPost,
+			"/api/v1/events/start",
+			signToken(t, fixture.UserID, nil),
+			map[string]any{
+				"baby_id":          fixture.BabyID,
+				"type":             "MEMO",
+				"start_time":       start.Add(offset).Format(time.RFC3339),
+				"allow_concurrent": true,
+			},
+			nil,
+		)
+	}
+	if rec := startMemo(0); rec.Code != http.StatusOK {
+		t.Fatalf("expected first memo 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if rec := startMemo(2 * time.Minute); rec.Code != http.StatusOK {
+		t.Fatalf("expected concurrent memo 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var openCount int
+	if err := testPool.QueryRow(
+		ctx,
+		`SELECT COUNT(*)::int FROM "Event" WHERE "babyId" = $1 AND type = 'MEMO' AND "endTime" IS NULL`,
+		fixture.BabyID,
+	).Scan(&openCount); err != nil {
+		t.Fatalf("count open memos: %v", err)
+	}
+	if openCount != 2 {
+		t.Fatalf("expected 2 open memos, got %d", openCount)
+	}
+}
+
+func TestStartManualEventAllowConcurrentRejectedForExclusiveType(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	start := time.Now().UTC().Add(-20 * time.Minute).Truncate(time.Second)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/events/start",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"baby_id":          fixture.BabyID,
+			"type":             "SLEEP",
+			"start_time":       start.Format(time.RFC3339),
+			"allow_concurrent": true,
+		},
+		nil,
+	)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for exclusive SLEEP, got %d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
 func TestStartManualEventAllowsDifferentTypeOverlap(t *testing.T) {
 	resetDatabase(t)
 	fixture := seedOwnerFixture(t)
@@ -590,3 +659,1213 @@ func TestStartManualEventSupportsExpandedStartableTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestGetStaleOpenEventsListsOldOpenSleepOnly(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	sleepStart := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/events/start",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"baby_id":    fixture.BabyID,
+			"type":       "SLEEP",
+			"start_time": now.Add(-5 * time.Hour).Format(time.RFC3339),
+		},
+		nil,
+	)
+	if sleepStart.Code != http.StatusOK {
+		t.Fatalf("sleep start failed: %d body=%s", sleepStart.Code, sleepStart.Body.String())
+	}
+	sleepEventID, _ := decodeJSONMap(t, sleepStart)["event_id"].(string)
+
+	formulaStart := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/events/start",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"baby_id":    fixture.BabyID,
+			"type":       "FORMULA",
+			"start_time": now.Add(-10 * time.Minute).Format(time.RFC3339),
+		},
+		nil,
+	)
+	if formulaStart.Code != http.StatusOK {
+		t.Fatalf("formula start failed: %d body=%s", formulaStart.Code, formulaStart.Body.String())
+	}
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/events/open/stale?baby_id="+fixture.BabyID+"&older_than_min=120",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	if count, ok := body["stale_count"].(float64); !ok || int(count) != 1 {
+		t.Fatalf("expected stale_count=1, got %v", body["stale_count"])
+	}
+	items, _ := body["stale_events"].([]any)
+	item, _ := items[0].(map[string]any)
+	if item["event_id"] != sleepEventID || item["type"] != "SLEEP" {
+		t.Fatalf("expected stale SLEEP %s, got %v", sleepEventID, item)
+	}
+	if elapsed, ok := item["elapsed_min"].(float64); !ok || elapsed < 299 {
+		t.Fatalf("expected elapsed_min around 300, got %v", item["elapsed_min"])
+	}
+	if item["suggested_action"] != "complete" {
+		t.Fatalf("expected suggested_action=complete, got %v", item["suggested_action"])
+	}
+
+	listRec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/events/open?baby_id="+fixture.BabyID,
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if count, ok := decodeJSONMap(t, listRec)["open_count"].(float64); !ok || int(count) != 2 {
+		t.Fatalf("expected stale lookup to leave both events open, got %v", count)
+	}
+}
+
+func TestMergeEventsSumsFormulaAndTrashesDuplicates(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	start := time.Now().UTC().Add(-90 * time.Minute).Truncate(time.Second)
+
+	keepID := seedEvent(t, "", fixture.BabyID, "FORMULA", start, nil, map[string]any{"ml": 60}, fixture.UserID)
+	duplicateID := seedEvent(t, "", fixture.BabyID, "FORMULA", start.Add(2*time.Minute), nil, map[string]any{"ml": 40, "memo": "second bottle"}, fixture.UserID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	app := New(baseTestConfig, testPool)
+	for _, seeded := range []struct {
+		start time.Time
+		ml    int
+	}{{start, 60}, {start.Add(2 * time.Minute), 40}} {
+		if err := app.projectEventToPRDTables(ctx, testPool, fixture.BabyID, "FORMULA", seeded.start, nil, map[string]any{"ml": seeded.ml}); err != nil {
+			t.Fatalf("project seeded event: %v", err)
+		}
+	}
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/events/merge",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"keep_event_id":   keepID,
+			"merge_event_ids": []string{duplicateID},
+		},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	var valueRaw []byte
+	if err := testPool.QueryRow(ctx, `SELECT "valueJson" FROM "Event" WHERE id = $1`, keepID).Scan(&valueRaw); err != nil {
+		t.Fatalf("query kept event: %v", err)
+	}
+	value := map[string]any{}
+	if err := json.Unmarshal(valueRaw, &value); err != nil {
+		t.Fatalf("unmarshal value json: %v", err)
+	}
+	if value["ml"] != float64(100) {
+		t.Fatalf("expected merged ml=100, got %v", value["ml"])
+	}
+	if value["memo"] != "second bottle" {
+		t.Fatalf("expected missing fields to be filled from the merged event, got %v", value["memo"])
+	}
+
+	var metadataRaw []byte
+	var deletedAt *time.Time
+	if err := testPool.QueryRow(ctx, `SELECT "metadataJson", "deletedAt" FROM "Event" WHERE id = $1`, duplicateID).Scan(&metadataRaw, &deletedAt); err != nil {
+		t.Fatalf("query merged event: %v", err)
+	}
+	metadata := map[string]any{}
+	if err := json.Unmarshal(metadataRaw, &metadata); err != nil {
+		t.Fatalf("unmarshal metadata json: %v", err)
+	}
+	if deletedAt == nil || metadata["merged_into"] != keepID {
+		t.Fatalf("expected merged event to be trashed into %s, got deleted_at=%v metadata=%v", keepID, deletedAt, metadata)
+	}
+
+	var intakeCount int
+	var intakeML float64
+	if err := testPool.QueryRow(
+		ctx,
+		`SELECT COUNT(*), COALESCE(MAX("amountMl"), 0)::float8 FROM "IntakeEvent" WHERE "childId" = $1`,
+		fixture.BabyID,
+	).Scan(&intakeCount, &intakeML); err != nil {
+		t.Fatalf("query projected intake rows: %v", err)
+	}
+	if intakeCount != 1 || intakeML != 100 {
+		t.Fatalf("expected one projected intake row with 100 ml, got count=%d ml=%v", intakeCount, intakeML)
+	}
+}
+
+func TestMergedEventCannotBeRestored(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	start := time.Now().UTC().Add(-90 * time.Minute).Truncate(time.Second)
+
+	keepID := seedEvent(t, "", fixture.BabyID, "FORMULA", start, nil, map[string]any{"ml": 60}, fixture.UserID)
+	duplicateID := seedEvent(t, "", fixture.BabyID, "FORMULA", start.Add(2*time.Minute), nil, map[string]any{"ml": 40}, fixture.UserID)
+
+	rec := performRequest(t, router, http.MethodPost, "/api/v1/events/merge", token, map[string]any{
+		"keep_event_id":   keepID,
+		"merge_event_ids": []string{duplicateID},
+	}, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = performRequest(t, router, http.MethodPost, "/api/v1/events/"+duplicateID+"/restore", token, nil, nil)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if body := decodeJSONMap(t, rec); body["merged_into"] != keepID {
+		t.Fatalf("expected merged_into=%s, got %v", keepID, body)
+	}
+}
+
+func TestMergeEventsRejectsOpenEvents(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	start := time.Now().UTC().Add(-90 * time.Minute).Truncate(time.Second)
+
+	keepID := seedEvent(t, "", fixture.BabyID, "FORMULA", start, nil, map[string]any{"ml": 60}, fixture.UserID)
+	started := performRequest(t, router, http.MethodPost, "/api/v1/events/start", token, map[string]any{
+		"baby_id":    fixture.BabyID,
+		"type":       "FORMULA",
+		"start_time": time.Now().UTC().Add(-5 * time.Minute).Format(time.RFC3339),
+	}, nil)
+	if started.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", started.Code, started.Body.String())
+	}
+	openID := decodeJSONMap(t, started)["event_id"].(string)
+
+	rec := performRequest(t, router, http.MethodPost, "/api/v1/events/merge", token, map[string]any{
+		"keep_event_id":   keepID,
+		"merge_event_ids": []string{openID},
+	}, nil)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if body := decodeJSONMap(t, rec); body["event_id"] != openID || body["event_status"] != "OPEN" {
+		t.Fatalf("expected the open event to be named, got %v", body)
+	}
+}
+
+func TestMergeEventsRejectsDifferentTypes(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	start := time.Now().UTC().Add(-90 * time.Minute).Truncate(time.Second)
+
+	keepID := seedEvent(t, "", fixture.BabyID, "FORMULA", start, nil, map[string]any{"ml": 60}, fixture.UserID)
+	otherID := seedEvent(t, "", fixture.BabyID, "PEE", start, nil, map[string]any{}, fixture.UserID)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/events/merge",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"keep_event_id":   keepID,
+			"merge_event_ids": []string{otherID},
+		},
+		nil,
+	)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestValidateEventReportsDuplicateAndOverlapWithoutSaving(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	sleepStart := time.Now().UTC().Add(-3 * time.Hour).Truncate(time.Second)
+	sleepEnd := sleepStart.Add(90 * time.Minute)
+	existingID := seedEvent(t, "", fixture.BabyID, "SLEEP", sleepStart, &sleepEnd, nil, fixture.UserID)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/events/validate",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"baby_id":    fixture.BabyID,
+			"type":       "SLEEP",
+			"start_time": sleepStart.Add(time.Minute).Format(time.RFC3339),
+			"end_time":   sleepEnd.Add(30 * time.Minute).Format(time.RFC3339),
+		},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	if body["valid"] != true {
+		t.Fatalf("expected warnings only, got %v", body)
+	}
+	warnings, _ := body["warnings"].([]any)
+	if len(warnings) != 1 {
+		t.Fatalf("expected one duplicate warning, got %v", warnings)
+	}
+	warning := warnings[0].(map[string]any)
+	if warning["code"] != "possible_duplicate" || warning["event_id"] != existingID {
+		t.Fatalf("unexpected warning: %v", warning)
+	}
+
+	invalidRec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/events/validate",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"baby_id":    fixture.BabyID,
+			"type":       "SLEEP",
+			"start_time": sleepEnd.Format(time.RFC3339),
+			"end_time":   sleepStart.Format(time.RFC3339),
+		},
+		nil,
+	)
+	if invalidRec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", invalidRec.Code, invalidRec.Body.String())
+	}
+	invalidBody := decodeJSONMap(t, invalidRec)
+	errs, _ := invalidBody["errors"].([]any)
+	if invalidBody["valid"] != false || len(errs) != 1 || errs[0].(map[string]any)["code"] != "before_start" {
+		t.Fatalf("expected before_start error, got %v", invalidBody)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var count int
+	if err := testPool.QueryRow(ctx, `SELECT COUNT(*)::int FROM "Event" WHERE "babyId" = $1`, fixture.BabyID).Scan(&count); err != nil {
+		t.Fatalf("count events: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected validate to persist nothing, got %d events", count)
+	}
+}
+
+func TestCompleteManualEventStoresDurationOverride(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	start := time.Now().UTC().Add(-3 * time.Hour).Truncate(time.Second)
+
+	startRec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/events/start",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"baby_id":    fixture.BabyID,
+			"type":       "SLEEP",
+			"start_time": start.Format(time.RFC3339),
+		},
+		nil,
+	)
+	if startRec.Code != http.StatusOK {
+		t.Fatalf("start request failed: %d body=%s", startRec.Code, startRec.Body.String())
+	}
+	eventID, _ := decodeJSONMap(t, startRec)["event_id"].(string)
+
+	tooLong := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPatch,
+		"/api/v1/events/"+eventID+"/complete",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{"duration_min": 400},
+		nil,
+	)
+	if tooLong.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for duration far beyond the interval, got %d body=%s", tooLong.Code, tooLong.Body.String())
+	}
+
+	completeRec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPatch,
+		"/api/v1/events/"+eventID+"/complete",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{"duration_min": 90},
+		nil,
+	)
+	if completeRec.Code != http.StatusOK {
+		t.Fatalf("complete request failed: %d body=%s", completeRec.Code, completeRec.Body.String())
+	}
+	if duration := decodeJSONMap(t, completeRec)["duration_min"]; duration != float64(90) {
+		t.Fatalf("expected duration_min=90 in response, got %v", duration)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var valueRaw []byte
+	if err := testPool.QueryRow(ctx, `SELECT "valueJson" FROM "Event" WHERE id = $1`, eventID).Scan(&valueRaw); err != nil {
+		t.Fatalf("query completed event: %v", err)
+	}
+	value := map[string]any{}
+	if err := json.Unmarshal(valueRaw, &value); err != nil {
+		t.Fatalf("unmarshal value json: %v", err)
+	}
+	// extractDurationMinutes prefers the stored value over end-start (~180 min).
+	if duration := extractDurationMinutes(value, start, nil); duration == nil || *duration != 90 {
+		t.Fatalf("expected stored duration_min=90, got %v", value["duration_min"])
+	}
+}
+
+func TestCompleteManualEventRejectsZeroDurationSleep(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	start := time.Now().UTC().Add(-10 * time.Minute).Truncate(time.Second)
+
+	startRec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/events/start",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"baby_id":    fixture.BabyID,
+			"type":       "SLEEP",
+			"start_time": start.Format(time.RFC3339),
+		},
+		nil,
+	)
+	if startRec.Code != http.StatusOK {
+		t.Fatalf("start request failed: %d body=%s", startRec.Code, startRec.Body.String())
+	}
+	eventID, _ := decodeJSONMap(t, startRec)["event_id"].(string)
+
+	completeRec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPatch,
+		"/api/v1/events/"+eventID+"/complete",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{"end_time": start.Add(20 * time.Second).Format(time.RFC3339)},
+		nil,
+	)
+	if completeRec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for sub-minute sleep, got %d body=%s", completeRec.Code, completeRec.Body.String())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var endTime *time.Time
+	if err := testPool.QueryRow(ctx, `SELECT "endTime" FROM "Event" WHERE id = $1`, eventID).Scan(&endTime); err != nil {
+		t.Fatalf("query sleep event: %v", err)
+	}
+	if endTime != nil {
+		t.Fatalf("expected rejected complete to leave the sleep open, got end %v", endTime)
+	}
+}
+
+func TestGetEventHistoryListsAuditEntriesInOrder(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	start := time.Now().UTC().Add(-20 * time.Minute).Truncate(time.Second)
+
+	startRec := performRequest(t, router, http.MethodPost, "/api/v1/events/start", token, map[string]any{
+		"baby_id":    fixture.BabyID,
+		"type":       "FORMULA",
+		"start_time": start.Format(time.RFC3339),
+	}, nil)
+	if startRec.Code != http.StatusOK {
+		t.Fatalf("start request failed: %d body=%s", startRec.Code, startRec.Body.String())
+	}
+	eventID, _ := decodeJSONMap(t, startRec)["event_id"].(string)
+
+	completeRec := performRequest(t, router, http.MethodPatch, "/api/v1/events/"+eventID+"/complete", token, map[string]any{
+		"end_time": start.Add(15 * time.Minute).Format(time.RFC3339),
+		"value":    map[string]any{"ml": 90},
+	}, nil)
+	if completeRec.Code != http.StatusOK {
+		t.Fatalf("complete request failed: %d body=%s", completeRec.Code, completeRec.Body.String())
+	}
+
+	rec := performRequest(t, router, http.MethodGet, "/api/v1/events/"+eventID+"/history", token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	entries, _ := decodeJSONMap(t, rec)["entries"].([]any)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 history entries, got %v", entries)
+	}
+	first, _ := entries[0].(map[string]any)
+	second, _ := entries[1].(map[string]any)
+	if first["action"] != "EVENT_MANUAL_STARTED" || second["action"] != "EVENT_MANUAL_COMPLETED" {
+		t.Fatalf("unexpected history order: %v, %v", first["action"], second["action"])
+	}
+	if first["actor_user_id"] != fixture.UserID {
+		t.Fatalf("expected actor %s, got %v", fixture.UserID, first["actor_user_id"])
+	}
+
+	outsiderID := seedUser(t, "")
+	denied := performRequest(t, router, http.MethodGet, "/api/v1/events/"+eventID+"/history", signToken(t, outsiderID, nil), nil, nil)
+	if denied.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for a non-member, got %d body=%s", denied.Code, denied.Body.String())
+	}
+}
+
+func TestShiftEventTimesMovesMatchingEventsAndKeepsOrder(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	base := time.Now().UTC().Add(-48 * time.Hour).Truncate(time.Second)
+
+	sleepEnd := base.Add(90 * time.Minute)
+	formulaID := seedEvent(t, "", fixture.BabyID, "FORMULA", base, nil, map[string]any{"ml": 90}, fixture.UserID)
+	sleepID := seedEvent(t, "", fixture.BabyID, "SLEEP", base.Add(30*time.Minute), &sleepEnd, nil, fixture.UserID)
+	peeID := seedEvent(t, "", fixture.BabyID, "PEE", base.Add(2*time.Hour), nil, nil, fixture.UserID)
+	outsideStart := base.Add(-5 * 24 * time.Hour)
+	outsideID := seedEvent(t, "", fixture.BabyID, "FORMULA", outsideStart, nil, map[string]any{"ml": 60}, fixture.UserID)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/babies/"+fixture.BabyID+"/events/shift",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"from":          base.Add(-time.Hour).Format(time.RFC3339),
+			"to":            base.Add(3 * time.Hour).Format(time.RFC3339),
+			"shift_minutes": -540,
+		},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if body := decodeJSONMap(t, rec); body["shifted_count"] != float64(3) {
+		t.Fatalf("expected 3 shifted events, got %v", body["shifted_count"])
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	rows, err := testPool.Query(
+		ctx,
+		`SELECT id, "startTime", "endTime" FROM "Event" WHERE "babyId" = $1 ORDER BY "startTime" ASC`,
+		fixture.BabyID,
+	)
+	if err != nil {
+		t.Fatalf("query events: %v", err)
+	}
+	defer rows.Close()
+	shift := -9 * time.Hour
+	expectedStarts := map[string]time.Time{
+		outsideID: outsideStart,
+		formulaID: base.Add(shift),
+		sleepID:   base.Add(30*time.Minute + shift),
+		peeID:     base.Add(2*time.Hour + shift),
+	}
+	order := make([]string, 0, 4)
+	for rows.Next() {
+		var eventID string
+		var startTime time.Time
+		var endTime *time.Time
+		if err := rows.Scan(&eventID, &startTime, &endTime); err != nil {
+			t.Fatalf("scan event: %v", err)
+		}
+		order = append(order, eventID)
+		if !startTime.UTC().Equal(expectedStarts[eventID]) {
+			t.Fatalf("expected event %s to start at %s, got %s", eventID, expectedStarts[eventID], startTime.UTC())
+		}
+		if eventID == sleepID && (endTime == nil || !endTime.UTC().Equal(sleepEnd.Add(shift))) {
+			t.Fatalf("expected sleep end to move with its start, got %v", endTime)
+		}
+	}
+	if strings.Join(order, ",") != strings.Join([]string{outsideID, formulaID, sleepID, peeID}, ",") {
+		t.Fatalf("expected event order to be preserved, got %v", order)
+	}
+
+	var auditCount int
+	if err := testPool.QueryRow(
+		ctx,
+		`SELECT COUNT(*) FROM "AuditLog" WHERE action = 'EVENT_TIME_SHIFTED'`,
+	).Scan(&auditCount); err != nil {
+		t.Fatalf("count audit logs: %v", err)
+	}
+	if auditCount != 3 {
+		t.Fatalf("expected one audit log per shifted event, got %d", auditCount)
+	}
+}
+
+func TestShiftEventTimesRejectsOversizedShift(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	now := time.Now().UTC()
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/babies/"+fixture.BabyID+"/events/shift",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"from":          now.Add(-24 * time.Hour).Format(time.RFC3339),
+			"to":            now.Format(time.RFC3339),
+			"shift_minutes": 3 * 24 * 60,
+		},
+		nil,
+	)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestCreateManualEventsBulkSavesAllInOneTransaction(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	start := time.Now().UTC().Add(-3 * time.Hour).Truncate(time.Second)
+	sleepEnd := start.Add(90 * time.Minute)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/events/bulk",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"baby_id": fixture.BabyID,
+			"events": []map[string]any{
+				{"type": "FORMULA", "start_time": start.Format(time.RFC3339), "value": map[string]any{"ml": 120}},
+				{"type": "SLEEP", "start_time": start.Add(30 * time.Minute).Format(time.RFC3339), "end_time": sleepEnd.Format(time.RFC3339)},
+				{"type": "pee", "start_time": start.Add(2 * time.Hour).Format(time.RFC3339)},
+			},
+		},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	if body["saved_event_count"] != float64(3) {
+		t.Fatalf("expected saved_event_count=3, got %v", body["saved_event_count"])
+	}
+	results, ok := body["results"].([]any)
+	if !ok || len(results) != 3 {
+		t.Fatalf("expected 3 results, got %v", body["results"])
+	}
+	if third, _ := results[2].(map[string]any); third["type"] != "PEE" || third["event_id"] == nil {
+		t.Fatalf("unexpected result at index 2: %v", results[2])
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var eventCount, auditCount int
+	if err := testPool.QueryRow(ctx, `SELECT COUNT(*) FROM "Event" WHERE "babyId" = $1`, fixture.BabyID).Scan(&eventCount); err != nil {
+		t.Fatalf("count events: %v", err)
+	}
+	if err := testPool.QueryRow(ctx, `SELECT COUNT(*) FROM "AuditLog" WHERE action = 'EVENT_MANUAL_BULK_CREATED'`).Scan(&auditCount); err != nil {
+		t.Fatalf("count audit logs: %v", err)
+	}
+	if eventCount != 3 || auditCount != 1 {
+		t.Fatalf("expected 3 events and 1 audit log, got %d and %d", eventCount, auditCount)
+	}
+}
+
+func TestCreateManualEventsBulkRejectsWholeBatchOnInvalidItem(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	start := time.Now().UTC().Add(-2 * time.Hour).Truncate(time.Second)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/events/bulk",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"baby_id": fixture.BabyID,
+			"events": []map[string]any{
+				{"type": "FORMULA", "start_time": start.Format(time.RFC3339), "value": map[string]any{"ml": 120}},
+				{"type": "SLEEP", "start_time": start.Format(time.RFC3339), "end_time": start.Add(-time.Hour).Format(time.RFC3339)},
+			},
+		},
+		nil,
+	)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if detail := responseDetail(t, rec); detail != "end_time must be after start_time at index 1" {
+		t.Fatalf("unexpected detail: %q", detail)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var eventCount int
+	if err := testPool.QueryRow(ctx, `SELECT COUNT(*) FROM "Event" WHERE "babyId" = $1`, fixture.BabyID).Scan(&eventCount); err != nil {
+		t.Fatalf("count events: %v", err)
+	}
+	if eventCount != 0 {
+		t.Fatalf("expected no saved events, got %d", eventCount)
+	}
+}
+
+func TestDeleteManualEventTrashesEventAndRemovesProjection(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	eventIDs := make([]string, 0, 2)
+	for _, start := range []time.Time{now.Add(-2 * time.Minute), now.Add(-time.Minute)} {
+		rec := performRequest(t, router, http.MethodPost, "/api/v1/events/manual", token, map[string]any{
+			"baby_id":    fixture.BabyID,
+			"type":       "FORMULA",
+			"start_time": start.Format(time.RFC3339),
+			"end_time":   start.Add(30 * time.Second).Format(time.RFC3339),
+			"value":      map[string]any{"ml": 100},
+		}, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("create event: expected 200, got %d body=%s", rec.Code, rec.Body.String())
+		}
+		eventIDs = append(eventIDs, decodeJSONMap(t, rec)["event_id"].(string))
+	}
+
+	rec := performRequest(t, router, http.MethodDelete, "/api/v1/events/"+eventIDs[0], token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if body := decodeJSONMap(t, rec); body["status"] != "TRASHED" || body["restorable_until"] == nil {
+		t.Fatalf("expected TRASHED status with restorable_until, got %v", body)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var eventCount, trashedCount, intakeCount, auditCount int
+	if err := testPool.QueryRow(
+		ctx,
+		`SELECT COUNT(*) FILTER (WHERE "deletedAt" IS NULL), COUNT(*) FILTER (WHERE "deletedAt" IS NOT NULL)
+		 FROM "Event" WHERE "babyId" = $1`,
+		fixture.BabyID,
+	).Scan(&eventCount, &trashedCount); err != nil {
+		t.Fatalf("count events: %v", err)
+	}
+	if err := testPool.QueryRow(ctx, `SELECT COUNT(*) FROM "IntakeEvent" WHERE "childId" = $1`, fixture.BabyID).Scan(&intakeCount); err != nil {
+		t.Fatalf("count intake events: %v", err)
+	}
+	if err := testPool.QueryRow(
+		ctx,
+		`SELECT COUNT(*) FROM "AuditLog" WHERE action = 'EVENT_TRASHED' AND "targetId" = $1`,
+		eventIDs[0],
+	).Scan(&auditCount); err != nil {
+		t.Fatalf("count audit logs: %v", err)
+	}
+	if eventCount != 1 || trashedCount != 1 || intakeCount != 1 || auditCount != 1 {
+		t.Fatalf(
+			"expected 1 event, 1 trashed event, 1 intake row, 1 audit log; got %d, %d, %d, %d",
+			eventCount, trashedCount, intakeCount, auditCount,
+		)
+	}
+
+	summary := performRequest(t, router, http.MethodGet, "/api/v1/quick/today-summary?baby_id="+fixture.BabyID, token, nil, nil)
+	if summary.Code != http.StatusOK {
+		t.Fatalf("today summary: expected 200, got %d body=%s", summary.Code, summary.Body.String())
+	}
+	if lines := decodeStringList(t, decodeJSONMap(t, summary)["summary_lines"]); !containsString(lines, "Feedings: 1") {
+		t.Fatalf("expected deleted event to drop out of today summary, got %v", lines)
+	}
+}
+
+func TestRestoreManualEventUndoesTrash(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	start := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+
+	rec := performRequest(t, router, http.MethodPost, "/api/v1/events/manual", token, map[string]any{
+		"baby_id":    fixture.BabyID,
+		"type":       "FORMULA",
+		"start_time": start.Format(time.RFC3339),
+		"end_time":   start.Add(time.Minute).Format(time.RFC3339),
+		"value":      map[string]any{"ml": 120},
+	}, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("create event: expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	eventID := decodeJSONMap(t, rec)["event_id"].(string)
+
+	rec = performRequest(t, router, http.MethodPost, "/api/v1/events/"+eventID+"/restore", token, nil, nil)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("restore live event: expected 404, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = performRequest(t, router, http.MethodDelete, "/api/v1/events/"+eventID, token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("delete event: expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	rec = performRequest(t, router, http.MethodPost, "/api/v1/events/"+eventID+"/restore", token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("restore event: expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if body := decodeJSONMap(t, rec); body["status"] != "RESTORED" {
+		t.Fatalf("expected RESTORED status, got %v", body)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var deletedAt *time.Time
+	var intakeCount, auditCount int
+	if err := testPool.QueryRow(ctx, `SELECT "deletedAt" FROM "Event" WHERE id = $1`, eventID).Scan(&deletedAt); err != nil {
+		t.Fatalf("load event: %v", err)
+	}
+	if err := testPool.QueryRow(ctx, `SELECT COUNT(*) FROM "IntakeEvent" WHERE "childId" = $1`, fixture.BabyID).Scan(&intakeCount); err != nil {
+		t.Fatalf("count intake events: %v", err)
+	}
+	if err := testPool.QueryRow(
+		ctx,
+		`SELECT COUNT(*) FROM "AuditLog" WHERE action = 'EVENT_RESTORED' AND "targetId" = $1`,
+		eventID,
+	).Scan(&auditCount); err != nil {
+		t.Fatalf("count audit logs: %v", err)
+	}
+	if deletedAt != nil || intakeCount != 1 || auditCount != 1 {
+		t.Fatalf("expected restored event with 1 intake row and 1 audit log; got deletedAt=%v, %d, %d", deletedAt, intakeCount, auditCount)
+	}
+}
+
+func TestRestoreManualEventRejectsExpiredTrash(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	start := time.Now().UTC().AddDate(0, 0, -40)
+	expiredID := seedEvent(t, "", fixture.BabyID, "FORMULA", start, nil, map[string]any{"ml": 90}, fixture.UserID)
+	recentID := seedEvent(t, "", fixture.BabyID, "FORMULA", start.Add(time.Hour), nil, map[string]any{"ml": 90}, fixture.UserID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := testPool.Exec(ctx, `UPDATE "Event" SET "deletedAt" = NOW() - INTERVAL '31 days' WHERE id = $1`, expiredID); err != nil {
+		t.Fatalf("trash expired event: %v", err)
+	}
+	if _, err := testPool.Exec(ctx, `UPDATE "Event" SET "deletedAt" = NOW() - INTERVAL '1 day' WHERE id = $1`, recentID); err != nil {
+		t.Fatalf("trash recent event: %v", err)
+	}
+
+	rec := performRequest(t, router, http.MethodPost, "/api/v1/events/"+expiredID+"/restore", token, nil, nil)
+	if rec.Code != http.StatusGone {
+		t.Fatalf("expected 410, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	app := New(baseTestConfig, testPool)
+	purged, err := app.purgeTrashedEvents(ctx, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("purge trashed events: %v", err)
+	}
+	var remaining int
+	if err := testPool.QueryRow(ctx, `SELECT COUNT(*) FROM "Event" WHERE "babyId" = $1`, fixture.BabyID).Scan(&remaining); err != nil {
+		t.Fatalf("count events: %v", err)
+	}
+	if purged != 1 || remaining != 1 {
+		t.Fatalf("expected only the expired event purged; purged=%d remaining=%d", purged, remaining)
+	}
+}
+
+func TestDeleteManualEventRejectsOpenEvent(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+
+	startRec := performRequest(t, router, http.MethodPost, "/api/v1/events/start", token, map[string]any{
+		"baby_id":    fixture.BabyID,
+		"type":       "SLEEP",
+		"start_time": time.Now().UTC().Add(-10 * time.Minute).Format(time.RFC3339),
+	}, nil)
+	if startRec.Code != http.StatusOK {
+		t.Fatalf("start event: expected 200, got %d body=%s", startRec.Code, startRec.Body.String())
+	}
+	eventID := decodeJSONMap(t, startRec)["event_id"].(string)
+
+	rec := performRequest(t, router, http.MethodDelete, "/api/v1/events/"+eventID, token, nil, nil)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestListEventsPagesNewestFirstWithCursor(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	base := time.Date(2026, 2, 10, 8, 0, 0, 0, time.UTC)
+
+	oldest := seedEvent(t, "", fixture.BabyID, "FORMULA", base, nil, map[string]any{"ml": 90}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "PEE", base.Add(time.Hour), nil, map[string]any{}, fixture.UserID)
+	newest := seedEvent(t, "", fixture.BabyID, "FORMULA", base.Add(2*time.Hour), nil, map[string]any{"ml": 120}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "FORMULA", base.AddDate(0, 0, 5), nil, map[string]any{"ml": 60}, fixture.UserID)
+
+	rec := performRequest(t, router, http.MethodGet,
+		"/api/v1/events?baby_id="+fixture.BabyID+"&from=2026-02-10&to=2026-02-10&limit=2", token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	events, _ := body["events"].([]any)
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events on first page, got %d", len(events))
+	}
+	first, _ := events[0].(map[string]any)
+	if first["event_id"] != newest || first["event_state"] != "CLOSED" {
+		t.Fatalf("expected newest CLOSED event first, got %v", first)
+	}
+	cursor, _ := body["next_cursor"].(string)
+	if cursor == "" {
+		t.Fatalf("expected next_cursor, got %v", body["next_cursor"])
+	}
+
+	rec = performRequest(t, router, http.MethodGet,
+		"/api/v1/events?baby_id="+fixture.BabyID+"&from=2026-02-10&to=2026-02-10&limit=2&cursor="+cursor, token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body = decodeJSONMap(t, rec)
+	events, _ = body["events"].([]any)
+	if len(events) != 1 || events[0].(map[string]any)["event_id"] != oldest {
+		t.Fatalf("expected only the oldest event on page 2, got %v", events)
+	}
+	if body["next_cursor"] != nil {
+		t.Fatalf("expected no next_cursor on last page, got %v", body["next_cursor"])
+	}
+
+	rec = performRequest(t, router, http.MethodGet,
+		"/api/v1/events?baby_id="+fixture.BabyID+"&type=formula&from=2026-02-10&to=2026-02-10", token, nil, nil)
+	events, _ = decodeJSONMap(t, rec)["events"].([]any)
+	if len(events) != 2 {
+		t.Fatalf("expected 2 FORMULA events, got %d", len(events))
+	}
+}
+
+func TestCreateManualEventRejectsOverlappingSleep(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	sleepStart := time.Now().UTC().Add(-4 * time.Hour).Truncate(time.Second)
+	sleepEnd := sleepStart.Add(time.Hour)
+	existingID := seedEvent(t, "", fixture.BabyID, "SLEEP", sleepStart, &sleepEnd, map[string]any{}, fixture.UserID)
+
+	overlapping := map[string]any{
+		"baby_id":    fixture.BabyID,
+		"type":       "SLEEP",
+		"start_time": sleepStart.Add(30 * time.Minute).Format(time.RFC3339),
+		"end_time":   sleepEnd.Add(30 * time.Minute).Format(time.RFC3339),
+	}
+	rec := performRequest(t, router, http.MethodPost, "/api/v1/events/manual", token, overlapping, nil)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if body := decodeJSONMap(t, rec); body["conflicting_event_id"] != existingID {
+		t.Fatalf("expected conflicting_event_id=%s, got %v", existingID, body["conflicting_event_id"])
+	}
+
+	rec = performRequest(t, router, http.MethodPost, "/api/v1/events/manual?allow_overlap=true", token, overlapping, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 with allow_overlap, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	// A sleep that starts exactly when the earlier one ended only touches it.
+	rec = performRequest(t, router, http.MethodPost, "/api/v1/events/manual", token, map[string]any{
+		"baby_id":    fixture.BabyID,
+		"type":       "SLEEP",
+		"start_time": sleepStart.Add(-time.Hour).Format(time.RFC3339),
+		"end_time":   sleepStart.Format(time.RFC3339),
+	}, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected touching sleep to be allowed, got %d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestCompleteManualEventRejectsOverlappingSleep(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	start := time.Now().UTC().Add(-3 * time.Hour).Truncate(time.Second)
+
+	startRec := performRequest(t, router, http.MethodPost, "/api/v1/events/start", token, map[string]any{
+		"baby_id":    fixture.BabyID,
+		"type":       "SLEEP",
+		"start_time": start.Format(time.RFC3339),
+	}, nil)
+	if startRec.Code != http.StatusOK {
+		t.Fatalf("start event: expected 200, got %d body=%s", startRec.Code, startRec.Body.String())
+	}
+	eventID := decodeJSONMap(t, startRec)["event_id"].(string)
+
+	otherStart := start.Add(time.Hour)
+	otherEnd := otherStart.Add(30 * time.Minute)
+	existingID := seedEvent(t, "", fixture.BabyID, "SLEEP", otherStart, &otherEnd, map[string]any{}, fixture.UserID)
+
+	rec := performRequest(t, router, http.MethodPatch, "/api/v1/events/"+eventID+"/complete", token, map[string]any{
+		"end_time": start.Add(2 * time.Hour).Format(time.RFC3339),
+	}, nil)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if body := decodeJSONMap(t, rec); body["conflicting_event_id"] != existingID {
+		t.Fatalf("expected conflicting_event_id=%s, got %v", existingID, body["conflicting_event_id"])
+	}
+
+	rec = performRequest(t, router, http.MethodPatch, "/api/v1/events/"+eventID+"/complete", token, map[string]any{
+		"end_time": otherStart.Format(time.RFC3339),
+	}, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected sleep ending at the next sleep's start to complete, got %d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestCreateManualEventRejectsDoubleTapUnlessForced(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	start := time.Now().UTC().Add(-10 * time.Minute).Truncate(time.Second)
+	create := func(path, eventType string, at time.Time) *httptest.ResponseRecorder {
+		return performRequest(t, router, http.MethodPost, path, token, map[string]any{
+			"baby_id":    fixture.BabyID,
+			"type":       eventType,
+			"start_time": at.Format(time.RFC3339),
+			"value":      map[string]any{"memo": "note"},
+		}, nil)
+	}
+
+	first := create("/api/v1/events/manual", "POO", start)
+	if first.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", first.Code, first.Body.String())
+	}
+	firstID := decodeJSONMap(t, first)["event_id"]
+
+	rec := create("/api/v1/events/manual", "POO", start.Add(5*time.Second))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for a double tap, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if body := decodeJSONMap(t, rec); body["duplicate"] != true || body["event_id"] != firstID {
+		t.Fatalf("expected duplicate flag and event_id=%v, got %v", firstID, body)
+	}
+
+	if rec := create("/api/v1/events/manual?force=true", "POO", start.Add(5*time.Second)); rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 with force, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if rec := create("/api/v1/events/manual", "POO", start.Add(2*time.Minute)); rec.Code != http.StatusOK {
+		t.Fatalf("expected a POO outside the window to save, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	for idx := 0; idx < 2; idx++ {
+		if rec := create("/api/v1/events/manual", "MEMO", start); rec.Code != http.StatusOK {
+			t.Fatalf("expected repeated memos to save, got %d body=%s", rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestCreateManualEventRejectsFutureStartUnlessAllowed(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	future := time.Now().UTC().Add(2 * time.Hour).Truncate(time.Second)
+	payload := map[string]any{
+		"baby_id":    fixture.BabyID,
+		"type":       "FORMULA",
+		"start_time": future.Format(time.RFC3339),
+		"value":      map[string]any{"ml": 120},
+	}
+
+	rec := performRequest(t, router, http.MethodPost, "/api/v1/events/manual", token, payload, nil)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(responseDetail(t, rec), "allow_future") {
+		t.Fatalf("expected 400 for a future FORMULA, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	rec = performRequest(t, router, http.MethodPost, "/api/v1/events/start", token, payload, nil)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a future start, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = performRequest(t, router, http.MethodPost, "/api/v1/events/manual?allow_future=true", token, payload, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 with allow_future, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	warnings, _ := decodeJSONMap(t, rec)["warnings"].([]any)
+	if len(warnings) != 1 || warnings[0].(map[string]any)["code"] != "future_start" {
+		t.Fatalf("expected a future_start warning, got %v", warnings)
+	}
+}
+
+func TestEventWritePathsRejectInvalidValues(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	start := time.Now().UTC().Add(-30 * time.Minute).Truncate(time.Second)
+
+	rec := performRequest(t, router, http.MethodPost, "/api/v1/events/manual", token, map[string]any{
+		"baby_id":    fixture.BabyID,
+		"type":       "FORMULA",
+		"start_time": start.Format(time.RFC3339),
+	}, nil)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(responseDetail(t, rec), "value.ml") {
+		t.Fatalf("expected 400 for FORMULA without ml, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = performRequest(t, router, http.MethodPost, "/api/v1/events/bulk", token, map[string]any{
+		"baby_id": fixture.BabyID,
+		"events": []map[string]any{
+			{"type": "MEMO", "start_time": start.Format(time.RFC3339)},
+			{"type": "GROWTH", "start_time": start.Format(time.RFC3339), "value": map[string]any{"weight_kg": "heavy"}},
+		},
+	}, nil)
+	if rec.Code != http.StatusBadRequest || !strings.HasSuffix(responseDetail(t, rec), "at index 1") {
+		t.Fatalf("expected 400 for the GROWTH item, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var eventCount int
+	if err := testPool.QueryRow(ctx, `SELECT COUNT(*)::int FROM "Event" WHERE "babyId" = $1`, fixture.BabyID).Scan(&eventCount); err != nil {
+		t.Fatalf("count events: %v", err)
+	}
+	if eventCount != 0 {
+		t.Fatalf("expected nothing saved, got %d events", eventCount)
+	}
+}
+
+func TestCreateManualEventConvertsOuncesToMilliliters(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	start := time.Now().UTC().Add(-30 * time.Minute).Truncate(time.Second)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/events/manual",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"baby_id":    fixture.BabyID,
+			"type":       "FORMULA",
+			"start_time": start.Format(time.RFC3339),
+			"value":      map[string]any{"amount_oz": 4},
+		},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	eventID := decodeJSONMap(t, rec)["event_id"].(string)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var valueRaw, metadataRaw []byte
+	if err := testPool.QueryRow(
+		ctx,
+		`SELECT "valueJson", "metadataJson" FROM "Event" WHERE id = $1`,
+		eventID,
+	).Scan(&valueRaw, &metadataRaw); err != nil {
+		t.Fatalf("query event: %v", err)
+	}
+	value := map[string]any{}
+	metadata := map[string]any{}
+	if err := json.Unmarshal(valueRaw, &value); err != nil {
+		t.Fatalf("unmarshal value json: %v", err)
+	}
+	if err := json.Unmarshal(metadataRaw, &metadata); err != nil {
+		t.Fatalf("unmarshal metadata json: %v", err)
+	}
+	if value["ml"] != float64(118) || value["amount_oz"] != nil {
+		t.Fatalf("expected 118 ml stored, got %v", value)
+	}
+	if metadata["original_unit"] != "oz" {
+		t.Fatalf("expected original_unit=oz, got %v", metadata["original_unit"])
+	}
+}
+
+func TestCreateManualEventReplaysIdempotencyKey(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	start := time.Now().UTC().Add(-10 * time.Minute).Truncate(time.Second)
+	payload := map[string]any{
+		"baby_id":    fixture.BabyID,
+		"type":       "FORMULA",
+		"start_time": start.Format(time.RFC3339),
+		"value":      map[string]any{"ml": 120},
+	}
+	headers := map[string]string{"Idempotency-Key": "retry-formula-1"}
+
+	first := performRequest(t, router, http.MethodPost, "/api/v1/events/manual", token, payload, headers)
+	if first.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", first.Code, first.Body.String())
+	}
+	retry := performRequest(t, router, http.MethodPost, "/api/v1/events/manual", token, payload, headers)
+	if retry.Code != http.StatusOK {
+		t.Fatalf("expected replayed 200, got %d body=%s", retry.Code, retry.Body.String())
+	}
+	if retry.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("expected Idempotent-Replayed header on retry")
+	}
+	if decodeJSONMap(t, retry)["event_id"] != decodeJSONMap(t, first)["event_id"] {
+		t.Fatalf("expected the original event_id, got %s vs %s", retry.Body.String(), first.Body.String())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var eventCount int
+	if err := testPool.QueryRow(ctx, `SELECT COUNT(*) FROM "Event" WHERE "babyId" = $1`, fixture.BabyID).Scan(&eventCount); err != nil {
+		t.Fatalf("count events: %v", err)
+	}
+	if eventCount != 1 {
+		t.Fatalf("expected one event after a retried request, got %d", eventCount)
+	}
+
+	// The same key from another user is a separate request.
+	other := seedOwnerFixture(t)
+	otherPayload := map[string]any{
+		"baby_id":    other.BabyID,
+		"type":       "FORMULA",
+		"start_time": start.Format(time.RFC3339),
+		"value":      map[string]any{"ml": 90},
+	}
+	rec := performRequest(t, router, http.MethodPost, "/api/v1/events/manual", signToken(t, other.UserID, nil), otherPayload, headers)
+	if rec.Code != http.StatusOK || rec.Header().Get("Idempotent-Replayed") != "" {
+		t.Fatalf("expected a fresh create for another user, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = performRequest(t, router, http.MethodPost, "/api/v1/events/bulk", token, map[string]any{
+		"baby_id": fixture.BabyID,
+		"events":  []map[string]any{payload},
+	}, headers)
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422 for a key reused on another endpoint, got %d body=%s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,636 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	// Household timezones are IANA names; embed the database so they resolve
+	// on hosts without zoneinfo.
+	_ "time/tzdata"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"babyai/apps/backend/internal/config"
+)
+
+const (
+	feedingReminderDefaultLeadMinutes = 10
+	feedingReminderMinLeadMinutes     = 5
+	feedingReminderMaxLeadMinutes     = 60
+	// Reminders are not sent from 22:00 to 07:00 in the household timezone
+	// unless allow_overnight is set.
+	feedingReminderQuietStartHour = 22
+	feedingReminderQuietEndHour   = 7
+	pushWebhookTimeout            = 10 * time.Second
+)
+
+var errPushNotConfigured = errors.New("push webhook is not configured")
+
+// pushMessage is one notification for one subscribed device.
+type pushMessage struct {
+	Type        string `json:"type"`
+	HouseholdID string `json:"household_id"`
+	BabyID      string `json:"baby_id"`
+	BabyName    string `json:"baby_name"`
+	UserID      string `json:"user_id"`
+	DeviceToken string `json:"device_token,omitempty"`
+	DueAt       string `json:"due_at"`
+	Title       string `json:"title"`
+	Body        string `json:"body"`
+}
+
+// PushNotifier delivers reminders to a device. The webhook notifier is the
+// only provider today; FCM or APNs clients can implement the same interface.
+type PushNotifier interface {
+	Send(ctx context.Context, message pushMessage) error
+}
+
+// MockPushNotifier drops every message so tests never call out.
+type MockPushNotifier struct{}
+
+func (MockPushNotifier) Send(context.Context, pushMessage) error {
+	return nil
+}
+
+// WebhookPushNotifier POSTs the message as JSON to PUSH_WEBHOOK_URL. The
+// target is never taken from a subscription, so clients cannot point the
+// server at arbitrary hosts.
+type WebhookPushNotifier struct {
+	defaultURL string
+	httpClient *http.Client
+}
+
+func NewWebhookPushNotifier(cfg config.Config) *WebhookPushNotifier {
+	return &WebhookPushNotifier{
+		defaultURL: strings.TrimSpace(cfg.PushWebhookURL),
+		httpClient: &http.Client{Timeout: pushWebhookTimeout},
+	}
+}
+
+func (n *WebhookPushNotifier) Send(ctx context.Context, message pushMessage) error {
+	if n.defaultURL == "" {
+		return errPushNotConfigured
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, n.defaultURL, strings.NewReader(mustMarshalJSON(message)))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := n.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("push webhook error (%d): %s", response.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// nextFeedingDueAt is the last feeding plus the average interval. Unlike the
+// ETA shown in the app it is not rolled forward once overdue, so each feeding
+// gets at most one reminder.
+func nextFeedingDueAt(feedings []time.Time, now time.Time) (time.Time, time.Time, bool) {
+	result := calculateNextFeedingETA(feedings, now)
+	if result.Unstable || result.AverageIntervalMinutes == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	ordered, _ := feedingIntervals(feedings, now.UTC())
+	lastFeeding := ordered[len(ordered)-1]
+	return lastFeeding, lastFeeding.Add(time.Duration(*result.AverageIntervalMinutes) * time.Minute), true
+}
+
+func isFeedingReminderQuietHour(now time.Time, loc *time.Location) bool {
+	hour := now.In(loc).Hour()
+	return hour >= feedingReminderQuietStartHour || hour < feedingReminderQuietEndHour
+}
+
+// shouldSendFeedingReminder reports whether now falls in the lead window
+// before dueAt and outside quiet hours.
+func shouldSendFeedingReminder(dueAt, now time.Time, leadMinutes int, loc *time.Location, allowOvernight bool) bool {
+	if now.Before(dueAt.Add(-time.Duration(leadMinutes)*time.Minute)) || now.After(dueAt) {
+		return false
+	}
+	return allowOvernight || !isFeedingReminderQuietHour(now, loc)
+}
+
+// parseHouseholdTimezone accepts an IANA zone name such as "Asia/Seoul".
+// Unlike a fixed tz_offset it follows daylight saving changes.
+func parseHouseholdTimezone(raw string) (*time.Location, error) {
+	name := strings.TrimSpace(raw)
+	if name == "" || name == "Local" {
+		return nil, errors.New("timezone must be an IANA zone such as Asia/Seoul")
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.New("timezone must be an IANA zone such as Asia/Seoul")
+	}
+	return loc, nil
+}
+
+// feedingReminderLocation prefers the household timezone and falls back to
+// the fixed tz_offset the subscriber sent for households that have none.
+func feedingReminderLocation(householdTimezone, tzOffset string) (*time.Location, error) {
+	if strings.TrimSpace(householdTimezone) != "" {
+		if loc, err := parseHouseholdTimezone(householdTimezone); err == nil {
+			return loc, nil
+		}
+	}
+	loc, _, err := parseTZOffset(tzOffset)
+	return loc, err
+}
+
+// subscribeFeedingReminders registers the caller's device for feeding
+// reminders in one household. Subscribing again replaces the settings.
+func (a *App) subscribeFeedingReminders(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var payload feedingReminderSubscribeRequest
+	if !mustJSON(c, &payload) {
+		return
+	}
+	householdID := strings.TrimSpace(payload.HouseholdID)
+	if householdID == "" {
+		writeError(c, http.StatusBadRequest, "household_id is required")
+		return
+	}
+	deviceToken := strings.TrimSpace(payload.DeviceToken)
+	if deviceToken == "" {
+		writeError(c, http.StatusBadRequest, "device_token is required")
+		return
+	}
+	_, tzOffset, err := parseTZOffset(payload.TZOffset)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	timezone := strings.TrimSpace(payload.Timezone)
+	if timezone != "" {
+		if _, err := parseHouseholdTimezone(timezone); err != nil {
+			writeError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	leadMinutes := feedingReminderDefaultLeadMinutes
+	if payload.LeadMinutes != nil {
+		if *payload.LeadMinutes < feedingReminderMinLeadMinutes || *payload.LeadMinutes > feedingReminderMaxLeadMinutes {
+			writeError(c, http.StatusBadRequest, fmt.Sprintf(
+				"lead_minutes must be between %d and %d",
+				feedingReminderMinLeadMinutes,
+				feedingReminderMaxLeadMinutes,
+			))
+			return
+		}
+		leadMinutes = *payload.LeadMinutes
+	}
+
+	role, statusCode, err := a.assertHouseholdAccess(c.Request.Context(), user.ID, householdID, readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+	// The timezone belongs to the household, so only members who may edit
+	// records can change it.
+	if timezone != "" && !containsRole(writeRoles, role) {
+		writeError(c, http.StatusForbidden, "Insufficient role for this action")
+		return
+	}
+
+	subscription := feedingReminderSubscription{
+		HouseholdID:    householdID,
+		UserID:         user.ID,
+		DeviceToken:    deviceToken,
+		TZOffset:       tzOffset,
+		Timezone:       timezone,
+		AllowOvernight: payload.AllowOvernight,
+		LeadMinutes:    leadMinutes,
+	}
+	subscriptionID, householdTimezone, err := a.saveFeedingReminderSubscription(c.Request.Context(), subscription)
+	if err != nil && isMissingFeedingReminderSchemaErr(err) {
+		if ensureErr := a.ensureFeedingReminderTables(c.Request.Context()); ensureErr != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to save feeding reminder subscription")
+			return
+		}
+		subscriptionID, householdTimezone, err = a.saveFeedingReminderSubscription(c.Request.Context(), subscription)
+	}
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to save feeding reminder subscription")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"subscription_id":  subscriptionID,
+		"household_id":     householdID,
+		"has_device_token": true,
+		"tz_offset":        tzOffset,
+		"timezone":         nullableString(householdTimezone),
+		"allow_overnight":  payload.AllowOvernight,
+		"lead_minutes":     leadMinutes,
+	})
+}
+
+type feedingReminderSubscription struct {
+	HouseholdID    string
+	UserID         string
+	DeviceToken    string
+	TZOffset       string
+	Timezone       string
+	AllowOvernight bool
+	LeadMinutes    int
+}
+
+// saveFeedingReminderSubscription upserts the subscription and, when a
+// timezone is given, stores it on the household. It returns the household
+// timezone in effect afterwards.
+func (a *App) saveFeedingReminderSubscription(ctx context.Context, subscription feedingReminderSubscription) (string, string, error) {
+	tx, err := a.db.Begin(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	defer tx.Rollback(ctx)
+
+	var householdTimezone string
+	if subscription.Timezone != "" {
+		var previous string
+		if err := tx.QueryRow(
+			ctx,
+			`SELECT COALESCE(timezone, '') FROM "Household" WHERE id = $1 FOR UPDATE`,
+			subscription.HouseholdID,
+		).Scan(&previous); err != nil {
+			return "", "", err
+		}
+		if previous != subscription.Timezone {
+			if _, err := tx.Exec(
+				ctx,
+				`UPDATE "Household" SET timezone = $2 WHERE id = $1`,
+				subscription.HouseholdID,
+				subscription.Timezone,
+			); err != nil {
+				return "", "", err
+			}
+			if err := recordAuditLog(
+				ctx,
+				tx,
+				subscription.HouseholdID,
+				subscription.UserID,
+				"HOUSEHOLD_TIMEZONE_UPDATED",
+				"Household",
+				&subscription.HouseholdID,
+				gin.H{"from": nullableString(previous), "to": subscription.Timezone},
+			); err != nil {
+				return "", "", err
+			}
+		}
+		householdTimezone = subscription.Timezone
+	} else if err := tx.QueryRow(
+		ctx,
+		`SELECT COALESCE(timezone, '') FROM "Household" WHERE id = $1`,
+		subscription.HouseholdID,
+	).Scan(&householdTimezone); err != nil {
+		return "", "", err
+	}
+
+	var subscriptionID string
+	if err := tx.QueryRow(
+		ctx,
+		`INSERT INTO "FeedingReminderSubscription"
+		   (id, "householdId", "userId", "deviceToken", "tzOffset",
+		    "allowOvernight", "leadMinutes", "createdAt", "updatedAt")
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+		 ON CONFLICT ("householdId", "userId") DO UPDATE SET
+		   "deviceToken" = EXCLUDED."deviceToken",
+		   "tzOffset" = EXCLUDED."tzOffset",
+		   "allowOvernight" = EXCLUDED."allowOvernight",
+		   "leadMinutes" = EXCLUDED."leadMinutes",
+		   "updatedAt" = NOW()
+		 RETURNING id`,
+		uuid.NewString(),
+		subscription.HouseholdID,
+		subscription.UserID,
+		subscription.DeviceToken,
+		subscription.TZOffset,
+		subscription.AllowOvernight,
+		subscription.LeadMinutes,
+	).Scan(&subscriptionID); err != nil {
+		return "", "", err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return "", "", err
+	}
+	return subscriptionID, householdTimezone, nil
+}
+
+// unsubscribeFeedingReminders removes the caller's subscription. It needs no
+// role so a removed member can still turn reminders off.
+func (a *App) unsubscribeFeedingReminders(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var payload feedingReminderUnsubscribeRequest
+	if !mustJSON(c, &payload) {
+		return
+	}
+	householdID := strings.TrimSpace(payload.HouseholdID)
+	if householdID == "" {
+		writeError(c, http.StatusBadRequest, "household_id is required")
+		return
+	}
+
+	result, err := a.db.Exec(
+		c.Request.Context(),
+		`DELETE FROM "FeedingReminderSubscription" WHERE "householdId" = $1 AND "userId" = $2`,
+		householdID,
+		user.ID,
+	)
+	if err != nil && !isMissingFeedingReminderTableErr(err) {
+		writeError(c, http.StatusInternalServerError, "Failed to delete feeding reminder subscription")
+		return
+	}
+	if err != nil || result.RowsAffected() == 0 {
+		writeError(c, http.StatusNotFound, "Feeding reminder subscription not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"household_id": householdID, "unsubscribed": true})
+}
+
+type feedingReminderCandidate struct {
+	SubscriptionID string
+	HouseholdID    string
+	UserID         string
+	DeviceToken    string
+	TZOffset       string
+	Timezone       string
+	AllowOvernight bool
+	LeadMinutes    int
+	BabyID         string
+	BabyName       string
+}
+
+// RunFeedingReminderScheduler checks every subscription on a fixed interval
+// until ctx is canceled. The interval should not exceed the smallest
+// lead_minutes or a reminder window can be skipped.
+func (a *App) RunFeedingReminderScheduler(ctx context.Context) {
+	intervalMin := a.cfg.FeedingReminderIntervalMin
+	if intervalMin <= 0 {
+		intervalMin = 5
+	}
+	ticker := time.NewTicker(time.Duration(intervalMin) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		sent, err := a.runFeedingReminderJob(ctx, time.Now().UTC())
+		if err != nil && ctx.Err() == nil {
+			log.Printf("feeding reminder job failed: %v", err)
+		} else if sent > 0 {
+			log.Printf("feeding reminder job sent %d reminder(s)", sent)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runFeedingReminderJob sends one reminder per subscriber, baby and last
+// feeding. Subscribers who are no longer household members are skipped, as
+// are older webhook-only subscriptions without a device token.
+func (a *App) runFeedingReminderJob(ctx context.Context, now time.Time) (int, error) {
+	if a.push == nil {
+		return 0, nil
+	}
+	rows, err := a.db.Query(
+		ctx,
+		`SELECT s.id, s."householdId", s."userId", COALESCE(s."deviceToken", ''),
+		        s."tzOffset", COALESCE(h.timezone, ''), s."allowOvernight",
+		        s."leadMinutes", b.id, b.name
+		 FROM "FeedingReminderSubscription" s
+		 JOIN "Household" h ON h.id = s."householdId"
+		 JOIN "Baby" b ON b."householdId" = s."householdId"
+		 WHERE s."deviceToken" IS NOT NULL
+		   AND (
+		     h."ownerUserId" = s."userId"
+		     OR EXISTS (
+		       SELECT 1 FROM "HouseholdMember" m
+		       WHERE m."householdId" = s."householdId"
+		         AND m."userId" = s."userId"
+		         AND m.status = 'ACTIVE'
+		     )
+		   )
+		 ORDER BY s.id, b.id`,
+	)
+	if err != nil && isMissingFeedingReminderSchemaErr(err) {
+		return 0, a.ensureFeedingReminderTables(ctx)
+	}
+	if err != nil {
+		return 0, err
+	}
+	candidates := make([]feedingReminderCandidate, 0, 16)
+	for rows.Next() {
+		var candidate feedingReminderCandidate
+		if err := rows.Scan(
+			&candidate.SubscriptionID,
+			&candidate.HouseholdID,
+			&candidate.UserID,
+			&candidate.DeviceToken,
+			&candidate.TZOffset,
+			&candidate.Timezone,
+			&candidate.AllowOvernight,
+			&candidate.LeadMinutes,
+			&candidate.BabyID,
+			&candidate.BabyName,
+		); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		candidates = append(candidates, candidate)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var firstErr error
+	for _, candidate := range candidates {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+		delivered, err := a.sendFeedingReminder(ctx, candidate, now)
+		if err != nil {
+			log.Printf("feeding reminder failed subscription_id=%s baby_id=%s: %v", candidate.SubscriptionID, candidate.BabyID, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if delivered {
+			sent++
+		}
+	}
+	return sent, firstErr
+}
+
+// sendFeedingReminder records the delivery before sending so that a second
+// API instance running the job skips it, and removes the record again when
+// the send fails so the next pass retries.
+func (a *App) sendFeedingReminder(ctx context.Context, candidate feedingReminderCandidate, now time.Time) (bool, error) {
+	loc, err := feedingReminderLocation(candidate.Timezone, candidate.TZOffset)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := a.db.Query(
+		ctx,
+		`SELECT "startTime"
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND type IN ('FORMULA', 'BREASTFEED')
+		   AND "startTime" <= $2
+		 ORDER BY "startTime" DESC
+		 LIMIT 10`,
+		candidate.BabyID,
+		now,
+	)
+	if err != nil {
+		return false, err
+	}
+	feedings := make([]time.Time, 0, 10)
+	for rows.Next() {
+		var startTime time.Time
+		if err := rows.Scan(&startTime); err != nil {
+			rows.Close()
+			return false, err
+		}
+		feedings = append(feedings, startTime)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+
+	lastFeeding, dueAt, ok := nextFeedingDueAt(feedings, now)
+	if !ok || !shouldSendFeedingReminder(dueAt, now, candidate.LeadMinutes, loc, candidate.AllowOvernight) {
+		return false, nil
+	}
+
+	deliveryID := uuid.NewString()
+	result, err := a.db.Exec(
+		ctx,
+		`INSERT INTO "FeedingReminderDelivery"
+		   (id, "subscriptionId", "babyId", "lastFeedingAt", "dueAt", "sentAt")
+		 VALUES ($1, $2, $3, $4, $5, NOW())
+		 ON CONFLICT ("subscriptionId", "babyId", "lastFeedingAt") DO NOTHING`,
+		deliveryID,
+		candidate.SubscriptionID,
+		candidate.BabyID,
+		lastFeeding,
+		dueAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	if result.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	message := pushMessage{
+		Type:        "feeding_reminder",
+		HouseholdID: candidate.HouseholdID,
+		BabyID:      candidate.BabyID,
+		BabyName:    candidate.BabyName,
+		UserID:      candidate.UserID,
+		DeviceToken: candidate.DeviceToken,
+		DueAt:       dueAt.UTC().Format(time.RFC3339),
+		Title:       "Feeding reminder",
+		Body:        fmt.Sprintf("%s's next feed is due around %s.", candidate.BabyName, dueAt.In(loc).Format("15:04")),
+	}
+	if err := a.push.Send(ctx, message); err != nil {
+		if _, deleteErr := a.db.Exec(ctx, `DELETE FROM "FeedingReminderDelivery" WHERE id = $1`, deliveryID); deleteErr != nil {
+			log.Printf("feeding reminder delivery cleanup failed delivery_id=%s: %v", deliveryID, deleteErr)
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (a *App) ensureHouseholdTimezoneColumn(ctx context.Context) error {
+	_, err := a.db.Exec(ctx, `ALTER TABLE "Household" ADD COLUMN IF NOT EXISTS timezone TEXT`)
+	return err
+}
+
+func isMissingHouseholdTimezoneColumnErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	lowered := strings.ToLower(err.Error())
+	return strings.Contains(lowered, "column") && strings.Contains(lowered, "timezone")
+}
+
+func (a *App) ensureFeedingReminderTables(ctx context.Context) error {
+	if err := a.ensureHouseholdTimezoneColumn(ctx); err != nil {
+		return err
+	}
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS "FeedingReminderSubscription" (
+			id TEXT PRIMARY KEY,
+			"householdId" TEXT NOT NULL REFERENCES "Household"(id) ON DELETE CASCADE ON UPDATE CASCADE,
+			"userId" TEXT NOT NULL REFERENCES "User"(id) ON DELETE CASCADE ON UPDATE CASCADE,
+			"deviceToken" TEXT,
+			"tzOffset" TEXT NOT NULL DEFAULT '+00:00',
+			"allowOvernight" BOOLEAN NOT NULL DEFAULT false,
+			"leadMinutes" INTEGER NOT NULL DEFAULT 10,
+			"createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			"updatedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS "FeedingReminderSubscription_householdId_userId_key"
+			ON "FeedingReminderSubscription"("householdId", "userId")`,
+		`CREATE TABLE IF NOT EXISTS "FeedingReminderDelivery" (
+			id TEXT PRIMARY KEY,
+			"subscriptionId" TEXT NOT NULL REFERENCES "FeedingReminderSubscription"(id) ON DELETE CASCADE ON UPDATE CASCADE,
+			"babyId" TEXT NOT NULL REFERENCES "Baby"(id) ON DELETE CASCADE ON UPDATE CASCADE,
+			"lastFeedingAt" TIMESTAMP(3) NOT NULL,
+			"dueAt" TIMESTAMP(3) NOT NULL,
+			"sentAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS "FeedingReminderDelivery_subscriptionId_babyId_lastFeedingAt_key"
+			ON "FeedingReminderDelivery"("subscriptionId", "babyId", "lastFeedingAt")`,
+	}
+	for _, stmt := range statements {
+		if _, err := a.db.Exec(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isMissingFeedingReminderTableErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	lowered := strings.ToLower(err.Error())
+	return strings.Contains(lowered, "relation") && strings.Contains(lowered, "feedingreminder")
+}
+
+// isMissingFeedingReminderSchemaErr also covers the household timezone
+// column that ensureFeedingReminderTables adds.
+func isMissingFeedingReminderSchemaErr(err error) bool {
+	return isMissingFeedingReminderTableErr(err) || isMissingHouseholdTimezoneColumnErr(err)
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+const (
+	feedingUnitML = "ml"
+	feedingUnitOz = "oz"
+	mlPerFluidOz  = 29.5735
+)
+
+func normalizeFeedingUnit(raw string) string {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "ml", "milliliter", "milliliters", "millilitre", "millilitres":
+		return feedingUnitML
+	case "oz", "fl oz", "fl_oz", "ounce", "ounces":
+		return feedingUnitOz
+	default:
+		return ""
+	}
+}
+
+// normalizeFeedingAmountUnit rewrites an ounce amount in a FORMULA or
+// BREASTFEED value map to whole ml, since every reader of valueJson expects
+// ml. The amount comes from amount_oz, or from the usual ml keys when unit is
+// "oz". The entered unit and amount are kept in metadata.
+func normalizeFeedingAmountUnit(eventType string, value, metadata map[string]any) error {
+	if eventType != "FORMULA" && eventType != "BREASTFEED" {
+		return nil
+	}
+	unit := feedingUnitML
+	if rawUnit, ok := value["unit"]; ok {
+		unit = normalizeFeedingUnit(toString(rawUnit))
+		if unit == "" {
+			return errors.New("value.unit must be one of: ml, oz")
+		}
+		delete(value, "unit")
+	}
+
+	_, hasOz := value["amount_oz"]
+	if !hasOz && unit != feedingUnitOz {
+		return nil
+	}
+	amountOz := extractNumberFromMap(value, "amount_oz")
+	if !hasOz {
+		amountOz = extractNumberFromMap(value, "ml", "amount_ml", "volume_ml", "amount")
+	}
+	if amountOz <= 0 {
+		return errors.New("feeding amount in oz must be positive")
+	}
+	for _, key := range []string{"amount_oz", "ml", "amount_ml", "volume_ml", "amount"} {
+		delete(value, key)
+	}
+	value["ml"] = int(math.Round(amountOz * mlPerFluidOz))
+	metadata["original_unit"] = feedingUnitOz
+	metadata["original_amount"] = amountOz
+	return nil
+}
+
+// normalizeFeedingValuePatch converts an ounce amount in a value patch before
+// it is merged into a stored value, so an existing ml amount is never read as
+// ounces. It returns the patch copy and the unit metadata to stamp.
+func normalizeFeedingValuePatch(eventType string, patch map[string]any) (map[string]any, map[string]any, error) {
+	normalized := mergeJSONMap(map[string]any{}, patch)
+	unitMetadata := map[string]any{}
+	if err := normalizeFeedingAmountUnit(eventType, normalized, unitMetadata); err != nil {
+		return nil, nil, err
+	}
+	return normalized, unitMetadata, nil
+}
+
+// mergeFeedingUnitMetadata stamps the entered unit from a patch. A patch that
+// sets a new ml amount clears the unit stamped by an earlier ounce entry.
+func mergeFeedingUnitMetadata(metadata, patch, unitMetadata map[string]any) map[string]any {
+	if len(unitMetadata) == 0 {
+		if firstPresentKey(patch, eventMergeAmountKeys) != "" {
+			delete(metadata, "original_unit")
+			delete(metadata, "original_amount")
+		}
+		return metadata
+	}
+	return mergeJSONMap(metadata, unitMetadata)
+}
+
+// feedingAmountInUnit converts a stored ml amount for display in the baby's
+// preferred unit; ounces keep one decimal place.
+func feedingAmountInUnit(ml *int, unit string) *float64 {
+	if ml == nil {
+		return nil
+	}
+	amount := float64(*ml)
+	if unit == feedingUnitOz {
+		amount = roundToOneDecimal(amount / mlPerFluidOz)
+	}
+	return &amount
+}
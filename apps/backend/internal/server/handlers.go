@@ -19,11 +19,33 @@ type parentOnboardingRequest struct {
 	FormulaType           string   `json:"formula_type"`
 	FormulaContainsStarch *bool    `json:"formula_contains_starch"`
 	RequiredConsents      []string `json:"required_consents"`
+	GestationalWeeks      *int     `json:"gestational_weeks"`
+	// TZOffset and DummySeedPreset only shape ONBOARDING_SEED_DUMMY_DATA
+	// events; the preset defaults to one matching the first baby's age.
+	TZOffset        string `json:"tz_offset"`
+	DummySeedPreset string `json:"dummy_seed_preset"`
+	// Babies onboards several babies (e.g. twins) into one household. When
+	// set, the single-baby Baby* and feeding fields above are ignored.
+	Babies []parentOnboardingBaby `json:"babies"`
+}
+
+type parentOnboardingBaby struct {
+	Name                  string   `json:"name"`
+	BirthDate             string   `json:"birth_date"`
+	Sex                   string   `json:"sex"`
+	WeightKg              *float64 `json:"weight_kg"`
+	FeedingMethod         string   `json:"feeding_method"`
+	FormulaBrand          string   `json:"formula_brand"`
+	FormulaProduct        string   `json:"formula_product"`
+	FormulaType           string   `json:"formula_type"`
+	FormulaContainsStarch *bool    `json:"formula_contains_starch"`
+	GestationalWeeks      *int     `json:"gestational_weeks"`
 }
 
 type voiceUploadRequest struct {
 	BabyID         string `json:"baby_id"`
 	TranscriptHint string `json:"transcript_hint"`
+	AudioObjectKey string `json:"audio_object_key"`
 }
 
 type eventItem struct {
@@ -33,6 +55,9 @@ type eventItem struct {
 	Value      map[string]any     `json:"value"`
 	Metadata   map[string]any     `json:"metadata,omitempty"`
 	Confidence map[string]float64 `json:"confidence,omitempty"`
+	// NeedsReview marks voice-parsed events the client should ask the parent
+	// to check before confirming.
+	NeedsReview bool `json:"needs_review,omitempty"`
 }
 
 type voiceParseResponse struct {
@@ -45,6 +70,11 @@ type voiceParseResponse struct {
 type confirmEventsRequest struct {
 	ClipID string      `json:"clip_id"`
 	Events []eventItem `json:"events"`
+	// ConfirmIndices and RejectIndices point into the clip's parsed events.
+	// Either one switches to partial confirmation; Events is then optional
+	// and, when sent, holds the edited events in ConfirmIndices order.
+	ConfirmIndices []int `json:"confirm_indices"`
+	RejectIndices  []int `json:"reject_indices"`
 }
 
 type aiQueryRequest struct {
@@ -61,6 +91,25 @@ type chatSessionCreateRequest struct {
 	ChildID string `json:"child_id"`
 }
 
+type chatSessionForkRequest struct {
+	UpToMessageID string `json:"up_to_message_id"`
+}
+
+type chatSessionUpdateRequest struct {
+	Title    *string `json:"title"`
+	Tone     *string `json:"tone"`
+	Language *string `json:"language"`
+}
+
+type chatClassifyRequest struct {
+	Question  string `json:"question"`
+	SessionID string `json:"session_id"`
+}
+
+type chatSessionReclassifyRequest struct {
+	Intent string `json:"intent"`
+}
+
 type chatMessageCreateRequest struct {
 	Role      string         `json:"role"`
 	Content   string         `json:"content"`
@@ -79,6 +128,14 @@ type chatQueryRequest struct {
 	DateMode        string `json:"date_mode"`
 	AnchorDate      string `json:"anchor_date"`
 	TZOffset        string `json:"tz_offset"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	EventID         string `json:"event_id"`
+	TranslateTo     string `json:"translate_to"`
+	ResponseFormat  string `json:"response_format"`
+	// Intent pins the turn's intent for server-side reruns such as
+	// regenerate; it is never read from the request body.
+	Intent string `json:"-"`
 }
 
 type photoUploadCompleteRequest struct {
@@ -87,11 +144,36 @@ type photoUploadCompleteRequest struct {
 	Downloadable bool   `json:"downloadable"`
 }
 
+type householdInviteCreateRequest struct {
+	Role           string `json:"role"`
+	ExpiresInHours *int   `json:"expires_in_hours"`
+}
+
+type feedingReminderSubscribeRequest struct {
+	HouseholdID string `json:"household_id"`
+	DeviceToken string `json:"device_token"`
+	TZOffset    string `json:"tz_offset"`
+	// Timezone is an IANA name stored on the household; it takes precedence
+	// over TZOffset for quiet hours and the reminder text.
+	Timezone       string `json:"timezone"`
+	AllowOvernight bool   `json:"allow_overnight"`
+	LeadMinutes    *int   `json:"lead_minutes"`
+}
+
+type feedingReminderUnsubscribeRequest struct {
+	HouseholdID string `json:"household_id"`
+}
+
 type checkoutRequest struct {
 	HouseholdID string `json:"household_id"`
 	Plan        string `json:"plan"`
 }
 
+type subscriptionAIModelRequest struct {
+	HouseholdID string `json:"household_id"`
+	Model       string `json:"model"`
+}
+
 type updateMySettingsRequest struct {
 	ThemeMode        *string         `json:"theme_mode"`
 	Language         *string         `json:"language"`
@@ -105,43 +187,66 @@ type updateMySettingsRequest struct {
 	HomeTileColumns  *int            `json:"home_tile_columns"`
 	HomeTileOrder    []string        `json:"home_tile_order"`
 	ShowSpecialMemo  *bool           `json:"show_special_memo"`
+	WeekStartsOn     *string         `json:"week_starts_on"`
+	SmalltalkMaxLen  *int            `json:"smalltalk_reply_max_chars"`
 }
 
 type manualEventCreateRequest struct {
-	BabyID    string         `json:"baby_id"`
-	Type      string         `json:"type"`
-	StartTime time.Time      `json:"start_time"`
-	EndTime   *time.Time     `json:"end_time,omitempty"`
-	Value     map[string]any `json:"value"`
-	Metadata  map[string]any `json:"metadata,omitempty"`
+	BabyID     string         `json:"baby_id"`
+	Type       string         `json:"type"`
+	StartTime  time.Time      `json:"start_time"`
+	EndTime    *time.Time     `json:"end_time,omitempty"`
+	Value      map[string]any `json:"value"`
+	Metadata   map[string]any `json:"metadata,omitempty"`
+	Visibility string         `json:"visibility,omitempty"`
+}
+
+type manualEventBulkCreateRequest struct {
+	BabyID string                     `json:"baby_id"`
+	Events []manualEventCreateRequest `json:"events"`
 }
 
 type manualEventStartRequest struct {
-	BabyID    string         `json:"baby_id"`
-	Type      string         `json:"type"`
-	StartTime time.Time      `json:"start_time"`
-	Value     map[string]any `json:"value"`
-	Metadata  map[string]any `json:"metadata,omitempty"`
+	BabyID          string         `json:"baby_id"`
+	Type            string         `json:"type"`
+	StartTime       time.Time      `json:"start_time"`
+	Value           map[string]any `json:"value"`
+	Metadata        map[string]any `json:"metadata,omitempty"`
+	AllowConcurrent bool           `json:"allow_concurrent,omitempty"`
 }
 
 type manualEventCompleteRequest struct {
-	EndTime  *time.Time     `json:"end_time,omitempty"`
-	Value    map[string]any `json:"value,omitempty"`
-	Metadata map[string]any `json:"metadata,omitempty"`
+	EndTime     *time.Time     `json:"end_time,omitempty"`
+	DurationMin *float64       `json:"duration_min,omitempty"`
+	Value       map[string]any `json:"value,omitempty"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
 }
 
 type manualEventUpdateRequest struct {
-	Type      *string        `json:"type,omitempty"`
-	StartTime *time.Time     `json:"start_time,omitempty"`
-	EndTime   *time.Time     `json:"end_time,omitempty"`
-	Value     map[string]any `json:"value,omitempty"`
-	Metadata  map[string]any `json:"metadata,omitempty"`
+	Type        *string        `json:"type,omitempty"`
+	StartTime   *time.Time     `json:"start_time,omitempty"`
+	EndTime     *time.Time     `json:"end_time,omitempty"`
+	DurationMin *float64       `json:"duration_min,omitempty"`
+	Value       map[string]any `json:"value,omitempty"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
 }
 
 type manualEventCancelRequest struct {
 	Reason string `json:"reason,omitempty"`
 }
 
+type eventMergeRequest struct {
+	KeepEventID   string   `json:"keep_event_id"`
+	MergeEventIDs []string `json:"merge_event_ids"`
+}
+
+type eventShiftRequest struct {
+	From         time.Time `json:"from"`
+	To           time.Time `json:"to"`
+	Type         string    `json:"type,omitempty"`
+	ShiftMinutes int       `json:"shift_minutes"`
+}
+
 type babyProfileUpsertRequest struct {
 	BabyID                string   `json:"baby_id"`
 	BabyName              string   `json:"baby_name"`
@@ -153,6 +258,11 @@ type babyProfileUpsertRequest struct {
 	FormulaProduct        string   `json:"formula_product"`
 	FormulaType           string   `json:"formula_type"`
 	FormulaContainsStarch *bool    `json:"formula_contains_starch"`
+	FormulaDailyGoalML    *int     `json:"formula_daily_goal_ml"`
+	FeedingUnit           string   `json:"feeding_unit"`
+	GestationalWeeks      *int     `json:"gestational_weeks"`
+	NapStartHour          *int     `json:"nap_start_hour"`
+	NightStartHour        *int     `json:"night_start_hour"`
 }
 
 type siriIntentRequest struct {
@@ -166,9 +276,75 @@ type bixbyQueryRequest struct {
 	Tone          string `json:"tone"`
 }
 
+// alexaQueryRequest is the part of an Alexa request envelope the API reads.
+// LaunchRequest has no intent and gets today's summary.
+type alexaQueryRequest struct {
+	BabyID  string `json:"baby_id"`
+	Tone    string `json:"tone"`
+	Request struct {
+		Type   string      `json:"type"`
+		Intent alexaIntent `json:"intent"`
+	} `json:"request"`
+}
+
+type alexaIntent struct {
+	Name  string               `json:"name"`
+	Slots map[string]alexaSlot `json:"slots"`
+}
+
+type alexaSlot struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
 type weeklyMetrics struct {
 	FeedingML    float64
 	SleepMinutes int
+	Feedings     feedingSplit
+}
+
+// feedingSplit keeps formula and breastfeed tallies apart so mixed-feeding
+// parents can see the balance between them.
+type feedingSplit struct {
+	FormulaCount      int     `json:"formula_count"`
+	FormulaML         float64 `json:"formula_ml"`
+	BreastfeedCount   int     `json:"breastfeed_count"`
+	BreastfeedMinutes int     `json:"breastfeed_min"`
+}
+
+// add counts one event; anything other than FORMULA and BREASTFEED is ignored.
+func (s *feedingSplit) add(eventType string, value map[string]any, startedAt time.Time, endedAt *time.Time) {
+	switch eventType {
+	case "FORMULA":
+		s.FormulaCount++
+		s.FormulaML += extractNumberFromMap(value, "ml", "amount_ml", "volume_ml")
+	case "BREASTFEED":
+		s.BreastfeedCount++
+		if duration := extractDurationMinutes(value, startedAt, endedAt); duration != nil && *duration > 0 {
+			s.BreastfeedMinutes += int(*duration + 0.5)
+		}
+	}
+}
+
+func (s feedingSplit) summaryLines() []string {
+	return []string{
+		"Feedings combined: " + strconv.Itoa(s.FormulaCount+s.BreastfeedCount) +
+			" (formula " + strconv.Itoa(s.FormulaCount) + ", breastfeed " + strconv.Itoa(s.BreastfeedCount) + ")",
+		"Formula: " + strconv.Itoa(s.FormulaCount) + " feeds, " + strconv.Itoa(int(s.FormulaML)) + " ml",
+		"Breastfeed: " + strconv.Itoa(s.BreastfeedCount) + " sessions, " + strconv.Itoa(s.BreastfeedMinutes) + " minutes",
+	}
+}
+
+type monthlyMetrics struct {
+	Days         int
+	FeedingCount int
+	FeedingML    float64
+	SleepMinutes int
+	PeeCount     int
+	PooCount     int
+	// Weeks buckets the month into 7-day slices from the first day; the last
+	// slice holds the remaining 0-3 days.
+	Weeks []weeklyMetrics
 }
 
 var validEventTypes = map[string]struct{}{
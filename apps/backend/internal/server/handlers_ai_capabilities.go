@@ -0,0 +1,180 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type aiCapabilityText struct {
+	Title       string
+	Description string
+	Examples    []string
+}
+
+type aiCapability struct {
+	Key    string
+	Intent aiIntent
+	Text   map[string]aiCapabilityText
+}
+
+// aiCapabilities is the single list of what the assistant answers. The help
+// endpoint and the empty-answer fallback both render from it.
+var aiCapabilities = []aiCapability{
+	{
+		Key:    "record_lookup",
+		Intent: aiIntentDataQuery,
+		Text: map[string]aiCapabilityText{
+			"ko": {
+				Title:       "기록 조회",
+				Description: "수유, 수면, 기저귀 기록의 마지막 시각과 횟수를 알려드려요.",
+				Examples:    []string{"마지막 수유가 언제였어?", "오늘 기저귀 몇 번 갈았어?"},
+			},
+			"en": {
+				Title:       "Record lookup",
+				Description: "Last times and counts from feeding, sleep and diaper records.",
+				Examples:    []string{"When was the last feeding?", "How many diapers today?"},
+			},
+		},
+	},
+	{
+		Key:    "summaries",
+		Intent: aiIntentDataQuery,
+		Text: map[string]aiCapabilityText{
+			"ko": {
+				Title:       "일간·주간 요약",
+				Description: "하루나 한 주의 기록을 요약하고 지난 기간과 비교해요.",
+				Examples:    []string{"오늘 하루 요약해줘", "이번 주 수면이 지난주보다 늘었어?"},
+			},
+			"en": {
+				Title:       "Daily and weekly summaries",
+				Description: "Summaries of a day or week, compared with the previous period.",
+				Examples:    []string{"Summarize today", "Did sleep go up compared to last week?"},
+			},
+		},
+	},
+	{
+		Key:    "care_routine",
+		Intent: aiIntentCareRoutine,
+		Text: map[string]aiCapabilityText{
+			"ko": {
+				Title:       "수면·수유 루틴",
+				Description: "기록된 패턴을 바탕으로 낮잠, 밤잠, 수유 일정을 함께 살펴봐요.",
+				Examples:    []string{"낮잠 시간을 어떻게 잡으면 좋을까?", "밤중 수유를 줄이려면?"},
+			},
+			"en": {
+				Title:       "Sleep and feeding routines",
+				Description: "Nap, night sleep and feeding schedules based on recorded patterns.",
+				Examples:    []string{"How should I plan nap times?", "How can we reduce night feeds?"},
+			},
+		},
+	},
+	{
+		Key:    "health_guidance",
+		Intent: aiIntentMedicalRelated,
+		Text: map[string]aiCapabilityText{
+			"ko": {
+				Title:       "증상 참고 안내",
+				Description: "발열, 구토 같은 증상에 대한 일반 안내와 병원 방문이 필요한 신호를 알려드려요. 진단을 대신하지는 않아요.",
+				Examples:    []string{"열이 38도인데 어떻게 해야 해?", "분유 먹고 토했는데 괜찮아?"},
+			},
+			"en": {
+				Title:       "Symptom guidance",
+				Description: "General guidance on symptoms like fever or vomiting and when to see a doctor. Not a diagnosis.",
+				Examples:    []string{"The temperature is 38C, what should I do?", "Is spitting up after formula okay?"},
+			},
+		},
+	},
+	{
+		Key:    "chat",
+		Intent: aiIntentSmalltalk,
+		Text: map[string]aiCapabilityText{
+			"ko": {
+				Title:       "육아 대화",
+				Description: "지친 하루에 가볍게 이야기 나눠요.",
+				Examples:    []string{"오늘 너무 힘들었어", "고마워"},
+			},
+			"en": {
+				Title:       "Everyday chat",
+				Description: "A light conversation after a long day.",
+				Examples:    []string{"Today was exhausting", "Thanks"},
+			},
+		},
+	},
+}
+
+// normalizeCapabilityLanguage maps an app language to one the capability
+// list is written in. Korean is the default; any other language gets English.
+func normalizeCapabilityLanguage(raw string) string {
+	language := strings.ToLower(strings.TrimSpace(raw))
+	language, _, _ = strings.Cut(language, "-")
+	switch language {
+	case "", "ko":
+		return "ko"
+	default:
+		return "en"
+	}
+}
+
+func localizedAICapabilities(language string) []gin.H {
+	language = normalizeCapabilityLanguage(language)
+	items := make([]gin.H, 0, len(aiCapabilities))
+	for _, capability := range aiCapabilities {
+		text := capability.Text[language]
+		items = append(items, gin.H{
+			"key":         capability.Key,
+			"intent":      string(capability.Intent),
+			"title":       text.Title,
+			"description": text.Description,
+			"examples":    append([]string{}, text.Examples...),
+		})
+	}
+	return items
+}
+
+// aiCapabilitiesFallbackAnswer is returned when the model's answer is empty
+// after sanitizing, so the user at least learns what they can ask.
+func aiCapabilitiesFallbackAnswer(language string) string {
+	language = normalizeCapabilityLanguage(language)
+	titles := make([]string, 0, len(aiCapabilities))
+	examples := make([]string, 0, len(aiCapabilities))
+	for _, capability := range aiCapabilities {
+		text := capability.Text[language]
+		titles = append(titles, text.Title)
+		if len(text.Examples) > 0 {
+			examples = append(examples, "\""+text.Examples[0]+"\"")
+		}
+	}
+	if language == "en" {
+		return "I can help with " + strings.Join(titles, ", ") + ". For example, try asking " + strings.Join(examples, " or ") + "."
+	}
+	return strings.Join(titles, ", ") + "을 도와드릴 수 있어요. 예를 들어 " + strings.Join(examples, ", ") + "처럼 물어봐 주세요."
+}
+
+// getAICapabilities lists what the assistant can answer for help and
+// onboarding screens. lang overrides the language from the user's settings.
+func (a *App) getAICapabilities(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	language := strings.TrimSpace(c.Query("lang"))
+	if language == "" {
+		persona, err := loadPersonaSettingsWithQuerier(c.Request.Context(), a.db, user.ID)
+		if err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to load settings")
+			return
+		}
+		language = resolveLanguage(persona)
+	}
+	language = normalizeCapabilityLanguage(language)
+
+	c.JSON(http.StatusOK, gin.H{
+		"language":        language,
+		"capabilities":    localizedAICapabilities(language),
+		"fallback_answer": aiCapabilitiesFallbackAnswer(language),
+	})
+}
@@ -0,0 +1,187 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	aiOutcomeSuccess       = "success"
+	aiOutcomeNotConfigured = "not_configured"
+	aiOutcomeProviderError = "provider_error"
+	aiOutcomeTimeout       = "timeout"
+	aiOutcomeEmptyAnswer   = "empty_answer"
+	aiOutcomeIncomplete    = "incomplete"
+	aiOutcomeMissingUsage  = "missing_usage"
+	aiOutcomeUnclassified  = "unclassified"
+
+	aiHealthDefaultWindowMin = 60
+	// aiHealthRetention is how long per-minute counts are kept in memory, and
+	// so the widest window the endpoint can report.
+	aiHealthRetention = 24 * time.Hour
+)
+
+var aiOutcomes = []string{
+	aiOutcomeSuccess,
+	aiOutcomeNotConfigured,
+	aiOutcomeProviderError,
+	aiOutcomeTimeout,
+	aiOutcomeEmptyAnswer,
+	aiOutcomeIncomplete,
+	aiOutcomeMissingUsage,
+	aiOutcomeUnclassified,
+}
+
+// classifyAIProviderError names the failure class of a chat provider error.
+// writeChatExecutionError maps the same classes to HTTP responses.
+func classifyAIProviderError(err error) string {
+	if err == nil {
+		return aiOutcomeSuccess
+	}
+	lowered := strings.ToLower(strings.TrimSpace(err.Error()))
+	switch {
+	case strings.Contains(lowered, "openai_api_key is not configured"):
+		return aiOutcomeNotConfigured
+	case strings.Contains(lowered, "openai responses error"):
+		return aiOutcomeProviderError
+	case strings.Contains(lowered, "context deadline exceeded"):
+		return aiOutcomeTimeout
+	case strings.Contains(lowered, "openai response answer is empty"):
+		return aiOutcomeEmptyAnswer
+	case strings.Contains(lowered, "openai response incomplete due max_output_tokens"):
+		return aiOutcomeIncomplete
+	case strings.Contains(lowered, "ai response missing usage tokens"):
+		return aiOutcomeMissingUsage
+	}
+	return aiOutcomeUnclassified
+}
+
+// aiHealthCounters counts chat provider outcomes in per-minute buckets. It is
+// process-local, so each API instance reports only its own traffic and the
+// counts reset on restart.
+type aiHealthCounters struct {
+	mu      sync.Mutex
+	buckets map[int64]map[string]int
+}
+
+func newAIHealthCounters() *aiHealthCounters {
+	return &aiHealthCounters{buckets: map[int64]map[string]int{}}
+}
+
+func (h *aiHealthCounters) record(outcome string, at time.Time) {
+	if h == nil {
+		return
+	}
+	minute := at.UTC().Unix() / 60
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	bucket, ok := h.buckets[minute]
+	if !ok {
+		bucket = map[string]int{}
+		h.buckets[minute] = bucket
+		oldest := minute - int64(aiHealthRetention/time.Minute)
+		for key := range h.buckets {
+			if key <= oldest {
+				delete(h.buckets, key)
+			}
+		}
+	}
+	bucket[outcome]++
+}
+
+// counts sums the buckets of the last window, including the current minute.
+func (h *aiHealthCounters) counts(window time.Duration, now time.Time) map[string]int {
+	result := make(map[string]int, len(aiOutcomes))
+	for _, outcome := range aiOutcomes {
+		result[outcome] = 0
+	}
+	if h == nil {
+		return result
+	}
+	currentMinute := now.UTC().Unix() / 60
+	firstMinute := currentMinute - int64(window/time.Minute) + 1
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for minute, bucket := range h.buckets {
+		if minute < firstMinute || minute > currentMinute {
+			continue
+		}
+		for outcome, count := range bucket {
+			result[outcome] += count
+		}
+	}
+	return result
+}
+
+func (a *App) recordAIOutcome(err error) {
+	a.aiHealth.record(classifyAIProviderError(err), time.Now())
+}
+
+func (a *App) isAdminUser(userID string) bool {
+	for _, adminID := range a.cfg.AdminUserIDs {
+		if strings.TrimSpace(adminID) != "" && strings.TrimSpace(adminID) == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// getAIHealth reports chat provider outcome counts and rates over the last
+// window_min minutes so operators can spot a degrading provider.
+func (a *App) getAIHealth(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	if !a.isAdminUser(user.ID) {
+		writeError(c, http.StatusForbidden, "Admin access required")
+		return
+	}
+
+	windowMin := aiHealthDefaultWindowMin
+	maxWindowMin := int(aiHealthRetention / time.Minute)
+	if raw := strings.TrimSpace(c.Query("window_min")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 || parsed > maxWindowMin {
+			writeError(c, http.StatusBadRequest, fmt.Sprintf("window_min must be between 1 and %d", maxWindowMin))
+			return
+		}
+		windowMin = parsed
+	}
+
+	counts := a.aiHealth.counts(time.Duration(windowMin)*time.Minute, time.Now())
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	rates := make(map[string]*float64, len(counts))
+	var failureRate *float64
+	for outcome, count := range counts {
+		if total == 0 {
+			rates[outcome] = nil
+			continue
+		}
+		rate := math.Round(float64(count)/float64(total)*1000) / 1000
+		rates[outcome] = &rate
+	}
+	if total > 0 {
+		rate := math.Round(float64(total-counts[aiOutcomeSuccess])/float64(total)*1000) / 1000
+		failureRate = &rate
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"window_min":   windowMin,
+		"total":        total,
+		"counts":       counts,
+		"rates":        rates,
+		"failure_rate": failureRate,
+	})
+}
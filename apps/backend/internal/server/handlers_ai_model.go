@@ -0,0 +1,194 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// selectableAIModels lists the models a household may pick. AI_MODEL_ALLOWLIST
+// narrows the choice; either way only priced models are offered, so a chat is
+// never billed at the fallback rate because of a household setting.
+func (a *App) selectableAIModels() []string {
+	priced := make(map[string]struct{}, len(defaultModelPricing))
+	for model := range defaultModelPricing {
+		priced[model] = struct{}{}
+	}
+	for model := range a.modelPricing {
+		priced[model] = struct{}{}
+	}
+
+	models := make([]string, 0, len(priced))
+	if len(a.cfg.AIModelAllowlist) == 0 {
+		for model := range priced {
+			models = append(models, model)
+		}
+	} else {
+		for _, entry := range a.cfg.AIModelAllowlist {
+			model := strings.TrimSpace(entry)
+			if _, ok := priced[model]; ok && !slices.Contains(models, model) {
+				models = append(models, model)
+			}
+		}
+	}
+	sort.Strings(models)
+	return models
+}
+
+func (a *App) isSelectableAIModel(model string) bool {
+	return slices.Contains(a.selectableAIModels(), strings.TrimSpace(model))
+}
+
+// householdAIModel returns the model the household picked, or "" when it
+// uses the defaults. A stored model that has since left the allowlist is
+// ignored rather than billed.
+func (a *App) householdAIModel(ctx context.Context, householdID string) (string, error) {
+	query := `SELECT COALESCE("aiModel", '') FROM "Subscription" WHERE "householdId" = $1 LIMIT 1`
+	var model string
+	err := a.db.QueryRow(ctx, query, householdID).Scan(&model)
+	if err != nil && isMissingSubscriptionAIModelColumnErr(err) {
+		if ensureErr := a.ensureSubscriptionAIModelColumn(ctx); ensureErr != nil {
+			return "", ensureErr
+		}
+		err = a.db.QueryRow(ctx, query, householdID).Scan(&model)
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	model = strings.TrimSpace(model)
+	if model == "" || !a.isSelectableAIModel(model) {
+		return "", nil
+	}
+	return model, nil
+}
+
+// chatModelFor resolves the model for one chat turn: the household's pick
+// when set, otherwise the per-intent default.
+func chatModelFor(householdModel string, intent aiIntent) string {
+	if householdModel != "" {
+		return householdModel
+	}
+	return chatModelForIntent(intent)
+}
+
+// updateSubscriptionAIModel stores the household's chat model. An empty model
+// clears the choice and restores the per-intent defaults.
+func (a *App) updateSubscriptionAIModel(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var payload subscriptionAIModelRequest
+	if !mustJSON(c, &payload) {
+		return
+	}
+	payload.HouseholdID = strings.TrimSpace(payload.HouseholdID)
+	payload.Model = strings.TrimSpace(payload.Model)
+	if payload.HouseholdID == "" {
+		writeError(c, http.StatusBadRequest, "household_id is required")
+		return
+	}
+	models := a.selectableAIModels()
+	if payload.Model != "" && !slices.Contains(models, payload.Model) {
+		writeError(c, http.StatusBadRequest, "model must be one of: "+strings.Join(models, ", "))
+		return
+	}
+	if _, statusCode, err := a.assertHouseholdAccess(c.Request.Context(), user.ID, payload.HouseholdID, billingRoles); err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+	hasFeature, _, _, err := a.hasSubscriptionFeature(c.Request.Context(), payload.HouseholdID, subscriptionFeatureAI)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		writeError(c, http.StatusInternalServerError, "Failed to load subscription")
+		return
+	}
+	if !hasFeature {
+		writeError(c, http.StatusPaymentRequired, subscriptionFeatureDetail(subscriptionFeatureAI))
+		return
+	}
+
+	subscriptionID, err := a.setSubscriptionAIModel(c.Request.Context(), user.ID, payload.HouseholdID, payload.Model)
+	if err != nil && isMissingSubscriptionAIModelColumnErr(err) {
+		if ensureErr := a.ensureSubscriptionAIModelColumn(c.Request.Context()); ensureErr != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to prepare subscription storage")
+			return
+		}
+		subscriptionID, err = a.setSubscriptionAIModel(c.Request.Context(), user.ID, payload.HouseholdID, payload.Model)
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		writeError(c, http.StatusNotFound, "Subscription not found")
+		return
+	}
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to update AI model")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"household_id":    payload.HouseholdID,
+		"subscription_id": subscriptionID,
+		"ai_model":        nullableString(payload.Model),
+		"ai_models":       models,
+	})
+}
+
+func (a *App) setSubscriptionAIModel(ctx context.Context, userID, householdID, model string) (string, error) {
+	tx, err := a.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	var subscriptionID string
+	if err := tx.QueryRow(
+		ctx,
+		`UPDATE "Subscription"
+		 SET "aiModel" = NULLIF($2, '')
+		 WHERE "householdId" = $1
+		 RETURNING id`,
+		householdID,
+		model,
+	).Scan(&subscriptionID); err != nil {
+		return "", err
+	}
+	if err := recordAuditLog(
+		ctx,
+		tx,
+		householdID,
+		userID,
+		"SUBSCRIPTION_AI_MODEL_UPDATED",
+		"Subscription",
+		&subscriptionID,
+		gin.H{"ai_model": nullableString(model)},
+	); err != nil {
+		return "", err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return "", err
+	}
+	return subscriptionID, nil
+}
+
+func (a *App) ensureSubscriptionAIModelColumn(ctx context.Context) error {
+	_, err := a.db.Exec(ctx, `ALTER TABLE "Subscription" ADD COLUMN IF NOT EXISTS "aiModel" TEXT`)
+	return err
+}
+
+func isMissingSubscriptionAIModelColumnErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	lowered := strings.ToLower(err.Error())
+	return strings.Contains(lowered, "column") && strings.Contains(lowered, "aimodel")
+}
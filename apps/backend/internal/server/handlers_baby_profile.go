@@ -30,6 +30,16 @@ type resolvedBabyProfile struct {
 	FormulaProduct        string
 	FormulaType           string
 	FormulaContainsStarch *bool
+	FormulaDailyGoalML    *int
+	FeedingUnit           string
+	GestationalWeeks      *int
+	// NapStartHour and NightStartHour bound the local hours whose sleep counts
+	// as naps; everything else is night sleep.
+	NapStartHour   int
+	NightStartHour int
+	// CorrectedAgeDays counts from the due date for babies born before 37
+	// weeks and equals AgeDays otherwise.
+	CorrectedAgeDays int
 }
 
 type feedingRecommendation struct {
@@ -198,6 +208,55 @@ func (a *App) upsertBabyProfile(c *gin.Context) {
 	if payload.FormulaContainsStarch != nil {
 		babySettings["formula_contains_starch"] = *payload.FormulaContainsStarch
 	}
+	if payload.FormulaDailyGoalML != nil {
+		goal := *payload.FormulaDailyGoalML
+		if goal < 0 || goal > maxFormulaDailyGoalML {
+			writeError(c, http.StatusBadRequest, fmt.Sprintf("formula_daily_goal_ml must be between 0 and %d", maxFormulaDailyGoalML))
+			return
+		}
+		// 0 clears the goal so the recommendation is used again.
+		if goal == 0 {
+			delete(babySettings, "formula_daily_goal_ml")
+		} else {
+			babySettings["formula_daily_goal_ml"] = goal
+		}
+	}
+	if payload.GestationalWeeks != nil {
+		weeks := *payload.GestationalWeeks
+		// 0 clears it; the baby is then treated as full term.
+		if weeks == 0 {
+			delete(babySettings, "gestational_weeks")
+		} else {
+			if err := validateGestationalWeeks(weeks); err != nil {
+				writeError(c, http.StatusBadRequest, err.Error())
+				return
+			}
+			babySettings["gestational_weeks"] = weeks
+		}
+	}
+	if unitRaw := strings.TrimSpace(payload.FeedingUnit); unitRaw != "" {
+		unit := normalizeFeedingUnit(unitRaw)
+		if unit == "" {
+			writeError(c, http.StatusBadRequest, "feeding_unit must be one of: ml, oz")
+			return
+		}
+		babySettings["feeding_unit"] = unit
+	}
+	if payload.NapStartHour != nil || payload.NightStartHour != nil {
+		napStartHour, nightStartHour := napNightHoursFromSettings(babySettings)
+		if payload.NapStartHour != nil {
+			napStartHour = *payload.NapStartHour
+		}
+		if payload.NightStartHour != nil {
+			nightStartHour = *payload.NightStartHour
+		}
+		if err := validateNapNightHours(napStartHour, nightStartHour); err != nil {
+			writeError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		babySettings["nap_start_hour"] = napStartHour
+		babySettings["night_start_hour"] = nightStartHour
+	}
 	babySettings["updated_at"] = time.Now().UTC().Format(time.RFC3339)
 	writeBabySettings(persona, baby.ID, babySettings)
 
@@ -293,7 +352,16 @@ func (a *App) resolveBabyProfile(
 		FormulaProduct:        strings.TrimSpace(toString(babySettings["formula_product"])),
 		FormulaType:           coalesceNonEmpty(normalizeFormulaType(toString(babySettings["formula_type"])), "standard"),
 		FormulaContainsStarch: mapBoolPointer(babySettings["formula_contains_starch"]),
+		FeedingUnit:           coalesceNonEmpty(normalizeFeedingUnit(toString(babySettings["feeding_unit"])), feedingUnitML),
+	}
+	if goal := int(extractNumberFromMap(babySettings, "formula_daily_goal_ml")); goal > 0 {
+		profile.FormulaDailyGoalML = &goal
 	}
+	if weeks := int(extractNumberFromMap(babySettings, "gestational_weeks")); weeks > 0 {
+		profile.GestationalWeeks = &weeks
+	}
+	profile.NapStartHour, profile.NightStartHour = napNightHoursFromSettings(babySettings)
+	profile.CorrectedAgeDays = ageDaysFromBirth(correctedBirthDate(profile.BirthDate, profile.GestationalWeeks), time.Now().UTC())
 
 	if sex != nil {
 		if normalized := normalizeBabySex(*sex); normalized != "" {
@@ -310,6 +378,7 @@ func (a *App) latestFeedingTime(ctx context.Context, babyID string) (*time.Time,
 		ctx,
 		`SELECT "startTime" FROM "Event"
 		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
 		   AND type IN ('FORMULA', 'BREASTFEED')
 		   AND NOT (
 		     "endTime" IS NULL
@@ -327,6 +396,7 @@ func (a *App) latestFeedingTime(ctx context.Context, babyID string) (*time.Time,
 			ctx,
 			`SELECT "startTime" FROM "Event"
 			 WHERE "babyId" = $1
+			   AND "deletedAt" IS NULL
 			   AND type IN ('FORMULA', 'BREASTFEED')
 			 ORDER BY "startTime" DESC LIMIT 1`,
 			babyID,
@@ -355,16 +425,42 @@ func isUndefinedSchemaReferenceError(err error) bool {
 	return pgErr.Code == "42703" || pgErr.Code == "42P01"
 }
 
+// feedingRecommendationTrace captures the intermediate values behind a
+// feedingRecommendation so the number can be explained after the fact.
+type feedingRecommendationTrace struct {
+	WeightKg       float64
+	WeightSource   string
+	MLPerKgPerDay  int
+	IntervalMin    int
+	MethodRatio    float64
+	DailyFormulaML *int
+	FeedsPerDay    *float64
+	RawPerFeedML   *int
+	MinPerFeedML   int
+	MaxPerFeedML   int
+}
+
 func calculateFeedingRecommendation(
 	profile resolvedBabyProfile,
 	lastFeedingTime *time.Time,
 	now time.Time,
 ) feedingRecommendation {
+	recommendation, _ := calculateFeedingRecommendationWithTrace(profile, lastFeedingTime, now)
+	return recommendation
+}
+
+func calculateFeedingRecommendationWithTrace(
+	profile resolvedBabyProfile,
+	lastFeedingTime *time.Time,
+	now time.Time,
+) (feedingRecommendation, feedingRecommendationTrace) {
 	normalizedNow := now.UTC()
 	weightKg := profile.WeightKg
+	weightSource := "profile"
 	if weightKg == nil {
 		defaultWeight := fallbackWeightKg(profile.AgeDays)
 		weightKg = &defaultWeight
+		weightSource = "age_fallback"
 	}
 
 	mlPerKgPerDay := baselineMLPerKgPerDay(profile.AgeDays)
@@ -385,6 +481,16 @@ func calculateFeedingRecommendation(
 		note = "Starch/thickened formula does not automatically mean longer feeding intervals. Keep clinician guidance first."
 	}
 
+	trace := feedingRecommendationTrace{
+		WeightKg:      *weightKg,
+		WeightSource:  weightSource,
+		MLPerKgPerDay: mlPerKgPerDay,
+		IntervalMin:   intervalMin,
+		MethodRatio:   methodRatio,
+		MinPerFeedML:  30,
+		MaxPerFeedML:  maxPerFeedByAge(profile.AgeDays),
+	}
+
 	var dailyFormulaMLPtr *int
 	var perFeedMLPtr *int
 	if methodRatio > 0 && weightKg != nil {
@@ -393,13 +499,17 @@ func calculateFeedingRecommendation(
 			dailyFormulaML = 0
 		}
 		dailyFormulaMLPtr = &dailyFormulaML
+		trace.DailyFormulaML = dailyFormulaMLPtr
 
 		feedsPerDay := float64(24*60) / float64(intervalMin)
 		if feedsPerDay < 1 {
 			feedsPerDay = 1
 		}
+		trace.FeedsPerDay = &feedsPerDay
 		perFeed := int(math.Round(float64(dailyFormulaML) / feedsPerDay))
-		perFeed = clampInt(perFeed, 30, maxPerFeedByAge(profile.AgeDays))
+		rawPerFeed := perFeed
+		trace.RawPerFeedML = &rawPerFeed
+		perFeed = clampInt(perFeed, trace.MinPerFeedML, trace.MaxPerFeedML)
 		perFeed = roundToNearest5(perFeed)
 		perFeedMLPtr = &perFeed
 	}
@@ -431,7 +541,115 @@ func calculateFeedingRecommendation(
 		RecommendedNextFeedingInMin: nextFeedingInMin,
 		ReferenceText:               referenceText,
 		Note:                        note,
+	}, trace
+}
+
+func (a *App) getRecommendationAudit(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
 	}
+
+	babyID := strings.TrimSpace(c.Param("baby_id"))
+	if babyID == "" {
+		writeError(c, http.StatusBadRequest, "baby_id is required")
+		return
+	}
+
+	profile, statusCode, err := a.resolveBabyProfile(c.Request.Context(), user.ID, babyID, readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	lastFeeding, err := a.latestFeedingTime(c.Request.Context(), profile.BabyID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load latest feeding event")
+		return
+	}
+	nowUTC := time.Now().UTC()
+	recommendation, trace := calculateFeedingRecommendationWithTrace(profile, lastFeeding, nowUTC)
+
+	steps := []string{
+		fmt.Sprintf("ml_per_kg_per_day=%d from age_days=%d", trace.MLPerKgPerDay, profile.AgeDays),
+		fmt.Sprintf("feed_interval_min=%d from age_days=%d and feeding_method=%s", trace.IntervalMin, profile.AgeDays, profile.FeedingMethod),
+		fmt.Sprintf("formula_ratio=%.2f from feeding_method=%s", trace.MethodRatio, profile.FeedingMethod),
+	}
+	if trace.DailyFormulaML != nil && trace.FeedsPerDay != nil && trace.RawPerFeedML != nil {
+		steps = append(
+			steps,
+			fmt.Sprintf(
+				"daily_formula_ml = round(%s kg x %d ml x %.2f) = %d",
+				formatWeightForReference(&trace.WeightKg),
+				trace.MLPerKgPerDay,
+				trace.MethodRatio,
+				*trace.DailyFormulaML,
+			),
+			fmt.Sprintf("feeds_per_day = 1440 / %d = %.2f", trace.IntervalMin, *trace.FeedsPerDay),
+			fmt.Sprintf("per_feed_ml = round(%d / %.2f) = %d", *trace.DailyFormulaML, *trace.FeedsPerDay, *trace.RawPerFeedML),
+		)
+		if recommendation.RecommendedFormulaPerFeedML != nil {
+			steps = append(
+				steps,
+				fmt.Sprintf(
+					"per_feed_ml clamped to [%d, %d] and rounded to nearest 5 = %d",
+					trace.MinPerFeedML,
+					trace.MaxPerFeedML,
+					*recommendation.RecommendedFormulaPerFeedML,
+				),
+			)
+		}
+	} else {
+		steps = append(steps, "formula amounts skipped because feeding_method=breastmilk")
+	}
+	if recommendation.RecommendedNextFeedingTime != nil {
+		steps = append(
+			steps,
+			fmt.Sprintf(
+				"next_feeding_time = last_feeding_time + %d min = %s",
+				trace.IntervalMin,
+				recommendation.RecommendedNextFeedingTime.UTC().Format(time.RFC3339),
+			),
+		)
+	} else {
+		steps = append(steps, "next_feeding_time skipped because no completed feeding is logged")
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"baby_id":      profile.BabyID,
+		"generated_at": nowUTC.Format(time.RFC3339),
+		"inputs": gin.H{
+			"age_days":                profile.AgeDays,
+			"weight_kg":               roundToOneDecimal(trace.WeightKg),
+			"weight_source":           trace.WeightSource,
+			"feeding_method":          profile.FeedingMethod,
+			"formula_type":            profile.FormulaType,
+			"formula_contains_starch": profile.FormulaContainsStarch,
+			"last_feeding_time":       formatNullableTimeRFC3339(lastFeeding),
+		},
+		"intermediate": gin.H{
+			"ml_per_kg_per_day":    trace.MLPerKgPerDay,
+			"feed_interval_min":    trace.IntervalMin,
+			"formula_ratio":        trace.MethodRatio,
+			"daily_formula_ml":     trace.DailyFormulaML,
+			"feeds_per_day":        trace.FeedsPerDay,
+			"raw_per_feed_ml":      trace.RawPerFeedML,
+			"per_feed_ml_min":      trace.MinPerFeedML,
+			"per_feed_ml_max":      trace.MaxPerFeedML,
+			"per_feed_rounding_ml": 5,
+		},
+		"steps": steps,
+		"result": gin.H{
+			"recommended_formula_daily_ml":    recommendation.RecommendedFormulaDailyML,
+			"recommended_formula_per_feed_ml": recommendation.RecommendedFormulaPerFeedML,
+			"recommended_feed_interval_min":   recommendation.RecommendedIntervalMin,
+			"recommended_next_feeding_time":   formatNullableTimeRFC3339(recommendation.RecommendedNextFeedingTime),
+			"recommended_next_feeding_in_min": recommendation.RecommendedNextFeedingInMin,
+		},
+		"reference_text": recommendation.ReferenceText,
+		"note":           recommendation.Note,
+	})
 }
 
 func profileResponse(profile resolvedBabyProfile, recommendation feedingRecommendation) gin.H {
@@ -442,6 +660,8 @@ func profileResponse(profile resolvedBabyProfile, recommendation feedingRecommen
 		"baby_profile_photo_url":          profile.ProfilePhotoURL,
 		"birth_date":                      profile.BirthDate.Format("2006-01-02"),
 		"age_days":                        profile.AgeDays,
+		"corrected_age_days":              profile.CorrectedAgeDays,
+		"gestational_weeks":               profile.GestationalWeeks,
 		"sex":                             profile.Sex,
 		"weight_kg":                       profile.WeightKg,
 		"feeding_method":                  profile.FeedingMethod,
@@ -450,6 +670,10 @@ func profileResponse(profile resolvedBabyProfile, recommendation feedingRecommen
 		"formula_type":                    profile.FormulaType,
 		"formula_contains_starch":         profile.FormulaContainsStarch,
 		"formula_display_name":            formulaDisplayName(profile),
+		"formula_daily_goal_ml":           profile.FormulaDailyGoalML,
+		"feeding_unit":                    profile.FeedingUnit,
+		"nap_start_hour":                  profile.NapStartHour,
+		"night_start_hour":                profile.NightStartHour,
 		"recommended_formula_daily_ml":    recommendation.RecommendedFormulaDailyML,
 		"recommended_formula_per_feed_ml": recommendation.RecommendedFormulaPerFeedML,
 		"recommended_feed_interval_min":   recommendation.RecommendedIntervalMin,
@@ -584,6 +808,61 @@ func ageDaysFromBirth(birthDate, now time.Time) int {
 	return days
 }
 
+const (
+	fullTermGestationalWeeks = 40
+	pretermGestationalWeeks  = 37
+	minGestationalWeeks      = 22
+	maxGestationalWeeks      = 44
+)
+
+func validateGestationalWeeks(weeks int) error {
+	if weeks < minGestationalWeeks || weeks > maxGestationalWeeks {
+		return fmt.Errorf("gestational_weeks must be between %d and %d", minGestationalWeeks, maxGestationalWeeks)
+	}
+	return nil
+}
+
+// correctedBirthDate moves a preterm baby's birth date forward by the weeks
+// they arrived early (40 - gestational weeks), which is the date corrected
+// age counts from. Babies born at 37 weeks or later, or with no gestational
+// age recorded, keep their birth date.
+func correctedBirthDate(birthDate time.Time, gestationalWeeks *int) time.Time {
+	if gestationalWeeks == nil || *gestationalWeeks <= 0 || *gestationalWeeks >= pretermGestationalWeeks {
+		return birthDate
+	}
+	return birthDate.AddDate(0, 0, (fullTermGestationalWeeks-*gestationalWeeks)*7)
+}
+
+// ageMonthsFromBirthDate returns completed months since birth. A birth day
+// that does not exist in the current month (e.g. the 31st in February) is
+// treated as reached on that month's last day.
+func ageMonthsFromBirthDate(birthDate, now time.Time) int {
+	if birthDate.IsZero() {
+		return 0
+	}
+	birthUTC := startOfUTCDay(birthDate.UTC())
+	nowUTC := startOfUTCDay(now.UTC())
+	if nowUTC.Before(birthUTC) {
+		return 0
+	}
+	months := (nowUTC.Year()-birthUTC.Year())*12 + int(nowUTC.Month()) - int(birthUTC.Month())
+	monthDay := birthUTC.Day()
+	if lastDay := daysInUTCMonth(nowUTC.Year(), nowUTC.Month()); monthDay > lastDay {
+		monthDay = lastDay
+	}
+	if nowUTC.Day() < monthDay {
+		months--
+	}
+	if months < 0 {
+		return 0
+	}
+	return months
+}
+
+func daysInUTCMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
 func fallbackWeightKg(ageDays int) float64 {
 	switch {
 	case ageDays <= 30:
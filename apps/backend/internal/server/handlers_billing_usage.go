@@ -0,0 +1,165 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	billingUsageDefaultRangeDays = 30
+	billingUsageMaxRangeDays     = 92
+)
+
+// billingUsageDay is one UTC day of AiUsageLog rows. Days are UTC because the
+// daily grace allowance resets on the UTC day (see countGraceUsedToday).
+type billingUsageDay struct {
+	Date             string         `json:"date"`
+	Queries          int            `json:"queries"`
+	ChargedCredits   int            `json:"charged_credits"`
+	PromptTokens     int            `json:"prompt_tokens"`
+	CompletionTokens int            `json:"completion_tokens"`
+	TotalTokens      int            `json:"total_tokens"`
+	ByBillingMode    map[string]int `json:"by_billing_mode"`
+	GraceUsed        int            `json:"grace_used"`
+}
+
+func newBillingUsageDay(date string) billingUsageDay {
+	return billingUsageDay{
+		Date:          date,
+		ByBillingMode: map[string]int{string(billingModePaid): 0, string(billingModeGrace): 0},
+	}
+}
+
+func (d *billingUsageDay) add(mode string, charged, prompt, completion, total int) {
+	d.Queries++
+	d.ChargedCredits += charged
+	d.PromptTokens += prompt
+	d.CompletionTokens += completion
+	d.TotalTokens += total
+	d.ByBillingMode[mode]++
+	if mode == string(billingModeGrace) {
+		d.GraceUsed++
+	}
+}
+
+// getBillingUsage shows where a household's AI credits went: one entry per
+// UTC day in the window, zero-filled so it can be charted directly.
+func (a *App) getBillingUsage(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	householdID := strings.TrimSpace(c.Query("household_id"))
+	if householdID == "" {
+		writeError(c, http.StatusBadRequest, "household_id is required")
+		return
+	}
+
+	toDate := startOfUTCDay(time.Now())
+	if raw := strings.TrimSpace(c.Query("to")); raw != "" {
+		parsed, err := parseDate(raw)
+		if err != nil {
+			writeError(c, http.StatusBadRequest, "to must be YYYY-MM-DD")
+			return
+		}
+		toDate = parsed
+	}
+	fromDate := toDate.AddDate(0, 0, -(billingUsageDefaultRangeDays - 1))
+	if raw := strings.TrimSpace(c.Query("from")); raw != "" {
+		parsed, err := parseDate(raw)
+		if err != nil {
+			writeError(c, http.StatusBadRequest, "from must be YYYY-MM-DD")
+			return
+		}
+		fromDate = parsed
+	}
+	if fromDate.After(toDate) {
+		writeError(c, http.StatusBadRequest, "from must be on or before to")
+		return
+	}
+	if toDate.Sub(fromDate) >= billingUsageMaxRangeDays*24*time.Hour {
+		writeError(c, http.StatusBadRequest, fmt.Sprintf("from/to range must be at most %d days", billingUsageMaxRangeDays))
+		return
+	}
+
+	if _, statusCode, err := a.assertHouseholdAccess(c.Request.Context(), user.ID, householdID, billingRoles); err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+	limits, err := a.planLimitsForHousehold(c.Request.Context(), householdID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load subscription")
+		return
+	}
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT "createdAt", "billingMode"::text, "chargedCredits",
+		        "promptTokens", "completionTokens", "totalTokens"
+		 FROM "AiUsageLog"
+		 WHERE "householdId" = $1
+		   AND "createdAt" >= $2
+		   AND "createdAt" < $3
+		 ORDER BY "createdAt" ASC`,
+		householdID,
+		fromDate,
+		toDate.AddDate(0, 0, 1),
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load credit usage")
+		return
+	}
+	defer rows.Close()
+
+	dayIndex := map[string]int{}
+	days := make([]billingUsageDay, 0, int(toDate.Sub(fromDate).Hours()/24)+1)
+	for day := fromDate; !day.After(toDate); day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		dayIndex[key] = len(days)
+		days = append(days, newBillingUsageDay(key))
+	}
+	totals := newBillingUsageDay("")
+
+	for rows.Next() {
+		var createdAt time.Time
+		var mode string
+		var charged, prompt, completion, total int
+		if err := rows.Scan(&createdAt, &mode, &charged, &prompt, &completion, &total); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to parse credit usage")
+			return
+		}
+		mode = strings.ToLower(mode)
+		idx, ok := dayIndex[createdAt.UTC().Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+		days[idx].add(mode, charged, prompt, completion, total)
+		totals.add(mode, charged, prompt, completion, total)
+	}
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse credit usage")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"household_id":        householdID,
+		"from":                fromDate.Format("2006-01-02"),
+		"to":                  toDate.Format("2006-01-02"),
+		"grace_limit_per_day": limits.GraceLimitPerDay,
+		"totals": gin.H{
+			"queries":           totals.Queries,
+			"charged_credits":   totals.ChargedCredits,
+			"prompt_tokens":     totals.PromptTokens,
+			"completion_tokens": totals.CompletionTokens,
+			"total_tokens":      totals.TotalTokens,
+			"by_billing_mode":   totals.ByBillingMode,
+			"grace_used":        totals.GraceUsed,
+		},
+		"days": days,
+	})
+}
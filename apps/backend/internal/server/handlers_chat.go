@@ -7,9 +7,12 @@ import (
 	"log"
 	"net/http"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -27,10 +30,15 @@ type chatSessionRecord struct {
 	MemorySummary          *string
 	MemorySummarizedCount  int
 	MemorySummaryUpdatedAt *time.Time
+	MemoryCompressedCount  int
+	Title                  *string
+	PreferredTone          *string
+	PreferredLanguage      *string
 }
 
 type chatSessionListItem struct {
 	SessionID      string
+	Title          *string
 	ChildID        *string
 	Status         string
 	StartedAt      time.Time
@@ -40,6 +48,7 @@ type chatSessionListItem struct {
 	LastPreview    *string
 	LastMessageAt  time.Time
 	MessageCount   int
+	Archived       bool
 }
 
 type chatContextResult struct {
@@ -52,6 +61,9 @@ type childProfileSnapshot struct {
 	BirthDate             time.Time
 	AgeDays               int
 	AgeMonths             int
+	CorrectedAgeDays      int
+	CorrectedAgeMonths    int
+	GestationalWeeks      *int
 	Sex                   string
 	FeedingMethod         string
 	FormulaBrand          string
@@ -76,7 +88,11 @@ type chatExecutionResult struct {
 	SessionID          string
 	AssistantMessageID string
 	Intent             aiIntent
+	IntentSource       chatIntentSource
 	Answer             string
+	AnswerTranslated   *string
+	TranslateTo        string
+	Facts              []chatFact
 	Model              string
 	Usage              AIUsage
 	Credit             billingResult
@@ -84,6 +100,17 @@ type chatExecutionResult struct {
 	ReferenceText      string
 }
 
+// chatIntentSource records which step of intent resolution decided a turn.
+type chatIntentSource string
+
+const (
+	chatIntentSourceForcedOverride        chatIntentSource = "forced_override"
+	chatIntentSourcePersistedFirstMessage chatIntentSource = "persisted_first_message"
+	chatIntentSourceAIRouter              chatIntentSource = "ai_router"
+	chatIntentSourceHeuristic             chatIntentSource = "heuristic"
+	chatIntentSourceCaregiverSelfTalk     chatIntentSource = "caregiver_self_talk_guard"
+)
+
 type chatHTTPError struct {
 	Status int
 	Detail string
@@ -91,16 +118,26 @@ type chatHTTPError struct {
 }
 
 const (
-	chatConversationTurnLimit             = 20
+	chatConversationTurnLimit             = 20 // free tier; see limitsForPlan
 	chatMemorySummaryCharMax              = 3200
 	chatMemoryLineCharMax                 = 180
-	smalltalkReplyRuneMax                 = 90
+	chatMemoryCompressTriggerChars        = chatMemorySummaryCharMax * 85 / 100
+	chatMemoryCompressTargetChars         = chatMemorySummaryCharMax / 2
+	smalltalkReplyRuneMax                 = 90 // default; see resolveSmalltalkReplyMaxChars
+	smalltalkReplyRuneMinSetting          = 40
+	smalltalkReplyRuneMaxSetting          = 400
+	chatSessionTitleRuneMax               = 60
+	chatMessagePageDefault                = 50
+	chatMessagePageMax                    = 200
 	chatRawWindowDuration                 = 72 * time.Hour
+	chatRequestedRangeMaxDays             = 90
 	chatCoreModel                         = "gpt-5-mini"
 	chatDailyModel                        = "gpt-5-nano"
 	chatContextModeLast3DRaw              = "last_3d_raw"
 	chatContextModeRequestedDateRaw       = "requested_date_raw"
+	chatContextModeRequestedRangeRaw      = "requested_range_raw"
 	chatContextModeRequestedDateSummary   = "requested_date_summary"
+	chatContextModeRequestedDateFuture    = "requested_date_future"
 	chatContextModeWeeklySummary          = "weekly_summary"
 	chatContextModeMonthlyMedicalSummary  = "monthly_medical_summary"
 	chatContextModeMonthlyParentingRollup = "monthly_parenting_rollup"
@@ -117,6 +154,40 @@ func chatModelForIntent(intent aiIntent) string {
 	return chatCoreModel
 }
 
+// defaultIntentMaxOutputTokens keeps smalltalk replies (capped at
+// smalltalkReplyRuneMaxSetting runes anyway) cheap and gives data queries room for
+// tables. Intents not listed use AI_MAX_OUTPUT_TOKENS.
+var defaultIntentMaxOutputTokens = map[aiIntent]int{
+	aiIntentSmalltalk: 400,
+	aiIntentDataQuery: 1600,
+}
+
+func parseIntentMaxOutputTokens(entries []string) map[aiIntent]int {
+	caps := make(map[aiIntent]int, len(entries))
+	for _, entry := range entries {
+		rawIntent, rawTokens, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+		intent := normalizeAIIntentLabel(rawIntent)
+		tokens, err := strconv.Atoi(strings.TrimSpace(rawTokens))
+		if intent == "" || err != nil || tokens <= 0 {
+			continue
+		}
+		caps[intent] = tokens
+	}
+	return caps
+}
+
+// maxOutputTokensForIntent returns the answer cap for an intent, or 0 to
+// leave the client's configured default in place.
+func (a *App) maxOutputTokensForIntent(intent aiIntent) int {
+	if tokens, ok := parseIntentMaxOutputTokens(a.cfg.AIIntentMaxOutputTokens)[intent]; ok {
+		return tokens
+	}
+	return defaultIntentMaxOutputTokens[intent]
+}
+
 func (a *App) createChatSession(c *gin.Context) {
 	user, ok := authUserFromContext(c)
 	if !ok {
@@ -213,6 +284,11 @@ func (a *App) listChatSessions(c *gin.Context) {
 	}
 
 	childID := strings.TrimSpace(c.Query("child_id"))
+	includeArchived, err := parseQueryFlag(c, "include_archived")
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
 	limit := 50
 	if rawLimit := strings.TrimSpace(c.Query("limit")); rawLimit != "" {
 		if parsed, err := strconv.Atoi(rawLimit); err == nil && parsed > 0 {
@@ -235,10 +311,9 @@ func (a *App) listChatSessions(c *gin.Context) {
 		childFilter = baby.ID
 	}
 
-	rows, err := a.db.Query(
-		c.Request.Context(),
-		`SELECT
+	listQuery := `SELECT
 			s.id,
+			s."title",
 			s."childId",
 			s.status::text,
 			s."startedAt",
@@ -273,16 +348,20 @@ func (a *App) listChatSessions(c *gin.Context) {
 				SELECT COUNT(*)::int
 				FROM "ChatMessage" m
 				WHERE m."sessionId" = s.id
-			) AS message_count
+			) AS message_count,
+			s."archived"
 		 FROM "ChatSession" s
 		 WHERE s."userId" = $1
 		   AND ($2::text IS NULL OR s."childId" = $2)
+		   AND ($4::boolean OR NOT s."archived")
 		 ORDER BY last_message_at DESC
-		 LIMIT $3`,
-		user.ID,
-		childFilter,
-		limit,
-	)
+		 LIMIT $3`
+	rows, err := a.db.Query(c.Request.Context(), listQuery, user.ID, childFilter, limit, includeArchived)
+	if err != nil && isMissingChatMemoryColumnErr(err) {
+		if ensureErr := a.ensureChatSessionMemoryColumns(c.Request.Context()); ensureErr == nil {
+			rows, err = a.db.Query(c.Request.Context(), listQuery, user.ID, childFilter, limit, includeArchived)
+		}
+	}
 	if err != nil {
 		writeError(c, http.StatusInternalServerError, "Failed to load chat sessions")
 		return
@@ -294,6 +373,7 @@ func (a *App) listChatSessions(c *gin.Context) {
 		record := chatSessionListItem{}
 		if err := rows.Scan(
 			&record.SessionID,
+			&record.Title,
 			&record.ChildID,
 			&record.Status,
 			&record.StartedAt,
@@ -303,11 +383,12 @@ func (a *App) listChatSessions(c *gin.Context) {
 			&record.LastPreview,
 			&record.LastMessageAt,
 			&record.MessageCount,
+			&record.Archived,
 		); err != nil {
 			writeError(c, http.StatusInternalServerError, "Failed to parse chat sessions")
 			return
 		}
-		title := deriveSessionTitle(record.FirstUserInput)
+		title := sessionTitle(record.Title, record.FirstUserInput)
 		preview := normalizeSessionPreview(record.LastPreview)
 		items = append(items, gin.H{
 			"session_id":      record.SessionID,
@@ -320,6 +401,7 @@ func (a *App) listChatSessions(c *gin.Context) {
 			"ended_at":        record.EndedAt,
 			"child_id":        record.ChildID,
 			"message_count":   record.MessageCount,
+			"archived":        record.Archived,
 		})
 	}
 
@@ -328,18 +410,819 @@ func (a *App) listChatSessions(c *gin.Context) {
 	})
 }
 
-func (a *App) createChatMessage(c *gin.Context) {
+func (a *App) createChatMessage(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var payload chatMessageCreateRequest
+	if !mustJSON(c, &payload) {
+		return
+	}
+
+	sessionID := strings.TrimSpace(c.Param("session_id"))
+	if sessionID == "" {
+		writeError(c, http.StatusBadRequest, "session_id is required")
+		return
+	}
+	session, err := a.loadChatSessionForUser(c.Request.Context(), user.ID, sessionID)
+	if err != nil {
+		a.writeChatExecutionError(c, err)
+		return
+	}
+
+	role := strings.ToLower(strings.TrimSpace(payload.Role))
+	if role != "user" && role != "assistant" && role != "system" {
+		writeError(c, http.StatusBadRequest, "role must be one of: user, assistant, system")
+		return
+	}
+	content := strings.TrimSpace(payload.Content)
+	if content == "" {
+		writeError(c, http.StatusBadRequest, "content is required")
+		return
+	}
+
+	var childID *string
+	if strings.TrimSpace(payload.ChildID) != "" {
+		baby, statusCode, babyErr := a.getBabyWithAccess(c.Request.Context(), user.ID, strings.TrimSpace(payload.ChildID), readRoles)
+		if babyErr != nil {
+			writeError(c, statusCode, babyErr.Error())
+			return
+		}
+		if baby.HouseholdID != session.HouseholdID {
+			writeError(c, http.StatusBadRequest, "child_id does not belong to this chat session household")
+			return
+		}
+		childID = &baby.ID
+	} else {
+		childID = session.ChildID
+	}
+
+	messageID, createdAt, err := a.insertChatMessage(
+		c.Request.Context(),
+		session.ID,
+		user.ID,
+		session.HouseholdID,
+		childID,
+		role,
+		content,
+		strings.TrimSpace(payload.Intent),
+		payload.Context,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to create chat message")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message_id":   messageID,
+		"session_id":   session.ID,
+		"created_at":   createdAt.UTC(),
+		"role":         role,
+		"household_id": session.HouseholdID,
+	})
+}
+
+func (a *App) getChatMessages(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	sessionID := strings.TrimSpace(c.Param("session_id"))
+	if sessionID == "" {
+		writeError(c, http.StatusBadRequest, "session_id is required")
+		return
+	}
+	session, err := a.loadChatSessionForUser(c.Request.Context(), user.ID, sessionID)
+	if err != nil {
+		a.writeChatExecutionError(c, err)
+		return
+	}
+
+	// Without before or limit the whole session is returned, as older clients
+	// expect. Either one switches to pages of the newest messages older than
+	// the cursor, still listed oldest first.
+	beforeID := strings.TrimSpace(c.Query("before"))
+	rawLimit := strings.TrimSpace(c.Query("limit"))
+	paginated := beforeID != "" || rawLimit != ""
+	limit := chatMessagePageDefault
+	if rawLimit != "" {
+		if parsed, err := strconv.Atoi(rawLimit); err == nil && parsed > 0 {
+			if parsed > chatMessagePageMax {
+				parsed = chatMessagePageMax
+			}
+			limit = parsed
+		}
+	}
+
+	var rows pgx.Rows
+	if !paginated {
+		rows, err = a.db.Query(
+			c.Request.Context(),
+			`SELECT id, role, content, intent, "contextJson", "createdAt"
+			 FROM "ChatMessage"
+			 WHERE "sessionId" = $1
+			 ORDER BY "createdAt" ASC`,
+			session.ID,
+		)
+	} else {
+		var cursorCreatedAt *time.Time
+		if beforeID != "" {
+			var createdAt time.Time
+			cursorErr := a.db.QueryRow(
+				c.Request.Context(),
+				`SELECT "createdAt" FROM "ChatMessage" WHERE id = $1 AND "sessionId" = $2`,
+				beforeID,
+				session.ID,
+			).Scan(&createdAt)
+			if errors.Is(cursorErr, pgx.ErrNoRows) {
+				writeError(c, http.StatusBadRequest, "before does not belong to this chat session")
+				return
+			}
+			if cursorErr != nil {
+				writeError(c, http.StatusInternalServerError, "Failed to load chat messages")
+				return
+			}
+			cursorCreatedAt = &createdAt
+		}
+		rows, err = a.db.Query(
+			c.Request.Context(),
+			`SELECT id, role, content, intent, "contextJson", "createdAt"
+			 FROM "ChatMessage"
+			 WHERE "sessionId" = $1
+			   AND ($2::timestamp IS NULL OR ("createdAt", id) < ($2::timestamp, $3::text))
+			 ORDER BY "createdAt" DESC, id DESC
+			 LIMIT $4`,
+			session.ID,
+			cursorCreatedAt,
+			beforeID,
+			limit+1,
+		)
+	}
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load chat messages")
+		return
+	}
+	defer rows.Close()
+
+	items := make([]gin.H, 0)
+	for rows.Next() {
+		var messageID, role, content string
+		var intent *string
+		var contextRaw []byte
+		var createdAt time.Time
+		if err := rows.Scan(&messageID, &role, &content, &intent, &contextRaw, &createdAt); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to parse chat messages")
+			return
+		}
+		item := gin.H{
+			"message_id": messageID,
+			"role":       strings.ToLower(strings.TrimSpace(role)),
+			"content":    content,
+			"created_at": createdAt.UTC(),
+		}
+		if intent != nil && strings.TrimSpace(*intent) != "" {
+			item["intent"] = strings.TrimSpace(*intent)
+		}
+		if len(contextRaw) > 0 {
+			contextMap := parseJSONStringMap(contextRaw)
+			item["context_json"] = contextMap
+			if item["role"] == "assistant" {
+				// Lifted out of context_json so clients can show them under
+				// each answer without knowing the context layout.
+				for _, key := range []string{"usage", "model", "credit"} {
+					if value, ok := contextMap[key]; ok {
+						item[key] = value
+					}
+				}
+			}
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse chat messages")
+		return
+	}
+
+	var nextCursor *string
+	if paginated {
+		if len(items) > limit {
+			items = items[:limit]
+			oldestID, _ := items[limit-1]["message_id"].(string)
+			nextCursor = &oldestID
+		}
+		for left, right := 0, len(items)-1; left < right; left, right = left+1, right-1 {
+			items[left], items[right] = items[right], items[left]
+		}
+	}
+
+	// The title comes from the session's first user message, which may not be
+	// on this page.
+	_, firstContent, _, err := a.loadFirstUserMessageIntent(c.Request.Context(), session.ID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load chat messages")
+		return
+	}
+	var firstUserInput *string
+	if firstContent != "" {
+		firstUserInput = &firstContent
+	}
+
+	response := gin.H{
+		"session_id":   session.ID,
+		"title":        sessionTitle(session.Title, firstUserInput),
+		"status":       strings.ToLower(strings.TrimSpace(session.Status)),
+		"started_at":   session.StartedAt.UTC(),
+		"ended_at":     session.EndedAt,
+		"household_id": session.HouseholdID,
+		"child_id":     session.ChildID,
+		"messages":     items,
+	}
+	if paginated {
+		response["next_cursor"] = nextCursor
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// deleteChatSession removes a chat session and its messages for good. The row
+// is gone rather than closed, so getOrCreateCompatChatSession starts a fresh
+// session on the next aiQuery and createChatSession has nothing to rotate.
+func (a *App) deleteChatSession(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	sessionID := strings.TrimSpace(c.Param("session_id"))
+	if sessionID == "" {
+		writeError(c, http.StatusBadRequest, "session_id is required")
+		return
+	}
+	session, err := a.loadChatSessionForUser(c.Request.Context(), user.ID, sessionID)
+	if err != nil {
+		a.writeChatExecutionError(c, err)
+		return
+	}
+
+	tx, err := a.db.Begin(c.Request.Context())
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback(c.Request.Context())
+
+	deletedMessages, err := tx.Exec(
+		c.Request.Context(),
+		`DELETE FROM "ChatMessage" WHERE "sessionId" = $1`,
+		session.ID,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to delete chat messages")
+		return
+	}
+	deletedSession, err := tx.Exec(
+		c.Request.Context(),
+		`DELETE FROM "ChatSession" WHERE id = $1 AND "userId" = $2`,
+		session.ID,
+		user.ID,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to delete chat session")
+		return
+	}
+	if deletedSession.RowsAffected() == 0 {
+		writeError(c, http.StatusNotFound, "Chat session not found")
+		return
+	}
+
+	if err := tx.Commit(c.Request.Context()); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"session_id":            session.ID,
+		"status":                "deleted",
+		"deleted_message_count": deletedMessages.RowsAffected(),
+	})
+}
+
+// updateChatSession sets a session's explicit title and its answer
+// preferences. Omitted fields are left as they are; an empty tone or language
+// clears the preference.
+func (a *App) updateChatSession(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var payload chatSessionUpdateRequest
+	if !mustJSON(c, &payload) {
+		return
+	}
+	if payload.Title == nil && payload.Tone == nil && payload.Language == nil {
+		writeError(c, http.StatusBadRequest, "title, tone or language is required")
+		return
+	}
+
+	sessionID := strings.TrimSpace(c.Param("session_id"))
+	if sessionID == "" {
+		writeError(c, http.StatusBadRequest, "session_id is required")
+		return
+	}
+	session, err := a.loadChatSessionForUser(c.Request.Context(), user.ID, sessionID)
+	if err != nil {
+		a.writeChatExecutionError(c, err)
+		return
+	}
+
+	title := session.Title
+	if payload.Title != nil {
+		normalized := strings.Join(strings.Fields(*payload.Title), " ")
+		if normalized == "" {
+			writeError(c, http.StatusBadRequest, "title must not be empty")
+			return
+		}
+		if runes := []rune(normalized); len(runes) > chatSessionTitleRuneMax {
+			normalized = strings.TrimSpace(string(runes[:chatSessionTitleRuneMax]))
+		}
+		title = &normalized
+	}
+	preferredTone := session.PreferredTone
+	if payload.Tone != nil {
+		preferredTone = nil
+		if strings.TrimSpace(*payload.Tone) != "" {
+			normalized := normalizeTone(*payload.Tone)
+			preferredTone = &normalized
+		}
+	}
+	preferredLanguage := session.PreferredLanguage
+	if payload.Language != nil {
+		preferredLanguage = nil
+		if strings.TrimSpace(*payload.Language) != "" {
+			normalized := normalizePreferredLanguage(*payload.Language)
+			if normalized == "" {
+				writeError(c, http.StatusBadRequest, "language must be one of: ko, "+strings.Join(supportedTranslateTargets(), ", "))
+				return
+			}
+			preferredLanguage = &normalized
+		}
+	}
+
+	if err := a.execChatMemoryUpdateWithRetry(
+		c.Request.Context(),
+		`UPDATE "ChatSession"
+		 SET "title" = $2,
+		     "preferredTone" = $3,
+		     "preferredLanguage" = $4,
+		     "updatedAt" = NOW()
+		 WHERE id = $1`,
+		session.ID,
+		title,
+		preferredTone,
+		preferredLanguage,
+	); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to update chat session")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"session_id": session.ID,
+		"title":      title,
+		"tone":       preferredTone,
+		"language":   preferredLanguage,
+	})
+}
+
+// archiveChatSession hides a session from listChatSessions without deleting
+// its history. An ACTIVE session is closed as well.
+func (a *App) archiveChatSession(c *gin.Context) {
+	a.setChatSessionArchived(c, true)
+}
+
+func (a *App) unarchiveChatSession(c *gin.Context) {
+	a.setChatSessionArchived(c, false)
+}
+
+func (a *App) setChatSessionArchived(c *gin.Context, archived bool) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	sessionID := strings.TrimSpace(c.Param("session_id"))
+	if sessionID == "" {
+		writeError(c, http.StatusBadRequest, "session_id is required")
+		return
+	}
+	session, err := a.loadChatSessionForUser(c.Request.Context(), user.ID, sessionID)
+	if err != nil {
+		a.writeChatExecutionError(c, err)
+		return
+	}
+
+	query := `UPDATE "ChatSession"
+		 SET "archived" = $2,
+		     status = CASE WHEN $2 THEN 'CLOSED' ELSE status END,
+		     "endedAt" = CASE WHEN $2 THEN COALESCE("endedAt", NOW()) ELSE "endedAt" END,
+		     "updatedAt" = NOW()
+		 WHERE id = $1
+		 RETURNING status::text, "endedAt"`
+	var status string
+	var endedAt *time.Time
+	err = a.db.QueryRow(c.Request.Context(), query, session.ID, archived).Scan(&status, &endedAt)
+	if err != nil && isMissingChatMemoryColumnErr(err) {
+		if ensureErr := a.ensureChatSessionMemoryColumns(c.Request.Context()); ensureErr == nil {
+			err = a.db.QueryRow(c.Request.Context(), query, session.ID, archived).Scan(&status, &endedAt)
+		}
+	}
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to update chat session")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"session_id": session.ID,
+		"archived":   archived,
+		"status":     strings.ToLower(status),
+		"ended_at":   endedAt,
+	})
+}
+
+func (a *App) forkChatSession(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var payload chatSessionForkRequest
+	if c.Request.ContentLength != 0 {
+		if !mustJSON(c, &payload) {
+			return
+		}
+	}
+
+	sessionID := strings.TrimSpace(c.Param("session_id"))
+	if sessionID == "" {
+		writeError(c, http.StatusBadRequest, "session_id is required")
+		return
+	}
+	source, err := a.loadChatSessionForUser(c.Request.Context(), user.ID, sessionID)
+	if err != nil {
+		a.writeChatExecutionError(c, err)
+		return
+	}
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT id, "childId", role, content, intent, "contextJson", "createdAt"
+		 FROM "ChatMessage"
+		 WHERE "sessionId" = $1
+		 ORDER BY "createdAt" ASC, id ASC`,
+		source.ID,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load chat messages")
+		return
+	}
+
+	type forkedMessage struct {
+		ID         string
+		ChildID    *string
+		Role       string
+		Content    string
+		Intent     *string
+		ContextRaw []byte
+		CreatedAt  time.Time
+	}
+	upToMessageID := strings.TrimSpace(payload.UpToMessageID)
+	messages := make([]forkedMessage, 0)
+	cutoffFound := upToMessageID == ""
+	for rows.Next() {
+		message := forkedMessage{}
+		if err := rows.Scan(
+			&message.ID,
+			&message.ChildID,
+			&message.Role,
+			&message.Content,
+			&message.Intent,
+			&message.ContextRaw,
+			&message.CreatedAt,
+		); err != nil {
+			rows.Close()
+			writeError(c, http.StatusInternalServerError, "Failed to parse chat messages")
+			return
+		}
+		if upToMessageID != "" && cutoffFound {
+			continue
+		}
+		messages = append(messages, message)
+		if message.ID == upToMessageID {
+			cutoffFound = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		writeError(c, http.StatusInternalServerError, "Failed to parse chat messages")
+		return
+	}
+	rows.Close()
+	if !cutoffFound {
+		writeError(c, http.StatusBadRequest, "up_to_message_id does not belong to this chat session")
+		return
+	}
+
+	tx, err := a.db.Begin(c.Request.Context())
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback(c.Request.Context())
+
+	if _, err := tx.Exec(
+		c.Request.Context(),
+		`UPDATE "ChatSession"
+		 SET status = 'CLOSED',
+		     "endedAt" = COALESCE("endedAt", NOW()),
+		     "updatedAt" = NOW()
+		 WHERE "userId" = $1
+		   AND "householdId" = $2
+		   AND COALESCE("childId", '') = COALESCE($3::text, '')
+		   AND status = 'ACTIVE'`,
+		user.ID,
+		source.HouseholdID,
+		source.ChildID,
+	); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to rotate previous chat session")
+		return
+	}
+
+	forkID := uuid.NewString()
+	var startedAt time.Time
+	if err := tx.QueryRow(
+		c.Request.Context(),
+		`INSERT INTO "ChatSession" (
+			id, "userId", "householdId", "childId", status, "startedAt", "updatedAt"
+		) VALUES ($1, $2, $3, $4, 'ACTIVE', NOW(), NOW())
+		RETURNING "startedAt"`,
+		forkID,
+		user.ID,
+		source.HouseholdID,
+		source.ChildID,
+	).Scan(&startedAt); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to create chat session")
+		return
+	}
+
+	var firstUserInput *string
+	for _, message := range messages {
+		if firstUserInput == nil && strings.EqualFold(strings.TrimSpace(message.Role), "user") {
+			candidate := strings.TrimSpace(message.Content)
+			if candidate != "" {
+				firstUserInput = &candidate
+			}
+		}
+		var contextValue any
+		if len(message.ContextRaw) > 0 {
+			contextValue = string(message.ContextRaw)
+		}
+		if _, err := tx.Exec(
+			c.Request.Context(),
+			`INSERT INTO "ChatMessage" (
+				id, "sessionId", "userId", "householdId", "childId", role, content, intent, "contextJson", "createdAt"
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+			uuid.NewString(),
+			forkID,
+			user.ID,
+			source.HouseholdID,
+			message.ChildID,
+			message.Role,
+			message.Content,
+			message.Intent,
+			contextValue,
+			message.CreatedAt,
+		); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to copy chat messages")
+			return
+		}
+	}
+
+	if err := tx.Commit(c.Request.Context()); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	// The fork starts with an empty memory; rebuild it from the copied turns so
+	// the first query on the fork sees the same summary the original would have.
+	fork, err := a.loadChatSessionForUser(c.Request.Context(), user.ID, forkID)
+	if err != nil {
+		a.writeChatExecutionError(c, err)
+		return
+	}
+	limits, err := a.planLimitsForHousehold(c.Request.Context(), fork.HouseholdID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load subscription")
+		return
+	}
+	_, _, memorySummarizedCount, err := a.prepareSessionMemory(c.Request.Context(), fork, limits.ConversationTurnLimit)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to rebuild chat session memory")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"session_id":                      fork.ID,
+		"forked_from_session_id":          source.ID,
+		"up_to_message_id":                nullableString(upToMessageID),
+		"title":                           deriveSessionTitle(firstUserInput),
+		"status":                          strings.ToLower(strings.TrimSpace(fork.Status)),
+		"started_at":                      startedAt.UTC(),
+		"child_id":                        fork.ChildID,
+		"household_id":                    fork.HouseholdID,
+		"message_count":                   len(messages),
+		"session_memory_summarized_count": memorySummarizedCount,
+	})
+}
+
+// reclassifyChatSession rewrites the persisted first-user intent that every
+// later turn inherits. An explicit intent wins; otherwise the router is run
+// again on the first user message.
+func (a *App) reclassifyChatSession(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var payload chatSessionReclassifyRequest
+	if c.Request.ContentLength != 0 {
+		if !mustJSON(c, &payload) {
+			return
+		}
+	}
+	explicitIntent := aiIntent("")
+	if raw := strings.TrimSpace(payload.Intent); raw != "" {
+		explicitIntent = normalizeAIIntentLabel(raw)
+		if explicitIntent == "" {
+			writeError(c, http.StatusBadRequest, "intent must be one of: smalltalk, data_query, medical_related, care_routine")
+			return
+		}
+	}
+
+	sessionID := strings.TrimSpace(c.Param("session_id"))
+	if sessionID == "" {
+		writeError(c, http.StatusBadRequest, "session_id is required")
+		return
+	}
+	session, err := a.loadChatSessionForUser(c.Request.Context(), user.ID, sessionID)
+	if err != nil {
+		a.writeChatExecutionError(c, err)
+		return
+	}
+	messageID, firstMessage, previousIntent, err := a.loadFirstUserMessageIntent(c.Request.Context(), session.ID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load chat messages")
+		return
+	}
+	if messageID == "" {
+		writeError(c, http.StatusBadRequest, "Session has no user message to classify")
+		return
+	}
+
+	intent := explicitIntent
+	source := chatIntentSourceForcedOverride
+	if intent == "" && isLikelyCaregiverSelfTalk(firstMessage) {
+		intent, source = aiIntentSmalltalk, chatIntentSourceCaregiverSelfTalk
+	}
+	if intent == "" {
+		routed, routeErr := a.resolveAIIntentByFirstMessage(c.Request.Context(), firstMessage, firstMessage)
+		if routeErr == nil && routed != "" {
+			intent, source = routed, chatIntentSourceAIRouter
+		} else {
+			intent, source = classifyAIIntent(firstMessage), chatIntentSourceHeuristic
+		}
+	}
+	if err := a.saveFirstUserIntent(c.Request.Context(), messageID, intent); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to save session intent")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"session_id":      session.ID,
+		"message_id":      messageID,
+		"previous_intent": nullableString(string(previousIntent)),
+		"intent":          string(intent),
+		"intent_source":   string(source),
+	})
+}
+
+// classifyChatQuestion previews how a question would be routed, following
+// resolveSessionIntentFromFirstUserMessage without saving the intent,
+// inserting messages or charging credits.
+func (a *App) classifyChatQuestion(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var payload chatClassifyRequest
+	if !mustJSON(c, &payload) {
+		return
+	}
+	question := strings.TrimSpace(payload.Question)
+	if question == "" {
+		writeError(c, http.StatusBadRequest, "question is required")
+		return
+	}
+
+	var turns []ChatTurn
+	firstMessage := ""
+	fixedIntent := aiIntent("")
+	if sessionID := strings.TrimSpace(payload.SessionID); sessionID != "" {
+		session, err := a.loadChatSessionForUser(c.Request.Context(), user.ID, sessionID)
+		if err != nil {
+			a.writeChatExecutionError(c, err)
+			return
+		}
+		turns, err = a.loadSessionTurns(c.Request.Context(), session.ID, chatConversationTurnLimit)
+		if err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to load chat messages")
+			return
+		}
+		_, firstMessage, fixedIntent, err = a.loadFirstUserMessageIntent(c.Request.Context(), session.ID)
+		if err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to load chat messages")
+			return
+		}
+	}
+
+	heuristicIntent := resolveAIIntentWithSession(question, turns)
+	if firstMessage == "" {
+		firstMessage = firstUserMessageFromTurns(turns)
+	}
+	if firstMessage == "" {
+		firstMessage = question
+	}
+	selfTalk := isLikelyCaregiverSelfTalk(firstMessage)
+
+	intent := heuristicIntent
+	source := chatIntentSourceHeuristic
+	var confidence *float64
+	switch {
+	case fixedIntent != "":
+		intent, source = fixedIntent, chatIntentSourcePersistedFirstMessage
+	case selfTalk:
+		intent, source = aiIntentSmalltalk, chatIntentSourceCaregiverSelfTalk
+	default:
+		routed, routedConfidence, err := a.routeAIIntent(c.Request.Context(), firstMessage, question)
+		if err == nil && routed != "" {
+			intent, source, confidence = routed, chatIntentSourceAIRouter, routedConfidence
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"intent":              string(intent),
+		"intent_source":       string(source),
+		"confidence":          confidence,
+		"heuristic_intent":    string(heuristicIntent),
+		"caregiver_self_talk": selfTalk,
+		"session_id":          nullableString(payload.SessionID),
+	})
+}
+
+type chatStoredMessage struct {
+	ID          string
+	UserID      string
+	HouseholdID string
+	ChildID     *string
+	Role        string
+	Content     string
+	Intent      *string
+	ContextRaw  []byte
+	CreatedAt   time.Time
+}
+
+// regenerateChatAnswer replaces the session's last assistant answer with a
+// fresh one. The last question and answer are removed first so the new turn
+// sees the same history the original did; runChatQuery then stores them again
+// and bills the turn as usual. If the new turn fails the removed pair is put
+// back.
+func (a *App) regenerateChatAnswer(c *gin.Context) {
 	user, ok := authUserFromContext(c)
 	if !ok {
 		writeError(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
-	var payload chatMessageCreateRequest
-	if !mustJSON(c, &payload) {
-		return
-	}
-
 	sessionID := strings.TrimSpace(c.Param("session_id"))
 	if sessionID == "" {
 		writeError(c, http.StatusBadRequest, "session_id is required")
@@ -351,165 +1234,273 @@ func (a *App) createChatMessage(c *gin.Context) {
 		return
 	}
 
-	role := strings.ToLower(strings.TrimSpace(payload.Role))
-	if role != "user" && role != "assistant" && role != "system" {
-		writeError(c, http.StatusBadRequest, "role must be one of: user, assistant, system")
-		return
-	}
-	content := strings.TrimSpace(payload.Content)
-	if content == "" {
-		writeError(c, http.StatusBadRequest, "content is required")
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT id, "userId", "householdId", "childId", role, content, intent, "contextJson", "createdAt"
+		 FROM "ChatMessage"
+		 WHERE "sessionId" = $1
+		 ORDER BY "createdAt" DESC, id DESC
+		 LIMIT 2`,
+		session.ID,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load chat messages")
 		return
 	}
-
-	var childID *string
-	if strings.TrimSpace(payload.ChildID) != "" {
-		baby, statusCode, babyErr := a.getBabyWithAccess(c.Request.Context(), user.ID, strings.TrimSpace(payload.ChildID), readRoles)
-		if babyErr != nil {
-			writeError(c, statusCode, babyErr.Error())
-			return
-		}
-		if baby.HouseholdID != session.HouseholdID {
-			writeError(c, http.StatusBadRequest, "child_id does not belong to this chat session household")
+	latest := make([]chatStoredMessage, 0, 2)
+	for rows.Next() {
+		var item chatStoredMessage
+		if err := rows.Scan(
+			&item.ID,
+			&item.UserID,
+			&item.HouseholdID,
+			&item.ChildID,
+			&item.Role,
+			&item.Content,
+			&item.Intent,
+			&item.ContextRaw,
+			&item.CreatedAt,
+		); err != nil {
+			rows.Close()
+			writeError(c, http.StatusInternalServerError, "Failed to parse chat messages")
 			return
 		}
-		childID = &baby.ID
-	} else {
-		childID = session.ChildID
+		latest = append(latest, item)
 	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse chat messages")
+		return
+	}
+	if len(latest) == 0 || !strings.EqualFold(strings.TrimSpace(latest[0].Role), "assistant") {
+		writeError(c, http.StatusConflict, "Last message is not an assistant answer")
+		return
+	}
+	if len(latest) < 2 || !strings.EqualFold(strings.TrimSpace(latest[1].Role), "user") {
+		writeError(c, http.StatusConflict, "No user question found before the last answer")
+		return
+	}
+	answer, question := latest[0], latest[1]
 
-	messageID, createdAt, err := a.insertChatMessage(
+	tx, err := a.db.Begin(c.Request.Context())
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback(c.Request.Context())
+	deleted, err := tx.Exec(
 		c.Request.Context(),
+		`DELETE FROM "ChatMessage" WHERE "sessionId" = $1 AND id = ANY($2)`,
 		session.ID,
-		user.ID,
-		session.HouseholdID,
-		childID,
-		role,
-		content,
-		strings.TrimSpace(payload.Intent),
-		payload.Context,
+		[]string{answer.ID, question.ID},
 	)
 	if err != nil {
-		writeError(c, http.StatusInternalServerError, "Failed to create chat message")
+		writeError(c, http.StatusInternalServerError, "Failed to delete previous answer")
+		return
+	}
+	if deleted.RowsAffected() != 2 {
+		writeError(c, http.StatusConflict, "Session changed while regenerating; try again")
+		return
+	}
+	if err := tx.Commit(c.Request.Context()); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to commit transaction")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message_id":   messageID,
-		"session_id":   session.ID,
-		"created_at":   createdAt.UTC(),
-		"role":         role,
-		"household_id": session.HouseholdID,
-	})
+	questionContext := parseJSONStringMap(question.ContextRaw)
+	payload := chatQueryRequest{
+		SessionID: session.ID,
+		Query:     question.Content,
+	}
+	if question.ChildID != nil {
+		payload.ChildID = *question.ChildID
+	}
+	if tone, ok := questionContext["tone"].(string); ok {
+		payload.Tone = tone
+	}
+	if usePersonalData, ok := questionContext["use_personal_data"].(bool); ok {
+		payload.UsePersonalData = usePersonalData
+	}
+	if answer.Intent != nil {
+		payload.Intent = strings.TrimSpace(*answer.Intent)
+	}
+
+	result, err := a.runChatQuery(c.Request.Context(), user, payload, "", nil)
+	if err != nil {
+		cleanupCtx := context.WithoutCancel(c.Request.Context())
+		for _, message := range []chatStoredMessage{question, answer} {
+			if _, restoreErr := a.db.Exec(
+				cleanupCtx,
+				`INSERT INTO "ChatMessage" (
+					id, "sessionId", "userId", "householdId", "childId", role, content, intent, "contextJson", "createdAt"
+				) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+				message.ID,
+				session.ID,
+				message.UserID,
+				message.HouseholdID,
+				message.ChildID,
+				message.Role,
+				message.Content,
+				message.Intent,
+				message.ContextRaw,
+				message.CreatedAt,
+			); restoreErr != nil {
+				log.Printf("chat regenerate restore failed session_id=%s message_id=%s err=%v", session.ID, message.ID, restoreErr)
+			}
+		}
+		a.writeChatExecutionError(c, err)
+		return
+	}
+
+	body := a.chatQueryResponseBody(result)
+	body["replaced_message_id"] = answer.ID
+	c.JSON(http.StatusOK, body)
 }
 
-func (a *App) getChatMessages(c *gin.Context) {
+func (a *App) chatQuery(c *gin.Context) {
 	user, ok := authUserFromContext(c)
 	if !ok {
 		writeError(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
-	sessionID := strings.TrimSpace(c.Param("session_id"))
-	if sessionID == "" {
-		writeError(c, http.StatusBadRequest, "session_id is required")
+	var payload chatQueryRequest
+	if !mustJSON(c, &payload) {
 		return
 	}
-	session, err := a.loadChatSessionForUser(c.Request.Context(), user.ID, sessionID)
+
+	result, err := a.runChatQuery(c.Request.Context(), user, payload, "", nil)
 	if err != nil {
 		a.writeChatExecutionError(c, err)
 		return
 	}
 
-	rows, err := a.db.Query(
-		c.Request.Context(),
-		`SELECT id, role, content, intent, "contextJson", "createdAt"
-		 FROM "ChatMessage"
-		 WHERE "sessionId" = $1
-		 ORDER BY "createdAt" ASC`,
-		session.ID,
-	)
-	if err != nil {
-		writeError(c, http.StatusInternalServerError, "Failed to load chat messages")
+	c.JSON(http.StatusOK, a.chatQueryResponseBody(result))
+}
+
+func (a *App) chatQueryResponseBody(result chatExecutionResult) gin.H {
+	return gin.H{
+		"session_id":          result.SessionID,
+		"message_id":          result.AssistantMessageID,
+		"answer":              result.Answer,
+		"answer_translated":   result.AnswerTranslated,
+		"translate_to":        nullableString(result.TranslateTo),
+		"facts":               result.Facts,
+		"intent":              string(result.Intent),
+		"intent_source":       string(result.IntentSource),
+		"model":               result.Model,
+		"usage":               usageMap(result.Usage),
+		"credit":              creditMap(result.Credit),
+		"low_balance_warning": a.isLowCreditBalance(result.Credit),
+		"remaining_balance":   result.Credit.BalanceAfter,
+		"context":             result.ContextMeta,
+		"reference_text":      result.ReferenceText,
+	}
+}
+
+// chatQueryStream answers like chatQuery but sends the answer as Server-Sent
+// Events: "delta" frames with each line or sentence while the model writes,
+// cleaned of UTC timestamps and internal terms like the final answer, then one
+// "done" frame with the chatQuery body. Clients should still replace the
+// streamed text with done.answer, which is what is persisted and may be
+// reshaped further. Errors before the first frame use the usual JSON status;
+// later ones arrive as an "error" frame.
+func (a *App) chatQueryStream(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
-	defer rows.Close()
 
-	items := make([]gin.H, 0)
-	var firstUserInput *string
-	for rows.Next() {
-		var messageID, role, content string
-		var intent *string
-		var contextRaw []byte
-		var createdAt time.Time
-		if err := rows.Scan(&messageID, &role, &content, &intent, &contextRaw, &createdAt); err != nil {
-			writeError(c, http.StatusInternalServerError, "Failed to parse chat messages")
+	var payload chatQueryRequest
+	if !mustJSON(c, &payload) {
+		return
+	}
+
+	streaming := false
+	startStream := func() {
+		if streaming {
 			return
 		}
-		item := gin.H{
-			"message_id": messageID,
-			"role":       strings.ToLower(strings.TrimSpace(role)),
-			"content":    content,
-			"created_at": createdAt.UTC(),
-		}
-		if firstUserInput == nil && strings.EqualFold(strings.TrimSpace(role), "user") {
-			candidate := strings.TrimSpace(content)
-			if candidate != "" {
-				firstUserInput = &candidate
-			}
-		}
-		if intent != nil && strings.TrimSpace(*intent) != "" {
-			item["intent"] = strings.TrimSpace(*intent)
+		streaming = true
+		header := c.Writer.Header()
+		header.Set("Content-Type", "text/event-stream")
+		header.Set("Cache-Control", "no-cache")
+		header.Set("Connection", "keep-alive")
+		header.Set("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+	}
+	onDelta := func(text string) {
+		if text == "" || c.Request.Context().Err() != nil {
+			return
 		}
-		if len(contextRaw) > 0 {
-			item["context_json"] = parseJSONStringMap(contextRaw)
+		startStream()
+		c.SSEvent("delta", gin.H{"text": text})
+		c.Writer.Flush()
+	}
+
+	result, err := a.runChatQuery(c.Request.Context(), user, payload, "", onDelta)
+	if err != nil {
+		if !streaming {
+			a.writeChatExecutionError(c, err)
+			return
 		}
-		items = append(items, item)
+		status, body := chatExecutionErrorResponse(err)
+		body["status"] = status
+		c.SSEvent("error", body)
+		c.Writer.Flush()
+		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"session_id":   session.ID,
-		"title":        deriveSessionTitle(firstUserInput),
-		"status":       strings.ToLower(strings.TrimSpace(session.Status)),
-		"started_at":   session.StartedAt.UTC(),
-		"ended_at":     session.EndedAt,
-		"household_id": session.HouseholdID,
-		"child_id":     session.ChildID,
-		"messages":     items,
-	})
+	startStream()
+	c.SSEvent("done", a.chatQueryResponseBody(result))
+	c.Writer.Flush()
 }
 
-func (a *App) chatQuery(c *gin.Context) {
+// getSessionStyleHint shows the smalltalk style hint the next turn would use
+// and the tone signals behind it. It is a debugging aid and returns 404
+// unless chat debug endpoints are enabled.
+func (a *App) getSessionStyleHint(c *gin.Context) {
+	if !a.chatDebugEndpointsEnabled() {
+		writeError(c, http.StatusNotFound, "Not found")
+		return
+	}
 	user, ok := authUserFromContext(c)
 	if !ok {
 		writeError(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
-	var payload chatQueryRequest
-	if !mustJSON(c, &payload) {
+	sessionID := strings.TrimSpace(c.Param("session_id"))
+	if sessionID == "" {
+		writeError(c, http.StatusBadRequest, "session_id is required")
 		return
 	}
-
-	result, err := a.runChatQuery(c.Request.Context(), user, payload, "")
+	session, err := a.loadChatSessionForUser(c.Request.Context(), user.ID, sessionID)
 	if err != nil {
 		a.writeChatExecutionError(c, err)
 		return
 	}
+	turns, err := a.loadSessionTurns(c.Request.Context(), session.ID, chatConversationTurnLimit)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load chat messages")
+		return
+	}
 
+	samples := collectUserToneSamples(turns, "", 8)
+	signals := computeSmalltalkToneSignals(samples)
 	c.JSON(http.StatusOK, gin.H{
-		"session_id":     result.SessionID,
-		"message_id":     result.AssistantMessageID,
-		"answer":         result.Answer,
-		"intent":         string(result.Intent),
-		"model":          result.Model,
-		"usage":          usageMap(result.Usage),
-		"credit":         creditMap(result.Credit),
-		"context":        result.ContextMeta,
-		"reference_text": result.ReferenceText,
+		"session_id": session.ID,
+		"style_hint": smalltalkStyleHintFromSignals(signals),
+		"signals":    signals,
+		"samples":    samples,
 	})
 }
 
+func (a *App) chatDebugEndpointsEnabled() bool {
+	return strings.EqualFold(strings.TrimSpace(a.cfg.AppEnv), "local") || a.cfg.ChatDebugEndpointsEnabled
+}
+
 func (a *App) aiQuery(c *gin.Context) {
 	user, ok := authUserFromContext(c)
 	if !ok {
@@ -571,6 +1562,7 @@ func (a *App) aiQuery(c *gin.Context) {
 			TZOffset:        payload.TZOffset,
 		},
 		baby.ID,
+		nil,
 	)
 	if err != nil {
 		a.writeChatExecutionError(c, err)
@@ -597,11 +1589,16 @@ func (a *App) aiQuery(c *gin.Context) {
 	})
 }
 
+// runChatQuery answers one chat turn. When onDelta is set the answer is
+// streamed to it a line or sentence at a time, cleaned like the final answer,
+// if the AI client supports streaming; the returned Answer is always the
+// sanitized full answer that is persisted.
 func (a *App) runChatQuery(
 	ctx context.Context,
 	user AuthUser,
 	payload chatQueryRequest,
 	fallbackChildID string,
+	onDelta func(string),
 ) (chatExecutionResult, error) {
 	sessionID := strings.TrimSpace(payload.SessionID)
 	if sessionID == "" {
@@ -611,12 +1608,31 @@ func (a *App) runChatQuery(
 	if question == "" {
 		return chatExecutionResult{}, &chatHTTPError{Status: http.StatusBadRequest, Detail: "query is required"}
 	}
-	tone := normalizeTone(payload.Tone)
+	forcedIntent := normalizeAIIntentLabel(payload.Intent)
+	translateTo := ""
+	if raw := strings.TrimSpace(payload.TranslateTo); raw != "" {
+		translateTo = normalizeTranslateTarget(raw)
+		if translateTo == "" {
+			return chatExecutionResult{}, &chatHTTPError{Status: http.StatusBadRequest, Detail: "translate_to must be one of: " + strings.Join(supportedTranslateTargets(), ", ")}
+		}
+	}
+	responseFormat, ok := normalizeChatResponseFormat(payload.ResponseFormat)
+	if !ok {
+		return chatExecutionResult{}, &chatHTTPError{Status: http.StatusBadRequest, Detail: "response_format must be one of: prose, facts"}
+	}
 
 	session, err := a.loadChatSessionForUser(ctx, user.ID, sessionID)
 	if err != nil {
 		return chatExecutionResult{}, err
 	}
+	tone := normalizeTone(payload.Tone)
+	if strings.TrimSpace(payload.Tone) == "" && session.PreferredTone != nil {
+		tone = normalizeTone(*session.PreferredTone)
+	}
+	preferredLanguage := ""
+	if session.PreferredLanguage != nil {
+		preferredLanguage = *session.PreferredLanguage
+	}
 	hasFeature, _, _, err := a.hasSubscriptionFeature(
 		ctx,
 		session.HouseholdID,
@@ -674,12 +1690,38 @@ func (a *App) runChatQuery(
 		childRef = nil
 	}
 
+	var focalEvent *normalizedEvidenceRow
+	if eventID := strings.TrimSpace(payload.EventID); eventID != "" {
+		if !payload.UsePersonalData {
+			return chatExecutionResult{}, &chatHTTPError{Status: http.StatusBadRequest, Detail: "event_id requires use_personal_data"}
+		}
+		row, err := a.loadChatFocalEvent(ctx, user.ID, eventID, childID)
+		if err != nil {
+			return chatExecutionResult{}, err
+		}
+		focalEvent = &row
+	}
+
 	now := time.Now().UTC()
 	scopeOverride := resolveRequestedChatScope(payload.DateMode, payload.AnchorDate, payload.TZOffset, now)
-	preflight, err := a.preflightBilling(ctx, user.ID, session.HouseholdID, now)
+	scopeOverride.RawStart, scopeOverride.RawEnd, err = resolveRequestedRawWindow(payload.From, payload.To, payload.TZOffset, now)
+	if err != nil {
+		return chatExecutionResult{}, &chatHTTPError{Status: http.StatusBadRequest, Detail: err.Error()}
+	}
+	householdModel, err := a.householdAIModel(ctx, session.HouseholdID)
+	if err != nil {
+		return chatExecutionResult{}, err
+	}
+	// Without a household model the intent (and so the model) is not resolved
+	// yet; hold credits at the core model's rates, which covers every intent
+	// except smalltalk.
+	preflight, err := a.preflightBilling(ctx, user.ID, session.HouseholdID, chatModelFor(householdModel, aiIntentDataQuery), now)
 	if err != nil {
 		return chatExecutionResult{}, err
 	}
+	// Reserved credits must be released even when the client has gone away
+	// and canceled ctx, e.g. a stream aborted mid-answer.
+	cleanupCtx := context.WithoutCancel(ctx)
 	if preflight.Mode == "" {
 		balance, berr := a.getWalletBalance(ctx, a.db, user.ID)
 		if berr != nil {
@@ -695,24 +1737,24 @@ func (a *App) runChatQuery(
 			Credit: &creditSnapshot{
 				Balance:    balance,
 				GraceUsed:  graceUsed,
-				GraceLimit: graceLimitPerDay,
+				GraceLimit: preflight.Limits.GraceLimitPerDay,
 			},
 		}
 	}
 
-	turns, sessionMemorySummary, memorySummarizedCount, err := a.prepareSessionMemory(ctx, session)
+	turns, sessionMemorySummary, memorySummarizedCount, err := a.prepareSessionMemory(ctx, session, preflight.Limits.ConversationTurnLimit)
 	if err != nil {
-		_ = a.releaseReservedCredits(ctx, user.ID, preflight.Reserved)
+		_ = a.releaseReservedCredits(cleanupCtx, user.ID, preflight.Reserved)
 		return chatExecutionResult{}, err
 	}
 
 	firstUserMessageID, firstUserMessage, fixedIntent, err := a.loadFirstUserMessageIntent(ctx, session.ID)
 	if err != nil {
-		_ = a.releaseReservedCredits(ctx, user.ID, preflight.Reserved)
+		_ = a.releaseReservedCredits(cleanupCtx, user.ID, preflight.Reserved)
 		return chatExecutionResult{}, err
 	}
 
-	intent := a.resolveSessionIntentFromFirstUserMessage(
+	intent, intentSource := a.resolveSessionIntentFromFirstUserMessage(
 		ctx,
 		session.ID,
 		question,
@@ -720,6 +1762,7 @@ func (a *App) runChatQuery(
 		firstUserMessageID,
 		firstUserMessage,
 		fixedIntent,
+		forcedIntent,
 	)
 	smalltalkStyleHint := ""
 	if intent == aiIntentSmalltalk {
@@ -737,12 +1780,17 @@ func (a *App) runChatQuery(
 		scopeOverride,
 	)
 	if err != nil {
-		_ = a.releaseReservedCredits(ctx, user.ID, preflight.Reserved)
+		_ = a.releaseReservedCredits(cleanupCtx, user.ID, preflight.Reserved)
 		return chatExecutionResult{}, err
 	}
+	if focalEvent != nil {
+		chatContext = applyFocalEventContext(chatContext, *focalEvent)
+	}
 
-	aiResponse, err := a.ai.Query(ctx, AIModelRequest{
-		Model: chatModelForIntent(intent),
+	chatModel := chatModelFor(householdModel, intent)
+	aiRequest := AIModelRequest{
+		Model:           chatModel,
+		MaxOutputTokens: a.maxOutputTokensForIntent(intent),
 		SystemPrompt: buildChatSystemPrompt(
 			intent,
 			tone,
@@ -750,27 +1798,104 @@ func (a *App) runChatQuery(
 			payload.UsePersonalData,
 			sessionMemorySummary,
 			smalltalkStyleHint,
+			preferredLanguage,
 		),
 		Conversation: turns,
 		UserPrompt:   question,
-	})
+	}
+	// Facts are only extracted for data questions; other intents answer in
+	// prose and return facts=null.
+	wantFacts := responseFormat == chatResponseFormatFacts && intent == aiIntentDataQuery
+	if wantFacts {
+		aiRequest.SystemPrompt += "\n" + strings.Join(chatFactsPromptLines(), "\n")
+	}
+	var aiResponse AIModelResponse
+	if onDelta != nil {
+		streamSanitizer := newChatStreamSanitizer(a.answerJargonTerms(), onDelta)
+		if streamer, ok := a.ai.(AIStreamingClient); ok {
+			aiResponse, err = streamer.QueryStream(ctx, aiRequest, streamSanitizer.Write)
+		} else {
+			aiResponse, err = a.ai.Query(ctx, aiRequest)
+			if err == nil {
+				streamSanitizer.Write(aiResponse.Answer)
+			}
+		}
+		if err == nil {
+			streamSanitizer.Flush()
+		}
+	} else {
+		aiResponse, err = a.ai.Query(ctx, aiRequest)
+	}
 	if err != nil {
-		log.Printf("ai query failed session_id=%s user_id=%s child_id=%s intent=%s err=%v", session.ID, user.ID, childID, intent, err)
-		_ = a.releaseReservedCredits(ctx, user.ID, preflight.Reserved)
+		// Provider errors can echo the prompt back, so only the failure class
+		// is logged.
+		log.Printf("ai query failed session_id=%s user_id=%s child_id=%s intent=%s outcome=%s", session.ID, user.ID, childID, intent, classifyAIProviderError(err))
+		if !errors.Is(err, context.Canceled) {
+			a.recordAIOutcome(err)
+		}
+		_ = a.releaseReservedCredits(cleanupCtx, user.ID, preflight.Reserved)
 		return chatExecutionResult{}, err
 	}
 	if aiResponse.Usage.TotalTokens <= 0 {
 		log.Printf("ai usage missing session_id=%s user_id=%s child_id=%s intent=%s model=%s", session.ID, user.ID, childID, intent, aiResponse.Model)
-		_ = a.releaseReservedCredits(ctx, user.ID, preflight.Reserved)
-		return chatExecutionResult{}, errors.New("AI response missing usage tokens")
+		err := errors.New("AI response missing usage tokens")
+		a.recordAIOutcome(err)
+		_ = a.releaseReservedCredits(cleanupCtx, user.ID, preflight.Reserved)
+		return chatExecutionResult{}, err
 	}
 	finalAnswer := strings.TrimSpace(aiResponse.Answer)
+	var facts []chatFact
+	if wantFacts {
+		var factsOK bool
+		finalAnswer, facts, factsOK = splitChatFactsBlock(finalAnswer)
+		if !factsOK {
+			log.Printf("ai answer facts missing or invalid session_id=%s model=%s", session.ID, aiResponse.Model)
+		}
+	}
 	finalAnswer = sanitizeUserFacingAnswer(finalAnswer)
+	if finalAnswer == "" {
+		// Nothing usable came back, so the turn is not billed or persisted.
+		log.Printf("ai answer empty after sanitizing session_id=%s user_id=%s intent=%s model=%s", session.ID, user.ID, intent, aiResponse.Model)
+		a.recordAIOutcome(errors.New("openai response answer is empty after sanitizing"))
+		_ = a.releaseReservedCredits(cleanupCtx, user.ID, preflight.Reserved)
+		return chatExecutionResult{}, &chatHTTPError{
+			Status: http.StatusBadGateway,
+			Detail: "AI provider returned an empty answer; no credits were charged",
+		}
+	}
+	a.recordAIOutcome(nil)
+	finalAnswer, leakedTerms := softenInternalJargon(finalAnswer, a.answerJargonTerms())
+	if len(leakedTerms) > 0 {
+		log.Printf("ai answer softened internal terms session_id=%s intent=%s terms=%v", session.ID, intent, leakedTerms)
+	}
 	if intent == aiIntentSmalltalk {
-		finalAnswer = sanitizeSmalltalkAnswer(finalAnswer)
+		replyMax := smalltalkReplyRuneMax
+		if persona, err := loadPersonaSettingsWithQuerier(ctx, a.db, user.ID); err == nil {
+			replyMax = resolveSmalltalkReplyMaxChars(persona)
+		} else {
+			log.Printf("smalltalk reply cap fell back to default user_id=%s err=%v", user.ID, err)
+		}
+		finalAnswer = sanitizeSmalltalkAnswer(finalAnswer, replyMax)
 	} else {
 		finalAnswer = enforceAnswerEvidenceGuide(finalAnswer)
 	}
+	if finalAnswer == "" {
+		finalAnswer = aiCapabilitiesFallbackAnswer(normalizePreferredLanguage(preferredLanguage))
+	}
+
+	// The translation is a second call on the same model; its tokens are
+	// added to the turn's usage so a single charge covers both.
+	usage := aiResponse.Usage
+	var answerTranslated *string
+	if translateTo != "" {
+		translated, translationUsage, err := a.translateChatAnswer(ctx, chatModel, finalAnswer, translateTo)
+		if err != nil {
+			log.Printf("ai answer translation failed session_id=%s translate_to=%s outcome=%s", session.ID, translateTo, classifyAIProviderError(err))
+		} else {
+			answerTranslated = &translated
+			usage = addAIUsage(usage, translationUsage)
+		}
+	}
 
 	userContext := cloneMap(chatContext.Meta)
 	userContext["tone"] = tone
@@ -790,13 +1915,23 @@ func (a *App) runChatQuery(
 		userContext,
 	)
 	if err != nil {
-		_ = a.releaseReservedCredits(ctx, user.ID, preflight.Reserved)
+		_ = a.releaseReservedCredits(cleanupCtx, user.ID, preflight.Reserved)
 		return chatExecutionResult{}, err
 	}
 
 	assistantContext := cloneMap(chatContext.Meta)
-	assistantContext["model"] = aiResponse.Model
-	assistantContext["usage"] = usageMap(aiResponse.Usage)
+	// Billing and history use the requested model; the provider may report a
+	// dated variant that has no pricing entry.
+	assistantContext["model"] = chatModel
+	assistantContext["usage"] = usageMap(usage)
+	if translateTo != "" {
+		assistantContext["translate_to"] = translateTo
+		assistantContext["answer_translated"] = answerTranslated
+	}
+	if wantFacts {
+		assistantContext["response_format"] = responseFormat
+		assistantContext["facts"] = facts
+	}
 
 	assistantMessageID, _, err := a.insertChatMessage(
 		ctx,
@@ -810,8 +1945,8 @@ func (a *App) runChatQuery(
 		assistantContext,
 	)
 	if err != nil {
-		_, _ = a.db.Exec(ctx, `DELETE FROM "ChatMessage" WHERE id = $1`, userMessageID)
-		_ = a.releaseReservedCredits(ctx, user.ID, preflight.Reserved)
+		_, _ = a.db.Exec(cleanupCtx, `DELETE FROM "ChatMessage" WHERE id = $1`, userMessageID)
+		_ = a.releaseReservedCredits(cleanupCtx, user.ID, preflight.Reserved)
 		return chatExecutionResult{}, err
 	}
 
@@ -821,15 +1956,15 @@ func (a *App) runChatQuery(
 		session.HouseholdID,
 		childID,
 		question,
-		aiResponse.Model,
-		aiResponse.Usage,
+		chatModel,
+		usage,
 		preflight,
 		now,
 	)
 	if err != nil {
-		_, _ = a.db.Exec(ctx, `DELETE FROM "ChatMessage" WHERE id = $1`, assistantMessageID)
-		_, _ = a.db.Exec(ctx, `DELETE FROM "ChatMessage" WHERE id = $1`, userMessageID)
-		_ = a.releaseReservedCredits(ctx, user.ID, preflight.Reserved)
+		_, _ = a.db.Exec(cleanupCtx, `DELETE FROM "ChatMessage" WHERE id = $1`, assistantMessageID)
+		_, _ = a.db.Exec(cleanupCtx, `DELETE FROM "ChatMessage" WHERE id = $1`, userMessageID)
+		_ = a.releaseReservedCredits(cleanupCtx, user.ID, preflight.Reserved)
 		return chatExecutionResult{}, err
 	}
 
@@ -845,9 +1980,13 @@ func (a *App) runChatQuery(
 		SessionID:          session.ID,
 		AssistantMessageID: assistantMessageID,
 		Intent:             intent,
+		IntentSource:       intentSource,
 		Answer:             finalAnswer,
-		Model:              aiResponse.Model,
-		Usage:              aiResponse.Usage,
+		AnswerTranslated:   answerTranslated,
+		TranslateTo:        translateTo,
+		Facts:              facts,
+		Model:              chatModel,
+		Usage:              usage,
 		Credit:             billing,
 		ContextMeta:        chatContext.Meta,
 		ReferenceText:      chatContext.Summary,
@@ -963,7 +2102,9 @@ func (a *App) getOrCreateCompatChatSession(
 func (a *App) loadChatSessionForUser(ctx context.Context, userID, sessionID string) (chatSessionRecord, error) {
 	record := chatSessionRecord{}
 	queryWithMemory := `SELECT id, "userId", "householdId", "childId", status::text, "startedAt", "endedAt",
-	        "memorySummary", COALESCE("memorySummarizedCount", 0), "memorySummaryUpdatedAt"
+	        "memorySummary", COALESCE("memorySummarizedCount", 0), "memorySummaryUpdatedAt",
+	        COALESCE("memoryCompressedCount", 0), "title",
+	        "preferredTone", "preferredLanguage"
 	 FROM "ChatSession"
 	 WHERE id = $1 AND "userId" = $2`
 	scanWithMemory := func() error {
@@ -983,6 +2124,10 @@ func (a *App) loadChatSessionForUser(ctx context.Context, userID, sessionID stri
 			&record.MemorySummary,
 			&record.MemorySummarizedCount,
 			&record.MemorySummaryUpdatedAt,
+			&record.MemoryCompressedCount,
+			&record.Title,
+			&record.PreferredTone,
+			&record.PreferredLanguage,
 		)
 	}
 
@@ -1200,6 +2345,7 @@ func (a *App) saveSessionMemorySummary(
 	ctx context.Context,
 	sessionID, summary string,
 	summarizedCount int,
+	compressedCount int,
 ) error {
 	if summarizedCount <= 0 || strings.TrimSpace(summary) == "" {
 		err := a.execChatMemoryUpdateWithRetry(
@@ -1207,7 +2353,8 @@ func (a *App) saveSessionMemorySummary(
 			`UPDATE "ChatSession"
 			 SET "memorySummary" = NULL,
 			     "memorySummarizedCount" = 0,
-			     "memorySummaryUpdatedAt" = NULL
+			     "memorySummaryUpdatedAt" = NULL,
+			     "memoryCompressedCount" = 0
 			 WHERE id = $1`,
 			sessionID,
 		)
@@ -1219,11 +2366,13 @@ func (a *App) saveSessionMemorySummary(
 		`UPDATE "ChatSession"
 		 SET "memorySummary" = $2,
 		     "memorySummarizedCount" = $3,
-		     "memorySummaryUpdatedAt" = NOW()
+		     "memorySummaryUpdatedAt" = NOW(),
+		     "memoryCompressedCount" = $4
 		 WHERE id = $1`,
 		sessionID,
 		strings.TrimSpace(summary),
 		summarizedCount,
+		compressedCount,
 	)
 	return err
 }
@@ -1248,6 +2397,13 @@ func (a *App) ensureChatSessionMemoryColumns(ctx context.Context) error {
 		`ALTER TABLE "ChatSession" ADD COLUMN IF NOT EXISTS "memorySummary" TEXT`,
 		`ALTER TABLE "ChatSession" ADD COLUMN IF NOT EXISTS "memorySummarizedCount" INTEGER NOT NULL DEFAULT 0`,
 		`ALTER TABLE "ChatSession" ADD COLUMN IF NOT EXISTS "memorySummaryUpdatedAt" TIMESTAMP(3)`,
+		`ALTER TABLE "ChatSession" ADD COLUMN IF NOT EXISTS "memoryCompressedCount" INTEGER NOT NULL DEFAULT 0`,
+		`ALTER TABLE "ChatSession" ADD COLUMN IF NOT EXISTS "memorySummaryModel" TEXT`,
+		`ALTER TABLE "ChatSession" ADD COLUMN IF NOT EXISTS "memorySummaryTokens" INTEGER NOT NULL DEFAULT 0`,
+		`ALTER TABLE "ChatSession" ADD COLUMN IF NOT EXISTS "title" TEXT`,
+		`ALTER TABLE "ChatSession" ADD COLUMN IF NOT EXISTS "preferredTone" TEXT`,
+		`ALTER TABLE "ChatSession" ADD COLUMN IF NOT EXISTS "preferredLanguage" TEXT`,
+		`ALTER TABLE "ChatSession" ADD COLUMN IF NOT EXISTS "archived" BOOLEAN NOT NULL DEFAULT false`,
 	}
 	for _, stmt := range statements {
 		if _, err := a.db.Exec(ctx, stmt); err != nil {
@@ -1267,19 +2423,27 @@ func isMissingChatMemoryColumnErr(err error) bool {
 	}
 	return strings.Contains(lowered, "memorysummary") ||
 		strings.Contains(lowered, "memorysummarizedcount") ||
-		strings.Contains(lowered, "memorysummaryupdatedat")
+		strings.Contains(lowered, "memorysummaryupdatedat") ||
+		strings.Contains(lowered, "memorycompressedcount") ||
+		strings.Contains(lowered, "title") ||
+		strings.Contains(lowered, "preferredtone") ||
+		strings.Contains(lowered, "preferredlanguage") ||
+		strings.Contains(lowered, "archived")
 }
 
+// prepareSessionMemory keeps the newest turnLimit turns verbatim and folds
+// older ones into the session memory summary.
 func (a *App) prepareSessionMemory(
 	ctx context.Context,
 	session chatSessionRecord,
+	turnLimit int,
 ) ([]ChatTurn, string, int, error) {
 	totalCount, err := a.loadSessionMessageCount(ctx, session.ID)
 	if err != nil {
 		return nil, "", 0, err
 	}
 
-	targetSummarizedCount := totalCount - chatConversationTurnLimit
+	targetSummarizedCount := totalCount - turnLimit
 	if targetSummarizedCount < 0 {
 		targetSummarizedCount = 0
 	}
@@ -1296,11 +2460,12 @@ func (a *App) prepareSessionMemory(
 	if session.MemorySummary != nil {
 		summary = strings.TrimSpace(*session.MemorySummary)
 	}
+	compressedCount := session.MemoryCompressedCount
 
 	switch {
 	case targetSummarizedCount == 0:
 		if currentSummarizedCount > 0 || summary != "" {
-			if err := a.saveSessionMemorySummary(ctx, session.ID, "", 0); err != nil {
+			if err := a.saveSessionMemorySummary(ctx, session.ID, "", 0, 0); err != nil {
 				return nil, "", 0, err
 			}
 			currentSummarizedCount = 0
@@ -1311,9 +2476,14 @@ func (a *App) prepareSessionMemory(
 		if err != nil {
 			return nil, "", 0, err
 		}
-		summary = buildSessionMemorySummary("", rebuildTurns)
+		var summarizer *AIModelResponse
+		summary, summarizer = a.summarizeSessionTurns(ctx, session.ID, "", rebuildTurns)
+		if summarizer != nil {
+			a.recordSessionMemorySummarizerUsage(ctx, session.ID, *summarizer)
+		}
 		currentSummarizedCount = targetSummarizedCount
-		if err := a.saveSessionMemorySummary(ctx, session.ID, summary, currentSummarizedCount); err != nil {
+		summary, compressedCount = a.maybeCompressSessionMemory(ctx, session.ID, summary, currentSummarizedCount, 0)
+		if err := a.saveSessionMemorySummary(ctx, session.ID, summary, currentSummarizedCount, compressedCount); err != nil {
 			return nil, "", 0, err
 		}
 	case currentSummarizedCount < targetSummarizedCount:
@@ -1322,20 +2492,145 @@ func (a *App) prepareSessionMemory(
 		if err != nil {
 			return nil, "", 0, err
 		}
-		summary = buildSessionMemorySummary(summary, newTurns)
+		var summarizer *AIModelResponse
+		summary, summarizer = a.summarizeSessionTurns(ctx, session.ID, summary, newTurns)
+		if summarizer != nil {
+			a.recordSessionMemorySummarizerUsage(ctx, session.ID, *summarizer)
+		}
 		currentSummarizedCount = targetSummarizedCount
-		if err := a.saveSessionMemorySummary(ctx, session.ID, summary, currentSummarizedCount); err != nil {
+		summary, compressedCount = a.maybeCompressSessionMemory(ctx, session.ID, summary, currentSummarizedCount, compressedCount)
+		if err := a.saveSessionMemorySummary(ctx, session.ID, summary, currentSummarizedCount, compressedCount); err != nil {
 			return nil, "", 0, err
 		}
 	}
 
-	turns, err := a.loadSessionTurns(ctx, session.ID, chatConversationTurnLimit)
+	turns, err := a.loadSessionTurns(ctx, session.ID, turnLimit)
 	if err != nil {
 		return nil, "", 0, err
 	}
 	return turns, summary, currentSummarizedCount, nil
 }
 
+// shouldCompressSessionMemory reports whether the mechanical summary is close
+// enough to its cap to be worth an AI pass, and whether at least everyTurns
+// turns have been summarized since the last pass. everyTurns <= 0 disables it.
+func shouldCompressSessionMemory(summary string, summarizedCount, compressedCount, everyTurns int) bool {
+	if everyTurns <= 0 {
+		return false
+	}
+	if utf8.RuneCountInString(strings.TrimSpace(summary)) < chatMemoryCompressTriggerChars {
+		return false
+	}
+	return compressedCount <= 0 || summarizedCount-compressedCount >= everyTurns
+}
+
+// maybeCompressSessionMemory replaces a near-full summary with an AI-compressed
+// one and returns the summarized count to record as the last pass. A failed
+// pass keeps the mechanical summary but still counts toward the cadence, so a
+// broken provider is not retried on every turn.
+func (a *App) maybeCompressSessionMemory(ctx context.Context, sessionID, summary string, summarizedCount, compressedCount int) (string, int) {
+	if !shouldCompressSessionMemory(summary, summarizedCount, compressedCount, a.cfg.ChatMemoryCompressEvery) {
+		return summary, compressedCount
+	}
+	response, err := a.ai.Query(ctx, AIModelRequest{
+		Model:        chatDailyModel,
+		SystemPrompt: buildSessionMemoryCompressionPrompt(),
+		UserPrompt:   summary,
+	})
+	if err != nil {
+		log.Printf("chat memory compression failed session_id=%s outcome=%s", sessionID, classifyAIProviderError(err))
+		return summary, summarizedCount
+	}
+	compressed := trimToRuneLimit(response.Answer, chatMemorySummaryCharMax)
+	if compressed == "" {
+		log.Printf("chat memory compression returned empty summary session_id=%s", sessionID)
+		return summary, summarizedCount
+	}
+	log.Printf(
+		"chat memory compressed session_id=%s before_chars=%d after_chars=%d total_tokens=%d",
+		sessionID,
+		utf8.RuneCountInString(summary),
+		utf8.RuneCountInString(compressed),
+		response.Usage.TotalTokens,
+	)
+	return compressed, summarizedCount
+}
+
+// summarizeSessionTurns folds turns that fell out of the raw conversation
+// window into the session memory. With CHAT_MEMORY_AI_SUMMARY on, the model
+// rewrites the memory as a short paragraph; otherwise, or when that call
+// fails, the turns are appended as truncated lines. The summarizer response
+// is returned only when the AI summary was used.
+func (a *App) summarizeSessionTurns(ctx context.Context, sessionID, existing string, turns []ChatTurn) (string, *AIModelResponse) {
+	mechanical := buildSessionMemorySummary(existing, turns)
+	if !a.cfg.ChatMemoryAISummary || mechanical == "" {
+		return mechanical, nil
+	}
+	transcript := buildSessionMemorySummary("", turns)
+	if transcript == "" {
+		return mechanical, nil
+	}
+	userPrompt := "새 대화:\n" + transcript
+	if trimmed := strings.TrimSpace(existing); trimmed != "" {
+		userPrompt = "기존 메모:\n" + trimmed + "\n\n" + userPrompt
+	}
+	response, err := a.ai.Query(ctx, AIModelRequest{
+		Model:        chatDailyModel,
+		SystemPrompt: buildSessionMemorySummaryPrompt(),
+		UserPrompt:   userPrompt,
+	})
+	if err != nil {
+		log.Printf("chat memory summary failed session_id=%s outcome=%s", sessionID, classifyAIProviderError(err))
+		return mechanical, nil
+	}
+	summary := trimToRuneLimit(response.Answer, chatMemorySummaryCharMax)
+	if summary == "" {
+		log.Printf("chat memory summary returned empty answer session_id=%s", sessionID)
+		return mechanical, nil
+	}
+	return summary, &response
+}
+
+func buildSessionMemorySummaryPrompt() string {
+	return strings.Join([]string{
+		"너는 육아 상담 대화의 지난 내용을 다음 답변을 위한 메모로 요약한다.",
+		fmt.Sprintf("기존 메모와 새 대화를 합쳐 %d자 이내의 한국어 한 문단으로 쓴다.", chatMemoryCompressTargetChars),
+		"아이의 상태(날짜, 시간, ml, 체온, 체중, 증상), 보호자가 정한 것, 아직 답하지 못한 질문을 우선 남긴다.",
+		"인사, 반복, 잡담은 뺀다. 대화에 없는 사실이나 조언을 추가하지 않는다.",
+		"메모 외의 설명은 출력하지 않는다.",
+	}, "\n")
+}
+
+// recordSessionMemorySummarizerUsage keeps the tokens spent on session memory
+// on the session itself. They are an operating cost, not part of the user's
+// turn, so they never reach finalizeBillingAndLog.
+func (a *App) recordSessionMemorySummarizerUsage(ctx context.Context, sessionID string, response AIModelResponse) {
+	if err := a.execChatMemoryUpdateWithRetry(
+		ctx,
+		`UPDATE "ChatSession"
+		 SET "memorySummaryModel" = $2,
+		     "memorySummaryTokens" = COALESCE("memorySummaryTokens", 0) + $3
+		 WHERE id = $1`,
+		sessionID,
+		response.Model,
+		response.Usage.TotalTokens,
+	); err != nil {
+		log.Printf("chat memory summarizer usage not recorded session_id=%s err=%v", sessionID, err)
+	}
+}
+
+func buildSessionMemoryCompressionPrompt() string {
+	return strings.Join([]string{
+		"너는 육아 상담 대화의 이전 기록 메모를 압축한다.",
+		fmt.Sprintf("입력 메모를 %d자 이내의 한국어 불릿 목록으로 줄인다.", chatMemoryCompressTargetChars),
+		"아이에 대한 사실(날짜, 시간, ml, 체온, 체중, 증상), 보호자의 걱정과 요청, 이미 안내한 내용을 우선 남긴다.",
+		"인사, 반복, 잡담은 뺀다. 새로운 사실이나 조언을 추가하지 않는다.",
+		"각 줄은 `- `로 시작한다. 메모 외의 설명은 출력하지 않는다.",
+	}, "\n")
+}
+
+// resolveSessionIntentFromFirstUserMessage decides the intent for a turn and
+// reports which step decided it, so misclassifications can be traced.
 func (a *App) resolveSessionIntentFromFirstUserMessage(
 	ctx context.Context,
 	sessionID string,
@@ -1344,10 +2639,14 @@ func (a *App) resolveSessionIntentFromFirstUserMessage(
 	firstUserMessageID string,
 	firstUserMessage string,
 	fixedIntent aiIntent,
-) aiIntent {
+	forcedIntent aiIntent,
+) (aiIntent, chatIntentSource) {
+	if forcedIntent != "" {
+		return forcedIntent, chatIntentSourceForcedOverride
+	}
 	fallback := resolveAIIntentWithSession(question, turns)
 	if fixedIntent != "" {
-		return fixedIntent
+		return fixedIntent, chatIntentSourcePersistedFirstMessage
 	}
 
 	firstMessage := strings.TrimSpace(firstUserMessage)
@@ -1358,7 +2657,7 @@ func (a *App) resolveSessionIntentFromFirstUserMessage(
 		firstMessage = strings.TrimSpace(question)
 	}
 	if firstMessage == "" {
-		return fallback
+		return fallback, chatIntentSourceHeuristic
 	}
 
 	// Guardrail: caregiver self-state utterances should stay in smalltalk.
@@ -1368,12 +2667,12 @@ func (a *App) resolveSessionIntentFromFirstUserMessage(
 				log.Printf("failed to persist caregiver-self smalltalk intent session_id=%s message_id=%s err=%v", sessionID, firstUserMessageID, saveErr)
 			}
 		}
-		return aiIntentSmalltalk
+		return aiIntentSmalltalk, chatIntentSourceCaregiverSelfTalk
 	}
 
 	intent, err := a.resolveAIIntentByFirstMessage(ctx, firstMessage, question)
 	if err != nil || intent == "" {
-		return fallback
+		return fallback, chatIntentSourceHeuristic
 	}
 
 	if strings.TrimSpace(firstUserMessageID) != "" {
@@ -1381,10 +2680,17 @@ func (a *App) resolveSessionIntentFromFirstUserMessage(
 			log.Printf("failed to persist first-user intent session_id=%s message_id=%s intent=%s err=%v", sessionID, firstUserMessageID, intent, saveErr)
 		}
 	}
-	return intent
+	return intent, chatIntentSourceAIRouter
 }
 
 func (a *App) resolveAIIntentByFirstMessage(ctx context.Context, firstMessage, latestQuestion string) (aiIntent, error) {
+	intent, _, err := a.routeAIIntent(ctx, firstMessage, latestQuestion)
+	return intent, err
+}
+
+// routeAIIntent asks the intent router model and also returns its
+// self-reported confidence, nil when the router left it out.
+func (a *App) routeAIIntent(ctx context.Context, firstMessage, latestQuestion string) (aiIntent, *float64, error) {
 	systemPrompt := strings.Join([]string{
 		"You classify childcare chat intent for session-level persona routing.",
 		"Return exactly one intent: smalltalk, data_query, medical_related, care_routine.",
@@ -1416,14 +2722,14 @@ func (a *App) resolveAIIntentByFirstMessage(ctx context.Context, firstMessage, l
 		UserPrompt:   userPrompt,
 	})
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	intent, ok := parseAIIntentRouterJSON(resp.Answer)
+	intent, confidence, ok := parseAIIntentRouterResult(resp.Answer)
 	if !ok {
-		return "", errors.New("intent router returned invalid JSON")
+		return "", nil, errors.New("intent router returned invalid JSON")
 	}
-	return intent, nil
+	return intent, confidence, nil
 }
 
 func isLikelyCaregiverSelfTalk(message string) bool {
@@ -1469,9 +2775,14 @@ func firstUserMessageFromTurns(turns []ChatTurn) string {
 }
 
 func parseAIIntentRouterJSON(answer string) (aiIntent, bool) {
+	intent, _, ok := parseAIIntentRouterResult(answer)
+	return intent, ok
+}
+
+func parseAIIntentRouterResult(answer string) (aiIntent, *float64, bool) {
 	candidate := strings.TrimSpace(answer)
 	if candidate == "" {
-		return "", false
+		return "", nil, false
 	}
 	if !strings.HasPrefix(candidate, "{") {
 		start := strings.Index(candidate, "{")
@@ -1488,9 +2799,13 @@ func parseAIIntentRouterJSON(answer string) (aiIntent, bool) {
 	parsed := parseJSONStringMap([]byte(candidate))
 	intent := normalizeAIIntentLabel(toString(parsed["intent"]))
 	if intent == "" {
-		return "", false
+		return "", nil, false
+	}
+	var confidence *float64
+	if value, ok := parsed["confidence"].(float64); ok && value >= 0 && value <= 1 {
+		confidence = &value
 	}
-	return intent, true
+	return intent, confidence, true
 }
 
 func normalizeAIIntentLabel(value string) aiIntent {
@@ -1601,10 +2916,14 @@ type chatContextSelection struct {
 	MonthEnd      time.Time
 }
 
+// chatScopeOverride is the context scope the client asked for. RawStart and
+// RawEnd, when set, replace the default raw window for data queries.
 type chatScopeOverride struct {
 	Mode       string
 	AnchorDate *time.Time
 	LocalZone  *time.Location
+	RawStart   time.Time
+	RawEnd     time.Time
 }
 
 type normalizedEvidenceRow struct {
@@ -1670,7 +2989,7 @@ func (a *App) buildChatContext(
 			fmt.Sprintf("일상대화용 아동 프로필 DB 스냅샷 (child_id=%s).", childID),
 			fmt.Sprintf("- 이름=%s", profileSnapshot.Name),
 			fmt.Sprintf("- 생년월일=%s", birthDateText),
-			fmt.Sprintf("- 나이=%d일 (만 %d개월, 생년월일 기준)", profileSnapshot.AgeDays, profileSnapshot.AgeMonths),
+			"- " + profileAgeText(profileSnapshot),
 		}
 		if onboardingLine := profileCareContextLine(profileSnapshot); onboardingLine != "" {
 			summaryLines = append(summaryLines, onboardingLine)
@@ -1695,7 +3014,34 @@ func (a *App) buildChatContext(
 		}, nil
 	}
 	selection := resolveChatContextSelection(question, intent, nowUTC, scopeOverride)
+	return a.buildChatContextForSelection(ctx, userID, childID, question, intent, nowUTC, selection, profileSnapshot, birthDateText)
+}
+
+// buildChatContextForSelection loads the context for an already resolved
+// selection; the profile snapshot is passed in by the caller.
+func (a *App) buildChatContextForSelection(
+	ctx context.Context,
+	userID string,
+	childID string,
+	question string,
+	intent aiIntent,
+	nowUTC time.Time,
+	selection chatContextSelection,
+	profileSnapshot childProfileSnapshot,
+	birthDateText string,
+) (chatContextResult, error) {
+	if selection.Mode == chatContextModeMonthlyMedicalSummary || selection.Mode == chatContextModeMonthlyParentingRollup {
+		eventCount, firstEventTime, err := a.loadChildHistorySpan(ctx, childID)
+		if err != nil {
+			return chatContextResult{}, err
+		}
+		if a.monthlyRollupTooSparse(eventCount, firstEventTime, nowUTC) {
+			return a.buildShortHistoryContext(ctx, userID, childID, question, intent, nowUTC, selection, profileSnapshot, birthDateText)
+		}
+	}
 	switch selection.Mode {
+	case chatContextModeRequestedDateFuture:
+		return buildFutureRequestedDateContext(childID, nowUTC, selection, profileSnapshot, birthDateText), nil
 	case chatContextModeRequestedDateSummary:
 		return a.buildRequestedDateSummaryContext(ctx, childID, nowUTC, selection, profileSnapshot, birthDateText)
 	case chatContextModeWeeklySummary:
@@ -1704,11 +3050,85 @@ func (a *App) buildChatContext(
 		return a.buildMonthlyMedicalSummaryContext(ctx, childID, nowUTC, selection, profileSnapshot, birthDateText)
 	case chatContextModeMonthlyParentingRollup:
 		return a.buildMonthlyParentingRollupContext(ctx, childID, nowUTC, selection, profileSnapshot, birthDateText)
-	case chatContextModeRequestedDateRaw, chatContextModeLast3DRaw:
-		return a.buildRawEventContext(ctx, childID, question, intent, nowUTC, selection, profileSnapshot, birthDateText)
+	case chatContextModeRequestedDateRaw, chatContextModeRequestedRangeRaw, chatContextModeLast3DRaw:
+		return a.buildRawEventContext(ctx, userID, childID, question, intent, nowUTC, selection, profileSnapshot, birthDateText)
 	default:
-		return a.buildRawEventContext(ctx, childID, question, intent, nowUTC, selection, profileSnapshot, birthDateText)
+		return a.buildRawEventContext(ctx, userID, childID, question, intent, nowUTC, selection, profileSnapshot, birthDateText)
+	}
+}
+
+func (a *App) loadChildHistorySpan(ctx context.Context, childID string) (int, *time.Time, error) {
+	var count int
+	var firstEventTime *time.Time
+	err := a.db.QueryRow(
+		ctx,
+		`SELECT COUNT(*)::int, MIN("startTime")
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'`,
+		childID,
+	).Scan(&count, &firstEventTime)
+	if err != nil {
+		return 0, nil, err
+	}
+	return count, firstEventTime, nil
+}
+
+// monthlyRollupTooSparse reports whether a baby has too little history for a
+// monthly rollup to say anything beyond "no records".
+func (a *App) monthlyRollupTooSparse(eventCount int, firstEventTime *time.Time, nowUTC time.Time) bool {
+	if a.cfg.ChatMonthlyMinEvents > 0 && eventCount < a.cfg.ChatMonthlyMinEvents {
+		return true
+	}
+	if a.cfg.ChatMonthlyMinHistoryDays > 0 {
+		if firstEventTime == nil {
+			return true
+		}
+		historyDays := int(nowUTC.Sub(firstEventTime.UTC()).Hours() / 24)
+		return historyDays < a.cfg.ChatMonthlyMinHistoryDays
+	}
+	return false
+}
+
+// buildShortHistoryContext answers a monthly question for a new baby from the
+// recent raw window and notes that the monthly section was skipped.
+func (a *App) buildShortHistoryContext(
+	ctx context.Context,
+	userID string,
+	childID string,
+	question string,
+	intent aiIntent,
+	nowUTC time.Time,
+	selection chatContextSelection,
+	profileSnapshot childProfileSnapshot,
+	birthDateText string,
+) (chatContextResult, error) {
+	selection.Mode = chatContextModeLast3DRaw
+	selection.RawStart = nowUTC.Add(-chatRawWindowDuration)
+	selection.RawEnd = nowUTC
+	result, err := a.buildRawEventContext(ctx, userID, childID, question, intent, nowUTC, selection, profileSnapshot, birthDateText)
+	if err != nil {
+		return chatContextResult{}, err
 	}
+	result.Meta["monthly_rollup_skipped"] = true
+	result.Summary = "기록 기간이 짧아 월간 요약은 생략하고 최근 기록을 사용합니다.\n" + result.Summary
+	return result, nil
+}
+
+// profileAgeText states the chronological age and, for preterm babies, the
+// corrected age that development and feeding guidance should use.
+func profileAgeText(profile childProfileSnapshot) string {
+	text := fmt.Sprintf("나이=%d일 (만 %d개월, 생년월일 기준)", profile.AgeDays, profile.AgeMonths)
+	if profile.GestationalWeeks == nil || profile.CorrectedAgeDays == profile.AgeDays {
+		return text
+	}
+	return text + fmt.Sprintf(
+		", 교정연령=%d일 (만 %d개월, 재태 %d주 출생 기준; 발달·수유 판단은 교정연령 우선)",
+		profile.CorrectedAgeDays,
+		profile.CorrectedAgeMonths,
+		*profile.GestationalWeeks,
+	)
 }
 
 func buildBaseProfileMeta(childID string, profile childProfileSnapshot, birthDateText string) map[string]any {
@@ -1731,6 +3151,9 @@ func buildBaseProfileMeta(childID string, profile childProfileSnapshot, birthDat
 		"profile_age_days":                profile.AgeDays,
 		"profile_age_months":              profile.AgeMonths,
 		"profile_age_months_basis":        "calendar_from_birth_date",
+		"profile_corrected_age_days":      profile.CorrectedAgeDays,
+		"profile_corrected_age_months":    profile.CorrectedAgeMonths,
+		"profile_gestational_weeks":       profile.GestationalWeeks,
 		"profile_sex":                     profile.Sex,
 		"profile_feeding_method":          profile.FeedingMethod,
 		"profile_formula_brand":           profile.FormulaBrand,
@@ -1909,6 +3332,47 @@ func resolveRequestedChatScope(rawMode, rawAnchorDate, rawTZOffset string, nowUT
 	}
 }
 
+// resolveRequestedRawWindow reads the optional from/to dates of a chat query
+// as whole local days in tz_offset. Both are required together; the window
+// ends no later than now and spans at most chatRequestedRangeMaxDays.
+func resolveRequestedRawWindow(rawFrom, rawTo, rawTZOffset string, nowUTC time.Time) (time.Time, time.Time, error) {
+	rawFrom = strings.TrimSpace(rawFrom)
+	rawTo = strings.TrimSpace(rawTo)
+	if rawFrom == "" && rawTo == "" {
+		return time.Time{}, time.Time{}, nil
+	}
+	if rawFrom == "" || rawTo == "" {
+		return time.Time{}, time.Time{}, errors.New("from and to must be sent together")
+	}
+	localZone, _, err := parseTZOffset(rawTZOffset)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	fromDate, err := time.ParseInLocation("2006-01-02", rawFrom, localZone)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("from must be YYYY-MM-DD")
+	}
+	toDate, err := time.ParseInLocation("2006-01-02", rawTo, localZone)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("to must be YYYY-MM-DD")
+	}
+	if fromDate.After(toDate) {
+		return time.Time{}, time.Time{}, errors.New("from must be on or before to")
+	}
+	if toDate.Sub(fromDate) >= chatRequestedRangeMaxDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("from/to range must be at most %d days", chatRequestedRangeMaxDays)
+	}
+	start := fromDate.UTC()
+	if !start.Before(nowUTC) {
+		return time.Time{}, time.Time{}, errors.New("from must not be in the future")
+	}
+	end := toDate.AddDate(0, 0, 1).UTC()
+	if end.After(nowUTC) {
+		end = nowUTC
+	}
+	return start, end, nil
+}
+
 func normalizeChatDateMode(raw string) string {
 	switch strings.ToLower(strings.TrimSpace(raw)) {
 	case "day", "daily", "date", "today", "d":
@@ -1964,6 +3428,12 @@ func resolveChatContextSelection(
 		MonthStart: startOfUTCMonth(nowUTC),
 		MonthEnd:   startOfUTCMonth(nowUTC).AddDate(0, 1, 0),
 	}
+	if intent == aiIntentDataQuery && !scopeOverride.RawStart.IsZero() {
+		selection.Mode = chatContextModeRequestedRangeRaw
+		selection.RawStart = scopeOverride.RawStart
+		selection.RawEnd = scopeOverride.RawEnd
+		return selection
+	}
 	if overridden, ok := selectionByRequestedScope(selection, intent, nowUTC, scopeOverride); ok {
 		return overridden
 	}
@@ -1980,7 +3450,9 @@ func resolveChatContextSelection(
 		selection.RequestedDate = &requestedStart
 		selection.RawStart = requestedStart
 		selection.RawEnd = requestedStart.Add(24 * time.Hour)
-		if nowUTC.Sub(requestedStart) > chatRawWindowDuration {
+		if requestedStart.After(startOfUTCDay(nowUTC)) {
+			selection.Mode = chatContextModeRequestedDateFuture
+		} else if nowUTC.Sub(requestedStart) > chatRawWindowDuration {
 			selection.Mode = chatContextModeRequestedDateSummary
 		} else {
 			selection.Mode = chatContextModeRequestedDateRaw
@@ -2026,7 +3498,9 @@ func selectionByRequestedScope(
 		selection.RequestedDate = &anchor
 		selection.RawStart = anchor
 		selection.RawEnd = anchor.Add(24 * time.Hour)
-		if nowUTC.Sub(anchor) > chatRawWindowDuration {
+		if anchor.After(startOfScopeLocalDayUTC(nowUTC, localZone)) {
+			selection.Mode = chatContextModeRequestedDateFuture
+		} else if nowUTC.Sub(anchor) > chatRawWindowDuration {
 			selection.Mode = chatContextModeRequestedDateSummary
 		} else {
 			selection.Mode = chatContextModeRequestedDateRaw
@@ -2248,8 +3722,79 @@ func normalizeEvidenceRow(
 	}
 }
 
+// loadChatFocalEvent fetches the event a chat question is about. The event
+// must belong to the child the session is answering for.
+func (a *App) loadChatFocalEvent(ctx context.Context, userID, eventID, childID string) (normalizedEvidenceRow, error) {
+	var babyID string
+	var eventType string
+	var startAt time.Time
+	var endAt *time.Time
+	var valueText string
+	var metadataText string
+	err := a.db.QueryRow(
+		ctx,
+		`SELECT "babyId", type::text, "startTime", "endTime", COALESCE("valueJson", '{}'::jsonb)::text, COALESCE("metadataJson", '{}'::jsonb)::text
+		 FROM "Event"
+		 WHERE id = $1
+		   AND "deletedAt" IS NULL
+		   AND `+eventVisibleToUserSQL("$2"),
+		eventID,
+		userID,
+	).Scan(&babyID, &eventType, &startAt, &endAt, &valueText, &metadataText)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return normalizedEvidenceRow{}, &chatHTTPError{Status: http.StatusNotFound, Detail: "Event not found"}
+	}
+	if err != nil {
+		return normalizedEvidenceRow{}, err
+	}
+	if babyID != strings.TrimSpace(childID) {
+		return normalizedEvidenceRow{}, &chatHTTPError{Status: http.StatusBadRequest, Detail: "event_id does not belong to this chat session child"}
+	}
+	return normalizeEvidenceRow(eventID, eventType, startAt, endAt, valueText, metadataText), nil
+}
+
+// applyFocalEventContext puts the referenced event at the top of the context,
+// independent of the window the rest of the context was built from. The
+// window's has_missing_data is kept: one focal event does not fill an empty window.
+func applyFocalEventContext(result chatContextResult, focal normalizedEvidenceRow) chatContextResult {
+	meta := cloneMap(result.Meta)
+	meta["focal_event_id"] = focal.EventID
+	existingIDs, _ := meta["evidence_event_ids"].([]string)
+	evidenceIDs := make([]string, 0, len(existingIDs)+1)
+	evidenceIDs = append(evidenceIDs, focal.EventID)
+	for _, id := range existingIDs {
+		if id != focal.EventID {
+			evidenceIDs = append(evidenceIDs, id)
+		}
+	}
+	meta["evidence_event_ids"] = evidenceIDs
+
+	lines := []string{
+		"초점 이벤트(사용자가 지정한 기록, action | date | start_time | end_time | type | note | evidence_event_id):",
+		fmt.Sprintf(
+			"- %s | %s | %s | %s | %s | %s | %s",
+			focal.Action,
+			focal.Date,
+			focal.Start,
+			focal.End,
+			focal.Type,
+			focal.Note,
+			focal.EventID,
+		),
+		"초점 이벤트 지시: 답변은 이 기록을 중심으로 하고, 다른 기록은 비교가 필요할 때만 보조 근거로 사용한다.",
+	}
+	if summary := strings.TrimSpace(result.Summary); summary != "" {
+		lines = append(lines, summary)
+	}
+	return chatContextResult{
+		Meta:    meta,
+		Summary: strings.Join(lines, "\n"),
+	}
+}
+
 func (a *App) buildRawEventContext(
 	ctx context.Context,
+	userID string,
 	childID string,
 	question string,
 	intent aiIntent,
@@ -2263,6 +3808,7 @@ func (a *App) buildRawEventContext(
 		`SELECT id, type::text, "startTime", "endTime", COALESCE("valueJson", '{}'::jsonb)::text, COALESCE("metadataJson", '{}'::jsonb)::text
 		 FROM "Event"
 		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
 		   AND "startTime" >= $2
 		   AND "startTime" < $3
 		   AND NOT (
@@ -2273,11 +3819,12 @@ func (a *App) buildRawEventContext(
 		     )
 		   )
 		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
-		 ORDER BY "startTime" DESC
-		 LIMIT 240`,
+		   AND `+eventVisibleToUserSQL("$4")+`
+		 ORDER BY "startTime" DESC`,
 		childID,
 		selection.RawStart,
 		selection.RawEnd,
+		userID,
 	)
 	if err != nil {
 		return chatContextResult{}, err
@@ -2285,7 +3832,7 @@ func (a *App) buildRawEventContext(
 	defer rows.Close()
 
 	focusTypes := focusEventTypesForQuestion(question, intent)
-	evidenceRows := make([]normalizedEvidenceRow, 0, 96)
+	events := make([]rawContextEvent, 0, 96)
 	evidenceIDs := make([]string, 0, 96)
 	for rows.Next() {
 		var eventID string
@@ -2300,17 +3847,13 @@ func (a *App) buildRawEventContext(
 		if !shouldKeepFocusEventType(eventType, focusTypes) {
 			continue
 		}
-		row := normalizeEvidenceRow(eventID, eventType, startAt, endAt, valueText, metadataText)
-		evidenceRows = append(evidenceRows, row)
-		evidenceIDs = append(evidenceIDs, row.EventID)
+		events = append(events, newRawContextEvent(eventID, eventType, startAt, endAt, valueText, metadataText))
+		evidenceIDs = append(evidenceIDs, strings.TrimSpace(eventID))
 	}
 	if err := rows.Err(); err != nil {
 		return chatContextResult{}, err
 	}
-	if len(evidenceRows) > 80 {
-		evidenceRows = evidenceRows[:80]
-		evidenceIDs = evidenceIDs[:80]
-	}
+	eventLines, summarized := compactRawContextLines(events, a.cfg.ChatRawContextMaxLines)
 
 	meta := buildBaseProfileMeta(childID, profileSnapshot, birthDateText)
 	meta["time_range"] = selection.Mode
@@ -2320,37 +3863,25 @@ func (a *App) buildRawEventContext(
 	meta["raw_until_utc"] = selection.RawEnd.UTC().Format(time.RFC3339)
 	meta["evidence_event_ids"] = evidenceIDs
 	meta["has_estimated_values"] = false
-	meta["has_missing_data"] = len(evidenceRows) == 0
+	meta["has_missing_data"] = len(events) == 0
+	meta["raw_lines_summarized"] = summarized
 	if selection.RequestedDate != nil {
 		meta["requested_date_utc"] = selection.RequestedDate.UTC().Format("2006-01-02")
 	}
 
 	summaryLines := []string{
 		fmt.Sprintf("질문 우선 컨텍스트 (child_id=%s). 질문과 직접 관련된 근거만 사용하세요.", childID),
-		fmt.Sprintf("아동 프로필: 이름=%s, 생년월일=%s, 나이=%d일 (만 %d개월).", profileSnapshot.Name, birthDateText, profileSnapshot.AgeDays, profileSnapshot.AgeMonths),
+		fmt.Sprintf("아동 프로필: 이름=%s, 생년월일=%s, %s.", profileSnapshot.Name, birthDateText, profileAgeText(profileSnapshot)),
 		fmt.Sprintf("근거 범위: %s ~ %s", formatContextTime(selection.RawStart), formatContextTime(selection.RawEnd)),
 	}
 	if onboardingLine := profileCareContextLine(profileSnapshot); onboardingLine != "" {
 		summaryLines = append(summaryLines, onboardingLine)
 	}
 	summaryLines = append(summaryLines, "정규화 이벤트 테이블(action | date | start_time | end_time | type | note | evidence_event_id):")
-	if len(evidenceRows) == 0 {
+	if len(events) == 0 {
 		summaryLines = append(summaryLines, "- 기록만으로는 판단이 어렵습니다.")
 	} else {
-		for _, item := range evidenceRows {
-			summaryLines = append(summaryLines,
-				fmt.Sprintf(
-					"- %s | %s | %s | %s | %s | %s | %s",
-					item.Action,
-					item.Date,
-					item.Start,
-					item.End,
-					item.Type,
-					item.Note,
-					item.EventID,
-				),
-			)
-		}
+		summaryLines = append(summaryLines, eventLines...)
 	}
 	return chatContextResult{
 		Meta:    meta,
@@ -2358,6 +3889,125 @@ func (a *App) buildRawEventContext(
 	}, nil
 }
 
+// rawContextEvent is one event of the raw context window, with the amounts
+// needed when it is folded into a per-type summary line.
+type rawContextEvent struct {
+	Row         normalizedEvidenceRow
+	StartAt     time.Time
+	AmountML    float64
+	DurationMin float64
+}
+
+func newRawContextEvent(eventID, eventType string, startAt time.Time, endAt *time.Time, valueText, metadataText string) rawContextEvent {
+	valueMap := parseJSONStringMap([]byte(valueText))
+	event := rawContextEvent{
+		Row:      normalizeEvidenceRow(eventID, eventType, startAt, endAt, valueText, metadataText),
+		StartAt:  startAt.UTC(),
+		AmountML: extractNumberFromMap(valueMap, "ml", "amount_ml", "volume_ml"),
+	}
+	if duration := extractDurationMinutes(valueMap, startAt.UTC(), endAt); duration != nil && *duration > 0 {
+		event.DurationMin = *duration
+	}
+	return event
+}
+
+func formatEvidenceTableLine(row normalizedEvidenceRow) string {
+	return fmt.Sprintf(
+		"- %s | %s | %s | %s | %s | %s | %s",
+		row.Action,
+		row.Date,
+		row.Start,
+		row.End,
+		row.Type,
+		row.Note,
+		row.EventID,
+	)
+}
+
+// compactRawContextLines renders events (newest first) as evidence table
+// lines, at most maxLines of them. When there are more events, the newest
+// ones stay verbatim and the older ones are folded into one line per type
+// after a note saying so. maxLines <= 0 disables the cap.
+func compactRawContextLines(events []rawContextEvent, maxLines int) ([]string, bool) {
+	if maxLines <= 0 || len(events) <= maxLines {
+		lines := make([]string, 0, len(events))
+		for _, event := range events {
+			lines = append(lines, formatEvidenceTableLine(event.Row))
+		}
+		return lines, false
+	}
+
+	// Reserve room for the note and one line per type that could end up in
+	// the folded part.
+	typeCount := map[string]struct{}{}
+	for _, event := range events {
+		typeCount[event.Row.Type] = struct{}{}
+	}
+	keep := maxLines - 1 - len(typeCount)
+	if keep < 0 {
+		keep = 0
+	}
+
+	lines := make([]string, 0, maxLines)
+	for _, event := range events[:keep] {
+		lines = append(lines, formatEvidenceTableLine(event.Row))
+	}
+	older := events[keep:]
+	lines = append(lines, fmt.Sprintf(
+		"- 이전 기록 %d건은 유형별 합계로 요약했습니다 (최근 %d건만 상세). 요약 행에는 개별 시각이 없습니다.",
+		len(older),
+		keep,
+	))
+
+	type typeAggregate struct {
+		Count       int
+		First       time.Time
+		Last        time.Time
+		AmountML    float64
+		DurationMin float64
+	}
+	aggregates := map[string]*typeAggregate{}
+	for _, event := range older {
+		agg, ok := aggregates[event.Row.Type]
+		if !ok {
+			agg = &typeAggregate{First: event.StartAt, Last: event.StartAt}
+			aggregates[event.Row.Type] = agg
+		}
+		agg.Count++
+		if event.StartAt.Before(agg.First) {
+			agg.First = event.StartAt
+		}
+		if event.StartAt.After(agg.Last) {
+			agg.Last = event.StartAt
+		}
+		agg.AmountML += event.AmountML
+		agg.DurationMin += event.DurationMin
+	}
+	types := make([]string, 0, len(aggregates))
+	for eventType := range aggregates {
+		types = append(types, eventType)
+	}
+	sort.Strings(types)
+	for _, eventType := range types {
+		agg := aggregates[eventType]
+		line := fmt.Sprintf(
+			"- 요약 | %s ~ %s | %s | %d건",
+			agg.First.Format("2006-01-02 15:04"),
+			agg.Last.Format("2006-01-02 15:04"),
+			eventType,
+			agg.Count,
+		)
+		if agg.AmountML > 0 {
+			line += fmt.Sprintf(", 총 %.0fml", agg.AmountML)
+		}
+		if agg.DurationMin > 0 {
+			line += fmt.Sprintf(", 총 %.0f분", agg.DurationMin)
+		}
+		lines = append(lines, line)
+	}
+	return lines, true
+}
+
 func (a *App) buildRequestedDateSummaryContext(
 	ctx context.Context,
 	childID string,
@@ -2428,7 +4078,7 @@ func (a *App) buildRequestedDateSummaryContext(
 
 	summaryLines := []string{
 		fmt.Sprintf("질문 우선 컨텍스트 (child_id=%s).", childID),
-		fmt.Sprintf("아동 프로필: 이름=%s, 생년월일=%s, 나이=%d일 (만 %d개월).", profileSnapshot.Name, birthDateText, profileSnapshot.AgeDays, profileSnapshot.AgeMonths),
+		fmt.Sprintf("아동 프로필: 이름=%s, 생년월일=%s, %s.", profileSnapshot.Name, birthDateText, profileAgeText(profileSnapshot)),
 		fmt.Sprintf("요청 날짜(%s)가 최근 3일을 초과하여 DailySummary 집계를 사용합니다.", targetDate.UTC().Format("2006-01-02")),
 	}
 	if onboardingLine := profileCareContextLine(profileSnapshot); onboardingLine != "" {
@@ -2456,6 +4106,44 @@ func (a *App) buildRequestedDateSummaryContext(
 	}, nil
 }
 
+// buildFutureRequestedDateContext answers a question about a day that has not
+// happened yet. There is nothing to look up, so no events or summaries are
+// queried; the context only carries the flag and the clarification directive.
+func buildFutureRequestedDateContext(
+	childID string,
+	nowUTC time.Time,
+	selection chatContextSelection,
+	profileSnapshot childProfileSnapshot,
+	birthDateText string,
+) chatContextResult {
+	targetDate := selection.RawStart
+	if selection.RequestedDate != nil {
+		targetDate = selection.RequestedDate.UTC()
+	}
+	targetText := targetDate.UTC().Format("2006-01-02")
+
+	meta := buildBaseProfileMeta(childID, profileSnapshot, birthDateText)
+	meta["time_range"] = chatContextModeRequestedDateFuture
+	meta["context_source"] = "none"
+	meta["reference_now_utc"] = nowUTC.Format(time.RFC3339)
+	meta["requested_date_utc"] = targetText
+	meta["requested_date_in_future"] = true
+	meta["evidence_event_ids"] = []string{}
+	meta["has_estimated_values"] = false
+	meta["has_missing_data"] = false
+
+	summaryLines := []string{
+		fmt.Sprintf("질문 우선 컨텍스트 (child_id=%s).", childID),
+		fmt.Sprintf("아동 프로필: 이름=%s, 생년월일=%s, %s.", profileSnapshot.Name, birthDateText, profileAgeText(profileSnapshot)),
+		fmt.Sprintf("요청 날짜(%s)는 현재 기준 시각(%s)보다 미래라서 아직 기록이 있을 수 없습니다.", targetText, formatContextTime(nowUTC)),
+		"- 기록을 조회하지 않았으며, 수치나 사건을 만들어내지 않습니다.",
+	}
+	return chatContextResult{
+		Meta:    meta,
+		Summary: strings.Join(summaryLines, "\n"),
+	}
+}
+
 func (a *App) buildWeeklySummaryContext(
 	ctx context.Context,
 	childID string,
@@ -2511,7 +4199,7 @@ func (a *App) buildWeeklySummaryContext(
 
 	summaryLines := []string{
 		fmt.Sprintf("질문 우선 컨텍스트 (child_id=%s).", childID),
-		fmt.Sprintf("아동 프로필: 이름=%s, 생년월일=%s, 나이=%d일 (만 %d개월).", profileSnapshot.Name, birthDateText, profileSnapshot.AgeDays, profileSnapshot.AgeMonths),
+		fmt.Sprintf("아동 프로필: 이름=%s, 생년월일=%s, %s.", profileSnapshot.Name, birthDateText, profileAgeText(profileSnapshot)),
 		"요청 범위가 최근 3일을 초과하여 WeeklySummary 집계를 사용합니다.",
 		"주간 집계 테이블(week_start_date | metrics_json | missingness_json):",
 	}
@@ -2594,7 +4282,7 @@ func (a *App) buildMonthlyMedicalSummaryContext(
 
 	summaryLines := []string{
 		fmt.Sprintf("질문 우선 컨텍스트 (child_id=%s).", childID),
-		fmt.Sprintf("아동 프로필: 이름=%s, 생년월일=%s, 나이=%d일 (만 %d개월).", profileSnapshot.Name, birthDateText, profileSnapshot.AgeDays, profileSnapshot.AgeMonths),
+		fmt.Sprintf("아동 프로필: 이름=%s, 생년월일=%s, %s.", profileSnapshot.Name, birthDateText, profileAgeText(profileSnapshot)),
 		"요청 범위가 최근 3일을 초과하여 MonthlyMedicalSummary 집계를 사용합니다.",
 		"월간 의료 집계 테이블(month | medical_timeline_json | missingness_json):",
 	}
@@ -2738,7 +4426,7 @@ func (a *App) buildMonthlyParentingRollupContext(
 
 	summaryLines := []string{
 		fmt.Sprintf("질문 우선 컨텍스트 (child_id=%s).", childID),
-		fmt.Sprintf("아동 프로필: 이름=%s, 생년월일=%s, 나이=%d일 (만 %d개월).", profileSnapshot.Name, birthDateText, profileSnapshot.AgeDays, profileSnapshot.AgeMonths),
+		fmt.Sprintf("아동 프로필: 이름=%s, 생년월일=%s, %s.", profileSnapshot.Name, birthDateText, profileAgeText(profileSnapshot)),
 		"요청 범위가 최근 3일을 초과하여 월간 육아 롤업(WeeklySummary + DailySummary)을 사용합니다.",
 		fmt.Sprintf("대상 월: %s", selection.MonthStart.UTC().Format("2006-01")),
 	}
@@ -2803,6 +4491,9 @@ func (a *App) loadChildProfileSnapshot(ctx context.Context, userID, childID stri
 		BirthDate:             startOfUTCDay(profile.BirthDate.UTC()),
 		AgeDays:               profile.AgeDays,
 		AgeMonths:             ageMonthsFromBirthDate(profile.BirthDate.UTC(), time.Now().UTC()),
+		CorrectedAgeDays:      profile.CorrectedAgeDays,
+		CorrectedAgeMonths:    ageMonthsFromBirthDate(correctedBirthDate(profile.BirthDate.UTC(), profile.GestationalWeeks), time.Now().UTC()),
+		GestationalWeeks:      profile.GestationalWeeks,
 		Sex:                   strings.TrimSpace(profile.Sex),
 		FeedingMethod:         strings.TrimSpace(profile.FeedingMethod),
 		FormulaBrand:          strings.TrimSpace(profile.FormulaBrand),
@@ -2844,6 +4535,7 @@ func (a *App) loadChildProfileSnapshot(ctx context.Context, userID, childID stri
 		`SELECT "startTime", "valueJson"::text
 		 FROM "Event"
 		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
 		   AND type = 'GROWTH'
 		 ORDER BY "startTime" DESC
 		 LIMIT 1`,
@@ -2885,25 +4577,6 @@ func (a *App) loadChildProfileSnapshot(ctx context.Context, userID, childID stri
 	return snapshot, nil
 }
 
-func ageMonthsFromBirthDate(birthDate, now time.Time) int {
-	if birthDate.IsZero() {
-		return 0
-	}
-	birthUTC := startOfUTCDay(birthDate.UTC())
-	nowUTC := startOfUTCDay(now.UTC())
-	if nowUTC.Before(birthUTC) {
-		return 0
-	}
-	months := (nowUTC.Year()-birthUTC.Year())*12 + int(nowUTC.Month()) - int(birthUTC.Month())
-	if nowUTC.Day() < birthUTC.Day() {
-		months--
-	}
-	if months < 0 {
-		return 0
-	}
-	return months
-}
-
 var (
 	htmlBreakTagPattern     = regexp.MustCompile(`(?i)<br\s*/?>`)
 	utcParenPattern         = regexp.MustCompile(`(?i)\(\s*UTC\s*\)`)
@@ -2983,15 +4656,21 @@ func buildChatSystemPrompt(
 	usePersonalData bool,
 	sessionMemorySummary string,
 	smalltalkStyleHint string,
+	preferredLanguage string,
 ) string {
 	toneValue := strings.TrimSpace(tone)
 	if toneValue == "" {
 		toneValue = "neutral"
 	}
 
+	languageLine := "모든 답변의 기본 언어는 한국어다. 사용자가 다른 언어를 명시적으로 요청할 때만 해당 언어를 사용한다."
+	if language, ok := translateTargetLanguages[normalizePreferredLanguage(preferredLanguage)]; ok {
+		languageLine = "이 세션은 " + language + " 답변을 선택했다. 아래 지침의 한국어 표현 규칙은 " + language + "에 맞게 적용하고, 모든 답변을 " + language + "로 작성한다."
+	}
+
 	lines := []string{
 		"너는 BabyAI이며, 보호자와 대화하는 따뜻하고 실용적인 육아 도우미다.",
-		"모든 답변의 기본 언어는 한국어다. 사용자가 다른 언어를 명시적으로 요청할 때만 해당 언어를 사용한다.",
+		languageLine,
 		"같은 세션의 이전 대화를 이어서 답하고, 단발성 답변처럼 끊지 않는다.",
 		"필요하면 직전 대화 맥락을 짧게 연결해 연속성을 유지한다.",
 		"사용자 노출 답변에서 UTC 같은 시간대 용어를 쓰지 않는다.",
@@ -3059,6 +4738,12 @@ func buildChatSystemPrompt(
 			"context.time_range가 monthly_parenting_rollup이면 WeeklySummary+DailySummary 롤업을 우선한다.",
 			"참고 컨텍스트: "+context.Summary,
 		)
+		if inFuture, _ := context.Meta["requested_date_in_future"].(bool); inFuture {
+			lines = append(lines,
+				"요청 날짜가 아직 오지 않은 미래 날짜다. 기록이 없다고만 답하지 말고, 그 날짜가 아직 지나지 않았다는 점을 먼저 알려준다.",
+				"이어서 혹시 다른 날짜(예: 작년 같은 날, 오늘)를 뜻했는지 한 문장으로 확인한다.",
+			)
+		}
 	} else {
 		lines = append(lines, "개인 기록이 비활성화된 질의이므로 일반 가이드만 제공한다.")
 	}
@@ -3146,52 +4831,58 @@ func creditMap(result billingResult) gin.H {
 		"billing_mode":     string(result.BillingMode),
 		"grace_used_today": result.GraceUsed,
 		"grace_limit":      result.GraceLimit,
+		"turn_limit":       result.TurnLimit,
 	}
 }
 
-func (a *App) writeChatExecutionError(c *gin.Context, err error) {
-	if err == nil {
-		return
+// isLowCreditBalance tells the client to prompt a top-up before the balance
+// runs out and the next query fails with 402.
+func (a *App) isLowCreditBalance(result billingResult) bool {
+	if a.cfg.AILowBalanceThreshold <= 0 {
+		return false
 	}
+	return result.BalanceAfter < a.cfg.AILowBalanceThreshold
+}
+
+// chatExecutionErrorResponse maps a runChatQuery error to the status and
+// JSON body the chat endpoints answer with.
+func chatExecutionErrorResponse(err error) (int, gin.H) {
 	var httpErr *chatHTTPError
 	if errors.As(err, &httpErr) {
+		body := gin.H{"detail": httpErr.Detail}
 		if httpErr.Credit != nil {
-			c.AbortWithStatusJSON(httpErr.Status, gin.H{
-				"detail": httpErr.Detail,
-				"credit": gin.H{
-					"balance":          httpErr.Credit.Balance,
-					"grace_used_today": httpErr.Credit.GraceUsed,
-					"grace_limit":      httpErr.Credit.GraceLimit,
-				},
-			})
-			return
+			body["credit"] = gin.H{
+				"balance":          httpErr.Credit.Balance,
+				"grace_used_today": httpErr.Credit.GraceUsed,
+				"grace_limit":      httpErr.Credit.GraceLimit,
+			}
 		}
-		writeError(c, httpErr.Status, httpErr.Detail)
-		return
-	}
-	lowered := strings.ToLower(strings.TrimSpace(err.Error()))
-	switch {
-	case strings.Contains(lowered, "openai_api_key is not configured"):
-		writeError(c, http.StatusServiceUnavailable, "AI provider is not configured: set OPENAI_API_KEY")
-		return
-	case strings.Contains(lowered, "openai responses error"):
-		writeError(c, http.StatusBadGateway, "AI provider request failed")
-		return
-	case strings.Contains(lowered, "context deadline exceeded"):
-		writeError(c, http.StatusBadGateway, "AI provider request timed out")
-		return
-	case strings.Contains(lowered, "openai response answer is empty"):
-		writeError(c, http.StatusBadGateway, "AI provider returned empty answer")
-		return
-	case strings.Contains(lowered, "openai response incomplete due max_output_tokens"):
-		writeError(c, http.StatusBadGateway, "AI provider response incomplete; increase AI_MAX_OUTPUT_TOKENS")
-		return
-	case strings.Contains(lowered, "ai response missing usage tokens"):
-		writeError(c, http.StatusBadGateway, "AI provider returned incomplete usage metadata")
+		return httpErr.Status, body
+	}
+	switch classifyAIProviderError(err) {
+	case aiOutcomeNotConfigured:
+		return http.StatusServiceUnavailable, gin.H{"detail": "AI provider is not configured: set OPENAI_API_KEY"}
+	case aiOutcomeProviderError:
+		return http.StatusBadGateway, gin.H{"detail": "AI provider request failed"}
+	case aiOutcomeTimeout:
+		return http.StatusBadGateway, gin.H{"detail": "AI provider request timed out"}
+	case aiOutcomeEmptyAnswer:
+		return http.StatusBadGateway, gin.H{"detail": "AI provider returned empty answer"}
+	case aiOutcomeIncomplete:
+		return http.StatusBadGateway, gin.H{"detail": "AI provider response incomplete; increase AI_MAX_OUTPUT_TOKENS"}
+	case aiOutcomeMissingUsage:
+		return http.StatusBadGateway, gin.H{"detail": "AI provider returned incomplete usage metadata"}
+	}
+	log.Printf("chat query failed unclassified err=%s", redactPII(truncateForLog(err.Error(), 600)))
+	return http.StatusInternalServerError, gin.H{"detail": "Failed to execute chat query"}
+}
+
+func (a *App) writeChatExecutionError(c *gin.Context, err error) {
+	if err == nil {
 		return
 	}
-	log.Printf("chat query failed unclassified err=%v", err)
-	writeError(c, http.StatusInternalServerError, "Failed to execute chat query")
+	status, body := chatExecutionErrorResponse(err)
+	c.AbortWithStatusJSON(status, body)
 }
 
 func buildSessionMemorySummary(existing string, turns []ChatTurn) string {
@@ -3225,7 +4916,9 @@ func normalizeMemoryContent(content string) string {
 	if compact == "" {
 		return ""
 	}
-	return truncateRunes(compact, chatMemoryLineCharMax)
+	// Redact before truncating so a cut cannot leave half a phone number
+	// that no longer matches.
+	return truncateRunes(redactPII(compact), chatMemoryLineCharMax)
 }
 
 func trimToRuneLimit(value string, limit int) string {
@@ -3299,6 +4992,156 @@ func sanitizeUserFacingAnswer(answer string) string {
 	return strings.TrimSpace(normalized)
 }
 
+// jargonTerm is an internal term the prompt forbids that is softened in the
+// final answer if the model uses it anyway. Replacement is used in Korean
+// answers and EnglishReplacement in every other language.
+type jargonTerm struct {
+	Term               string
+	Replacement        string
+	EnglishReplacement string
+	matcher            *regexp.Regexp
+}
+
+// newJargonTerm compiles the term's matcher once. ASCII terms only match on
+// word boundaries so "tokenize" or "JSONP" style substrings are left alone;
+// the captured spaces around a match let removals tidy only their own span.
+func newJargonTerm(term, replacement, englishReplacement string) jargonTerm {
+	pattern := regexp.QuoteMeta(term)
+	if isASCIIWord(term) {
+		pattern = `\b` + pattern + `\b`
+	}
+	return jargonTerm{
+		Term:               term,
+		Replacement:        replacement,
+		EnglishReplacement: englishReplacement,
+		matcher:            regexp.MustCompile(`(?i)([ \t]*)` + pattern + `([ \t]*)`),
+	}
+}
+
+func (t jargonTerm) replacementFor(korean bool) string {
+	if korean {
+		return t.Replacement
+	}
+	return t.EnglishReplacement
+}
+
+// defaultJargonTerms only lists phrases that are internal in any context, so
+// ordinary words like "prompt" or "token" are left alone. It is ordered
+// longest phrase first so "Event 테이블" wins over a bare "JSON" inside it.
+var defaultJargonTerms = []jargonTerm{
+	newJargonTerm("Event 테이블", "기록", "records"),
+	newJargonTerm("이벤트 테이블", "기록", "records"),
+	newJargonTerm("Event table", "기록", "records"),
+	newJargonTerm("시스템 프롬프트", "", ""),
+	newJargonTerm("system prompt", "", ""),
+	newJargonTerm("completion tokens", "", ""),
+	newJargonTerm("prompt tokens", "", ""),
+	newJargonTerm("언어 모델", "AI", "AI"),
+	newJargonTerm("language model", "AI", "AI"),
+	newJargonTerm("AI 모델", "AI", "AI"),
+	newJargonTerm("AI model", "AI", "AI"),
+	newJargonTerm("JSON", "기록", "records"),
+	newJargonTerm("스키마", "기록 형식", "record format"),
+	newJargonTerm("schema", "기록 형식", "record format"),
+}
+
+// parseJargonTerms reads "term=replacement" entries; a bare term or an empty
+// replacement removes the term. Configured replacements apply in every
+// language.
+func parseJargonTerms(entries []string) []jargonTerm {
+	terms := make([]jargonTerm, 0, len(entries))
+	for _, entry := range entries {
+		term, replacement, _ := strings.Cut(entry, "=")
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		replacement = strings.TrimSpace(replacement)
+		terms = append(terms, newJargonTerm(term, replacement, replacement))
+	}
+	return terms
+}
+
+func (a *App) answerJargonTerms() []jargonTerm {
+	if len(a.jargonTerms) > 0 {
+		return a.jargonTerms
+	}
+	return defaultJargonTerms
+}
+
+// isMostlyKoreanText treats text as Korean when Hangul syllables are at least
+// half as common as Latin letters, so a Korean name in an English answer does
+// not switch the replacement language.
+func isMostlyKoreanText(text string) bool {
+	hangul, latin := 0, 0
+	for _, r := range text {
+		switch {
+		case r >= 0xAC00 && r <= 0xD7A3:
+			hangul++
+		case r <= unicode.MaxASCII && unicode.IsLetter(r):
+			latin++
+		}
+	}
+	return hangul > 0 && hangul*2 >= latin
+}
+
+// softenInternalJargon replaces internal terms case-insensitively and returns
+// the terms it found so callers can flag the answer. Replacements follow the
+// answer's language, and only the spaces around a replaced span are tidied
+// so the rest of the answer keeps its formatting.
+func softenInternalJargon(answer string, terms []jargonTerm) (string, []string) {
+	found := make([]string, 0, 2)
+	korean := isMostlyKoreanText(answer)
+	for _, term := range terms {
+		if !term.matcher.MatchString(answer) {
+			continue
+		}
+		answer = replaceJargonSpans(answer, term.matcher, term.replacementFor(korean))
+		found = append(found, term.Term)
+	}
+	if len(found) == 0 {
+		return answer, found
+	}
+	return strings.TrimSpace(answer), found
+}
+
+// replaceJargonSpans swaps each match for replacement. A removed term takes
+// one of its surrounding spaces with it, and no space is left before the
+// punctuation or line end that followed it. Indentation at the start of a
+// line is kept.
+func replaceJargonSpans(answer string, matcher *regexp.Regexp, replacement string) string {
+	var builder strings.Builder
+	last := 0
+	for _, match := range matcher.FindAllStringSubmatchIndex(answer, -1) {
+		start, end := match[0], match[1]
+		lead := answer[match[2]:match[3]]
+		trail := answer[match[4]:match[5]]
+		builder.WriteString(answer[last:start])
+		last = end
+
+		if replacement != "" {
+			builder.WriteString(lead + replacement + trail)
+			continue
+		}
+		atLineStart := start == 0 || answer[start-1] == '\n'
+		atClause := end == len(answer) || strings.ContainsRune(".,!?;:)\n", rune(answer[end]))
+		if lead != "" && (atLineStart || !atClause) {
+			builder.WriteString(lead)
+		}
+	}
+	builder.WriteString(answer[last:])
+	return builder.String()
+}
+
+func isASCIIWord(value string) bool {
+	for _, r := range value {
+		if r > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
 func normalizeUserFacingDateTimes(input string) string {
 	return rfc3339DateTimePattern.ReplaceAllStringFunc(input, func(raw string) string {
 		if parsed, ok := parseRFC3339DateTime(raw); ok {
@@ -3328,7 +5171,9 @@ func parseRFC3339DateTime(raw string) (time.Time, bool) {
 	return time.Time{}, false
 }
 
-func sanitizeSmalltalkAnswer(answer string) string {
+// sanitizeSmalltalkAnswer flattens markdown headings and lists into one line
+// and caps it at maxRunes, cutting at a sentence or word boundary.
+func sanitizeSmalltalkAnswer(answer string, maxRunes int) string {
 	trimmed := strings.TrimSpace(answer)
 	if trimmed == "" {
 		return ""
@@ -3355,7 +5200,34 @@ func sanitizeSmalltalkAnswer(answer string) string {
 	if merged == "" {
 		merged = strings.Join(strings.Fields(trimmed), " ")
 	}
-	return truncateRunes(merged, smalltalkReplyRuneMax)
+	return truncateRunesAtBoundary(merged, maxRunes)
+}
+
+// truncateRunesAtBoundary caps value at max runes. It prefers ending after the
+// last sentence terminator, then before the last space, as long as that keeps
+// at least half of the allowed length; otherwise it cuts at max. Cuts are
+// rune-based, so a multi-byte character is never split.
+func truncateRunesAtBoundary(value string, max int) string {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" || max <= 0 {
+		return ""
+	}
+	runes := []rune(trimmed)
+	if len(runes) <= max {
+		return trimmed
+	}
+	floor := max / 2
+	for idx := max - 1; idx >= floor; idx-- {
+		if strings.ContainsRune(".!?。~…", runes[idx]) && unicode.IsSpace(runes[idx+1]) {
+			return string(runes[:idx+1])
+		}
+	}
+	for idx := max; idx >= floor; idx-- {
+		if unicode.IsSpace(runes[idx]) {
+			return strings.TrimSpace(string(runes[:idx])) + "..."
+		}
+	}
+	return strings.TrimSpace(string(runes[:max])) + "..."
 }
 
 func enforceAnswerEvidenceGuide(answer string) string {
@@ -3598,17 +5470,23 @@ func stripSmalltalkListPrefix(line string) string {
 	return trimmed
 }
 
+// smalltalkToneSignals are the counts behind a smalltalk style hint.
+type smalltalkToneSignals struct {
+	SampleCount   int  `json:"sample_count"`
+	FormalScore   int  `json:"formal_score"`
+	CasualScore   int  `json:"casual_score"`
+	EmojiScore    int  `json:"emoji_score"`
+	AvgSampleLen  int  `json:"avg_sample_len"`
+	HasHangulText bool `json:"has_hangul"`
+}
+
 func deriveSmalltalkStyleHint(turns []ChatTurn, latestQuestion string) string {
-	samples := collectUserToneSamples(turns, latestQuestion, 8)
-	if len(samples) == 0 {
-		return ""
-	}
+	return smalltalkStyleHintFromSignals(computeSmalltalkToneSignals(collectUserToneSamples(turns, latestQuestion, 8)))
+}
 
-	formalScore := 0
-	casualScore := 0
-	emojiLike := 0
+func computeSmalltalkToneSignals(samples []string) smalltalkToneSignals {
+	signals := smalltalkToneSignals{SampleCount: len(samples)}
 	totalRunes := 0
-	hasHangul := false
 
 	for _, sample := range samples {
 		text := strings.TrimSpace(sample)
@@ -3622,50 +5500,56 @@ func deriveSmalltalkStyleHint(turns []ChatTurn, latestQuestion string) string {
 			"합니다", "습니다", "요", "해주세요", "부탁", "괜찮을까요", "인가요",
 			"please", "could you", "would you",
 		}) {
-			formalScore++
+			signals.FormalScore++
 		}
 		if containsAnyKeyword(lowered, []string{
 			"해줘", "줘", "ㅋㅋ", "ㅎㅎ", "ㅠㅠ", "ㅜㅜ", "~",
 			"lol", "haha", "pls", "thx",
 		}) {
-			casualScore++
+			signals.CasualScore++
 		}
 		if containsEmojiHint(text) {
-			emojiLike++
+			signals.EmojiScore++
 		}
 		if hasHangulText(text) {
-			hasHangul = true
+			signals.HasHangulText = true
 		}
 	}
 
-	avgLen := 0
 	if len(samples) > 0 {
-		avgLen = totalRunes / len(samples)
+		signals.AvgSampleLen = totalRunes / len(samples)
+	}
+	return signals
+}
+
+func smalltalkStyleHintFromSignals(signals smalltalkToneSignals) string {
+	if signals.SampleCount == 0 {
+		return ""
 	}
 
 	hints := []string{
 		"사용자 말투를 자연스럽게 맞추고 따뜻한 톤을 유지하세요.",
 	}
-	if hasHangul {
+	if signals.HasHangulText {
 		switch {
-		case formalScore >= casualScore+1:
+		case signals.FormalScore >= signals.CasualScore+1:
 			hints = append(hints, "존댓말 어미를 안정적으로 사용하세요.")
-		case casualScore >= formalScore+1:
+		case signals.CasualScore >= signals.FormalScore+1:
 			hints = append(hints, "사용자와 비슷한 구어체 한국어를 쓰되 예의는 유지하세요.")
 		default:
 			hints = append(hints, "친근하지만 균형 잡힌 한국어 톤을 사용하세요.")
 		}
 	} else {
-		if formalScore >= casualScore+1 {
+		if signals.FormalScore >= signals.CasualScore+1 {
 			hints = append(hints, "정중하고 배려 있는 표현을 사용하세요.")
 		} else {
 			hints = append(hints, "가볍고 친근한 표현을 사용하세요.")
 		}
 	}
-	if avgLen > 0 && avgLen <= 30 {
+	if signals.AvgSampleLen > 0 && signals.AvgSampleLen <= 30 {
 		hints = append(hints, "짧은 문장을 우선하세요.")
 	}
-	if emojiLike > 0 {
+	if signals.EmojiScore > 0 {
 		hints = append(hints, "맥락에 맞으면 이모지는 가볍게만 사용하세요.")
 	}
 	return strings.Join(hints, " ")
@@ -3728,6 +5612,15 @@ func normalizeSessionPreview(input *string) string {
 	return strings.TrimSpace(normalized[:maxLen]) + "..."
 }
 
+// sessionTitle prefers a title the user set explicitly and otherwise derives
+// one from the first user message.
+func sessionTitle(storedTitle, firstUserInput *string) string {
+	if storedTitle != nil && strings.TrimSpace(*storedTitle) != "" {
+		return strings.TrimSpace(*storedTitle)
+	}
+	return deriveSessionTitle(firstUserInput)
+}
+
 func deriveSessionTitle(firstUserInput *string) string {
 	if firstUserInput == nil {
 		return "New conversation"
@@ -0,0 +1,157 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	chatExportLocalTimeLayout = "2006-01-02 15:04"
+	// chatExportFlushEvery bounds how many messages sit in the buffer before
+	// they are sent to the client.
+	chatExportFlushEvery = 50
+)
+
+// chatExportFormats maps the accepted format values to their content type
+// and file extension.
+var chatExportFormats = map[string]struct {
+	ContentType string
+	Extension   string
+}{
+	"md":  {ContentType: "text/markdown; charset=utf-8", Extension: "md"},
+	"txt": {ContentType: "text/plain; charset=utf-8", Extension: "txt"},
+}
+
+func chatExportRoleLabel(role string) string {
+	switch strings.ToLower(strings.TrimSpace(role)) {
+	case "user":
+		return "Parent"
+	case "assistant":
+		return "Assistant"
+	case "system":
+		return "System"
+	default:
+		return strings.TrimSpace(role)
+	}
+}
+
+func chatExportHeader(format, title string, startedAt time.Time, loc *time.Location, tzNormalized string) string {
+	started := startedAt.In(loc).Format(chatExportLocalTimeLayout)
+	if format == "md" {
+		return fmt.Sprintf("# %s\n\n_Started %s (UTC%s)_\n\n", title, started, tzNormalized)
+	}
+	return fmt.Sprintf("%s\nStarted %s (UTC%s)\n\n", title, started, tzNormalized)
+}
+
+func chatExportMessage(format, role, content string, createdAt time.Time, loc *time.Location) string {
+	label := chatExportRoleLabel(role)
+	timestamp := createdAt.In(loc).Format(chatExportLocalTimeLayout)
+	content = strings.TrimSpace(strings.ReplaceAll(content, "\r\n", "\n"))
+	if format == "md" {
+		return fmt.Sprintf("### %s · %s\n\n%s\n\n", label, timestamp, content)
+	}
+	return fmt.Sprintf("[%s] %s:\n%s\n\n", timestamp, label, content)
+}
+
+// exportChatSession streams a chat session as a readable transcript for
+// parents to keep. Only role, time and content are written; context_json
+// stays internal.
+func (a *App) exportChatSession(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	sessionID := strings.TrimSpace(c.Param("session_id"))
+	if sessionID == "" {
+		writeError(c, http.StatusBadRequest, "session_id is required")
+		return
+	}
+	format := strings.ToLower(strings.TrimSpace(c.DefaultQuery("format", "md")))
+	formatSpec, ok := chatExportFormats[format]
+	if !ok {
+		writeError(c, http.StatusBadRequest, "format must be md or txt")
+		return
+	}
+	loc, tzNormalized, err := parseTZOffset(c.Query("tz_offset"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	session, err := a.loadChatSessionForUser(c.Request.Context(), user.ID, sessionID)
+	if err != nil {
+		a.writeChatExecutionError(c, err)
+		return
+	}
+	_, firstContent, _, err := a.loadFirstUserMessageIntent(c.Request.Context(), session.ID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load chat messages")
+		return
+	}
+	var firstUserInput *string
+	if firstContent != "" {
+		firstUserInput = &firstContent
+	}
+	title := sessionTitle(session.Title, firstUserInput)
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT role, content, "createdAt"
+		 FROM "ChatMessage"
+		 WHERE "sessionId" = $1
+		 ORDER BY "createdAt" ASC`,
+		session.ID,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load chat messages")
+		return
+	}
+	defer rows.Close()
+
+	dateText := session.StartedAt.In(loc).Format("2006-01-02")
+	c.Header("Content-Type", formatSpec.ContentType)
+	c.Header("Content-Disposition", fmt.Sprintf(
+		"attachment; filename=\"%s\"; filename*=UTF-8''%s",
+		fmt.Sprintf("babyai_chat_%s_%s.%s", sanitizeCSVFilename(title), dateText, formatSpec.Extension),
+		url.PathEscape(fmt.Sprintf("babyai_chat_%s_%s.%s", strings.TrimSpace(title), dateText, formatSpec.Extension)),
+	))
+	c.Status(http.StatusOK)
+
+	// Headers are sent with the first flush, so failures past this point can
+	// only cut the transcript short.
+	writer := bufio.NewWriter(c.Writer)
+	if _, err := writer.WriteString(chatExportHeader(format, title, session.StartedAt, loc, tzNormalized)); err != nil {
+		return
+	}
+	written := 0
+	for rows.Next() {
+		var role, content string
+		var createdAt time.Time
+		if err := rows.Scan(&role, &content, &createdAt); err != nil {
+			log.Printf("chat export scan failed session_id=%s: %v", session.ID, err)
+			break
+		}
+		if _, err := writer.WriteString(chatExportMessage(format, role, content, createdAt, loc)); err != nil {
+			log.Printf("chat export write failed session_id=%s: %v", session.ID, err)
+			return
+		}
+		written++
+		if written%chatExportFlushEvery == 0 {
+			writer.Flush()
+			c.Writer.Flush()
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("chat export read failed session_id=%s: %v", session.ID, err)
+	}
+	writer.Flush()
+}
@@ -0,0 +1,183 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	chatSearchDefaultLimit = 20
+	chatSearchMaxLimit     = 50
+	chatSearchMaxQueryLen  = 100
+	// chatSearchSnippetContext is how many characters of the message are kept
+	// on each side of the first match.
+	chatSearchSnippetContext = 60
+)
+
+var chatSearchLikeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// chatSearchHighlight marks one match in a snippet. Offsets count characters
+// (runes), not bytes.
+type chatSearchHighlight struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// chatSearchSnippet trims content to the text around the first
+// case-insensitive match of query and returns every match inside it.
+func chatSearchSnippet(content, query string) (string, []chatSearchHighlight) {
+	contentRunes := []rune(content)
+	queryRunes := []rune(query)
+	matches := findFoldedRunes(contentRunes, queryRunes)
+	if len(matches) == 0 {
+		if len(contentRunes) > chatSearchSnippetContext*2 {
+			return string(contentRunes[:chatSearchSnippetContext*2]) + "…", []chatSearchHighlight{}
+		}
+		return content, []chatSearchHighlight{}
+	}
+
+	start := matches[0] - chatSearchSnippetContext
+	prefix := "…"
+	if start <= 0 {
+		start = 0
+		prefix = ""
+	}
+	end := matches[0] + len(queryRunes) + chatSearchSnippetContext
+	suffix := "…"
+	if end >= len(contentRunes) {
+		end = len(contentRunes)
+		suffix = ""
+	}
+
+	offset := utf8.RuneCountInString(prefix) - start
+	highlights := make([]chatSearchHighlight, 0, len(matches))
+	for _, match := range matches {
+		if match < start || match+len(queryRunes) > end {
+			continue
+		}
+		highlights = append(highlights, chatSearchHighlight{
+			Start: match + offset,
+			End:   match + len(queryRunes) + offset,
+		})
+	}
+	return prefix + string(contentRunes[start:end]) + suffix, highlights
+}
+
+// findFoldedRunes returns the rune index of every non-overlapping
+// case-insensitive occurrence of needle in haystack.
+func findFoldedRunes(haystack, needle []rune) []int {
+	if len(needle) == 0 {
+		return nil
+	}
+	matches := make([]int, 0, 1)
+	for idx := 0; idx+len(needle) <= len(haystack); idx++ {
+		matched := true
+		for offset, r := range needle {
+			if unicode.ToLower(haystack[idx+offset]) != unicode.ToLower(r) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			matches = append(matches, idx)
+			idx += len(needle) - 1
+		}
+	}
+	return matches
+}
+
+// searchChatMessages finds the caller's chat messages containing q, newest
+// first. Sessions in households the caller has left are not searched.
+func (a *App) searchChatMessages(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		writeError(c, http.StatusBadRequest, "q is required")
+		return
+	}
+	if utf8.RuneCountInString(query) > chatSearchMaxQueryLen {
+		writeError(c, http.StatusBadRequest, fmt.Sprintf("q must be at most %d characters", chatSearchMaxQueryLen))
+		return
+	}
+	limit := chatSearchDefaultLimit
+	if raw := strings.TrimSpace(c.Query("limit")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			writeError(c, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		if parsed > chatSearchMaxLimit {
+			parsed = chatSearchMaxLimit
+		}
+		limit = parsed
+	}
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT m.id, m."sessionId", m.role, m.content, m."createdAt", s."childId", s.title
+		 FROM "ChatMessage" m
+		 JOIN "ChatSession" s ON s.id = m."sessionId"
+		 JOIN "Household" h ON h.id = s."householdId"
+		 WHERE s."userId" = $1
+		   AND m.content ILIKE '%' || $2 || '%' ESCAPE '\'
+		   AND (
+		     h."ownerUserId" = $1
+		     OR EXISTS (
+		       SELECT 1 FROM "HouseholdMember" hm
+		       WHERE hm."householdId" = s."householdId"
+		         AND hm."userId" = $1
+		         AND hm.status = 'ACTIVE'
+		     )
+		   )
+		 ORDER BY m."createdAt" DESC, m.id DESC
+		 LIMIT $3`,
+		user.ID,
+		chatSearchLikeEscaper.Replace(query),
+		limit,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to search chat messages")
+		return
+	}
+	defer rows.Close()
+
+	results := make([]gin.H, 0)
+	for rows.Next() {
+		var messageID, sessionID, role, content string
+		var createdAt time.Time
+		var childID, title *string
+		if err := rows.Scan(&messageID, &sessionID, &role, &content, &createdAt, &childID, &title); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to parse chat messages")
+			return
+		}
+		snippet, highlights := chatSearchSnippet(content, query)
+		results = append(results, gin.H{
+			"session_id":    sessionID,
+			"session_title": title,
+			"child_id":      childID,
+			"message_id":    messageID,
+			"role":          role,
+			"snippet":       snippet,
+			"highlights":    highlights,
+			"created_at":    createdAt.UTC().Format(time.RFC3339),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse chat messages")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"query": query, "results": results})
+}
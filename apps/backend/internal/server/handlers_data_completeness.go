@@ -0,0 +1,192 @@
+package server
+
+import (
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	completenessWindowDays        = 7
+	completenessUnderLoggedCutoff = 60
+)
+
+// completenessCategory describes one logging category the score looks at.
+// ExpectedPerDay is a low bar for a "fully logged" day, not a care target.
+type completenessCategory struct {
+	Key            string
+	EventTypes     []string
+	ExpectedPerDay int
+	Weight         float64
+}
+
+var completenessCategories = []completenessCategory{
+	{Key: "feeding", EventTypes: []string{"FORMULA", "BREASTFEED"}, ExpectedPerDay: 4, Weight: 0.4},
+	{Key: "sleep", EventTypes: []string{"SLEEP"}, ExpectedPerDay: 1, Weight: 0.3},
+	{Key: "diaper", EventTypes: []string{"PEE", "POO"}, ExpectedPerDay: 3, Weight: 0.3},
+}
+
+type completenessCategoryScore struct {
+	Category    string `json:"category"`
+	Score       int    `json:"score"`
+	DaysLogged  int    `json:"days_logged"`
+	TotalEvents int    `json:"total_events"`
+	UnderLogged bool   `json:"under_logged"`
+}
+
+type completenessScore struct {
+	Score       int                         `json:"score"`
+	Categories  []completenessCategoryScore `json:"categories"`
+	UnderLogged []string                    `json:"under_logged"`
+}
+
+// scoreDataCompleteness turns per-day event counts (keyed by category, one
+// slot per day of the window) into a 0-100 score. Each category blends
+// coverage (how many days have any log) with consistency (how close each day
+// gets to the expected count), so a burst of logs on one day scores lower
+// than steady logging across the week.
+func scoreDataCompleteness(dailyCounts map[string][]int, windowDays int) completenessScore {
+	result := completenessScore{
+		Categories:  make([]completenessCategoryScore, 0, len(completenessCategories)),
+		UnderLogged: []string{},
+	}
+	if windowDays <= 0 {
+		return result
+	}
+
+	weighted := 0.0
+	totalWeight := 0.0
+	for _, category := range completenessCategories {
+		counts := dailyCounts[category.Key]
+		daysLogged := 0
+		totalEvents := 0
+		consistency := 0.0
+		for day := 0; day < windowDays && day < len(counts); day++ {
+			count := counts[day]
+			if count <= 0 {
+				continue
+			}
+			daysLogged++
+			totalEvents += count
+			consistency += math.Min(float64(count)/float64(category.ExpectedPerDay), 1)
+		}
+		coverage := float64(daysLogged) / float64(windowDays)
+		consistency /= float64(windowDays)
+		categoryScore := int(math.Round((coverage*0.6 + consistency*0.4) * 100))
+		underLogged := categoryScore < completenessUnderLoggedCutoff
+
+		result.Categories = append(result.Categories, completenessCategoryScore{
+			Category:    category.Key,
+			Score:       categoryScore,
+			DaysLogged:  daysLogged,
+			TotalEvents: totalEvents,
+			UnderLogged: underLogged,
+		})
+		if underLogged {
+			result.UnderLogged = append(result.UnderLogged, category.Key)
+		}
+		weighted += float64(categoryScore) * category.Weight
+		totalWeight += category.Weight
+	}
+	if totalWeight > 0 {
+		result.Score = int(math.Round(weighted / totalWeight))
+	}
+	return result
+}
+
+func completenessCategoryForEventType(eventType string) string {
+	for _, category := range completenessCategories {
+		for _, candidate := range category.EventTypes {
+			if candidate == eventType {
+				return category.Key
+			}
+		}
+	}
+	return ""
+}
+
+func (a *App) getDataCompleteness(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	localZone, tzNormalized, err := parseTZOffset(c.Query("tz_offset"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, c.Param("baby_id"), readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	localNow := time.Now().In(localZone)
+	todayStart := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, localZone)
+	windowStart := todayStart.AddDate(0, 0, -(completenessWindowDays - 1))
+	windowEnd := todayStart.AddDate(0, 0, 1)
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT type, "startTime"
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND "startTime" >= $2
+		   AND "startTime" < $3
+		   AND NOT (`+openEventPredicateSQL+`)
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'`,
+		baby.ID,
+		windowStart.UTC(),
+		windowEnd.UTC(),
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load events")
+		return
+	}
+	defer rows.Close()
+
+	dailyCounts := make(map[string][]int, len(completenessCategories))
+	for _, category := range completenessCategories {
+		dailyCounts[category.Key] = make([]int, completenessWindowDays)
+	}
+	for rows.Next() {
+		var eventType string
+		var startedAt time.Time
+		if err := rows.Scan(&eventType, &startedAt); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to parse events")
+			return
+		}
+		category := completenessCategoryForEventType(eventType)
+		if category == "" {
+			continue
+		}
+		local := startedAt.In(localZone)
+		localDay := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, localZone)
+		dayIndex := int(math.Round(localDay.Sub(windowStart).Hours() / 24))
+		if dayIndex < 0 || dayIndex >= completenessWindowDays {
+			continue
+		}
+		dailyCounts[category][dayIndex]++
+	}
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse events")
+		return
+	}
+
+	score := scoreDataCompleteness(dailyCounts, completenessWindowDays)
+	c.JSON(http.StatusOK, gin.H{
+		"baby_id":      baby.ID,
+		"tz_offset":    tzNormalized,
+		"window_start": windowStart.Format("2006-01-02"),
+		"window_end":   todayStart.Format("2006-01-02"),
+		"window_days":  completenessWindowDays,
+		"score":        score.Score,
+		"categories":   score.Categories,
+		"under_logged": score.UnderLogged,
+	})
+}
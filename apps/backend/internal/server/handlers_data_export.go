@@ -5,13 +5,25 @@ import (
 	"encoding/csv"
 	"errors"
 	"fmt"
+	"log"
+	"math"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	eventExportDefaultRangeDays = 30
+	eventExportLocalTimeLayout  = "2006-01-02 15:04"
+	// eventExportFlushEvery bounds how many rows sit in the writer's buffer
+	// before they are sent to the client.
+	eventExportFlushEvery = 200
+)
+
 func sanitizeCSVFilename(input string) string {
 	trimmed := strings.TrimSpace(input)
 	if trimmed == "" {
@@ -76,8 +88,11 @@ func (a *App) exportBabyDataCSV(c *gin.Context) {
 			"createdAt"
 		FROM "Event"
 		WHERE "babyId" = $1
+		  AND "deletedAt" IS NULL
+		  AND `+eventVisibleToUserSQL("$2")+`
 		ORDER BY "startTime" ASC, "createdAt" ASC`,
 		baby.ID,
+		user.ID,
 	)
 	if err != nil {
 		writeError(c, http.StatusInternalServerError, "Failed to load events")
@@ -167,6 +182,158 @@ func (a *App) exportBabyDataCSV(c *gin.Context) {
 	c.String(http.StatusOK, out.String())
 }
 
+// exportEventsCSV streams a baby's events as a spreadsheet for the
+// pediatrician: one row per event with times in the caller's tz_offset and
+// the value flattened to an amount and a memo. from/to are local dates and
+// default to the last 30 days.
+func (a *App) exportEventsCSV(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	babyID := strings.TrimSpace(c.Query("baby_id"))
+	if babyID == "" {
+		writeError(c, http.StatusBadRequest, "baby_id is required")
+		return
+	}
+	loc, _, err := parseTZOffset(c.Query("tz_offset"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	localNow := time.Now().In(loc)
+	toDate := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, loc)
+	if raw := strings.TrimSpace(c.Query("to")); raw != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", raw, loc)
+		if err != nil {
+			writeError(c, http.StatusBadRequest, "to must be YYYY-MM-DD")
+			return
+		}
+		toDate = parsed
+	}
+	fromDate := toDate.AddDate(0, 0, -(eventExportDefaultRangeDays - 1))
+	if raw := strings.TrimSpace(c.Query("from")); raw != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", raw, loc)
+		if err != nil {
+			writeError(c, http.StatusBadRequest, "from must be YYYY-MM-DD")
+			return
+		}
+		fromDate = parsed
+	}
+	if fromDate.After(toDate) {
+		writeError(c, http.StatusBadRequest, "from must be on or before to")
+		return
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, babyID, readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+	var babyName string
+	if err := a.db.QueryRow(
+		c.Request.Context(),
+		`SELECT name FROM "Baby" WHERE id = $1`,
+		baby.ID,
+	).Scan(&babyName); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load baby")
+		return
+	}
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT type::text, "startTime", "endTime", COALESCE("valueJson", '{}'::jsonb), source::text
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
+		   AND `+eventVisibleToUserSQL("$2")+`
+		   AND "startTime" >= $3
+		   AND "startTime" < $4
+		 ORDER BY "startTime" ASC, id ASC`,
+		baby.ID,
+		user.ID,
+		fromDate.UTC(),
+		toDate.AddDate(0, 0, 1).UTC(),
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load events")
+		return
+	}
+	defer rows.Close()
+
+	fromText := fromDate.Format("2006-01-02")
+	toText := toDate.Format("2006-01-02")
+	filename := fmt.Sprintf("babyai_events_%s_%s_%s.csv", sanitizeCSVFilename(babyName), fromText, toText)
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(
+		"attachment; filename=\"%s\"; filename*=UTF-8''%s",
+		filename,
+		url.PathEscape(fmt.Sprintf("babyai_events_%s_%s_%s.csv", strings.TrimSpace(babyName), fromText, toText)),
+	))
+	c.Status(http.StatusOK)
+
+	// Headers are sent with the first flush, so failures past this point can
+	// only cut the file short.
+	writer := csv.NewWriter(c.Writer)
+	if err := writer.Write([]string{"type", "start_local", "end_local", "duration_min", "amount_ml", "memo", "source"}); err != nil {
+		return
+	}
+	written := 0
+	for rows.Next() {
+		var (
+			eventType string
+			startTime time.Time
+			endTime   *time.Time
+			valueRaw  []byte
+			source    string
+		)
+		if err := rows.Scan(&eventType, &startTime, &endTime, &valueRaw, &source); err != nil {
+			log.Printf("event export scan failed baby_id=%s: %v", baby.ID, err)
+			break
+		}
+		if err := writer.Write(eventExportRow(eventType, startTime, endTime, parseJSONStringMap(valueRaw), source, loc)); err != nil {
+			log.Printf("event export write failed baby_id=%s: %v", baby.ID, err)
+			return
+		}
+		written++
+		if written%eventExportFlushEvery == 0 {
+			writer.Flush()
+			c.Writer.Flush()
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("event export read failed baby_id=%s: %v", baby.ID, err)
+	}
+	writer.Flush()
+}
+
+func eventExportRow(eventType string, startTime time.Time, endTime *time.Time, value map[string]any, source string, loc *time.Location) []string {
+	endLocal := ""
+	if endTime != nil {
+		endLocal = endTime.In(loc).Format(eventExportLocalTimeLayout)
+	}
+	durationText := ""
+	if duration := extractDurationMinutes(value, startTime, endTime); duration != nil {
+		durationText = strconv.Itoa(int(math.Round(*duration)))
+	}
+	amountText := ""
+	if amount := extractNumberFromMap(value, "ml", "amount_ml", "volume_ml"); amount > 0 {
+		amountText = strconv.FormatFloat(math.Round(amount*10)/10, 'f', -1, 64)
+	}
+	return []string{
+		eventType,
+		startTime.In(loc).Format(eventExportLocalTimeLayout),
+		endLocal,
+		durationText,
+		amountText,
+		extractMemoText(value),
+		source,
+	}
+}
+
 func ensureCSVContainsHeader(raw string) error {
 	if strings.TrimSpace(raw) == "" {
 		return errors.New("empty csv")
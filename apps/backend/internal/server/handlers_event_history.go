@@ -0,0 +1,101 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+const eventHistoryMaxEntries = 200
+
+// getEventHistory lists the audit-log entries written for one event, oldest
+// first, so caregivers sharing a baby can see who changed a record.
+func (a *App) getEventHistory(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventID := strings.TrimSpace(c.Param("event_id"))
+	if eventID == "" {
+		writeError(c, http.StatusBadRequest, "event_id is required")
+		return
+	}
+
+	var eventBabyID string
+	err := a.db.QueryRow(
+		c.Request.Context(),
+		`SELECT "babyId" FROM "Event" WHERE id = $1 AND `+eventVisibleToUserSQL("$2"),
+		eventID,
+		user.ID,
+	).Scan(&eventBabyID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		writeError(c, http.StatusNotFound, "Event not found")
+		return
+	}
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load event")
+		return
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, eventBabyID, readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT log.id, log."actorUserId", actor.name, log.action, log."payloadJson", log."createdAt"
+		 FROM "AuditLog" log
+		 LEFT JOIN "User" actor ON actor.id = log."actorUserId"
+		 WHERE log."householdId" = $1
+		   AND log."targetType" = 'Event'
+		   AND log."targetId" = $2
+		 ORDER BY log."createdAt" ASC, log.id ASC
+		 LIMIT $3`,
+		baby.HouseholdID,
+		eventID,
+		eventHistoryMaxEntries,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load event history")
+		return
+	}
+	defer rows.Close()
+
+	entries := make([]gin.H, 0)
+	for rows.Next() {
+		var logID, action string
+		var actorUserID, actorName *string
+		var payloadRaw []byte
+		var createdAt time.Time
+		if err := rows.Scan(&logID, &actorUserID, &actorName, &action, &payloadRaw, &createdAt); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to parse event history")
+			return
+		}
+		entries = append(entries, gin.H{
+			"id":            logID,
+			"actor_user_id": actorUserID,
+			"actor_name":    actorName,
+			"action":        action,
+			"created_at":    createdAt.UTC().Format(time.RFC3339),
+			"detail":        parseJSONStringMap(payloadRaw),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse event history")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"event_id": eventID,
+		"baby_id":  baby.ID,
+		"entries":  entries,
+	})
+}
@@ -0,0 +1,188 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	eventListPageDefault = 50
+	eventListPageMax     = 200
+)
+
+// eventStateFromRecord mirrors openEventPredicateSQL: an event without an end
+// that was started from the start flow is still OPEN.
+func eventStateFromRecord(endTime *time.Time, metadata map[string]any) string {
+	state := strings.ToUpper(strings.TrimSpace(toString(metadata["event_state"])))
+	if state == "CANCELED" {
+		return "CANCELED"
+	}
+	entryMode := strings.ToLower(strings.TrimSpace(toString(metadata["entry_mode"])))
+	if endTime == nil && (state == "OPEN" || entryMode == "manual_start") {
+		return "OPEN"
+	}
+	return "CLOSED"
+}
+
+// listEvents pages through a baby's OPEN and CLOSED events newest first. The
+// cursor is the last event_id of the previous page, so pages stay stable when
+// several events share a start time.
+func (a *App) listEvents(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	babyID := strings.TrimSpace(c.Query("baby_id"))
+	if babyID == "" {
+		writeError(c, http.StatusBadRequest, "baby_id is required")
+		return
+	}
+	eventType := ""
+	if raw := strings.TrimSpace(c.Query("type")); raw != "" {
+		normalized, valid := normalizeEventType(raw)
+		if !valid {
+			writeError(c, http.StatusBadRequest, "type is invalid")
+			return
+		}
+		eventType = normalized
+	}
+	var fromUTC, toUTC *time.Time
+	if raw := strings.TrimSpace(c.Query("from")); raw != "" {
+		parsed, err := parseDate(raw)
+		if err != nil {
+			writeError(c, http.StatusBadRequest, "from must be YYYY-MM-DD")
+			return
+		}
+		fromUTC = &parsed
+	}
+	if raw := strings.TrimSpace(c.Query("to")); raw != "" {
+		parsed, err := parseDate(raw)
+		if err != nil {
+			writeError(c, http.StatusBadRequest, "to must be YYYY-MM-DD")
+			return
+		}
+		// to is inclusive of the whole day.
+		end := parsed.AddDate(0, 0, 1)
+		toUTC = &end
+	}
+	if fromUTC != nil && toUTC != nil && !fromUTC.Before(*toUTC) {
+		writeError(c, http.StatusBadRequest, "from must be on or before to")
+		return
+	}
+	limit := eventListPageDefault
+	if raw := strings.TrimSpace(c.Query("limit")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			writeError(c, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		if parsed > eventListPageMax {
+			parsed = eventListPageMax
+		}
+		limit = parsed
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, babyID, readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	cursorID := strings.TrimSpace(c.Query("cursor"))
+	var cursorStart *time.Time
+	if cursorID != "" {
+		var startTime time.Time
+		cursorErr := a.db.QueryRow(
+			c.Request.Context(),
+			`SELECT "startTime" FROM "Event" WHERE id = $1 AND "deletedAt" IS NULL AND "babyId" = $2`,
+			cursorID,
+			baby.ID,
+		).Scan(&startTime)
+		if errors.Is(cursorErr, pgx.ErrNoRows) {
+			writeError(c, http.StatusBadRequest, "cursor does not belong to this baby")
+			return
+		}
+		if cursorErr != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to load events")
+			return
+		}
+		cursorStart = &startTime
+	}
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT id, type::text, "startTime", "endTime", "valueJson", COALESCE("metadataJson", '{}'::jsonb), "createdAt"
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
+		   AND `+eventVisibleToUserSQL("$2")+`
+		   AND ($3::text = '' OR type::text = $3)
+		   AND ($4::timestamp IS NULL OR "startTime" >= $4::timestamp)
+		   AND ($5::timestamp IS NULL OR "startTime" < $5::timestamp)
+		   AND ($6::timestamp IS NULL OR ("startTime", id) < ($6::timestamp, $7::text))
+		 ORDER BY "startTime" DESC, id DESC
+		 LIMIT $8`,
+		baby.ID,
+		user.ID,
+		eventType,
+		fromUTC,
+		toUTC,
+		cursorStart,
+		cursorID,
+		limit+1,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load events")
+		return
+	}
+	defer rows.Close()
+
+	events := make([]gin.H, 0)
+	for rows.Next() {
+		var eventID, rowType string
+		var startTime time.Time
+		var endTime *time.Time
+		var valueRaw, metadataRaw []byte
+		var createdAt time.Time
+		if err := rows.Scan(&eventID, &rowType, &startTime, &endTime, &valueRaw, &metadataRaw, &createdAt); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to parse events")
+			return
+		}
+		metadata := parseJSONStringMap(metadataRaw)
+		events = append(events, gin.H{
+			"event_id":    eventID,
+			"type":        rowType,
+			"event_state": eventStateFromRecord(endTime, metadata),
+			"start_time":  startTime.UTC().Format(time.RFC3339),
+			"end_time":    formatNullableTimeRFC3339(endTime),
+			"value":       parseJSONStringMap(valueRaw),
+			"metadata":    metadata,
+			"created_at":  createdAt.UTC().Format(time.RFC3339),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse events")
+		return
+	}
+
+	var nextCursor *string
+	if len(events) > limit {
+		events = events[:limit]
+		lastID, _ := events[limit-1]["event_id"].(string)
+		nextCursor = &lastID
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"baby_id":     baby.ID,
+		"events":      events,
+		"next_cursor": nextCursor,
+	})
+}
@@ -0,0 +1,197 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// eventShiftMaxMinutes covers any device timezone mix-up, including the
+	// widest UTC-12 to UTC+14 gap.
+	eventShiftMaxMinutes = 26 * 60
+	eventShiftMaxRange   = 31 * 24 * time.Hour
+	eventShiftMaxEvents  = 500
+)
+
+// shiftEventTimes moves every closed or open event of a baby that started in
+// [from, to) by a fixed number of minutes, for records logged with the wrong
+// device timezone. Canceled events and other caregivers' private events are
+// left alone.
+func (a *App) shiftEventTimes(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var payload eventShiftRequest
+	if !mustJSON(c, &payload) {
+		return
+	}
+	if payload.From.IsZero() || payload.To.IsZero() {
+		writeError(c, http.StatusBadRequest, "from and to are required")
+		return
+	}
+	from := payload.From.UTC()
+	to := payload.To.UTC()
+	if !to.After(from) {
+		writeError(c, http.StatusBadRequest, "to must be after from")
+		return
+	}
+	if to.Sub(from) > eventShiftMaxRange {
+		writeError(c, http.StatusBadRequest, "from and to must be at most 31 days apart")
+		return
+	}
+	if payload.ShiftMinutes == 0 || payload.ShiftMinutes > eventShiftMaxMinutes || payload.ShiftMinutes < -eventShiftMaxMinutes {
+		writeError(c, http.StatusBadRequest, fmt.Sprintf("shift_minutes must be non-zero and between -%d and %d", eventShiftMaxMinutes, eventShiftMaxMinutes))
+		return
+	}
+	eventType := ""
+	if raw := strings.TrimSpace(payload.Type); raw != "" {
+		normalized, valid := normalizeEventType(raw)
+		if !valid {
+			writeError(c, http.StatusBadRequest, "type is invalid")
+			return
+		}
+		eventType = normalized
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, c.Param("baby_id"), writeRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	tx, err := a.db.Begin(c.Request.Context())
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback(c.Request.Context())
+
+	type shiftedEvent struct {
+		ID        string
+		Type      string
+		StartTime time.Time
+	}
+	rows, err := tx.Query(
+		c.Request.Context(),
+		`SELECT id, type::text, "startTime"
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND "startTime" >= $2
+		   AND "startTime" < $3
+		   AND ($4::text = '' OR type::text = $4::text)
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
+		   AND `+eventVisibleToUserSQL("$5")+`
+		 ORDER BY "startTime" ASC, id ASC
+		 LIMIT $6
+		 FOR UPDATE`,
+		baby.ID,
+		from,
+		to,
+		eventType,
+		user.ID,
+		eventShiftMaxEvents+1,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to lock events")
+		return
+	}
+	events := make([]shiftedEvent, 0)
+	for rows.Next() {
+		var item shiftedEvent
+		if err := rows.Scan(&item.ID, &item.Type, &item.StartTime); err != nil {
+			rows.Close()
+			writeError(c, http.StatusInternalServerError, "Failed to parse events")
+			return
+		}
+		events = append(events, item)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse events")
+		return
+	}
+	if len(events) > eventShiftMaxEvents {
+		writeError(c, http.StatusBadRequest, fmt.Sprintf("more than %d events match; narrow from/to or type", eventShiftMaxEvents))
+		return
+	}
+	if len(events) == 0 {
+		c.JSON(http.StatusOK, gin.H{
+			"status":        "NO_MATCH",
+			"baby_id":       baby.ID,
+			"shift_minutes": payload.ShiftMinutes,
+			"shifted_count": 0,
+			"event_ids":     []string{},
+		})
+		return
+	}
+
+	eventIDs := make([]string, 0, len(events))
+	startsByType := map[string][]time.Time{}
+	for _, item := range events {
+		eventIDs = append(eventIDs, item.ID)
+		startsByType[item.Type] = append(startsByType[item.Type], item.StartTime.UTC())
+	}
+
+	if _, err := tx.Exec(
+		c.Request.Context(),
+		`UPDATE "Event"
+		 SET "startTime" = "startTime" + $2::int * INTERVAL '1 minute',
+		     "endTime" = "endTime" + $2::int * INTERVAL '1 minute'
+		 WHERE id = ANY($1)`,
+		eventIDs,
+		payload.ShiftMinutes,
+	); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to update events")
+		return
+	}
+
+	for shiftedType, startTimes := range startsByType {
+		if err := shiftProjectedEvents(c.Request.Context(), tx, baby.ID, shiftedType, startTimes, payload.ShiftMinutes); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to update projected events")
+			return
+		}
+	}
+
+	for _, item := range events {
+		eventID := item.ID
+		if err := recordAuditLog(
+			c.Request.Context(),
+			tx,
+			baby.HouseholdID,
+			user.ID,
+			"EVENT_TIME_SHIFTED",
+			"Event",
+			&eventID,
+			gin.H{
+				"baby_id":       baby.ID,
+				"type":          item.Type,
+				"shift_minutes": payload.ShiftMinutes,
+				"from_start":    item.StartTime.UTC().Format(time.RFC3339),
+			},
+		); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to write audit log")
+			return
+		}
+	}
+
+	if err := tx.Commit(c.Request.Context()); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":        "SHIFTED",
+		"baby_id":       baby.ID,
+		"shift_minutes": payload.ShiftMinutes,
+		"shifted_count": len(events),
+		"event_ids":     eventIDs,
+	})
+}
@@ -0,0 +1,172 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type feedingEfficiencyPoint struct {
+	EventID     string  `json:"event_id"`
+	Time        string  `json:"time"`
+	ML          float64 `json:"ml"`
+	DurationMin float64 `json:"duration_min"`
+	MLPerMin    float64 `json:"ml_per_min"`
+}
+
+type feedingEfficiencySummary struct {
+	SampleCount int
+	TotalML     float64
+	TotalMin    float64
+	AvgMLPerMin *float64
+}
+
+// feedingEfficiencySample computes ml per minute for one feeding. Events
+// missing either an amount or a positive duration are skipped.
+func feedingEfficiencySample(value map[string]any, startTime time.Time, endTime *time.Time) (float64, float64, bool) {
+	ml := extractNumberFromMap(value, "ml", "amount_ml", "volume_ml")
+	if ml <= 0 {
+		return 0, 0, false
+	}
+	duration := extractDurationMinutes(value, startTime, endTime)
+	if duration == nil || *duration <= 0 {
+		return 0, 0, false
+	}
+	return ml, *duration, true
+}
+
+// summarizeFeedingEfficiency averages over total ml / total minutes, so a
+// long session weighs more than a two-minute top-up.
+func summarizeFeedingEfficiency(points []feedingEfficiencyPoint) feedingEfficiencySummary {
+	summary := feedingEfficiencySummary{SampleCount: len(points)}
+	for _, point := range points {
+		summary.TotalML += point.ML
+		summary.TotalMin += point.DurationMin
+	}
+	if summary.TotalMin > 0 {
+		avg := roundToOneDecimal(summary.TotalML / summary.TotalMin)
+		summary.AvgMLPerMin = &avg
+	}
+	summary.TotalML = roundToOneDecimal(summary.TotalML)
+	summary.TotalMin = roundToOneDecimal(summary.TotalMin)
+	return summary
+}
+
+func (a *App) getFeedingEfficiency(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	localZone, tzNormalized, err := parseTZOffset(c.Query("tz_offset"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	rangeKey := strings.ToLower(strings.TrimSpace(c.DefaultQuery("range", "week")))
+	weekStartsOn, statusCode, err := a.resolveWeekStartsOn(c.Request.Context(), user.ID, c.Query("week_starts_on"))
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+	localStart, localEnd, _, rangeLabel, err := quickRangeWindow(time.Now().In(localZone), rangeKey, weekStartsOn)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	// The trend compares against the window of the same size just before.
+	previousStart := localStart.AddDate(0, 0, -1)
+	switch rangeKey {
+	case "week":
+		previousStart = localStart.AddDate(0, 0, -7)
+	case "month":
+		previousStart = localStart.AddDate(0, -1, 0)
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, c.Param("baby_id"), readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT id, "startTime", "endTime", "valueJson"
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND type = 'BREASTFEED'
+		   AND "startTime" >= $2
+		   AND "startTime" < $3
+		   AND NOT (`+openEventPredicateSQL+`)
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
+		   AND `+eventVisibleToUserSQL("$4")+`
+		 ORDER BY "startTime" ASC, id ASC`,
+		baby.ID,
+		previousStart.UTC(),
+		localEnd.UTC(),
+		user.ID,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load feedings")
+		return
+	}
+	defer rows.Close()
+
+	points := []feedingEfficiencyPoint{}
+	previousPoints := []feedingEfficiencyPoint{}
+	for rows.Next() {
+		var eventID string
+		var startTime time.Time
+		var endTime *time.Time
+		var valueRaw []byte
+		if err := rows.Scan(&eventID, &startTime, &endTime, &valueRaw); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to parse feedings")
+			return
+		}
+		ml, duration, ok := feedingEfficiencySample(parseJSONStringMap(valueRaw), startTime, endTime)
+		if !ok {
+			continue
+		}
+		point := feedingEfficiencyPoint{
+			EventID:     eventID,
+			Time:        startTime.UTC().Format(time.RFC3339),
+			ML:          roundToOneDecimal(ml),
+			DurationMin: roundToOneDecimal(duration),
+			MLPerMin:    roundToOneDecimal(ml / duration),
+		}
+		if startTime.Before(localStart) {
+			previousPoints = append(previousPoints, point)
+		} else {
+			points = append(points, point)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse feedings")
+		return
+	}
+
+	current := summarizeFeedingEfficiency(points)
+	previous := summarizeFeedingEfficiency(previousPoints)
+	var trend *string
+	if current.AvgMLPerMin != nil && previous.AvgMLPerMin != nil {
+		value := trendString(*current.AvgMLPerMin, *previous.AvgMLPerMin)
+		trend = &value
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"baby_id":                 baby.ID,
+		"range":                   rangeKey,
+		"range_label":             rangeLabel,
+		"tz_offset":               tzNormalized,
+		"sample_count":            current.SampleCount,
+		"total_ml":                current.TotalML,
+		"total_min":               current.TotalMin,
+		"avg_ml_per_min":          current.AvgMLPerMin,
+		"previous_avg_ml_per_min": previous.AvgMLPerMin,
+		"trend":                   trend,
+		"points":                  points,
+	})
+}
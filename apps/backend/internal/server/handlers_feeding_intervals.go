@@ -0,0 +1,159 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	feedingIntervalDefaultDays = 7
+	feedingIntervalMaxDays     = 30
+	// feedingIntervalMinStable is how many intervals the stats need before
+	// the rhythm is reported as stable.
+	feedingIntervalMinStable = 3
+)
+
+// feedingIntervalStats summarizes the gaps between consecutive feedings.
+// CoefficientOfVariation is the standard deviation over the mean; the lower
+// it is, the steadier the rhythm.
+type feedingIntervalStats struct {
+	Count                  int       `json:"count"`
+	IntervalsMinutes       []float64 `json:"intervals_minutes"`
+	MinMinutes             *float64  `json:"min_minutes"`
+	MedianMinutes          *float64  `json:"median_minutes"`
+	MeanMinutes            *float64  `json:"mean_minutes"`
+	MaxMinutes             *float64  `json:"max_minutes"`
+	CoefficientOfVariation *float64  `json:"coefficient_of_variation"`
+	Unstable               bool      `json:"unstable"`
+}
+
+// calculateFeedingIntervalStats uses the same past-only, oldest-first
+// intervals as the feeding ETA.
+func calculateFeedingIntervalStats(feedings []time.Time, now time.Time) feedingIntervalStats {
+	_, intervals := feedingIntervals(feedings, now.UTC())
+	stats := feedingIntervalStats{
+		Count:            len(intervals),
+		IntervalsMinutes: make([]float64, 0, len(intervals)),
+		Unstable:         len(intervals) < feedingIntervalMinStable,
+	}
+	if len(intervals) == 0 {
+		return stats
+	}
+	for _, interval := range intervals {
+		stats.IntervalsMinutes = append(stats.IntervalsMinutes, roundToOneDecimal(interval))
+	}
+
+	sorted := append([]float64(nil), intervals...)
+	sort.Float64s(sorted)
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+	mean := averageFloat(intervals)
+	variance := 0.0
+	for _, interval := range intervals {
+		variance += (interval - mean) * (interval - mean)
+	}
+	variance /= float64(len(intervals))
+
+	minMinutes := roundToOneDecimal(sorted[0])
+	maxMinutes := roundToOneDecimal(sorted[len(sorted)-1])
+	medianMinutes := roundToOneDecimal(median)
+	meanMinutes := roundToOneDecimal(mean)
+	stats.MinMinutes = &minMinutes
+	stats.MaxMinutes = &maxMinutes
+	stats.MedianMinutes = &medianMinutes
+	stats.MeanMinutes = &meanMinutes
+	if mean > 0 {
+		cv := roundToTwoDecimals(math.Sqrt(variance) / mean)
+		stats.CoefficientOfVariation = &cv
+	}
+	return stats
+}
+
+// quickFeedingIntervals reports the feeding rhythm over the last days local
+// days, today included.
+func (a *App) quickFeedingIntervals(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	localZone, tzNormalized, err := parseTZOffset(c.Query("tz_offset"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	dayCount := feedingIntervalDefaultDays
+	if raw := strings.TrimSpace(c.Query("days")); raw != "" {
+		parsed, parseErr := strconv.Atoi(raw)
+		if parseErr != nil || parsed <= 0 || parsed > feedingIntervalMaxDays {
+			writeError(c, http.StatusBadRequest, fmt.Sprintf("days must be between 1 and %d", feedingIntervalMaxDays))
+			return
+		}
+		dayCount = parsed
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, c.Query("baby_id"), readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	nowUTC := time.Now().UTC()
+	localNow := nowUTC.In(localZone)
+	today := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, localZone)
+	windowStart := today.AddDate(0, 0, -(dayCount - 1))
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT "startTime" FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND type IN ('FORMULA', 'BREASTFEED')
+		   AND "startTime" >= $2
+		   AND "startTime" <= $3
+		 ORDER BY "startTime" ASC`,
+		baby.ID,
+		windowStart.UTC(),
+		nowUTC,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load feeding events")
+		return
+	}
+	defer rows.Close()
+
+	var times []time.Time
+	for rows.Next() {
+		var startedAt time.Time
+		if err := rows.Scan(&startedAt); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to parse feeding events")
+			return
+		}
+		times = append(times, startedAt.UTC())
+	}
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse feeding events")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"baby_id":   baby.ID,
+		"tz_offset": tzNormalized,
+		"days":      dayCount,
+		"range": gin.H{
+			"from": windowStart.Format(time.RFC3339),
+			"to":   localNow.Format(time.RFC3339),
+		},
+		"feeding_count": len(times),
+		"intervals":     calculateFeedingIntervalStats(times, nowUTC),
+	})
+}
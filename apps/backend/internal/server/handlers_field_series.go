@@ -0,0 +1,196 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	fieldSeriesDefaultRangeDays = 30
+	fieldSeriesMaxRangeDays     = 366
+)
+
+// fieldSeriesAliases lists, per canonical field, the valueJson keys that
+// clients have written for the same measurement.
+var fieldSeriesAliases = map[string][]string{
+	"temperature_c":         {"temperature_c", "temp_c", "temp"},
+	"ml":                    {"ml", "amount_ml", "volume_ml"},
+	"weight_kg":             {"weight_kg", "weight"},
+	"height_cm":             {"height_cm", "length_cm", "height"},
+	"head_circumference_cm": {"head_circumference_cm", "head_cm"},
+	"duration_min":          {"duration_min"},
+}
+
+var fieldSeriesNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+type fieldSeriesPoint struct {
+	EventID string  `json:"event_id"`
+	Time    string  `json:"time"`
+	Value   float64 `json:"value"`
+}
+
+// resolveFieldSeriesKeys maps a requested field (canonical name or alias) to
+// its canonical name and the keys to look up. Unknown but well-formed names
+// are read as-is so new numeric fields chart without a code change.
+func resolveFieldSeriesKeys(raw string) (string, []string, bool) {
+	field := strings.ToLower(strings.TrimSpace(raw))
+	if !fieldSeriesNamePattern.MatchString(field) {
+		return "", nil, false
+	}
+	if keys, ok := fieldSeriesAliases[field]; ok {
+		return field, keys, true
+	}
+	for canonical, keys := range fieldSeriesAliases {
+		for _, key := range keys {
+			if key == field {
+				return canonical, keys, true
+			}
+		}
+	}
+	return field, []string{field}, true
+}
+
+// fieldSeriesValue reads one point's value. A missing key yields no point
+// rather than a zero; duration_min falls back to end-start like elsewhere.
+func fieldSeriesValue(field string, keys []string, value map[string]any, startTime time.Time, endTime *time.Time) (float64, bool) {
+	if field == "duration_min" {
+		duration := extractDurationMinutes(value, startTime, endTime)
+		if duration == nil {
+			return 0, false
+		}
+		return roundToOneDecimal(*duration), true
+	}
+	for _, key := range keys {
+		raw, ok := value[key]
+		if !ok || raw == nil {
+			continue
+		}
+		if strings.TrimSpace(toString(raw)) == "" {
+			continue
+		}
+		return extractNumberFromMap(value, key), true
+	}
+	return 0, false
+}
+
+func (a *App) getFieldSeries(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	localZone, tzNormalized, err := parseTZOffset(c.Query("tz_offset"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	eventType, valid := normalizeEventType(c.Query("type"))
+	if !valid {
+		writeError(c, http.StatusBadRequest, "type is invalid")
+		return
+	}
+	field, keys, valid := resolveFieldSeriesKeys(c.Query("field"))
+	if !valid {
+		writeError(c, http.StatusBadRequest, "field must be a snake_case value field name")
+		return
+	}
+
+	localNow := time.Now().In(localZone)
+	toDate := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, localZone)
+	if raw := strings.TrimSpace(c.Query("to")); raw != "" {
+		parsed, parseErr := parseDate(raw)
+		if parseErr != nil {
+			writeError(c, http.StatusBadRequest, "to must be YYYY-MM-DD")
+			return
+		}
+		toDate = time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, localZone)
+	}
+	fromDate := toDate.AddDate(0, 0, -(fieldSeriesDefaultRangeDays - 1))
+	if raw := strings.TrimSpace(c.Query("from")); raw != "" {
+		parsed, parseErr := parseDate(raw)
+		if parseErr != nil {
+			writeError(c, http.StatusBadRequest, "from must be YYYY-MM-DD")
+			return
+		}
+		fromDate = time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, localZone)
+	}
+	if fromDate.After(toDate) {
+		writeError(c, http.StatusBadRequest, "from must be on or before to")
+		return
+	}
+	if toDate.Sub(fromDate) >= fieldSeriesMaxRangeDays*24*time.Hour {
+		writeError(c, http.StatusBadRequest, fmt.Sprintf("from/to range must be at most %d days", fieldSeriesMaxRangeDays))
+		return
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, c.Param("baby_id"), readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT id, "startTime", "endTime", "valueJson"
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND type = $2
+		   AND "startTime" >= $3
+		   AND "startTime" < $4
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
+		   AND `+eventVisibleToUserSQL("$5")+`
+		 ORDER BY "startTime" ASC, id ASC`,
+		baby.ID,
+		eventType,
+		fromDate.UTC(),
+		toDate.AddDate(0, 0, 1).UTC(),
+		user.ID,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load events")
+		return
+	}
+	defer rows.Close()
+
+	points := []fieldSeriesPoint{}
+	for rows.Next() {
+		var eventID string
+		var startTime time.Time
+		var endTime *time.Time
+		var valueRaw []byte
+		if err := rows.Scan(&eventID, &startTime, &endTime, &valueRaw); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to parse events")
+			return
+		}
+		pointValue, found := fieldSeriesValue(field, keys, parseJSONStringMap(valueRaw), startTime, endTime)
+		if !found {
+			continue
+		}
+		points = append(points, fieldSeriesPoint{
+			EventID: eventID,
+			Time:    startTime.UTC().Format(time.RFC3339),
+			Value:   pointValue,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse events")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"baby_id":   baby.ID,
+		"type":      eventType,
+		"field":     field,
+		"tz_offset": tzNormalized,
+		"from":      fromDate.Format("2006-01-02"),
+		"to":        toDate.Format("2006-01-02"),
+		"points":    points,
+	})
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const formulaPrepNote = "General guidance only. Always follow the mixing instructions on your formula's label and your clinician's advice."
+
+type formulaPrepRatio struct {
+	WaterMLPerScoop float64
+	ScoopGrams      float64
+	Note            string
+}
+
+// formulaPrepRatios maps "brand product", "brand" and "type:<formula_type>"
+// keys to scoop ratios, most specific first. Anything unlisted uses
+// formulaPrepGenericRatio.
+var formulaPrepRatios = map[string]formulaPrepRatio{
+	"similac": {WaterMLPerScoop: 60, ScoopGrams: 8.7},
+	"enfamil": {WaterMLPerScoop: 60, ScoopGrams: 8.7},
+	"aptamil": {WaterMLPerScoop: 30, ScoopGrams: 4.6},
+	"type:thickened": {
+		WaterMLPerScoop: 30,
+		ScoopGrams:      4.6,
+		Note:            "Thickened formula keeps thickening after mixing; shake well and feed promptly.",
+	},
+	"type:specialty": {
+		WaterMLPerScoop: 30,
+		ScoopGrams:      4.5,
+		Note:            "Specialty formulas often use a prescribed ratio; confirm it with your clinician.",
+	},
+}
+
+// formulaPrepGenericRatio is the common one level scoop per 30 ml standard.
+var formulaPrepGenericRatio = formulaPrepRatio{WaterMLPerScoop: 30, ScoopGrams: 4.4}
+
+type formulaPrepPlan struct {
+	Scoops   float64
+	WaterML  int
+	PowderG  float64
+	TargetML int
+}
+
+// resolveFormulaPrepRatio returns the ratio for the stored formula and which
+// level of the table matched: product, brand, formula_type or generic.
+func resolveFormulaPrepRatio(brand, product, formulaType string) (formulaPrepRatio, string) {
+	brandKey := strings.ToLower(strings.Join(strings.Fields(brand), " "))
+	productKey := strings.ToLower(strings.Join(strings.Fields(product), " "))
+	if brandKey != "" && productKey != "" {
+		if ratio, ok := formulaPrepRatios[brandKey+" "+productKey]; ok {
+			return ratio, "product"
+		}
+	}
+	if brandKey != "" {
+		if ratio, ok := formulaPrepRatios[brandKey]; ok {
+			return ratio, "brand"
+		}
+	}
+	if ratio, ok := formulaPrepRatios["type:"+normalizeFormulaType(formulaType)]; ok {
+		return ratio, "formula_type"
+	}
+	return formulaPrepGenericRatio, "generic_standard"
+}
+
+// planFormulaPrep rounds the target up to whole level scoops, since partial
+// scoops are hard to measure and change the concentration.
+func planFormulaPrep(targetML int, ratio formulaPrepRatio) formulaPrepPlan {
+	scoops := math.Ceil(float64(targetML) / ratio.WaterMLPerScoop)
+	if scoops < 1 {
+		scoops = 1
+	}
+	return formulaPrepPlan{
+		Scoops:   scoops,
+		WaterML:  int(scoops * ratio.WaterMLPerScoop),
+		PowderG:  roundToOneDecimal(scoops * ratio.ScoopGrams),
+		TargetML: targetML,
+	}
+}
+
+func (a *App) getFormulaPrep(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	babyID := strings.TrimSpace(c.Param("baby_id"))
+	if babyID == "" {
+		writeError(c, http.StatusBadRequest, "baby_id is required")
+		return
+	}
+
+	profile, statusCode, err := a.resolveBabyProfile(c.Request.Context(), user.ID, babyID, readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+	recommendation := calculateFeedingRecommendation(profile, nil, time.Now().UTC())
+	ratio, ratioSource := resolveFormulaPrepRatio(profile.FormulaBrand, profile.FormulaProduct, profile.FormulaType)
+
+	var prep gin.H
+	if recommendation.RecommendedFormulaPerFeedML != nil {
+		plan := planFormulaPrep(*recommendation.RecommendedFormulaPerFeedML, ratio)
+		prep = gin.H{
+			"scoops":    plan.Scoops,
+			"water_ml":  plan.WaterML,
+			"powder_g":  plan.PowderG,
+			"target_ml": plan.TargetML,
+		}
+	}
+	notes := []string{formulaPrepNote}
+	if ratio.Note != "" {
+		notes = append(notes, ratio.Note)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"baby_id":                         profile.BabyID,
+		"formula_display_name":            formulaDisplayName(profile),
+		"formula_type":                    profile.FormulaType,
+		"feeding_method":                  profile.FeedingMethod,
+		"recommended_formula_per_feed_ml": recommendation.RecommendedFormulaPerFeedML,
+		"ratio": gin.H{
+			"water_ml_per_scoop": ratio.WaterMLPerScoop,
+			"scoop_grams":        ratio.ScoopGrams,
+			"source":             ratioSource,
+		},
+		"prep": prep,
+		"steps": []string{
+			"Wash your hands and clean the bottle and teat.",
+			"Boil fresh water and let it cool for no more than 30 minutes (about 70C).",
+			"Pour the water into the bottle first, then add level, unpacked scoops.",
+			"Close the bottle and shake until the powder dissolves.",
+			"Cool to body temperature and check a few drops on your wrist before feeding.",
+		},
+		"is_general_guidance": true,
+		"notes":               notes,
+	})
+}
@@ -0,0 +1,323 @@
+package server
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// whoLMS is one row of a WHO Child Growth Standards LMS table. A measurement
+// X has z-score ((X/M)^L - 1) / (L*S), or ln(X/M)/S when L is 0.
+type whoLMS struct {
+	L float64
+	M float64
+	S float64
+}
+
+// WHO 2006 weight-for-age (kg) and length-for-age (cm) by completed month,
+// 0-24 months. Length tables use L = 1.
+var (
+	whoWeightForAgeLMS = map[string][]whoLMS{
+		"male": {
+			{0.3487, 3.3464, 0.14602}, {0.2297, 4.4709, 0.13395}, {0.1970, 5.5675, 0.12385},
+			{0.1738, 6.3762, 0.11727}, {0.1553, 7.0023, 0.11316}, {0.1395, 7.5105, 0.11080},
+			{0.1257, 7.9340, 0.10958}, {0.1134, 8.2970, 0.10902}, {0.1021, 8.6151, 0.10882},
+			{0.0917, 8.9014, 0.10881}, {0.0820, 9.1649, 0.10891}, {0.0730, 9.4122, 0.10906},
+			{0.0644, 9.6479, 0.10925}, {0.0563, 9.8749, 0.10949}, {0.0487, 10.0953, 0.10976},
+			{0.0413, 10.3108, 0.11007}, {0.0343, 10.5228, 0.11041}, {0.0275, 10.7319, 0.11079},
+			{0.0211, 10.9385, 0.11119}, {0.0148, 11.1430, 0.11164}, {0.0087, 11.3462, 0.11211},
+			{0.0029, 11.5486, 0.11261}, {-0.0028, 11.7504, 0.11314}, {-0.0083, 11.9514, 0.11369},
+			{-0.0137, 12.1515, 0.11426},
+		},
+		"female": {
+			{0.3809, 3.2322, 0.14171}, {0.1714, 4.1873, 0.13724}, {0.0962, 5.1282, 0.13000},
+			{0.0402, 5.8458, 0.12619}, {-0.0050, 6.4237, 0.12402}, {-0.0430, 6.8985, 0.12274},
+			{-0.0756, 7.2970, 0.12204}, {-0.1039, 7.6422, 0.12178}, {-0.1288, 7.9487, 0.12181},
+			{-0.1507, 8.2254, 0.12199}, {-0.1700, 8.4800, 0.12223}, {-0.1872, 8.7192, 0.12247},
+			{-0.2024, 8.9481, 0.12268}, {-0.2158, 9.1699, 0.12283}, {-0.2278, 9.3870, 0.12294},
+			{-0.2384, 9.6008, 0.12299}, {-0.2478, 9.8124, 0.12303}, {-0.2562, 10.0226, 0.12306},
+			{-0.2637, 10.2315, 0.12309}, {-0.2703, 10.4393, 0.12315}, {-0.2762, 10.6464, 0.12323},
+			{-0.2815, 10.8534, 0.12335}, {-0.2862, 11.0608, 0.12350}, {-0.2903, 11.2688, 0.12369},
+			{-0.2941, 11.4775, 0.12390},
+		},
+	}
+	whoLengthForAgeLMS = map[string][]whoLMS{
+		"male": {
+			{1, 49.8842, 0.03795}, {1, 54.7244, 0.03557}, {1, 58.4249, 0.03424},
+			{1, 61.4292, 0.03328}, {1, 63.8860, 0.03257}, {1, 65.9026, 0.03204},
+			{1, 67.6236, 0.03165}, {1, 69.1645, 0.03139}, {1, 70.5994, 0.03124},
+			{1, 71.9687, 0.03117}, {1, 73.2812, 0.03118}, {1, 74.5388, 0.03125},
+			{1, 75.7488, 0.03137}, {1, 76.9186, 0.03154}, {1, 78.0497, 0.03174},
+			{1, 79.1458, 0.03197}, {1, 80.2113, 0.03222}, {1, 81.2487, 0.03250},
+			{1, 82.2587, 0.03279}, {1, 83.2418, 0.03310}, {1, 84.1996, 0.03342},
+			{1, 85.1348, 0.03376}, {1, 86.0477, 0.03410}, {1, 86.9410, 0.03445},
+			{1, 87.8161, 0.03479},
+		},
+		"female": {
+			{1, 49.1477, 0.03790}, {1, 53.6872, 0.03640}, {1, 57.0673, 0.03568},
+			{1, 59.8029, 0.03520}, {1, 62.0899, 0.03486}, {1, 64.0301, 0.03463},
+			{1, 65.7311, 0.03448}, {1, 67.2873, 0.03441}, {1, 68.7498, 0.03440},
+			{1, 70.1435, 0.03444}, {1, 71.4818, 0.03452}, {1, 72.7710, 0.03464},
+			{1, 74.0150, 0.03479}, {1, 75.2176, 0.03496}, {1, 76.3817, 0.03514},
+			{1, 77.5099, 0.03534}, {1, 78.6055, 0.03555}, {1, 79.6710, 0.03576},
+			{1, 80.7079, 0.03598}, {1, 81.7182, 0.03620}, {1, 82.7036, 0.03643},
+			{1, 83.6654, 0.03666}, {1, 84.6040, 0.03688}, {1, 85.5202, 0.03711},
+			{1, 86.4153, 0.03734},
+		},
+	}
+)
+
+// whoPercentile returns the whole-number percentile of value for the sex and
+// age, or nil when the table has no row for them.
+func whoPercentile(table map[string][]whoLMS, sex string, ageMonths int, value float64) *int {
+	rows, ok := table[sex]
+	if !ok || ageMonths < 0 || ageMonths >= len(rows) || value <= 0 {
+		return nil
+	}
+	row := rows[ageMonths]
+	var z float64
+	if row.L == 0 {
+		z = math.Log(value/row.M) / row.S
+	} else {
+		z = (math.Pow(value/row.M, row.L) - 1) / (row.L * row.S)
+	}
+	percentile := int(math.Round(50 * (1 + math.Erf(z/math.Sqrt2))))
+	return &percentile
+}
+
+// growthMeasurements reads weight and height from a GROWTH value under the
+// key aliases clients have used. Missing or non-positive values are nil.
+func growthMeasurements(valueMap map[string]any) (*float64, *float64) {
+	var weightKg, heightCm *float64
+	if weight := extractNumberFromMap(valueMap, "weight_kg", "weightKg", "weight"); weight > 0 {
+		rounded := roundToOneDecimal(weight)
+		weightKg = &rounded
+	}
+	if height := extractNumberFromMap(
+		valueMap,
+		"height_cm",
+		"length_cm",
+		"stature_cm",
+		"heightCm",
+		"lengthCm",
+		"height",
+		"length",
+	); height > 0 {
+		rounded := roundToOneDecimal(height)
+		heightCm = &rounded
+	}
+	return weightKg, heightCm
+}
+
+// getGrowthReport places the latest GROWTH measurement on the WHO
+// weight-for-age and length-for-age curves at the age it was measured.
+func (a *App) getGrowthReport(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	babyID := strings.TrimSpace(c.Query("baby_id"))
+	if babyID == "" {
+		writeError(c, http.StatusBadRequest, "baby_id is required")
+		return
+	}
+	profile, statusCode, err := a.resolveBabyProfile(c.Request.Context(), user.ID, babyID, readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+	sex := normalizeBabySex(profile.Sex)
+	if sex == "" {
+		sex = "unknown"
+	}
+
+	var measuredAt time.Time
+	var valueRaw []byte
+	err = a.db.QueryRow(
+		c.Request.Context(),
+		`SELECT "startTime", "valueJson"
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND type = 'GROWTH'
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
+		   AND `+eventVisibleToUserSQL("$2")+`
+		 ORDER BY "startTime" DESC
+		 LIMIT 1`,
+		profile.BabyID,
+		user.ID,
+	).Scan(&measuredAt, &valueRaw)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		writeError(c, http.StatusInternalServerError, "Failed to load growth events")
+		return
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusOK, gin.H{
+			"baby_id":           profile.BabyID,
+			"sex":               sex,
+			"age_months":        ageMonthsFromBirthDate(profile.BirthDate, time.Now().UTC()),
+			"measured_at":       nil,
+			"weight_kg":         nil,
+			"height_cm":         nil,
+			"weight_percentile": nil,
+			"height_percentile": nil,
+			"reference_text":    "No growth records yet. Log weight or height to get percentiles.",
+		})
+		return
+	}
+
+	weightKg, heightCm := growthMeasurements(parseJSONStringMap(valueRaw))
+	ageMonths := ageMonthsFromBirthDate(profile.BirthDate, measuredAt)
+	var weightPercentile, heightPercentile *int
+	referenceText := "WHO Child Growth Standards weight-for-age and length-for-age at the age measured."
+	switch {
+	case sex != "male" && sex != "female":
+		referenceText = "Set the baby's sex to male or female to get percentiles; WHO curves differ by sex."
+	case ageMonths >= len(whoWeightForAgeLMS[sex]):
+		referenceText = "WHO percentiles here cover 0-24 months; this measurement is outside that range."
+	default:
+		if weightKg != nil {
+			weightPercentile = whoPercentile(whoWeightForAgeLMS, sex, ageMonths, *weightKg)
+		}
+		if heightCm != nil {
+			heightPercentile = whoPercentile(whoLengthForAgeLMS, sex, ageMonths, *heightCm)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"baby_id":           profile.BabyID,
+		"sex":               sex,
+		"age_months":        ageMonths,
+		"measured_at":       measuredAt.UTC().Format(time.RFC3339),
+		"weight_kg":         weightKg,
+		"height_cm":         heightCm,
+		"weight_percentile": weightPercentile,
+		"height_percentile": heightPercentile,
+		"reference_text":    referenceText,
+	})
+}
+
+type growthSeriesPoint struct {
+	EventID    string   `json:"event_id"`
+	MeasuredAt string   `json:"measured_at"`
+	WeightKg   *float64 `json:"weight_kg"`
+	HeightCm   *float64 `json:"height_cm"`
+	AgeDays    int      `json:"age_days"`
+	AgeMonths  int      `json:"age_months"`
+}
+
+// getGrowthSeries lists every GROWTH measurement in [from, to], oldest
+// first, for the growth chart. from defaults to the birth date and to to
+// today in the caller's timezone.
+func (a *App) getGrowthSeries(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	babyID := strings.TrimSpace(c.Query("baby_id"))
+	if babyID == "" {
+		writeError(c, http.StatusBadRequest, "baby_id is required")
+		return
+	}
+	localZone, tzNormalized, err := parseTZOffset(c.Query("tz_offset"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	profile, statusCode, err := a.resolveBabyProfile(c.Request.Context(), user.ID, babyID, readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	localNow := time.Now().In(localZone)
+	toDate := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, localZone)
+	if raw := strings.TrimSpace(c.Query("to")); raw != "" {
+		parsed, parseErr := parseDate(raw)
+		if parseErr != nil {
+			writeError(c, http.StatusBadRequest, "to must be YYYY-MM-DD")
+			return
+		}
+		toDate = time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, localZone)
+	}
+	birthUTC := profile.BirthDate.UTC()
+	fromDate := time.Date(birthUTC.Year(), birthUTC.Month(), birthUTC.Day(), 0, 0, 0, 0, localZone)
+	if raw := strings.TrimSpace(c.Query("from")); raw != "" {
+		parsed, parseErr := parseDate(raw)
+		if parseErr != nil {
+			writeError(c, http.StatusBadRequest, "from must be YYYY-MM-DD")
+			return
+		}
+		fromDate = time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, localZone)
+	}
+	if fromDate.After(toDate) {
+		writeError(c, http.StatusBadRequest, "from must be on or before to")
+		return
+	}
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT id, "startTime", "valueJson"
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND type = 'GROWTH'
+		   AND "startTime" >= $2
+		   AND "startTime" < $3
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
+		   AND `+eventVisibleToUserSQL("$4")+`
+		 ORDER BY "startTime" ASC, id ASC`,
+		profile.BabyID,
+		fromDate.UTC(),
+		toDate.AddDate(0, 0, 1).UTC(),
+		user.ID,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load growth events")
+		return
+	}
+	defer rows.Close()
+
+	series := []growthSeriesPoint{}
+	for rows.Next() {
+		var eventID string
+		var measuredAt time.Time
+		var valueRaw []byte
+		if err := rows.Scan(&eventID, &measuredAt, &valueRaw); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to parse growth events")
+			return
+		}
+		weightKg, heightCm := growthMeasurements(parseJSONStringMap(valueRaw))
+		if weightKg == nil && heightCm == nil {
+			continue
+		}
+		series = append(series, growthSeriesPoint{
+			EventID:    eventID,
+			MeasuredAt: measuredAt.UTC().Format(time.RFC3339),
+			WeightKg:   weightKg,
+			HeightCm:   heightCm,
+			AgeDays:    ageDaysFromBirth(profile.BirthDate, measuredAt),
+			AgeMonths:  ageMonthsFromBirthDate(profile.BirthDate, measuredAt),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse growth events")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"baby_id":    profile.BabyID,
+		"birth_date": profile.BirthDate.Format("2006-01-02"),
+		"tz_offset":  tzNormalized,
+		"from":       fromDate.Format("2006-01-02"),
+		"to":         toDate.Format("2006-01-02"),
+		"series":     series,
+	})
+}
@@ -0,0 +1,310 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+type householdDashboardOpenEvent struct {
+	EventID   string `json:"event_id"`
+	Type      string `json:"type"`
+	StartTime string `json:"start_time"`
+}
+
+type householdDashboardBaby struct {
+	BabyID             string                        `json:"baby_id"`
+	BabyName           string                        `json:"baby_name"`
+	FeedingsCount      int                           `json:"feedings_count"`
+	FormulaTotalML     float64                       `json:"formula_total_ml"`
+	DiaperCount        int                           `json:"diaper_count"`
+	SleepTotalMin      int                           `json:"sleep_total_min"`
+	LastFeedingTime    *string                       `json:"last_feeding_time"`
+	LastSleepStartTime *string                       `json:"last_sleep_start_time"`
+	LastSleepEndTime   *string                       `json:"last_sleep_end_time"`
+	OpenEvents         []householdDashboardOpenEvent `json:"open_events"`
+}
+
+// getHouseholdDashboard returns a compact today-summary for every baby in
+// the household. Babies are summarized concurrently; the first failure
+// cancels the rest and fails the request.
+func (a *App) getHouseholdDashboard(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	localZone, tzNormalized, err := parseTZOffset(c.Query("tz_offset"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	householdID := strings.TrimSpace(c.Param("household_id"))
+	if householdID == "" {
+		writeError(c, http.StatusBadRequest, "household_id is required")
+		return
+	}
+	if _, statusCode, err := a.assertHouseholdAccess(c.Request.Context(), user.ID, householdID, readRoles); err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT id, name FROM "Baby" WHERE "householdId" = $1 ORDER BY "createdAt" ASC, id ASC`,
+		householdID,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load babies")
+		return
+	}
+	babies := make([]householdDashboardBaby, 0, 2)
+	for rows.Next() {
+		var baby householdDashboardBaby
+		if err := rows.Scan(&baby.BabyID, &baby.BabyName); err != nil {
+			rows.Close()
+			writeError(c, http.StatusInternalServerError, "Failed to parse babies")
+			return
+		}
+		babies = append(babies, baby)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse babies")
+		return
+	}
+
+	localNow := time.Now().In(localZone)
+	dayStart := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, localZone)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+	for i := range babies {
+		wg.Go(func() {
+			if err := a.fillHouseholdDashboardBaby(ctx, &babies[i], user.ID, dayStart, dayEnd); err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		})
+	}
+	wg.Wait()
+	if firstErr != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to build household dashboard")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"household_id": householdID,
+		"date":         dayStart.Format("2006-01-02"),
+		"tz_offset":    tzNormalized,
+		"babies":       babies,
+	})
+}
+
+func (a *App) fillHouseholdDashboardBaby(ctx context.Context, baby *householdDashboardBaby, userID string, dayStart, dayEnd time.Time) error {
+	baby.OpenEvents = []householdDashboardOpenEvent{}
+
+	rows, err := a.db.Query(
+		ctx,
+		`SELECT type, "startTime", "endTime", "valueJson"
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND "startTime" >= $2
+		   AND "startTime" < $3
+		   AND NOT (`+openEventPredicateSQL+`)
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
+		   AND `+eventVisibleToUserSQL("$4"),
+		baby.BabyID,
+		dayStart.UTC(),
+		dayEnd.UTC(),
+		userID,
+	)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var eventType string
+		var startTime time.Time
+		var endTime *time.Time
+		var valueRaw []byte
+		if err := rows.Scan(&eventType, &startTime, &endTime, &valueRaw); err != nil {
+			rows.Close()
+			return err
+		}
+		value := parseJSONStringMap(valueRaw)
+		switch eventType {
+		case "FORMULA":
+			baby.FeedingsCount++
+			baby.FormulaTotalML += extractNumberFromMap(value, "ml", "amount_ml", "volume_ml")
+		case "BREASTFEED":
+			baby.FeedingsCount++
+		case "PEE", "POO":
+			baby.DiaperCount++
+		case "SLEEP":
+			if isZeroDurationSleep(eventType, value, startTime, endTime) {
+				continue
+			}
+			if duration := extractDurationMinutes(value, startTime, endTime); duration != nil {
+				baby.SleepTotalMin += int(*duration + 0.5)
+			}
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	baby.FormulaTotalML = roundToOneDecimal(baby.FormulaTotalML)
+
+	lastFeeding, err := a.latestFeedingTime(ctx, baby.BabyID)
+	if err != nil {
+		return err
+	}
+	baby.LastFeedingTime = formatNullableTimeRFC3339(lastFeeding)
+
+	var sleepStart time.Time
+	var sleepEnd *time.Time
+	err = a.db.QueryRow(
+		ctx,
+		`SELECT "startTime", "endTime"
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND type = 'SLEEP'
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
+		   AND NOT `+zeroDurationSleepSQL+`
+		 ORDER BY "startTime" DESC
+		 LIMIT 1`,
+		baby.BabyID,
+	).Scan(&sleepStart, &sleepEnd)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	if err == nil {
+		baby.LastSleepStartTime = formatNullableTimeRFC3339(&sleepStart)
+		baby.LastSleepEndTime = formatNullableTimeRFC3339(sleepEnd)
+	}
+
+	openRows, err := a.db.Query(
+		ctx,
+		`SELECT id, type, "startTime"
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND `+openEventPredicateSQL+`
+		   AND `+eventVisibleToUserSQL("$2")+`
+		 ORDER BY "startTime" DESC`,
+		baby.BabyID,
+		userID,
+	)
+	if err != nil {
+		return err
+	}
+	defer openRows.Close()
+	for openRows.Next() {
+		var event householdDashboardOpenEvent
+		var startTime time.Time
+		if err := openRows.Scan(&event.EventID, &event.Type, &startTime); err != nil {
+			return err
+		}
+		event.StartTime = startTime.UTC().Format(time.RFC3339)
+		baby.OpenEvents = append(baby.OpenEvents, event)
+	}
+	return openRows.Err()
+}
+
+// getHouseholdOpenEvents lists every running timer across the household's
+// babies, oldest first, so a timer left open on one child is not missed
+// while looking at another.
+func (a *App) getHouseholdOpenEvents(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	householdID := strings.TrimSpace(c.Param("household_id"))
+	if householdID == "" {
+		writeError(c, http.StatusBadRequest, "household_id is required")
+		return
+	}
+	if _, statusCode, err := a.assertHouseholdAccess(c.Request.Context(), user.ID, householdID, readRoles); err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT open_event.id, open_event.type, open_event."startTime", open_event."valueJson",
+		        open_event."metadataJson", open_event."createdAt", baby.id, baby.name
+		 FROM (
+		   SELECT id, type::text AS type, "babyId", "startTime", "valueJson", "metadataJson", "createdAt"
+		   FROM "Event"
+		   WHERE "babyId" IN (SELECT id FROM "Baby" WHERE "householdId" = $1)
+		     AND "deletedAt" IS NULL
+		     AND `+openEventPredicateSQL+`
+		     AND `+eventVisibleToUserSQL("$2")+`
+		 ) open_event
+		 JOIN "Baby" baby ON baby.id = open_event."babyId"
+		 ORDER BY open_event."startTime" ASC, open_event.id ASC`,
+		householdID,
+		user.ID,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load open events")
+		return
+	}
+	defer rows.Close()
+
+	nowUTC := time.Now().UTC()
+	events := make([]gin.H, 0)
+	for rows.Next() {
+		var eventID, eventType, babyID, babyName string
+		var startTime, createdAt time.Time
+		var valueRaw, metadataRaw []byte
+		if err := rows.Scan(&eventID, &eventType, &startTime, &valueRaw, &metadataRaw, &createdAt, &babyID, &babyName); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to parse open events")
+			return
+		}
+		elapsedMin := int(nowUTC.Sub(startTime).Minutes())
+		if elapsedMin < 0 {
+			elapsedMin = 0
+		}
+		events = append(events, gin.H{
+			"event_id":    eventID,
+			"type":        eventType,
+			"status":      "OPEN",
+			"baby_id":     babyID,
+			"baby_name":   babyName,
+			"start_time":  startTime.UTC().Format(time.RFC3339),
+			"elapsed_min": elapsedMin,
+			"value":       parseJSONStringMap(valueRaw),
+			"metadata":    parseJSONStringMap(metadataRaw),
+			"created_at":  createdAt.UTC().Format(time.RFC3339),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse open events")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"household_id": householdID,
+		"open_events":  events,
+		"open_count":   len(events),
+		"event_state":  "OPEN",
+	})
+}
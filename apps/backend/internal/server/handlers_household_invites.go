@@ -0,0 +1,244 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	householdInviteDefaultHours = 72
+	householdInviteMaxHours     = 24 * 30
+)
+
+// invitableRoles excludes OWNER: a household has exactly one owner, the
+// Household.ownerUserId.
+var invitableRoles = map[string]struct{}{
+	roleParent:       {},
+	roleCaregiver:    {},
+	roleFamilyViewer: {},
+}
+
+func newHouseholdInviteToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// createHouseholdInvite lets an owner or billing member create a one-time
+// invite token for another caregiver. The role is fixed at invite time.
+func (a *App) createHouseholdInvite(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	householdID := strings.TrimSpace(c.Param("household_id"))
+
+	var payload householdInviteCreateRequest
+	if !mustJSON(c, &payload) {
+		return
+	}
+	role := strings.ToUpper(strings.TrimSpace(payload.Role))
+	if role == "" {
+		role = roleParent
+	}
+	if !containsRole(invitableRoles, role) {
+		writeError(c, http.StatusBadRequest, "role must be one of: PARENT, CAREGIVER, FAMILY_VIEWER")
+		return
+	}
+	expiresInHours := householdInviteDefaultHours
+	if payload.ExpiresInHours != nil {
+		if *payload.ExpiresInHours <= 0 || *payload.ExpiresInHours > householdInviteMaxHours {
+			writeError(c, http.StatusBadRequest, "expires_in_hours must be between 1 and 720")
+			return
+		}
+		expiresInHours = *payload.ExpiresInHours
+	}
+
+	if _, statusCode, err := a.assertHouseholdAccess(c.Request.Context(), user.ID, householdID, billingRoles); err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	token, err := newHouseholdInviteToken()
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to create invite token")
+		return
+	}
+	inviteID := uuid.NewString()
+	expiresAt := time.Now().UTC().Add(time.Duration(expiresInHours) * time.Hour)
+
+	tx, err := a.db.Begin(c.Request.Context())
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback(c.Request.Context())
+
+	if _, err := tx.Exec(
+		c.Request.Context(),
+		`INSERT INTO "Invite" (id, "householdId", token, role, "expiresAt", "invitedBy", "createdAt")
+		 VALUES ($1, $2, $3, $4, $5, $6, NOW())`,
+		inviteID,
+		householdID,
+		token,
+		role,
+		expiresAt,
+		user.ID,
+	); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to create invite")
+		return
+	}
+
+	if err := recordAuditLog(
+		c.Request.Context(),
+		tx,
+		householdID,
+		user.ID,
+		"HOUSEHOLD_INVITE_CREATED",
+		"Invite",
+		&inviteID,
+		gin.H{"role": role, "expires_at": expiresAt.Format(time.RFC3339)},
+	); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to write audit log")
+		return
+	}
+
+	if err := tx.Commit(c.Request.Context()); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"invite_id":    inviteID,
+		"household_id": householdID,
+		"token":        token,
+		"role":         role,
+		"expires_at":   expiresAt.Format(time.RFC3339),
+	})
+}
+
+// acceptHouseholdInvite adds the caller as an ACTIVE member with the invite's
+// role. A previously REMOVED membership is reactivated, since HouseholdMember
+// is unique per household and user.
+func (a *App) acceptHouseholdInvite(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	token := strings.TrimSpace(c.Param("token"))
+
+	tx, err := a.db.Begin(c.Request.Context())
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback(c.Request.Context())
+
+	var inviteID, householdID, role, ownerUserID string
+	var expiresAt time.Time
+	var usedAt *time.Time
+	err = tx.QueryRow(
+		c.Request.Context(),
+		`SELECT i.id, i."householdId", i.role::text, i."expiresAt", i."usedAt", h."ownerUserId"
+		 FROM "Invite" i
+		 JOIN "Household" h ON h.id = i."householdId"
+		 WHERE i.token = $1
+		 FOR UPDATE OF i`,
+		token,
+	).Scan(&inviteID, &householdID, &role, &expiresAt, &usedAt, &ownerUserID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		writeError(c, http.StatusNotFound, "Invite not found")
+		return
+	}
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load invite")
+		return
+	}
+	if usedAt != nil {
+		writeError(c, http.StatusConflict, "Invite has already been used")
+		return
+	}
+	if !expiresAt.After(time.Now().UTC()) {
+		writeError(c, http.StatusGone, "Invite has expired")
+		return
+	}
+
+	if ownerUserID == user.ID {
+		writeError(c, http.StatusConflict, "User is already a member of this household")
+		return
+	}
+	var existingStatus string
+	err = tx.QueryRow(
+		c.Request.Context(),
+		`SELECT status::text FROM "HouseholdMember" WHERE "householdId" = $1 AND "userId" = $2`,
+		householdID,
+		user.ID,
+	).Scan(&existingStatus)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		writeError(c, http.StatusInternalServerError, "Failed to load household membership")
+		return
+	}
+	if existingStatus == "ACTIVE" {
+		writeError(c, http.StatusConflict, "User is already a member of this household")
+		return
+	}
+
+	if _, err := tx.Exec(
+		c.Request.Context(),
+		`INSERT INTO "HouseholdMember" (id, "householdId", "userId", role, status, "createdAt")
+		 VALUES ($1, $2, $3, $4, 'ACTIVE', NOW())
+		 ON CONFLICT ("householdId", "userId") DO UPDATE SET role = EXCLUDED.role, status = 'ACTIVE'`,
+		uuid.NewString(),
+		householdID,
+		user.ID,
+		role,
+	); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to add household member")
+		return
+	}
+	if _, err := tx.Exec(
+		c.Request.Context(),
+		`UPDATE "Invite" SET "usedAt" = NOW() WHERE id = $1`,
+		inviteID,
+	); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to update invite")
+		return
+	}
+
+	if err := recordAuditLog(
+		c.Request.Context(),
+		tx,
+		householdID,
+		user.ID,
+		"HOUSEHOLD_INVITE_ACCEPTED",
+		"Invite",
+		&inviteID,
+		gin.H{"member_user_id": user.ID, "role": role},
+	); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to write audit log")
+		return
+	}
+
+	if err := tx.Commit(c.Request.Context()); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"household_id": householdID,
+		"role":         role,
+		"status":       "ACTIVE",
+	})
+}
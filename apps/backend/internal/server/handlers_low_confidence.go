@@ -0,0 +1,130 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	lowConfidenceDefaultThreshold = 0.8
+	lowConfidenceMaxEvents        = 200
+)
+
+// minConfidence returns the lowest per-field confidence the voice parser
+// reported for an event.
+func minConfidence(confidence map[string]float64) (float64, bool) {
+	if len(confidence) == 0 {
+		return 0, false
+	}
+	lowest := 1.0
+	for _, value := range confidence {
+		if value < lowest {
+			lowest = value
+		}
+	}
+	return lowest, true
+}
+
+// lowConfidenceFields lists the fields below threshold, sorted for stable
+// output.
+func lowConfidenceFields(confidence map[string]any, threshold float64) []string {
+	fields := make([]string, 0, len(confidence))
+	for field := range confidence {
+		if extractNumberFromMap(confidence, field) < threshold {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// getLowConfidenceEvents is the review queue for voice-logged events whose
+// weakest parsed field fell below threshold. Only voice events carry
+// confidence, so manual and imported events never show up here.
+func (a *App) getLowConfidenceEvents(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	threshold := lowConfidenceDefaultThreshold
+	if raw := strings.TrimSpace(c.Query("threshold")); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil || parsed < 0 || parsed > 1 {
+			writeError(c, http.StatusBadRequest, "threshold must be between 0 and 1")
+			return
+		}
+		threshold = parsed
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, c.Param("baby_id"), readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT id, type, "startTime", "endTime", "valueJson", "metadataJson"
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND source = 'VOICE'
+		   AND "metadataJson" ? 'min_confidence'
+		   AND ("metadataJson"->>'min_confidence')::double precision < $2
+		   AND NOT (`+openEventPredicateSQL+`)
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
+		   AND `+eventVisibleToUserSQL("$3")+`
+		 ORDER BY "startTime" DESC, id DESC
+		 LIMIT $4`,
+		baby.ID,
+		threshold,
+		user.ID,
+		lowConfidenceMaxEvents,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load events")
+		return
+	}
+	defer rows.Close()
+
+	events := make([]gin.H, 0)
+	for rows.Next() {
+		var eventID, eventType string
+		var startTime time.Time
+		var endTime *time.Time
+		var valueRaw, metadataRaw []byte
+		if err := rows.Scan(&eventID, &eventType, &startTime, &endTime, &valueRaw, &metadataRaw); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to parse events")
+			return
+		}
+		metadata := parseJSONStringMap(metadataRaw)
+		confidence, _ := metadata["confidence"].(map[string]any)
+		events = append(events, gin.H{
+			"event_id":       eventID,
+			"type":           eventType,
+			"start_time":     startTime.UTC().Format(time.RFC3339),
+			"end_time":       formatNullableTimeRFC3339(endTime),
+			"value":          parseJSONStringMap(valueRaw),
+			"min_confidence": extractNumberFromMap(metadata, "min_confidence"),
+			"confidence":     confidence,
+			"low_fields":     lowConfidenceFields(confidence, threshold),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse events")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"baby_id":   baby.ID,
+		"threshold": threshold,
+		"events":    events,
+		"count":     len(events),
+	})
+}
@@ -384,10 +384,18 @@ func (a *App) getMySubscription(c *gin.Context) {
 		return
 	}
 
+	aiModel, err := a.householdAIModel(c.Request.Context(), householdID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load subscription")
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"household_id": householdID,
 		"plan":         normalizeSubscriptionPlan(plan),
 		"status":       strings.ToLower(normalizeSubscriptionStatus(statusValue)),
+		"ai_model":     nullableString(aiModel),
+		"ai_models":    a.selectableAIModels(),
 	})
 }
 
@@ -420,6 +428,16 @@ func (a *App) checkoutSubscription(c *gin.Context) {
 		writeError(c, statusCode, err.Error())
 		return
 	}
+	// The provider echoes externalId back in billing webhooks; it is kept
+	// across repeat checkouts so earlier provider records still resolve.
+	externalID, err := a.lookupSubscriptionExternalID(c.Request.Context(), payload.HouseholdID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load subscription")
+		return
+	}
+	if externalID == "" {
+		externalID = uuid.NewString()
+	}
 
 	tx, err := a.db.Begin(c.Request.Context())
 	if err != nil {
@@ -440,11 +458,12 @@ func (a *App) checkoutSubscription(c *gin.Context) {
 		subscriptionID = uuid.NewString()
 		if _, err := tx.Exec(
 			c.Request.Context(),
-			`INSERT INTO "Subscription" (id, "householdId", plan, status, "createdAt")
-			 VALUES ($1, $2, $3, 'TRIALING', NOW())`,
+			`INSERT INTO "Subscription" (id, "householdId", plan, status, "externalId", "createdAt")
+			 VALUES ($1, $2, $3, 'TRIALING', $4, NOW())`,
 			subscriptionID,
 			payload.HouseholdID,
 			payload.Plan,
+			externalID,
 		); err != nil {
 			writeError(c, http.StatusInternalServerError, "Failed to create subscription")
 			return
@@ -455,9 +474,10 @@ func (a *App) checkoutSubscription(c *gin.Context) {
 	} else {
 		if _, err := tx.Exec(
 			c.Request.Context(),
-			`UPDATE "Subscription" SET plan = $2, status = 'TRIALING' WHERE id = $1`,
+			`UPDATE "Subscription" SET plan = $2, status = 'TRIALING', "externalId" = COALESCE("externalId", $3) WHERE id = $1`,
 			subscriptionID,
 			payload.Plan,
+			externalID,
 		); err != nil {
 			writeError(c, http.StatusInternalServerError, "Failed to update subscription")
 			return
@@ -501,6 +521,7 @@ func (a *App) checkoutSubscription(c *gin.Context) {
 		"status":                  "pending_payment",
 		"plan":                    payload.Plan,
 		"household_id":            payload.HouseholdID,
+		"external_id":             externalID,
 		"care_meta_enriched_baby": enrichedChildren,
 	})
 }
@@ -694,6 +715,7 @@ func (a *App) buildBreastfeedPreanalysisMetadata(
 	query := `SELECT "startTime", "endTime"
 	          FROM "Event"
 	          WHERE "babyId" = $1
+	            AND "deletedAt" IS NULL
 	            AND type = 'BREASTFEED'
 	            AND "startTime" >= $2
 	            AND NOT (
@@ -712,6 +734,7 @@ func (a *App) buildBreastfeedPreanalysisMetadata(
 			`SELECT "startTime", "endTime"
 			 FROM "Event"
 			 WHERE "babyId" = $1
+			   AND "deletedAt" IS NULL
 			   AND type = 'BREASTFEED'
 			   AND "startTime" >= $2
 			 ORDER BY "startTime" ASC`,
@@ -791,7 +814,7 @@ func (a *App) assistantDialog(ctx context.Context, babyID, tone, intent string)
 		err := a.db.QueryRow(
 			ctx,
 			`SELECT "startTime" FROM "Event"
-			 WHERE "babyId" = $1 AND type = 'POO'
+			 WHERE "babyId" = $1 AND "deletedAt" IS NULL AND type = 'POO'
 			 ORDER BY "startTime" DESC LIMIT 1`,
 			babyID,
 		).Scan(&lastPoo)
@@ -815,6 +838,7 @@ func (a *App) assistantDialog(ctx context.Context, babyID, tone, intent string)
 			ctx,
 			`SELECT "startTime" FROM "Event"
 			 WHERE "babyId" = $1
+			   AND "deletedAt" IS NULL
 			   AND type IN ('FORMULA', 'BREASTFEED')
 			   AND "startTime" <= $2
 			 ORDER BY "startTime" DESC LIMIT 10`,
@@ -862,7 +886,7 @@ func (a *App) assistantDialog(ctx context.Context, babyID, tone, intent string)
 		rows, err := a.db.Query(
 			ctx,
 			`SELECT type FROM "Event"
-			 WHERE "babyId" = $1 AND "startTime" >= $2 AND "startTime" < $3`,
+			 WHERE "babyId" = $1 AND "deletedAt" IS NULL AND "startTime" >= $2 AND "startTime" < $3`,
 			babyID,
 			start,
 			end,
@@ -938,7 +962,36 @@ func (a *App) handleSiriIntent(c *gin.Context, intent string) {
 		writeError(c, http.StatusInternalServerError, "Failed to build assistant response")
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"dialog": dialog, "reference": reference})
+	response := gin.H{"dialog": dialog, "reference": reference}
+	if !a.addAssistantFeedingRecommendation(c, response, user.ID, baby.ID, intent) {
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// addAssistantFeedingRecommendation adds the landing snapshot's recommended_*
+// numbers to a GetNextFeedingEta answer so clients can render a card without
+// parsing the sentence. Other intents are left as they are.
+func (a *App) addAssistantFeedingRecommendation(c *gin.Context, response gin.H, userID, babyID, intent string) bool {
+	if intent != "GetNextFeedingEta" {
+		return true
+	}
+	profile, statusCode, err := a.resolveBabyProfile(c.Request.Context(), userID, babyID, readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return false
+	}
+	lastFeeding, err := a.latestFeedingTime(c.Request.Context(), profile.BabyID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load latest feeding event")
+		return false
+	}
+	recommendation := calculateFeedingRecommendation(profile, lastFeeding, time.Now().UTC())
+	response["recommended_formula_per_feed_ml"] = recommendation.RecommendedFormulaPerFeedML
+	response["recommended_feed_interval_min"] = recommendation.RecommendedIntervalMin
+	response["recommended_next_feeding_time"] = formatNullableTimeRFC3339(recommendation.RecommendedNextFeedingTime)
+	response["recommended_next_feeding_in_min"] = recommendation.RecommendedNextFeedingInMin
+	return true
 }
 
 func (a *App) siriLastPoo(c *gin.Context) {
@@ -958,6 +1011,18 @@ func (a *App) siriDynamic(c *gin.Context) {
 	a.handleSiriIntent(c, intentName)
 }
 
+// voiceAssistantIntentOrSummary maps voice assistant actions that
+// assistantDialog does not know to GetTodaySummary, so a skill never answers
+// "Unsupported intent."
+func voiceAssistantIntentOrSummary(intent string) string {
+	switch intent {
+	case "GetLastPooTime", "GetNextFeedingEta", "GetTodaySummary":
+		return intent
+	default:
+		return "GetTodaySummary"
+	}
+}
+
 func (a *App) bixbyQuery(c *gin.Context) {
 	user, ok := authUserFromContext(c)
 	if !ok {
@@ -996,21 +1061,88 @@ func (a *App) bixbyQuery(c *gin.Context) {
 		return
 	}
 
-	intent := payload.CapsuleAction
-	switch intent {
-	case "GetLastPooTime", "GetNextFeedingEta", "GetTodaySummary":
-	default:
-		intent = "GetTodaySummary"
-	}
-
+	intent := voiceAssistantIntentOrSummary(payload.CapsuleAction)
 	dialog, _, err := a.assistantDialog(c.Request.Context(), baby.ID, payload.Tone, intent)
 	if err != nil {
 		writeError(c, http.StatusInternalServerError, "Failed to build assistant response")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"answer":       dialog,
 		"resultMoment": true,
+	}
+	if !a.addAssistantFeedingRecommendation(c, response, user.ID, baby.ID, intent) {
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+const alexaReprompt = "You can ask when the last poo was, when the next feeding is, or for today's summary."
+
+// alexaQuery answers an Alexa skill request. The skill backend forwards the
+// Alexa request envelope with baby_id (top level or as a slot) and gets an
+// Alexa response envelope back; the session stays open with a reprompt.
+func (a *App) alexaQuery(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var payload alexaQueryRequest
+	if !mustJSON(c, &payload) {
+		return
+	}
+	slots := payload.Request.Intent.Slots
+	babyID := strings.TrimSpace(payload.BabyID)
+	if babyID == "" {
+		babyID = strings.TrimSpace(slots["baby_id"].Value)
+	}
+	tone := payload.Tone
+	if strings.TrimSpace(tone) == "" {
+		tone = slots["tone"].Value
+	}
+	tone = normalizeTone(tone)
+	if babyID == "" {
+		writeError(c, http.StatusBadRequest, "baby_id is required")
+		return
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, babyID, readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+	hasFeature, plan, statusValue, err := a.hasSubscriptionFeature(
+		c.Request.Context(),
+		baby.HouseholdID,
+		subscriptionFeatureAI,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load subscription")
+		return
+	}
+	if !hasFeature {
+		a.writeSubscriptionRequired(c, subscriptionFeatureAI, plan, statusValue)
+		return
+	}
+
+	intent := voiceAssistantIntentOrSummary(strings.TrimSpace(payload.Request.Intent.Name))
+	dialog, _, err := a.assistantDialog(c.Request.Context(), baby.ID, tone, intent)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to build assistant response")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"version": "1.0",
+		"response": gin.H{
+			"outputSpeech": gin.H{"type": "PlainText", "text": dialog},
+			"reprompt": gin.H{
+				"outputSpeech": gin.H{"type": "PlainText", "text": alexaReprompt},
+			},
+			"shouldEndSession": false,
+		},
 	})
 }
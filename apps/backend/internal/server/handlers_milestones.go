@@ -0,0 +1,155 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	milestonesDefaultHorizonDays = 30
+	milestonesMaxHorizonDays     = 366
+)
+
+var milestoneMonths = []int{1, 2, 3, 6}
+
+type babyMilestone struct {
+	Key           string `json:"key"`
+	Label         string `json:"label"`
+	LabelKo       string `json:"label_ko"`
+	Date          string `json:"date"`
+	DaysFromToday int    `json:"days_from_today"`
+}
+
+// addMonthsClamped moves a calendar date by whole months, landing on the
+// month's last day when the birth day does not exist there, the same rule
+// ageMonthsFromBirthDate uses.
+func addMonthsClamped(date time.Time, months int) time.Time {
+	firstOfMonth := time.Date(date.Year(), date.Month()+time.Month(months), 1, 0, 0, 0, 0, date.Location())
+	day := date.Day()
+	if lastDay := daysInUTCMonth(firstOfMonth.Year(), firstOfMonth.Month()); day > lastDay {
+		day = lastDay
+	}
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, 0, 0, 0, 0, date.Location())
+}
+
+// babyMilestones splits the milestones within horizonDays of today into
+// recent, today and upcoming. 백일 follows the Korean count where the birth
+// day is day 1, so it falls 99 days after birth; the first birthday is 돌.
+func babyMilestones(birthDate, today time.Time, horizonDays int) ([]babyMilestone, []babyMilestone, []babyMilestone) {
+	birth := time.Date(birthDate.Year(), birthDate.Month(), birthDate.Day(), 0, 0, 0, 0, time.UTC)
+	todayDate := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	horizonEnd := todayDate.AddDate(0, 0, horizonDays)
+
+	candidates := []babyMilestone{{Key: "100_days", Label: "100 days", LabelKo: "백일", Date: birth.AddDate(0, 0, 99).Format("2006-01-02")}}
+	for _, months := range milestoneMonths {
+		candidates = append(candidates, babyMilestone{
+			Key:     fmt.Sprintf("%d_months", months),
+			Label:   fmt.Sprintf("%d months", months),
+			LabelKo: fmt.Sprintf("%d개월", months),
+			Date:    addMonthsClamped(birth, months).Format("2006-01-02"),
+		})
+	}
+	for year := 1; ; year++ {
+		birthday := addMonthsClamped(birth, 12*year)
+		if birthday.After(horizonEnd) {
+			break
+		}
+		milestone := babyMilestone{
+			Key:     fmt.Sprintf("birthday_%d", year),
+			Label:   fmt.Sprintf("Birthday %d", year),
+			LabelKo: fmt.Sprintf("%d번째 생일", year),
+			Date:    birthday.Format("2006-01-02"),
+		}
+		if year == 1 {
+			milestone.Label = "First birthday"
+			milestone.LabelKo = "돌"
+		}
+		candidates = append(candidates, milestone)
+	}
+
+	recent := []babyMilestone{}
+	todayList := []babyMilestone{}
+	upcoming := []babyMilestone{}
+	for _, milestone := range candidates {
+		date, _ := time.Parse("2006-01-02", milestone.Date)
+		milestone.DaysFromToday = int(date.Sub(todayDate).Hours() / 24)
+		switch {
+		case milestone.DaysFromToday == 0:
+			todayList = append(todayList, milestone)
+		case milestone.DaysFromToday < 0 && milestone.DaysFromToday >= -horizonDays:
+			recent = append(recent, milestone)
+		case milestone.DaysFromToday > 0 && milestone.DaysFromToday <= horizonDays:
+			upcoming = append(upcoming, milestone)
+		}
+	}
+	return recent, todayList, upcoming
+}
+
+func (a *App) getMilestones(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	localZone, tzNormalized, err := parseTZOffset(c.Query("tz_offset"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	horizonDays := milestonesDefaultHorizonDays
+	if raw := strings.TrimSpace(c.Query("horizon_days")); raw != "" {
+		parsed, parseErr := strconv.Atoi(raw)
+		if parseErr != nil || parsed <= 0 || parsed > milestonesMaxHorizonDays {
+			writeError(c, http.StatusBadRequest, fmt.Sprintf("horizon_days must be between 1 and %d", milestonesMaxHorizonDays))
+			return
+		}
+		horizonDays = parsed
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, c.Param("baby_id"), readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	var birthDate time.Time
+	err = a.db.QueryRow(
+		c.Request.Context(),
+		`SELECT "birthDate" FROM "Baby" WHERE id = $1`,
+		baby.ID,
+	).Scan(&birthDate)
+	if errors.Is(err, pgx.ErrNoRows) {
+		writeError(c, http.StatusNotFound, "Baby not found")
+		return
+	}
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load baby")
+		return
+	}
+
+	localNow := time.Now().In(localZone)
+	localToday := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, time.UTC)
+	recent, todayList, upcoming := babyMilestones(birthDate.UTC(), localToday, horizonDays)
+
+	c.JSON(http.StatusOK, gin.H{
+		"baby_id":      baby.ID,
+		"tz_offset":    tzNormalized,
+		"birth_date":   birthDate.UTC().Format("2006-01-02"),
+		"today":        localToday.Format("2006-01-02"),
+		"age_days":     ageDaysFromBirth(birthDate, localToday),
+		"age_months":   ageMonthsFromBirthDate(birthDate, localToday),
+		"horizon_days": horizonDays,
+		"milestones": gin.H{
+			"recent":   recent,
+			"today":    todayList,
+			"upcoming": upcoming,
+		},
+	})
+}
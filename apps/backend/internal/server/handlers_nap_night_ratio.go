@@ -0,0 +1,257 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	napNightDefaultDays = 14
+	napNightMaxDays     = 90
+	// napNightMinTrendDays is how many days with night sleep (and so a ratio)
+	// the trend needs before it says anything.
+	napNightMinTrendDays = 4
+	// napNightTrendTolerance is the relative change in the nap:night ratio
+	// between the two halves of the window that still counts as stable.
+	napNightTrendTolerance = 0.1
+
+	defaultNapStartHour   = 6
+	defaultNightStartHour = 18
+)
+
+type napNightDay struct {
+	Date     string   `json:"date"`
+	NapMin   int      `json:"nap_min"`
+	NightMin int      `json:"night_min"`
+	Ratio    *float64 `json:"ratio"`
+}
+
+// sleepBandForEvent decides whether a sleep is a nap or night sleep. An
+// explicit sleep_type on the event wins; otherwise the sleep is a nap when
+// most of it falls between napStartHour and nightStartHour local time. Ties
+// and sleeps without a length fall back to the start hour.
+func sleepBandForEvent(value map[string]any, startLocal time.Time, durationMin float64, napStartHour, nightStartHour int) string {
+	switch strings.ToLower(strings.TrimSpace(toString(value["sleep_type"]))) {
+	case "nap":
+		return "nap"
+	case "night":
+		return "night"
+	}
+	if durationMin > 0 {
+		endLocal := startLocal.Add(time.Duration(durationMin * float64(time.Minute)))
+		napMinutes := napWindowMinutes(startLocal, endLocal, napStartHour, nightStartHour)
+		switch {
+		case napMinutes*2 > durationMin:
+			return "nap"
+		case napMinutes*2 < durationMin:
+			return "night"
+		}
+	}
+	if startLocal.Hour() >= napStartHour && startLocal.Hour() < nightStartHour {
+		return "nap"
+	}
+	return "night"
+}
+
+// napWindowMinutes counts the minutes of [start, end) that fall inside the
+// daily nap window, across as many local days as the interval spans.
+func napWindowMinutes(start, end time.Time, napStartHour, nightStartHour int) float64 {
+	location := start.Location()
+	total := 0.0
+	for day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, location); day.Before(end); day = day.AddDate(0, 0, 1) {
+		from := time.Date(day.Year(), day.Month(), day.Day(), napStartHour, 0, 0, 0, location)
+		to := time.Date(day.Year(), day.Month(), day.Day(), nightStartHour, 0, 0, 0, location)
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		if to.After(from) {
+			total += to.Sub(from).Minutes()
+		}
+	}
+	return total
+}
+
+// napNightHoursFromSettings reads the per-baby nap/night boundary hours,
+// falling back to 06:00 and 18:00.
+func napNightHoursFromSettings(babySettings map[string]any) (int, int) {
+	napStartHour := defaultNapStartHour
+	nightStartHour := defaultNightStartHour
+	if _, ok := babySettings["nap_start_hour"]; ok {
+		napStartHour = int(extractNumberFromMap(babySettings, "nap_start_hour"))
+	}
+	if _, ok := babySettings["night_start_hour"]; ok {
+		nightStartHour = int(extractNumberFromMap(babySettings, "night_start_hour"))
+	}
+	if validateNapNightHours(napStartHour, nightStartHour) != nil {
+		return defaultNapStartHour, defaultNightStartHour
+	}
+	return napStartHour, nightStartHour
+}
+
+func validateNapNightHours(napStartHour, nightStartHour int) error {
+	if napStartHour < 0 || nightStartHour > 23 || napStartHour >= nightStartHour {
+		return errors.New("nap_start_hour and night_start_hour must be hours 0-23 with nap_start_hour before night_start_hour")
+	}
+	return nil
+}
+
+// napNightConsolidationTrend compares the average nap:night ratio of the
+// older and newer half of the days that have a ratio. A falling ratio means
+// sleep is consolidating toward the night.
+func napNightConsolidationTrend(days []napNightDay) (string, *float64, *float64) {
+	ratios := make([]float64, 0, len(days))
+	for _, day := range days {
+		if day.Ratio != nil {
+			ratios = append(ratios, *day.Ratio)
+		}
+	}
+	if len(ratios) < napNightMinTrendDays {
+		return "insufficient_data", nil, nil
+	}
+	half := len(ratios) / 2
+	older := averageFloat(ratios[:half])
+	newer := averageFloat(ratios[len(ratios)-half:])
+	olderRounded := roundToTwoDecimals(older)
+	newerRounded := roundToTwoDecimals(newer)
+	switch {
+	case older > 0 && (newer-older)/older <= -napNightTrendTolerance:
+		return "consolidating", &olderRounded, &newerRounded
+	case older > 0 && (newer-older)/older >= napNightTrendTolerance:
+		return "fragmenting", &olderRounded, &newerRounded
+	default:
+		return "stable", &olderRounded, &newerRounded
+	}
+}
+
+func averageFloat(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+	return total / float64(len(values))
+}
+
+func roundToTwoDecimals(value float64) float64 {
+	return float64(int(value*100+0.5)) / 100
+}
+
+func (a *App) getNapNightRatio(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	localZone, tzNormalized, err := parseTZOffset(c.Query("tz_offset"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	dayCount := napNightDefaultDays
+	if raw := strings.TrimSpace(c.Query("days")); raw != "" {
+		parsed, parseErr := strconv.Atoi(raw)
+		if parseErr != nil || parsed <= 0 || parsed > napNightMaxDays {
+			writeError(c, http.StatusBadRequest, fmt.Sprintf("days must be between 1 and %d", napNightMaxDays))
+			return
+		}
+		dayCount = parsed
+	}
+
+	profile, statusCode, err := a.resolveBabyProfile(c.Request.Context(), user.ID, c.Param("baby_id"), readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	localNow := time.Now().In(localZone)
+	today := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, localZone)
+	windowStart := today.AddDate(0, 0, -(dayCount - 1))
+	days := make([]napNightDay, dayCount)
+	dayIndex := make(map[string]int, dayCount)
+	for i := range days {
+		days[i].Date = windowStart.AddDate(0, 0, i).Format("2006-01-02")
+		dayIndex[days[i].Date] = i
+	}
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT "startTime", "endTime", "valueJson"
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND type = 'SLEEP'
+		   AND "startTime" >= $2
+		   AND "startTime" < $3
+		   AND "endTime" IS NOT NULL
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
+		   AND `+eventVisibleToUserSQL("$4"),
+		profile.BabyID,
+		windowStart.UTC(),
+		today.AddDate(0, 0, 1).UTC(),
+		user.ID,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load sleep events")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var startTime time.Time
+		var endTime *time.Time
+		var valueRaw []byte
+		if err := rows.Scan(&startTime, &endTime, &valueRaw); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to parse sleep events")
+			return
+		}
+		value := parseJSONStringMap(valueRaw)
+		if isZeroDurationSleep("SLEEP", value, startTime, endTime) {
+			continue
+		}
+		duration := extractDurationMinutes(value, startTime, endTime)
+		if duration == nil {
+			continue
+		}
+		startLocal := startTime.In(localZone)
+		index, found := dayIndex[startLocal.Format("2006-01-02")]
+		if !found {
+			continue
+		}
+		if sleepBandForEvent(value, startLocal, *duration, profile.NapStartHour, profile.NightStartHour) == "nap" {
+			days[index].NapMin += int(*duration + 0.5)
+		} else {
+			days[index].NightMin += int(*duration + 0.5)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse sleep events")
+		return
+	}
+	for i := range days {
+		if days[i].NightMin > 0 {
+			ratio := roundToTwoDecimals(float64(days[i].NapMin) / float64(days[i].NightMin))
+			days[i].Ratio = &ratio
+		}
+	}
+
+	trend, olderRatio, newerRatio := napNightConsolidationTrend(days)
+	c.JSON(http.StatusOK, gin.H{
+		"baby_id":           profile.BabyID,
+		"tz_offset":         tzNormalized,
+		"days":              days,
+		"trend":             trend,
+		"earlier_avg_ratio": olderRatio,
+		"recent_avg_ratio":  newerRatio,
+	})
+}
@@ -0,0 +1,258 @@
+package server
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	nextNapHistoryDays = 7
+	// nextNapMinObservedGaps is how many daytime wake windows the observed
+	// average needs before it is trusted over the age range alone.
+	nextNapMinObservedGaps = 3
+	// nextNapWindowHalfWidthMin is how far either side of the target wake
+	// window the suggested put-down window reaches.
+	nextNapWindowHalfWidthMin = 15
+)
+
+// wakeWindowRange is the commonly recommended awake time between sleeps for
+// an age, in minutes. It widens as naps consolidate.
+func wakeWindowRange(ageDays int) (int, int) {
+	switch {
+	case ageDays < 42:
+		return 45, 60
+	case ageDays < 90:
+		return 60, 90
+	case ageDays < 150:
+		return 75, 120
+	case ageDays < 210:
+		return 120, 180
+	case ageDays < 300:
+		return 150, 210
+	case ageDays < 420:
+		return 180, 240
+	case ageDays < 540:
+		return 240, 300
+	default:
+		return 300, 360
+	}
+}
+
+type nextNapWindow struct {
+	Earliest         time.Time
+	Latest           time.Time
+	TargetWakeMin    int
+	AgeWakeMin       int
+	AgeWakeMax       int
+	ObservedAvgMin   *int
+	ObservedGapCount int
+	Unstable         bool
+}
+
+// computeNextNapWindow picks a target wake window from the observed daytime
+// average when there are enough samples, clamped to the age range, and falls
+// back to the middle of the age range otherwise. The suggested window is the
+// target plus or minus nextNapWindowHalfWidthMin, kept inside the age range.
+func computeNextNapWindow(ageDays int, lastSleepEnd time.Time, wakeGapsMin []float64) nextNapWindow {
+	ageMin, ageMax := wakeWindowRange(ageDays)
+	result := nextNapWindow{AgeWakeMin: ageMin, AgeWakeMax: ageMax}
+
+	observed := make([]float64, 0, len(wakeGapsMin))
+	for _, gap := range wakeGapsMin {
+		// Gaps far outside the age range are usually missed logs, not real
+		// wake windows.
+		if gap >= float64(ageMin)/2 && gap <= float64(ageMax)*2 {
+			observed = append(observed, gap)
+		}
+	}
+	result.ObservedGapCount = len(observed)
+
+	target := (ageMin + ageMax) / 2
+	if len(observed) > 0 {
+		average := int(math.Round(averageFloat(observed)))
+		result.ObservedAvgMin = &average
+	}
+	if len(observed) >= nextNapMinObservedGaps {
+		target = *result.ObservedAvgMin
+		if target < ageMin {
+			target = ageMin
+		}
+		if target > ageMax {
+			target = ageMax
+		}
+	} else {
+		result.Unstable = true
+	}
+	result.TargetWakeMin = target
+
+	earliest := target - nextNapWindowHalfWidthMin
+	if earliest < ageMin {
+		earliest = ageMin
+	}
+	latest := target + nextNapWindowHalfWidthMin
+	if latest > ageMax {
+		latest = ageMax
+	}
+	result.Earliest = lastSleepEnd.Add(time.Duration(earliest) * time.Minute)
+	result.Latest = lastSleepEnd.Add(time.Duration(latest) * time.Minute)
+	return result
+}
+
+// getNextNapWindow suggests when to put the baby down next, counted from the
+// end of the last sleep.
+func (a *App) getNextNapWindow(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	localZone, tzNormalized, err := parseTZOffset(c.Query("tz_offset"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	babyID := strings.TrimSpace(c.Param("baby_id"))
+	if babyID == "" {
+		writeError(c, http.StatusBadRequest, "baby_id is required")
+		return
+	}
+	profile, statusCode, err := a.resolveBabyProfile(c.Request.Context(), user.ID, babyID, readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	nowUTC := time.Now().UTC()
+	var openSleepStart time.Time
+	err = a.db.QueryRow(
+		c.Request.Context(),
+		`SELECT "startTime" FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND type = 'SLEEP'
+		   AND `+openEventPredicateSQL+`
+		   AND `+eventVisibleToUserSQL("$2")+`
+		 ORDER BY "startTime" DESC
+		 LIMIT 1`,
+		profile.BabyID,
+		user.ID,
+	).Scan(&openSleepStart)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		writeError(c, http.StatusInternalServerError, "Failed to load sleep events")
+		return
+	}
+	if err == nil {
+		c.JSON(http.StatusOK, gin.H{
+			"baby_id":            profile.BabyID,
+			"tz_offset":          tzNormalized,
+			"currently_sleeping": true,
+			"sleep_started_at":   openSleepStart.UTC().Format(time.RFC3339),
+			"window":             nil,
+			"unstable":           true,
+			"reference_text":     "A sleep is in progress; the next window starts from when it ends.",
+		})
+		return
+	}
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT "startTime", "endTime", "valueJson"
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND type = 'SLEEP'
+		   AND "startTime" >= $2
+		   AND "startTime" <= $3
+		   AND "endTime" IS NOT NULL
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
+		   AND `+eventVisibleToUserSQL("$4")+`
+		 ORDER BY "startTime" ASC, id ASC`,
+		profile.BabyID,
+		nowUTC.AddDate(0, 0, -nextNapHistoryDays),
+		nowUTC,
+		user.ID,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load sleep events")
+		return
+	}
+	defer rows.Close()
+
+	var lastSleepEnd *time.Time
+	wakeGaps := make([]float64, 0)
+	for rows.Next() {
+		var startTime time.Time
+		var endTime *time.Time
+		var valueRaw []byte
+		if err := rows.Scan(&startTime, &endTime, &valueRaw); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to parse sleep events")
+			return
+		}
+		if isZeroDurationSleep("SLEEP", parseJSONStringMap(valueRaw), startTime, endTime) {
+			continue
+		}
+		// Only daytime wakes count; short night wakings would pull the
+		// average far below a real nap wake window.
+		if lastSleepEnd != nil && startTime.After(*lastSleepEnd) {
+			wakeLocal := lastSleepEnd.In(localZone)
+			if wakeLocal.Hour() >= 6 && wakeLocal.Hour() < 20 {
+				wakeGaps = append(wakeGaps, startTime.Sub(*lastSleepEnd).Minutes())
+			}
+		}
+		if lastSleepEnd == nil || endTime.After(*lastSleepEnd) {
+			end := endTime.UTC()
+			lastSleepEnd = &end
+		}
+	}
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse sleep events")
+		return
+	}
+
+	if lastSleepEnd == nil {
+		ageMin, ageMax := wakeWindowRange(profile.AgeDays)
+		c.JSON(http.StatusOK, gin.H{
+			"baby_id":            profile.BabyID,
+			"tz_offset":          tzNormalized,
+			"currently_sleeping": false,
+			"window":             nil,
+			"age_wake_window":    gin.H{"min_minutes": ageMin, "max_minutes": ageMax},
+			"unstable":           true,
+			"reference_text":     "No sleep records in the last 7 days. Log a sleep to get a nap window.",
+		})
+		return
+	}
+
+	result := computeNextNapWindow(profile.AgeDays, *lastSleepEnd, wakeGaps)
+	referenceText := "Based on the age-recommended wake window and recent daytime wake windows."
+	if result.Unstable {
+		referenceText = "Not enough recent daytime sleep records; using the age-recommended wake window."
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"baby_id":            profile.BabyID,
+		"tz_offset":          tzNormalized,
+		"currently_sleeping": false,
+		"last_sleep_end":     lastSleepEnd.Format(time.RFC3339),
+		"awake_minutes":      int(nowUTC.Sub(*lastSleepEnd).Minutes()),
+		"window": gin.H{
+			"earliest":       result.Earliest.UTC().Format(time.RFC3339),
+			"latest":         result.Latest.UTC().Format(time.RFC3339),
+			"earliest_local": result.Earliest.In(localZone).Format("15:04"),
+			"latest_local":   result.Latest.In(localZone).Format("15:04"),
+		},
+		"overdue":                 nowUTC.After(result.Latest),
+		"target_wake_minutes":     result.TargetWakeMin,
+		"age_wake_window":         gin.H{"min_minutes": result.AgeWakeMin, "max_minutes": result.AgeWakeMax},
+		"observed_avg_wake_min":   result.ObservedAvgMin,
+		"observed_wake_gap_count": result.ObservedGapCount,
+		"unstable":                result.Unstable,
+		"reference_text":          referenceText,
+	})
+}
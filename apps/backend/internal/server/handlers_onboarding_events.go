@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"errors"
+	"io"
 	"log"
 	"net/http"
 	"strconv"
@@ -24,6 +25,54 @@ var startableManualEventTypes = map[string]struct{}{
 	"MEMO":       {},
 }
 
+// concurrentOpenEventTypes may have several open events at once when the
+// start request sets allow_concurrent. Every other startable type (FORMULA,
+// BREASTFEED, SLEEP, PEE, POO) stays exclusive: one open event per type.
+var concurrentOpenEventTypes = map[string]struct{}{
+	"MEDICATION": {},
+	"MEMO":       {},
+}
+
+// openEventPredicateSQL matches in-progress Event rows. Legacy rows created
+// through manual_start before event_state existed are still treated as open.
+const openEventPredicateSQL = `"endTime" IS NULL
+		  AND (
+		    COALESCE("metadataJson"->>'event_state', '') = 'OPEN'
+		    OR COALESCE("metadataJson"->>'entry_mode', '') = 'manual_start'
+		  )`
+
+const (
+	eventVisibilityHousehold = "household"
+	eventVisibilityPrivate   = "private"
+)
+
+// eventVisibleToUserSQL hides private events from everyone but their author.
+// userParam is the bind placeholder (e.g. "$2") that carries the viewer's id.
+func eventVisibleToUserSQL(userParam string) string {
+	return `(COALESCE("metadataJson"->>'visibility', '') <> '` + eventVisibilityPrivate + `' OR "createdBy" = ` + userParam + `)`
+}
+
+// normalizeEventVisibility validates the requested visibility for an event
+// type. Only MEMO events may be private; empty means household-visible.
+func normalizeEventVisibility(raw, eventType string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", eventVisibilityHousehold:
+		return eventVisibilityHousehold, nil
+	case eventVisibilityPrivate:
+		if eventType != "MEMO" {
+			return "", errors.New("visibility private is only supported for MEMO events")
+		}
+		return eventVisibilityPrivate, nil
+	default:
+		return "", errors.New("visibility must be one of: household, private")
+	}
+}
+
+const (
+	staleOpenEventDefaultMin = 120
+	staleOpenEventMaxMin     = 7 * 24 * 60
+)
+
 type onboardingDummySeedEvent struct {
 	Type      string
 	StartTime time.Time
@@ -31,6 +80,112 @@ type onboardingDummySeedEvent struct {
 	Value     map[string]any
 }
 
+const onboardingMaxBabies = 6
+
+type onboardingBaby struct {
+	Name                  string
+	BirthDate             time.Time
+	Sex                   string
+	WeightKg              *float64
+	FeedingMethod         string
+	FormulaBrand          string
+	FormulaProduct        string
+	FormulaType           string
+	FormulaContainsStarch *bool
+	GestationalWeeks      *int
+}
+
+// onboardingBabiesFromRequest returns the babies to create, from the babies
+// array or else the legacy single-baby fields. Error messages name the field
+// the client sent, e.g. baby_name or babies[1].name.
+func onboardingBabiesFromRequest(payload parentOnboardingRequest) ([]onboardingBaby, error) {
+	if len(payload.Babies) == 0 {
+		legacy := parentOnboardingBaby{
+			Name:                  payload.BabyName,
+			BirthDate:             payload.BabyBirthDate,
+			Sex:                   payload.BabySex,
+			WeightKg:              payload.BabyWeightKg,
+			FeedingMethod:         payload.FeedingMethod,
+			FormulaBrand:          payload.FormulaBrand,
+			FormulaProduct:        payload.FormulaProduct,
+			FormulaType:           payload.FormulaType,
+			FormulaContainsStarch: payload.FormulaContainsStarch,
+			GestationalWeeks:      payload.GestationalWeeks,
+		}
+		baby, err := normalizeOnboardingBaby(legacy, func(key string) string {
+			switch key {
+			case "name", "birth_date", "sex":
+				return "baby_" + key
+			}
+			return key
+		})
+		if err != nil {
+			return nil, err
+		}
+		return []onboardingBaby{baby}, nil
+	}
+	if len(payload.Babies) > onboardingMaxBabies {
+		return nil, errors.New("babies must have at most " + strconv.Itoa(onboardingMaxBabies) + " items")
+	}
+
+	babies := make([]onboardingBaby, 0, len(payload.Babies))
+	for idx, input := range payload.Babies {
+		prefix := "babies[" + strconv.Itoa(idx) + "]."
+		baby, err := normalizeOnboardingBaby(input, func(key string) string { return prefix + key })
+		if err != nil {
+			return nil, err
+		}
+		babies = append(babies, baby)
+	}
+	return babies, nil
+}
+
+func normalizeOnboardingBaby(input parentOnboardingBaby, field func(string) string) (onboardingBaby, error) {
+	baby := onboardingBaby{
+		Name:                  strings.TrimSpace(input.Name),
+		WeightKg:              input.WeightKg,
+		FormulaBrand:          strings.TrimSpace(input.FormulaBrand),
+		FormulaProduct:        strings.TrimSpace(input.FormulaProduct),
+		FormulaContainsStarch: input.FormulaContainsStarch,
+		GestationalWeeks:      input.GestationalWeeks,
+	}
+	if baby.Name == "" {
+		return onboardingBaby{}, errors.New(field("name") + " is required")
+	}
+	birthDate, err := parseDate(input.BirthDate)
+	if err != nil {
+		return onboardingBaby{}, errors.New(field("birth_date") + " must be YYYY-MM-DD")
+	}
+	baby.BirthDate = birthDate
+	baby.Sex = normalizeBabySex(input.Sex)
+	if strings.TrimSpace(input.Sex) != "" && baby.Sex == "" {
+		return onboardingBaby{}, errors.New(field("sex") + " must be one of: male, female, other, unknown")
+	}
+	if baby.Sex == "" {
+		baby.Sex = "unknown"
+	}
+	baby.FeedingMethod = normalizeFeedingMethod(input.FeedingMethod)
+	if strings.TrimSpace(input.FeedingMethod) != "" && baby.FeedingMethod == "" {
+		return onboardingBaby{}, errors.New(field("feeding_method") + " must be one of: formula, breastmilk, mixed")
+	}
+	if baby.FeedingMethod == "" {
+		baby.FeedingMethod = "mixed"
+	}
+	baby.FormulaType = normalizeFormulaType(input.FormulaType)
+	if strings.TrimSpace(input.FormulaType) != "" && baby.FormulaType == "" {
+		return onboardingBaby{}, errors.New(field("formula_type") + " is invalid")
+	}
+	if baby.FormulaType == "" {
+		baby.FormulaType = "standard"
+	}
+	if baby.GestationalWeeks != nil {
+		if err := validateGestationalWeeks(*baby.GestationalWeeks); err != nil {
+			return onboardingBaby{}, errors.New(field("gestational_weeks") + strings.TrimPrefix(err.Error(), "gestational_weeks"))
+		}
+	}
+	return baby, nil
+}
+
 func (a *App) onboardingParent(c *gin.Context) {
 	user, ok := authUserFromContext(c)
 	if !ok {
@@ -43,39 +198,28 @@ func (a *App) onboardingParent(c *gin.Context) {
 		return
 	}
 	provider := providerFromClaim(payload.Provider)
-	if strings.TrimSpace(payload.BabyName) == "" {
-		writeError(c, http.StatusBadRequest, "baby_name is required")
-		return
-	}
-	birthDate, err := parseDate(payload.BabyBirthDate)
+	babies, err := onboardingBabiesFromRequest(payload)
 	if err != nil {
-		writeError(c, http.StatusBadRequest, "baby_birth_date must be YYYY-MM-DD")
-		return
-	}
-	normalizedSex := normalizeBabySex(payload.BabySex)
-	if strings.TrimSpace(payload.BabySex) != "" && normalizedSex == "" {
-		writeError(c, http.StatusBadRequest, "baby_sex must be one of: male, female, other, unknown")
+		writeError(c, http.StatusBadRequest, err.Error())
 		return
 	}
-	if normalizedSex == "" {
-		normalizedSex = "unknown"
-	}
-	feedingMethod := normalizeFeedingMethod(payload.FeedingMethod)
-	if strings.TrimSpace(payload.FeedingMethod) != "" && feedingMethod == "" {
-		writeError(c, http.StatusBadRequest, "feeding_method must be one of: formula, breastmilk, mixed")
-		return
+	seedPreset := onboardingSeedPresetForAge(ageDaysFromBirth(babies[0].BirthDate, time.Now().UTC()))
+	if raw := strings.TrimSpace(payload.DummySeedPreset); raw != "" {
+		seedPreset = normalizeOnboardingSeedPreset(raw)
+		if seedPreset == "" {
+			writeError(c, http.StatusBadRequest, "dummy_seed_preset must be one of: newborn, 6mo, toddler")
+			return
+		}
 	}
-	if feedingMethod == "" {
-		feedingMethod = "mixed"
+	seedTZOffset := strings.TrimSpace(payload.TZOffset)
+	if seedTZOffset == "" {
+		seedTZOffset = onboardingSeedDefaultTZOffset
 	}
-	formulaType := normalizeFormulaType(payload.FormulaType)
-	if strings.TrimSpace(payload.FormulaType) != "" && formulaType == "" {
-		writeError(c, http.StatusBadRequest, "formula_type is invalid")
+	seedLocation, _, err := parseTZOffset(seedTZOffset)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
 		return
 	}
-	if formulaType == "" {
-		formulaType = "standard"
-	}
 
 	consentMap := map[string]string{
 		"terms":           "TERMS",
@@ -120,46 +264,58 @@ func (a *App) onboardingParent(c *gin.Context) {
 		return
 	}
 
-	babyID := uuid.NewString()
-	var sexValue any
-	if normalizedSex == "unknown" {
-		sexValue = nil
-	} else {
-		sexValue = normalizedSex
-	}
-
-	if _, err := tx.Exec(
-		c.Request.Context(),
-		`INSERT INTO "Baby" (id, "householdId", name, "birthDate", sex, "createdAt")
-		 VALUES ($1, $2, $3, $4, $5, NOW())`,
-		babyID,
-		householdID,
-		strings.TrimSpace(payload.BabyName),
-		birthDate,
-		sexValue,
-	); err != nil {
-		writeError(c, http.StatusInternalServerError, "Failed to create baby profile")
-		return
-	}
-
 	persona, err := loadPersonaSettingsWithQuerier(c.Request.Context(), tx, user.ID)
 	if err != nil {
 		writeError(c, http.StatusInternalServerError, "Failed to load settings")
 		return
 	}
-	babySettings := readBabySettings(persona, babyID)
-	if payload.BabyWeightKg != nil {
-		babySettings["weight_kg"] = roundToOneDecimal(clampWeightKg(*payload.BabyWeightKg))
-	}
-	babySettings["feeding_method"] = feedingMethod
-	babySettings["formula_brand"] = strings.TrimSpace(payload.FormulaBrand)
-	babySettings["formula_product"] = strings.TrimSpace(payload.FormulaProduct)
-	babySettings["formula_type"] = formulaType
-	if payload.FormulaContainsStarch != nil {
-		babySettings["formula_contains_starch"] = *payload.FormulaContainsStarch
+
+	// resolvePrimaryChildForHousehold picks the oldest createdAt, so babies
+	// are spaced a millisecond apart to keep the first one primary.
+	createdAt := time.Now().UTC()
+	babyIDs := make([]string, 0, len(babies))
+	for idx, baby := range babies {
+		babyID := uuid.NewString()
+		var sexValue any
+		if baby.Sex == "unknown" {
+			sexValue = nil
+		} else {
+			sexValue = baby.Sex
+		}
+
+		if _, err := tx.Exec(
+			c.Request.Context(),
+			`INSERT INTO "Baby" (id, "householdId", name, "birthDate", sex, "createdAt")
+			 VALUES ($1, $2, $3, $4, $5, $6)`,
+			babyID,
+			householdID,
+			baby.Name,
+			baby.BirthDate,
+			sexValue,
+			createdAt.Add(time.Duration(idx)*time.Millisecond),
+		); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to create baby profile")
+			return
+		}
+
+		babySettings := readBabySettings(persona, babyID)
+		if baby.WeightKg != nil {
+			babySettings["weight_kg"] = roundToOneDecimal(clampWeightKg(*baby.WeightKg))
+		}
+		babySettings["feeding_method"] = baby.FeedingMethod
+		babySettings["formula_brand"] = baby.FormulaBrand
+		babySettings["formula_product"] = baby.FormulaProduct
+		babySettings["formula_type"] = baby.FormulaType
+		if baby.FormulaContainsStarch != nil {
+			babySettings["formula_contains_starch"] = *baby.FormulaContainsStarch
+		}
+		if baby.GestationalWeeks != nil {
+			babySettings["gestational_weeks"] = *baby.GestationalWeeks
+		}
+		babySettings["updated_at"] = time.Now().UTC().Format(time.RFC3339)
+		writeBabySettings(persona, babyID, babySettings)
+		babyIDs = append(babyIDs, babyID)
 	}
-	babySettings["updated_at"] = time.Now().UTC().Format(time.RFC3339)
-	writeBabySettings(persona, babyID, babySettings)
 	if err := upsertPersonaSettingsWithQuerier(c.Request.Context(), tx, user.ID, persona); err != nil {
 		writeError(c, http.StatusInternalServerError, "Failed to save settings")
 		return
@@ -181,19 +337,25 @@ func (a *App) onboardingParent(c *gin.Context) {
 
 	dummySeeded := false
 	dummySeededCount := 0
+	var dummySeedPreset any
 	if a.cfg.OnboardingSeedDummyData {
-		seededCount, seedErr := a.seedOnboardingDummyData(
-			c.Request.Context(),
-			tx,
-			babyID,
-			user.ID,
-			birthDate.UTC(),
-		)
-		if seedErr != nil {
-			log.Printf("onboarding dummy seed failed baby_id=%s user_id=%s err=%v", babyID, user.ID, seedErr)
-		} else if seededCount > 0 {
-			dummySeeded = true
-			dummySeededCount = seededCount
+		dummySeedPreset = seedPreset
+		for idx, babyID := range babyIDs {
+			seededCount, seedErr := a.seedOnboardingDummyData(
+				c.Request.Context(),
+				tx,
+				babyID,
+				user.ID,
+				babies[idx].BirthDate.UTC(),
+				seedLocation,
+				seedPreset,
+			)
+			if seedErr != nil {
+				log.Printf("onboarding dummy seed failed baby_id=%s user_id=%s err=%v", babyID, user.ID, seedErr)
+			} else if seededCount > 0 {
+				dummySeeded = true
+				dummySeededCount += seededCount
+			}
 		}
 	}
 
@@ -205,7 +367,7 @@ func (a *App) onboardingParent(c *gin.Context) {
 		"ONBOARDING_PARENT_COMPLETED",
 		"Household",
 		&householdID,
-		gin.H{"baby_id": babyID, "provider": provider},
+		gin.H{"baby_id": babyIDs[0], "baby_ids": babyIDs, "provider": provider},
 	); err != nil {
 		writeError(c, http.StatusInternalServerError, "Failed to write audit log")
 		return
@@ -219,62 +381,152 @@ func (a *App) onboardingParent(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"status":               "created",
 		"household_id":         householdID,
-		"baby_id":              babyID,
+		"baby_id":              babyIDs[0],
+		"baby_ids":             babyIDs,
 		"baby_profile_created": true,
 		"provider":             provider,
 		"dummy_seeded":         dummySeeded,
 		"dummy_seeded_count":   dummySeededCount,
+		"dummy_seed_preset":    dummySeedPreset,
 	})
 }
 
-func buildOnboardingDummySeedEvents(nowUTC time.Time) []onboardingDummySeedEvent {
-	// Image-based temporary dummy seed preset (timeline screenshot provided by user).
-	kst := time.FixedZone("KST", 9*60*60)
-	nowLocal := nowUTC.In(kst)
-	seedDay := time.Date(nowLocal.Year(), nowLocal.Month(), nowLocal.Day(), 0, 0, 0, 0, kst)
-	// If onboarding happens earlier in the day, shift to previous day so all preset events are in the past.
-	if nowLocal.Hour() < 16 {
+const (
+	onboardingSeedPresetNewborn = "newborn"
+	onboardingSeedPresetSixMo   = "6mo"
+	onboardingSeedPresetToddler = "toddler"
+	// onboardingSeedDefaultTZOffset keeps the original KST demo timeline for
+	// clients that do not send tz_offset.
+	onboardingSeedDefaultTZOffset = "+09:00"
+)
+
+// onboardingSeedSpec is one preset entry in local wall-clock "HH:MM"; End is
+// empty for point events.
+type onboardingSeedSpec struct {
+	Type  string
+	Start string
+	End   string
+	Value map[string]any
+}
+
+var onboardingSeedPresets = map[string][]onboardingSeedSpec{
+	// Newborn: 2-3 hourly small feeds with short sleeps around the clock.
+	onboardingSeedPresetNewborn: {
+		{Type: "SLEEP", Start: "00:10", End: "02:40", Value: map[string]any{"sleep_type": "night"}},
+		{Type: "FORMULA", Start: "02:50", Value: map[string]any{"ml": 70}},
+		{Type: "SLEEP", Start: "03:10", End: "05:30", Value: map[string]any{"sleep_type": "night"}},
+		{Type: "FORMULA", Start: "05:40", Value: map[string]any{"ml": 80}},
+		{Type: "PEE", Start: "05:45", Value: map[string]any{"count": 1}},
+		{Type: "SLEEP", Start: "06:10", End: "08:00", Value: map[string]any{"sleep_type": "nap"}},
+		{Type: "FORMULA", Start: "08:20", Value: map[string]any{"ml": 70}},
+		{Type: "SLEEP", Start: "08:50", End: "10:40", Value: map[string]any{"sleep_type": "nap"}},
+		{Type: "FORMULA", Start: "11:00", Value: map[string]any{"ml": 90}},
+		{Type: "POO", Start: "11:05", Value: map[string]any{"count": 1}},
+		{Type: "SLEEP", Start: "11:30", End: "13:20", Value: map[string]any{"sleep_type": "nap"}},
+		{Type: "FORMULA", Start: "13:45", Value: map[string]any{"ml": 80}},
+		{Type: "SLEEP", Start: "14:15", End: "15:50", Value: map[string]any{"sleep_type": "nap"}},
+		{Type: "FORMULA", Start: "16:10", Value: map[string]any{"ml": 90}},
+	},
+	// 6 months: split night, four feeds of 150-200ml and three naps.
+	onboardingSeedPresetSixMo: {
+		{Type: "SLEEP", Start: "00:57", End: "02:35", Value: map[string]any{"sleep_type": "night"}},
+		{Type: "SLEEP", Start: "02:38", End: "06:13", Value: map[string]any{"sleep_type": "night"}},
+		{Type: "FORMULA", Start: "06:36", Value: map[string]any{"ml": 180}},
+		{Type: "SLEEP", Start: "08:45", End: "09:50", Value: map[string]any{"sleep_type": "nap"}},
+		{Type: "FORMULA", Start: "10:20", Value: map[string]any{"ml": 200}},
+		{Type: "PEE", Start: "10:25", Value: map[string]any{"count": 1}},
+		{Type: "SLEEP", Start: "12:30", End: "14:00", Value: map[string]any{"sleep_type": "nap"}},
+		{Type: "FORMULA", Start: "14:15", Value: map[string]any{"ml": 150}},
+		{Type: "POO", Start: "14:40", Value: map[string]any{"count": 1}},
+		{Type: "SLEEP", Start: "15:30", End: "16:10", Value: map[string]any{"sleep_type": "nap"}},
+		{Type: "FORMULA", Start: "16:30", Value: map[string]any{"ml": 180}},
+	},
+	// Toddler: one long night, a single afternoon nap and milk with meals.
+	onboardingSeedPresetToddler: {
+		{Type: "SLEEP", Start: "00:00", End: "06:40", Value: map[string]any{"sleep_type": "night"}},
+		{Type: "FORMULA", Start: "07:00", Value: map[string]any{"ml": 200}},
+		{Type: "PEE", Start: "07:20", Value: map[string]any{"count": 1}},
+		{Type: "POO", Start: "09:30", Value: map[string]any{"count": 1}},
+		{Type: "SLEEP", Start: "12:30", End: "14:30", Value: map[string]any{"sleep_type": "nap"}},
+		{Type: "FORMULA", Start: "15:00", Value: map[string]any{"ml": 150}},
+		{Type: "PEE", Start: "15:30", Value: map[string]any{"count": 1}},
+		{Type: "MEMO", Start: "16:00", Value: map[string]any{"memo": "Played outside for an hour"}},
+	},
+}
+
+func normalizeOnboardingSeedPreset(raw string) string {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "newborn":
+		return onboardingSeedPresetNewborn
+	case "6mo", "6m", "six_months":
+		return onboardingSeedPresetSixMo
+	case "toddler":
+		return onboardingSeedPresetToddler
+	default:
+		return ""
+	}
+}
+
+// onboardingSeedPresetForAge picks a preset when the request names none.
+func onboardingSeedPresetForAge(ageDays int) string {
+	switch {
+	case ageDays < 90:
+		return onboardingSeedPresetNewborn
+	case ageDays < 365:
+		return onboardingSeedPresetSixMo
+	default:
+		return onboardingSeedPresetToddler
+	}
+}
+
+func parseSeedClock(value string) (int, int) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, 0
+	}
+	return parsed.Hour(), parsed.Minute()
+}
+
+// buildOnboardingDummySeedEvents lays a preset's day out in loc. When the
+// local day has not yet reached the preset's last entry, yesterday is used so
+// every seeded event is in the past.
+func buildOnboardingDummySeedEvents(nowUTC time.Time, loc *time.Location, preset string) []onboardingDummySeedEvent {
+	specs := onboardingSeedPresets[preset]
+	if len(specs) == 0 {
+		return nil
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	nowLocal := nowUTC.In(loc)
+	seedDay := time.Date(nowLocal.Year(), nowLocal.Month(), nowLocal.Day(), 0, 0, 0, 0, loc)
+
+	at := func(clock string) time.Time {
+		hour, minute := parseSeedClock(clock)
+		return time.Date(seedDay.Year(), seedDay.Month(), seedDay.Day(), hour, minute, 0, 0, loc)
+	}
+	latest := seedDay
+	for _, spec := range specs {
+		for _, clock := range []string{spec.Start, spec.End} {
+			if clock != "" && at(clock).After(latest) {
+				latest = at(clock)
+			}
+		}
+	}
+	if nowLocal.Before(latest) {
 		seedDay = seedDay.AddDate(0, 0, -1)
 	}
 
-	at := func(hour, minute int) time.Time {
-		return time.Date(
-			seedDay.Year(),
-			seedDay.Month(),
-			seedDay.Day(),
-			hour,
-			minute,
-			0,
-			0,
-			kst,
-		).UTC()
-	}
-	withEnd := func(startHour, startMinute, endHour, endMinute int) (time.Time, *time.Time) {
-		start := at(startHour, startMinute)
-		end := at(endHour, endMinute)
-		return start, &end
-	}
-
-	sleep1Start, sleep1End := withEnd(0, 57, 2, 35)
-	sleep2Start, sleep2End := withEnd(2, 38, 6, 13)
-	nap1Start, nap1End := withEnd(7, 45, 8, 47)
-	nap2Start, nap2End := withEnd(10, 23, 11, 7)
-	nap3Start, nap3End := withEnd(12, 51, 13, 21)
-	nap4Start, nap4End := withEnd(14, 58, 15, 21)
-
-	return []onboardingDummySeedEvent{
-		{Type: "SLEEP", StartTime: sleep1Start, EndTime: sleep1End, Value: map[string]any{"sleep_type": "night"}},
-		{Type: "SLEEP", StartTime: sleep2Start, EndTime: sleep2End, Value: map[string]any{"sleep_type": "night"}},
-		{Type: "FORMULA", StartTime: at(6, 36), Value: map[string]any{"ml": 145}},
-		{Type: "SLEEP", StartTime: nap1Start, EndTime: nap1End, Value: map[string]any{"sleep_type": "nap"}},
-		{Type: "FORMULA", StartTime: at(9, 22), Value: map[string]any{"ml": 125}},
-		{Type: "SLEEP", StartTime: nap2Start, EndTime: nap2End, Value: map[string]any{"sleep_type": "nap"}},
-		{Type: "FORMULA", StartTime: at(11, 29), Value: map[string]any{"ml": 130}},
-		{Type: "SLEEP", StartTime: nap3Start, EndTime: nap3End, Value: map[string]any{"sleep_type": "nap"}},
-		{Type: "FORMULA", StartTime: at(13, 37), Value: map[string]any{"ml": 90}},
-		{Type: "SLEEP", StartTime: nap4Start, EndTime: nap4End, Value: map[string]any{"sleep_type": "nap"}},
-		{Type: "FORMULA", StartTime: at(15, 55), Value: map[string]any{"ml": 150}},
+	events := make([]onboardingDummySeedEvent, 0, len(specs))
+	for _, spec := range specs {
+		event := onboardingDummySeedEvent{Type: spec.Type, StartTime: at(spec.Start).UTC(), Value: spec.Value}
+		if spec.End != "" {
+			end := at(spec.End).UTC()
+			event.EndTime = &end
+		}
+		events = append(events, event)
 	}
+	return events
 }
 
 func (a *App) seedOnboardingDummyData(
@@ -283,9 +535,11 @@ func (a *App) seedOnboardingDummyData(
 	babyID string,
 	userID string,
 	birthDateUTC time.Time,
+	loc *time.Location,
+	preset string,
 ) (int, error) {
 	nowUTC := time.Now().UTC()
-	events := buildOnboardingDummySeedEvents(nowUTC)
+	events := buildOnboardingDummySeedEvents(nowUTC, loc, preset)
 	if len(events) == 0 {
 		return 0, nil
 	}
@@ -296,7 +550,7 @@ func (a *App) seedOnboardingDummyData(
 			continue
 		}
 		startUTC := item.StartTime.UTC()
-		if startUTC.After(nowUTC) {
+		if isFutureEventStart(startUTC, nowUTC, 0) {
 			continue
 		}
 		if !birthDateUTC.IsZero() && startUTC.Before(birthDateUTC) {
@@ -326,9 +580,10 @@ func (a *App) seedOnboardingDummyData(
 			value = map[string]any{}
 		}
 		metadata := map[string]any{
-			"entry_mode":  "dummy_seed",
-			"event_state": "CLOSED",
-			"dummy_seed":  true,
+			"entry_mode":        "dummy_seed",
+			"event_state":       "CLOSED",
+			"dummy_seed":        true,
+			"dummy_seed_preset": preset,
 		}
 
 		eventID := uuid.NewString()
@@ -367,6 +622,10 @@ func (a *App) seedOnboardingDummyData(
 	return insertedCount, nil
 }
 
+// parseVoiceEvent accepts a multipart "audio" upload or a JSON
+// audio_object_key, transcribes it and lets the AI extract the events. A JSON
+// transcript_hint skips transcription for clients that already have text.
+// Multipart audio is not kept, so only object-key clips record an audioUrl.
 func (a *App) parseVoiceEvent(c *gin.Context) {
 	user, ok := authUserFromContext(c)
 	if !ok {
@@ -375,7 +634,30 @@ func (a *App) parseVoiceEvent(c *gin.Context) {
 	}
 
 	var payload voiceUploadRequest
-	if !mustJSON(c, &payload) {
+	var audio *voiceAudio
+	if c.ContentType() == "multipart/form-data" {
+		payload.BabyID = c.PostForm("baby_id")
+		payload.TranscriptHint = c.PostForm("transcript_hint")
+		fileHeader, err := c.FormFile("audio")
+		if err == nil {
+			if fileHeader.Size > voiceAudioMaxBytes {
+				writeError(c, http.StatusRequestEntityTooLarge, "audio is too large")
+				return
+			}
+			file, err := fileHeader.Open()
+			if err != nil {
+				writeError(c, http.StatusBadRequest, "audio could not be read")
+				return
+			}
+			data, err := io.ReadAll(file)
+			file.Close()
+			if err != nil {
+				writeError(c, http.StatusBadRequest, "audio could not be read")
+				return
+			}
+			audio = &voiceAudio{Data: data, Filename: fileHeader.Filename}
+		}
+	} else if !mustJSON(c, &payload) {
 		return
 	}
 
@@ -385,24 +667,56 @@ func (a *App) parseVoiceEvent(c *gin.Context) {
 		return
 	}
 
-	now := time.Now().UTC()
-	event := eventItem{
-		Type:      "POO",
-		StartTime: now.Add(-10 * time.Minute),
-		Value:     map[string]any{"count": 1},
-		Metadata:  map[string]any{"baby_id": baby.ID},
-		Confidence: map[string]float64{
-			"type":       0.98,
-			"start_time": 0.95,
-			"count":      0.97,
-		},
+	audioURL := ""
+	objectKey := strings.TrimSpace(payload.AudioObjectKey)
+	if audio == nil && objectKey != "" {
+		if strings.TrimSpace(a.cfg.VoiceAudioBaseURL) == "" {
+			writeError(c, http.StatusServiceUnavailable, "Voice audio storage is not configured: set VOICE_AUDIO_BASE_URL")
+			return
+		}
+		fetched, err := fetchVoiceAudio(c.Request.Context(), a.cfg.VoiceAudioBaseURL, objectKey)
+		if err != nil {
+			log.Printf("voice audio fetch failed baby_id=%s key=%s err=%v", baby.ID, objectKey, err)
+			writeError(c, http.StatusBadRequest, "audio_object_key could not be loaded")
+			return
+		}
+		audio = &fetched
+		audioURL = objectKey
 	}
+
 	transcript := strings.TrimSpace(payload.TranscriptHint)
+	if audio != nil {
+		if a.stt == nil {
+			writeError(c, http.StatusServiceUnavailable, "Speech-to-text provider is not configured: set STT_PROVIDER and OPENAI_API_KEY")
+			return
+		}
+		transcribed, err := a.stt.Transcribe(c.Request.Context(), *audio)
+		if errors.Is(err, errSTTNotConfigured) {
+			writeError(c, http.StatusServiceUnavailable, "Speech-to-text provider is not configured: set STT_PROVIDER and OPENAI_API_KEY")
+			return
+		}
+		if err != nil {
+			log.Printf("voice transcription failed baby_id=%s err=%v", baby.ID, err)
+			writeError(c, http.StatusBadGateway, "Speech-to-text request failed")
+			return
+		}
+		transcript = transcribed
+	}
 	if transcript == "" {
-		transcript = "Logged one poo event 10 minutes ago."
+		if audio != nil {
+			writeError(c, http.StatusUnprocessableEntity, "No speech was recognized in the audio")
+			return
+		}
+		writeError(c, http.StatusBadRequest, "audio, audio_object_key or transcript_hint is required")
+		return
+	}
+
+	events, err := a.extractVoiceEvents(c.Request.Context(), transcript, baby.ID, time.Now().UTC())
+	if err != nil {
+		a.writeChatExecutionError(c, err)
+		return
 	}
 	clipID := uuid.NewString()
-	audioURL := "uploads/voice/" + uuid.NewString() + ".m4a"
 
 	tx, err := a.db.Begin(c.Request.Context())
 	if err != nil {
@@ -421,8 +735,8 @@ func (a *App) parseVoiceEvent(c *gin.Context) {
 		baby.ID,
 		audioURL,
 		transcript,
-		mustMarshalJSON([]eventItem{event}),
-		mustMarshalJSON(event.Confidence),
+		mustMarshalJSON(events),
+		mustMarshalJSON(voiceEventConfidences(events)),
 	); err != nil {
 		writeError(c, http.StatusInternalServerError, "Failed to save voice clip")
 		return
@@ -436,7 +750,7 @@ func (a *App) parseVoiceEvent(c *gin.Context) {
 		"VOICE_CLIP_PARSED",
 		"VoiceClip",
 		&clipID,
-		gin.H{"baby_id": baby.ID},
+		gin.H{"baby_id": baby.ID, "parsed_event_count": len(events)},
 	); err != nil {
 		writeError(c, http.StatusInternalServerError, "Failed to write audit log")
 		return
@@ -450,7 +764,7 @@ func (a *App) parseVoiceEvent(c *gin.Context) {
 	c.JSON(http.StatusOK, voiceParseResponse{
 		ClipID:       clipID,
 		Transcript:   transcript,
-		ParsedEvents: []eventItem{event},
+		ParsedEvents: events,
 		Status:       "PARSED",
 	})
 }
@@ -462,6 +776,15 @@ func (a *App) confirmEvents(c *gin.Context) {
 		return
 	}
 
+	idempotencyKey, err := idempotencyKeyFromRequest(c)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if a.replayIdempotentResponse(c, user.ID, idempotencyKey, idempotencyEndpointConfirm) {
+		return
+	}
+
 	var payload confirmEventsRequest
 	if !mustJSON(c, &payload) {
 		return
@@ -470,29 +793,20 @@ func (a *App) confirmEvents(c *gin.Context) {
 		writeError(c, http.StatusBadRequest, "clip_id is required")
 		return
 	}
+	if payload.ConfirmIndices != nil || payload.RejectIndices != nil {
+		a.confirmVoiceClipSubset(c, user, idempotencyKey, payload)
+		return
+	}
 	if len(payload.Events) == 0 {
 		writeError(c, http.StatusBadRequest, "events is required")
 		return
 	}
-	for idx, event := range payload.Events {
-		eventType, ok := normalizeEventType(event.Type)
-		if !ok {
-			writeError(c, http.StatusBadRequest, "Invalid event type at index "+strconv.Itoa(idx))
-			return
-		}
-		if event.StartTime.IsZero() {
-			writeError(c, http.StatusBadRequest, "start_time is required at index "+strconv.Itoa(idx))
-			return
-		}
-		payload.Events[idx].Type = eventType
+	if err := normalizeConfirmEvents(payload.Events, nil); err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	var householdID, babyID string
-	err := a.db.QueryRow(
-		c.Request.Context(),
-		`SELECT "householdId", "babyId" FROM "VoiceClip" WHERE id = $1`,
-		payload.ClipID,
-	).Scan(&householdID, &babyID)
+	clip, err := a.loadVoiceClipForConfirm(c.Request.Context(), a.db, payload.ClipID, false)
 	if errors.Is(err, pgx.ErrNoRows) {
 		writeError(c, http.StatusNotFound, "Voice clip not found")
 		return
@@ -501,6 +815,7 @@ func (a *App) confirmEvents(c *gin.Context) {
 		writeError(c, http.StatusInternalServerError, "Failed to load voice clip")
 		return
 	}
+	householdID, babyID := clip.HouseholdID, clip.BabyID
 
 	if _, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, babyID, writeRoles); err != nil {
 		writeError(c, statusCode, err.Error())
@@ -514,51 +829,36 @@ func (a *App) confirmEvents(c *gin.Context) {
 	}
 	defer tx.Rollback(c.Request.Context())
 
-	for _, event := range payload.Events {
-		metadata := map[string]any{}
-		for k, v := range event.Metadata {
-			metadata[k] = v
-		}
-		metadata["entry_mode"] = "voice_confirm"
-		metadata["event_state"] = "CLOSED"
+	// Re-read under a row lock so a partial confirm that lands meanwhile
+	// cannot be overwritten.
+	clip, err = a.loadVoiceClipForConfirm(c.Request.Context(), tx, payload.ClipID, true)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load voice clip")
+		return
+	}
+	if clip.Status == voiceClipStatusPartiallyConfirmed {
+		writeError(c, http.StatusConflict, "Voice clip is partially confirmed; resolve the rest with confirm_indices or reject_indices")
+		return
+	}
 
-		if _, err := tx.Exec(
-			c.Request.Context(),
-			`INSERT INTO "Event" (
-					id, "babyId", type, "startTime", "endTime", "valueJson", "metadataJson", source, "createdBy", "createdAt"
-				) VALUES ($1, $2, $3, $4, $5, $6, $7, 'VOICE', $8, NOW())`,
-			uuid.NewString(),
-			babyID,
-			event.Type,
-			event.StartTime.UTC(),
-			event.EndTime,
-			mustMarshalJSON(event.Value),
-			mustMarshalJSON(metadata),
-			user.ID,
-		); err != nil {
-			writeError(c, http.StatusInternalServerError, "Failed to save event")
-			return
-		}
-		if err := a.projectEventToPRDTables(
-			c.Request.Context(),
-			tx,
-			babyID,
-			event.Type,
-			event.StartTime.UTC(),
-			event.EndTime,
-			event.Value,
-		); err != nil {
-			log.Printf("projectEventToPRDTables failed clip_id=%s baby_id=%s event_type=%s err=%v", payload.ClipID, babyID, event.Type, err)
-			writeError(c, http.StatusInternalServerError, "Failed to project PRD event")
+	eventIDs := make([]string, 0, len(payload.Events))
+	resolutions := make([]voiceClipResolution, 0, len(payload.Events))
+	for idx, event := range payload.Events {
+		eventID, err := a.saveVoiceConfirmedEvent(c.Request.Context(), tx, payload.ClipID, babyID, user.ID, event)
+		if err != nil {
+			writeVoiceConfirmSaveError(c, err)
 			return
 		}
+		eventIDs = append(eventIDs, eventID)
+		resolutions = append(resolutions, voiceClipResolution{Index: idx, Status: voiceClipResolutionConfirmed, EventID: eventID})
 	}
 
 	if _, err := tx.Exec(
 		c.Request.Context(),
-		`UPDATE "VoiceClip" SET status = 'CONFIRMED', "parsedEventsJson" = $2 WHERE id = $1`,
+		`UPDATE "VoiceClip" SET status = 'CONFIRMED', "parsedEventsJson" = $2, "resolutionsJson" = $3 WHERE id = $1`,
 		payload.ClipID,
 		mustMarshalJSON(payload.Events),
+		mustMarshalJSON(resolutions),
 	); err != nil {
 		writeError(c, http.StatusInternalServerError, "Failed to update voice clip")
 		return
@@ -578,15 +878,130 @@ func (a *App) confirmEvents(c *gin.Context) {
 		return
 	}
 
+	response := gin.H{
+		"status":            "CONFIRMED",
+		"clip_id":           payload.ClipID,
+		"saved_event_count": len(payload.Events),
+		"event_ids":         eventIDs,
+	}
+	if !a.storeIdempotentResponse(c, tx, user.ID, idempotencyKey, idempotencyEndpointConfirm, response) {
+		return
+	}
 	if err := tx.Commit(c.Request.Context()); err != nil {
 		writeError(c, http.StatusInternalServerError, "Failed to commit transaction")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"status":            "CONFIRMED",
-		"clip_id":           payload.ClipID,
-		"saved_event_count": len(payload.Events),
+	c.JSON(http.StatusOK, response)
+}
+
+// normalizeConfirmEvents validates events in place and upper-cases their
+// types. positions, when set, names each event's parsed index in errors.
+func normalizeConfirmEvents(events []eventItem, positions []int) error {
+	for idx, event := range events {
+		position := idx
+		if positions != nil {
+			position = positions[idx]
+		}
+		eventType, ok := normalizeEventType(event.Type)
+		if !ok {
+			return errors.New("Invalid event type at index " + strconv.Itoa(position))
+		}
+		if event.StartTime.IsZero() {
+			return errors.New("start_time is required at index " + strconv.Itoa(position))
+		}
+		if issue := validateEventValue(eventType, event.Value); issue != nil {
+			return errors.New(issue.Message + " at index " + strconv.Itoa(position))
+		}
+		events[idx].Type = eventType
+	}
+	return nil
+}
+
+var errVoiceEventProjection = errors.New("Failed to project PRD event")
+
+// saveVoiceConfirmedEvent stores one confirmed voice event and its PRD
+// projection inside the caller's transaction.
+func (a *App) saveVoiceConfirmedEvent(ctx context.Context, tx dbQuerier, clipID, babyID, userID string, event eventItem) (string, error) {
+	metadata := map[string]any{}
+	for k, v := range event.Metadata {
+		metadata[k] = v
+	}
+	metadata["entry_mode"] = "voice_confirm"
+	metadata["event_state"] = "CLOSED"
+	if lowest, ok := minConfidence(event.Confidence); ok {
+		metadata["confidence"] = event.Confidence
+		metadata["min_confidence"] = lowest
+	}
+
+	eventID := uuid.NewString()
+	if _, err := tx.Exec(
+		ctx,
+		`INSERT INTO "Event" (
+				id, "babyId", type, "startTime", "endTime", "valueJson", "metadataJson", source, "createdBy", "createdAt"
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, 'VOICE', $8, NOW())`,
+		eventID,
+		babyID,
+		event.Type,
+		event.StartTime.UTC(),
+		event.EndTime,
+		mustMarshalJSON(event.Value),
+		mustMarshalJSON(metadata),
+		userID,
+	); err != nil {
+		return "", err
+	}
+	if err := a.projectEventToPRDTables(
+		ctx,
+		tx,
+		babyID,
+		event.Type,
+		event.StartTime.UTC(),
+		event.EndTime,
+		event.Value,
+	); err != nil {
+		log.Printf("projectEventToPRDTables failed clip_id=%s baby_id=%s event_type=%s err=%v", clipID, babyID, event.Type, err)
+		return "", errVoiceEventProjection
+	}
+	return eventID, nil
+}
+
+func writeVoiceConfirmSaveError(c *gin.Context, err error) {
+	if errors.Is(err, errVoiceEventProjection) {
+		writeError(c, http.StatusInternalServerError, errVoiceEventProjection.Error())
+		return
+	}
+	writeError(c, http.StatusInternalServerError, "Failed to save event")
+}
+
+// parseQueryFlag reads an optional boolean query flag. ?allow_overlap lets a
+// SLEEP be saved over another recorded sleep (e.g. both parents logging a
+// co-sleep); ?allow_future lets a start be scheduled ahead, e.g. a dose;
+// ?force saves an event that looks like a double tap.
+func parseQueryFlag(c *gin.Context, name string) (bool, error) {
+	raw := strings.TrimSpace(c.Query(name))
+	if raw == "" {
+		return false, nil
+	}
+	allow, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, errors.New(name + " must be true or false")
+	}
+	return allow, nil
+}
+
+func writeSleepOverlapConflict(c *gin.Context, overlapID string) {
+	c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+		"detail":               "sleep overlaps another recorded SLEEP event; pass allow_overlap=true to keep both",
+		"conflicting_event_id": overlapID,
+	})
+}
+
+func writeDuplicateEventConflict(c *gin.Context, eventType, duplicateID string) {
+	c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+		"detail":    "a " + eventType + " event was just logged at the same time; pass force=true to keep both",
+		"duplicate": true,
+		"event_id":  duplicateID,
 	})
 }
 
@@ -597,45 +1012,86 @@ func (a *App) createManualEvent(c *gin.Context) {
 		return
 	}
 
+	idempotencyKey, err := idempotencyKeyFromRequest(c)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if a.replayIdempotentResponse(c, user.ID, idempotencyKey, idempotencyEndpointManualEvent) {
+		return
+	}
+
 	var payload manualEventCreateRequest
 	if !mustJSON(c, &payload) {
 		return
 	}
-
-	babyID := strings.TrimSpace(payload.BabyID)
-	if babyID == "" {
-		writeError(c, http.StatusBadRequest, "baby_id is required")
+	allowOverlap, err := parseQueryFlag(c, "allow_overlap")
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
 		return
 	}
-
-	eventType, valid := normalizeEventType(payload.Type)
-	if !valid {
-		writeError(c, http.StatusBadRequest, "type is invalid")
+	allowFuture, err := parseQueryFlag(c, "allow_future")
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
 		return
 	}
-	if payload.StartTime.IsZero() {
-		writeError(c, http.StatusBadRequest, "start_time is required")
+	force, err := parseQueryFlag(c, "force")
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	startTime := payload.StartTime.UTC()
-	var endTime any
-	if payload.EndTime != nil {
-		if payload.EndTime.UTC().Before(startTime) {
-			writeError(c, http.StatusBadRequest, "end_time must be after start_time")
-			return
-		}
-		parsed := payload.EndTime.UTC()
-		endTime = parsed
-	} else {
-		endTime = nil
+	now := time.Now().UTC()
+	event, validationErrors, warnings := validateManualEventPayload(payload, now)
+	if len(validationErrors) > 0 {
+		writeError(c, http.StatusBadRequest, validationErrors[0].Message)
+		return
+	}
+	if !allowFuture && isFutureEventStart(event.StartTime, now, eventFutureSkewTolerance) {
+		writeError(c, http.StatusBadRequest, errFutureEventStart.Error())
+		return
+	}
+	eventType := event.Type
+	visibility := event.Visibility
+	startTime := event.StartTime
+	var endTime any
+	if event.EndTime != nil {
+		endTime = *event.EndTime
 	}
 
-	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, babyID, writeRoles)
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, event.BabyID, writeRoles)
 	if err != nil {
 		writeError(c, statusCode, err.Error())
 		return
 	}
+	if window := a.eventDuplicateWindow(eventType); window > 0 && !force {
+		duplicateID, err := findDuplicateEvent(c.Request.Context(), a.db, user.ID, baby.ID, eventType, startTime, window)
+		if err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to validate event")
+			return
+		}
+		if duplicateID != "" {
+			writeDuplicateEventConflict(c, eventType, duplicateID)
+			return
+		}
+	}
+	if eventType == "SLEEP" && event.EndTime != nil && !allowOverlap {
+		overlapID, err := findOverlappingSleep(c.Request.Context(), a.db, baby.ID, "", startTime, *event.EndTime)
+		if err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to validate event")
+			return
+		}
+		if overlapID != "" {
+			writeSleepOverlapConflict(c, overlapID)
+			return
+		}
+	}
+	recordWarnings, err := a.manualEventRecordWarnings(c.Request.Context(), a.db, user.ID, event)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to validate event")
+		return
+	}
+	warnings = append(warnings, recordWarnings...)
 
 	value := payload.Value
 	if value == nil {
@@ -647,6 +1103,27 @@ func (a *App) createManualEvent(c *gin.Context) {
 	}
 	metadata["entry_mode"] = "manual_form"
 	metadata["event_state"] = "CLOSED"
+	delete(metadata, "visibility")
+	if visibility == eventVisibilityPrivate {
+		metadata["visibility"] = eventVisibilityPrivate
+	}
+	if err := normalizeFeedingAmountUnit(eventType, value, metadata); err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if issue := validateEventValue(eventType, value); issue != nil {
+		writeError(c, http.StatusBadRequest, issue.Message)
+		return
+	}
+	zeroSleepIssue, rejected := a.zeroDurationSleepIssue(eventType, value, startTime, event.EndTime)
+	if zeroSleepIssue != nil && rejected {
+		writeError(c, http.StatusBadRequest, zeroSleepIssue.Message)
+		return
+	}
+	if zeroSleepIssue != nil {
+		warnings = append(warnings, *zeroSleepIssue)
+	}
+	markZeroDurationSleep(metadata, zeroSleepIssue != nil)
 
 	eventID := uuid.NewString()
 	tx, err := a.db.Begin(c.Request.Context())
@@ -673,24 +1150,27 @@ func (a *App) createManualEvent(c *gin.Context) {
 		writeError(c, http.StatusInternalServerError, "Failed to save event")
 		return
 	}
-	if err := a.projectEventToPRDTables(
-		c.Request.Context(),
-		tx,
-		baby.ID,
-		eventType,
-		startTime,
-		payload.EndTime,
-		value,
-	); err != nil {
-		// Keep the primary event write successful even when optional PRD projection
-		// tables are temporarily unavailable or schema-mismatched in local/dev.
-		log.Printf(
-			"projectEventToPRDTables warning event_id=%s baby_id=%s event_type=%s err=%v",
-			eventID,
+	// Projection tables have no author column, so private memos stay out of them.
+	if visibility != eventVisibilityPrivate {
+		if err := a.projectEventToPRDTables(
+			c.Request.Context(),
+			tx,
 			baby.ID,
 			eventType,
-			err,
-		)
+			startTime,
+			payload.EndTime,
+			value,
+		); err != nil {
+			// Keep the primary event write successful even when optional PRD projection
+			// tables are temporarily unavailable or schema-mismatched in local/dev.
+			log.Printf(
+				"projectEventToPRDTables warning event_id=%s baby_id=%s event_type=%s err=%v",
+				eventID,
+				baby.ID,
+				eventType,
+				err,
+			)
+		}
 	}
 
 	if err := recordAuditLog(
@@ -710,15 +1190,293 @@ func (a *App) createManualEvent(c *gin.Context) {
 		return
 	}
 
+	response := gin.H{
+		"status":   "CREATED",
+		"event_id": eventID,
+		"type":     eventType,
+		"warnings": warnings,
+	}
+	if !a.storeIdempotentResponse(c, tx, user.ID, idempotencyKey, idempotencyEndpointManualEvent, response) {
+		return
+	}
+	if err := tx.Commit(c.Request.Context()); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// manualEventBulkMax caps how many events one bulk request may create.
+const manualEventBulkMax = 100
+
+// createManualEventsBulk saves a batch of manual events all or nothing. Every
+// item is validated before any row is written; one invalid item rejects the
+// whole batch with per-index errors.
+func (a *App) createManualEventsBulk(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	idempotencyKey, err := idempotencyKeyFromRequest(c)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if a.replayIdempotentResponse(c, user.ID, idempotencyKey, idempotencyEndpointBulkEvents) {
+		return
+	}
+
+	var payload manualEventBulkCreateRequest
+	if !mustJSON(c, &payload) {
+		return
+	}
+	babyID := strings.TrimSpace(payload.BabyID)
+	if babyID == "" {
+		writeError(c, http.StatusBadRequest, "baby_id is required")
+		return
+	}
+	if len(payload.Events) == 0 {
+		writeError(c, http.StatusBadRequest, "events is required")
+		return
+	}
+	if len(payload.Events) > manualEventBulkMax {
+		writeError(c, http.StatusBadRequest, "events must contain at most "+strconv.Itoa(manualEventBulkMax)+" items")
+		return
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, babyID, writeRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	now := time.Now().UTC()
+	validated := make([]validatedManualEvent, len(payload.Events))
+	results := make([]gin.H, len(payload.Events))
+	firstInvalid := -1
+	for idx := range payload.Events {
+		item := payload.Events[idx]
+		if strings.TrimSpace(item.BabyID) == "" {
+			item.BabyID = baby.ID
+		}
+		if item.Value == nil {
+			item.Value = map[string]any{}
+		}
+		if item.Metadata == nil {
+			item.Metadata = map[string]any{}
+		}
+		payload.Events[idx] = item
+
+		event, validationErrors, warnings := validateManualEventPayload(item, now)
+		if len(validationErrors) == 0 {
+			if err := normalizeFeedingAmountUnit(event.Type, item.Value, item.Metadata); err != nil {
+				validationErrors = append(validationErrors, eventValidationIssue{Field: "value", Code: "invalid_unit", Message: err.Error()})
+			} else if issue := validateEventValue(event.Type, item.Value); issue != nil {
+				validationErrors = append(validationErrors, *issue)
+			}
+		}
+		if len(validationErrors) == 0 && event.BabyID != baby.ID {
+			validationErrors = append(validationErrors, eventValidationIssue{
+				Field:   "baby_id",
+				Code:    "mismatch",
+				Message: "baby_id must match the request baby_id",
+			})
+		}
+		if len(validationErrors) == 0 {
+			if issue, rejected := a.zeroDurationSleepIssue(event.Type, item.Value, event.StartTime, event.EndTime); issue != nil {
+				if rejected {
+					validationErrors = append(validationErrors, *issue)
+				} else {
+					warnings = append(warnings, *issue)
+				}
+			}
+		}
+		if len(validationErrors) > 0 && firstInvalid < 0 {
+			firstInvalid = idx
+		}
+		validated[idx] = event
+		results[idx] = gin.H{
+			"index":    idx,
+			"type":     event.Type,
+			"valid":    len(validationErrors) == 0,
+			"errors":   validationErrors,
+			"warnings": warnings,
+		}
+	}
+	if firstInvalid >= 0 {
+		firstErrors := results[firstInvalid]["errors"].([]eventValidationIssue)
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"detail":  firstErrors[0].Message + " at index " + strconv.Itoa(firstInvalid),
+			"results": results,
+		})
+		return
+	}
+
+	tx, err := a.db.Begin(c.Request.Context())
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback(c.Request.Context())
+
+	for idx, event := range validated {
+		item := payload.Events[idx]
+		metadata := map[string]any{}
+		for key, value := range item.Metadata {
+			metadata[key] = value
+		}
+		metadata["entry_mode"] = "manual_form"
+		metadata["event_state"] = "CLOSED"
+		delete(metadata, "visibility")
+		if event.Visibility == eventVisibilityPrivate {
+			metadata["visibility"] = eventVisibilityPrivate
+		}
+		markZeroDurationSleep(metadata, isZeroDurationSleep(event.Type, item.Value, event.StartTime, event.EndTime))
+
+		var endTime any
+		if event.EndTime != nil {
+			endTime = *event.EndTime
+		}
+		eventID := uuid.NewString()
+		if _, err := tx.Exec(
+			c.Request.Context(),
+			`INSERT INTO "Event" (
+				id, "babyId", type, "startTime", "endTime", "valueJson", "metadataJson", source, "createdBy", "createdAt"
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, 'MANUAL', $8, NOW())`,
+			eventID,
+			baby.ID,
+			event.Type,
+			event.StartTime,
+			endTime,
+			mustMarshalJSON(item.Value),
+			mustMarshalJSON(metadata),
+			user.ID,
+		); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to save event")
+			return
+		}
+		// Projection tables have no author column, so private memos stay out of them.
+		if event.Visibility != eventVisibilityPrivate {
+			if err := a.projectEventToPRDTables(
+				c.Request.Context(),
+				tx,
+				baby.ID,
+				event.Type,
+				event.StartTime,
+				event.EndTime,
+				item.Value,
+			); err != nil {
+				log.Printf("projectEventToPRDTables failed bulk_index=%d baby_id=%s event_type=%s err=%v", idx, baby.ID, event.Type, err)
+				writeError(c, http.StatusInternalServerError, "Failed to project PRD event")
+				return
+			}
+		}
+		results[idx]["event_id"] = eventID
+	}
+
+	if err := recordAuditLog(
+		c.Request.Context(),
+		tx,
+		baby.HouseholdID,
+		user.ID,
+		"EVENT_MANUAL_BULK_CREATED",
+		"Baby",
+		&baby.ID,
+		gin.H{"saved_event_count": len(validated)},
+	); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to write audit log")
+		return
+	}
+
+	response := gin.H{
+		"status":            "CREATED",
+		"baby_id":           baby.ID,
+		"saved_event_count": len(validated),
+		"results":           results,
+	}
+	if !a.storeIdempotentResponse(c, tx, user.ID, idempotencyKey, idempotencyEndpointBulkEvents, response) {
+		return
+	}
 	if err := tx.Commit(c.Request.Context()); err != nil {
 		writeError(c, http.StatusInternalServerError, "Failed to commit transaction")
 		return
 	}
 
+	c.JSON(http.StatusOK, response)
+}
+
+func (a *App) validateEvent(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var payload manualEventCreateRequest
+	if !mustJSON(c, &payload) {
+		return
+	}
+
+	allowFuture, err := parseQueryFlag(c, "allow_future")
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	event, validationErrors, warnings := validateManualEventPayload(payload, time.Now().UTC())
+	if !allowFuture {
+		// events/manual rejects a future start, so report it as an error here.
+		kept := warnings[:0]
+		for _, warning := range warnings {
+			if warning.Code == "future_start" {
+				validationErrors = append(validationErrors, eventValidationIssue{Field: warning.Field, Code: warning.Code, Message: errFutureEventStart.Error()})
+				continue
+			}
+			kept = append(kept, warning)
+		}
+		warnings = kept
+	}
+	if event.BabyID != "" {
+		if _, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, event.BabyID, writeRoles); err != nil {
+			writeError(c, statusCode, err.Error())
+			return
+		}
+	}
+	if len(validationErrors) == 0 {
+		// Normalize a copy so an oz amount is checked the way create stores it.
+		value := cloneMap(payload.Value)
+		if err := normalizeFeedingAmountUnit(event.Type, value, map[string]any{}); err != nil {
+			validationErrors = append(validationErrors, eventValidationIssue{Field: "value", Code: "invalid_unit", Message: err.Error()})
+		} else if issue := validateEventValue(event.Type, value); issue != nil {
+			validationErrors = append(validationErrors, *issue)
+		}
+	}
+	if len(validationErrors) == 0 {
+		if issue, rejected := a.zeroDurationSleepIssue(event.Type, payload.Value, event.StartTime, event.EndTime); issue != nil {
+			if rejected {
+				validationErrors = append(validationErrors, *issue)
+			} else {
+				warnings = append(warnings, *issue)
+			}
+		}
+	}
+	if len(validationErrors) == 0 {
+		recordWarnings, err := a.manualEventRecordWarnings(c.Request.Context(), a.db, user.ID, event)
+		if err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to validate event")
+			return
+		}
+		warnings = append(warnings, recordWarnings...)
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"status":   "CREATED",
-		"event_id": eventID,
-		"type":     eventType,
+		"valid":    len(validationErrors) == 0,
+		"type":     event.Type,
+		"errors":   validationErrors,
+		"warnings": warnings,
 	})
 }
 
@@ -759,11 +1517,24 @@ func (a *App) startManualEvent(c *gin.Context) {
 		writeError(c, http.StatusBadRequest, "type does not support start/complete flow")
 		return
 	}
+	if _, concurrent := concurrentOpenEventTypes[eventType]; payload.AllowConcurrent && !concurrent {
+		writeError(c, http.StatusBadRequest, "allow_concurrent is only supported for MEDICATION and MEMO")
+		return
+	}
 	if payload.StartTime.IsZero() {
 		writeError(c, http.StatusBadRequest, "start_time is required")
 		return
 	}
 	startTime := payload.StartTime.UTC()
+	allowFuture, err := parseQueryFlag(c, "allow_future")
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !allowFuture && isFutureEventStart(startTime, time.Now(), eventFutureSkewTolerance) {
+		writeError(c, http.StatusBadRequest, errFutureEventStart.Error())
+		return
+	}
 
 	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, babyID, writeRoles)
 	if err != nil {
@@ -799,32 +1570,35 @@ func (a *App) startManualEvent(c *gin.Context) {
 		return
 	}
 
-	var existingEventID string
-	err = tx.QueryRow(
-		c.Request.Context(),
-		`SELECT id FROM "Event"
-		 WHERE "babyId" = $1
-		   AND type = $2
-		   AND "endTime" IS NULL
-		   AND (
-		     COALESCE("metadataJson"->>'event_state', '') = 'OPEN'
-		     OR COALESCE("metadataJson"->>'entry_mode', '') = 'manual_start'
-		   )
-		 ORDER BY "startTime" DESC
-		 LIMIT 1`,
-		baby.ID,
-		eventType,
-	).Scan(&existingEventID)
-	if err == nil {
-		c.AbortWithStatusJSON(http.StatusConflict, gin.H{
-			"detail":            "open event already exists for this type",
-			"existing_event_id": existingEventID,
-		})
-		return
-	}
-	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
-		writeError(c, http.StatusInternalServerError, "Failed to validate open event")
-		return
+	if !payload.AllowConcurrent {
+		var existingEventID string
+		err = tx.QueryRow(
+			c.Request.Context(),
+			`SELECT id FROM "Event"
+			 WHERE "babyId" = $1
+			   AND "deletedAt" IS NULL
+			   AND type = $2
+			   AND "endTime" IS NULL
+			   AND (
+			     COALESCE("metadataJson"->>'event_state', '') = 'OPEN'
+			     OR COALESCE("metadataJson"->>'entry_mode', '') = 'manual_start'
+			   )
+			 ORDER BY "startTime" DESC
+			 LIMIT 1`,
+			baby.ID,
+			eventType,
+		).Scan(&existingEventID)
+		if err == nil {
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+				"detail":            "open event already exists for this type",
+				"existing_event_id": existingEventID,
+			})
+			return
+		}
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			writeError(c, http.StatusInternalServerError, "Failed to validate open event")
+			return
+		}
 	}
 
 	eventID := uuid.NewString()
@@ -897,7 +1671,7 @@ func (a *App) updateManualEvent(c *gin.Context) {
 	hasType := payload.Type != nil && strings.TrimSpace(*payload.Type) != ""
 	hasStart := payload.StartTime != nil && !payload.StartTime.IsZero()
 	hasEnd := payload.EndTime != nil && !payload.EndTime.IsZero()
-	if !hasType && !hasStart && !hasEnd && payload.Value == nil && payload.Metadata == nil {
+	if !hasType && !hasStart && !hasEnd && payload.DurationMin == nil && payload.Value == nil && payload.Metadata == nil {
 		writeError(c, http.StatusBadRequest, "at least one field must be provided for update")
 		return
 	}
@@ -905,7 +1679,7 @@ func (a *App) updateManualEvent(c *gin.Context) {
 	var eventBabyID string
 	err := a.db.QueryRow(
 		c.Request.Context(),
-		`SELECT "babyId" FROM "Event" WHERE id = $1`,
+		`SELECT "babyId" FROM "Event" WHERE id = $1 AND "deletedAt" IS NULL`,
 		eventID,
 	).Scan(&eventBabyID)
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -940,9 +1714,12 @@ func (a *App) updateManualEvent(c *gin.Context) {
 		`SELECT type, "startTime", "endTime", "valueJson", "metadataJson"
 		 FROM "Event"
 		 WHERE id = $1 AND "babyId" = $2
+		   AND "deletedAt" IS NULL
+		   AND `+eventVisibleToUserSQL("$3")+`
 		 FOR UPDATE`,
 		eventID,
 		baby.ID,
+		user.ID,
 	).Scan(
 		&existingType,
 		&existingStart,
@@ -1005,10 +1782,34 @@ func (a *App) updateManualEvent(c *gin.Context) {
 		return
 	}
 
-	value := mergeJSONMap(parseJSONStringMap(existingValueRaw), payload.Value)
-	metadata := mergeJSONMap(existingMetadata, payload.Metadata)
+	valuePatch, unitMetadata, err := normalizeFeedingValuePatch(resolvedType, payload.Value)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	value := mergeJSONMap(parseJSONStringMap(existingValueRaw), valuePatch)
+	if payload.DurationMin != nil {
+		durationOverride, err := resolveDurationOverride(*payload.DurationMin, resolvedStart, resolvedEnd.UTC())
+		if err != nil {
+			writeError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		value["duration_min"] = durationOverride
+	}
+	metadata := mergeFeedingUnitMetadata(mergeJSONMap(existingMetadata, payload.Metadata), valuePatch, unitMetadata)
 	metadata["entry_mode"] = "manual_edit"
 	metadata["event_state"] = "CLOSED"
+	zeroSleepIssue, rejected := a.zeroDurationSleepIssue(resolvedType, value, resolvedStart, resolvedEnd)
+	if zeroSleepIssue != nil && rejected {
+		writeError(c, http.StatusBadRequest, zeroSleepIssue.Message)
+		return
+	}
+	markZeroDurationSleep(metadata, zeroSleepIssue != nil)
+	// Visibility is fixed at creation; a metadata patch cannot expose a private memo.
+	delete(metadata, "visibility")
+	if visibility, ok := existingMetadata["visibility"]; ok {
+		metadata["visibility"] = visibility
+	}
 
 	if _, err := tx.Exec(
 		c.Request.Context(),
@@ -1052,9 +1853,9 @@ func (a *App) updateManualEvent(c *gin.Context) {
 		return
 	}
 
-	durationMin := int(resolvedEnd.UTC().Sub(resolvedStart).Minutes())
-	if durationMin < 0 {
-		durationMin = 0
+	durationMin := 0
+	if duration := extractDurationMinutes(value, resolvedStart, resolvedEnd); duration != nil {
+		durationMin = int(*duration)
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -1085,11 +1886,16 @@ func (a *App) completeManualEvent(c *gin.Context) {
 	if !mustJSON(c, &payload) {
 		return
 	}
+	allowOverlap, err := parseQueryFlag(c, "allow_overlap")
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	var eventBabyID string
-	err := a.db.QueryRow(
+	err = a.db.QueryRow(
 		c.Request.Context(),
-		`SELECT "babyId" FROM "Event" WHERE id = $1`,
+		`SELECT "babyId" FROM "Event" WHERE id = $1 AND "deletedAt" IS NULL`,
 		eventID,
 	).Scan(&eventBabyID)
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -1123,7 +1929,7 @@ func (a *App) completeManualEvent(c *gin.Context) {
 		c.Request.Context(),
 		`SELECT type, "startTime", "endTime", "valueJson", "metadataJson"
 		 FROM "Event"
-		 WHERE id = $1 AND "babyId" = $2
+		 WHERE id = $1 AND "deletedAt" IS NULL AND "babyId" = $2
 		 FOR UPDATE`,
 		eventID,
 		baby.ID,
@@ -1157,10 +1963,40 @@ func (a *App) completeManualEvent(c *gin.Context) {
 		return
 	}
 
-	value := mergeJSONMap(parseJSONStringMap(valueRaw), payload.Value)
-	metadata := mergeJSONMap(existingMetadata, payload.Metadata)
+	valuePatch, unitMetadata, err := normalizeFeedingValuePatch(eventType, payload.Value)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	value := mergeJSONMap(parseJSONStringMap(valueRaw), valuePatch)
+	if payload.DurationMin != nil {
+		durationOverride, err := resolveDurationOverride(*payload.DurationMin, startTime.UTC(), resolvedEnd)
+		if err != nil {
+			writeError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		value["duration_min"] = durationOverride
+	}
+	metadata := mergeFeedingUnitMetadata(mergeJSONMap(existingMetadata, payload.Metadata), valuePatch, unitMetadata)
 	metadata["entry_mode"] = "manual_complete"
 	metadata["event_state"] = "CLOSED"
+	zeroSleepIssue, rejected := a.zeroDurationSleepIssue(eventType, value, startTime.UTC(), &resolvedEnd)
+	if zeroSleepIssue != nil && rejected {
+		writeError(c, http.StatusBadRequest, zeroSleepIssue.Message)
+		return
+	}
+	markZeroDurationSleep(metadata, zeroSleepIssue != nil)
+	if eventType == "SLEEP" && !allowOverlap {
+		overlapID, err := findOverlappingSleep(c.Request.Context(), tx, baby.ID, eventID, startTime, resolvedEnd)
+		if err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to validate event")
+			return
+		}
+		if overlapID != "" {
+			writeSleepOverlapConflict(c, overlapID)
+			return
+		}
+	}
 
 	commandTag, err := tx.Exec(
 		c.Request.Context(),
@@ -1228,9 +2064,9 @@ func (a *App) completeManualEvent(c *gin.Context) {
 		return
 	}
 
-	durationMin := int(resolvedEnd.Sub(startTime.UTC()).Minutes())
-	if durationMin < 0 {
-		durationMin = 0
+	durationMin := 0
+	if duration := extractDurationMinutes(value, startTime.UTC(), &resolvedEndUTC); duration != nil {
+		durationMin = int(*duration)
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -1265,7 +2101,7 @@ func (a *App) cancelManualEvent(c *gin.Context) {
 	var eventBabyID string
 	err := a.db.QueryRow(
 		c.Request.Context(),
-		`SELECT "babyId" FROM "Event" WHERE id = $1`,
+		`SELECT "babyId" FROM "Event" WHERE id = $1 AND "deletedAt" IS NULL`,
 		eventID,
 	).Scan(&eventBabyID)
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -1299,9 +2135,12 @@ func (a *App) cancelManualEvent(c *gin.Context) {
 		`SELECT type, "startTime", "endTime", "metadataJson"
 		 FROM "Event"
 		 WHERE id = $1 AND "babyId" = $2
+		   AND "deletedAt" IS NULL
+		   AND `+eventVisibleToUserSQL("$3")+`
 		 FOR UPDATE`,
 		eventID,
 		baby.ID,
+		user.ID,
 	).Scan(&eventType, &startTime, &existingEnd, &metadataRaw)
 	if errors.Is(err, pgx.ErrNoRows) {
 		writeError(c, http.StatusNotFound, "Event not found")
@@ -1394,16 +2233,595 @@ func (a *App) cancelManualEvent(c *gin.Context) {
 	})
 }
 
-func (a *App) listOpenEvents(c *gin.Context) {
+// deleteManualEvent moves a mistakenly logged event to the trash and removes
+// the PRD row projected from it. Trashed events can be restored for
+// eventTrashRetentionDays before the purge job deletes them. Open events must
+// be canceled first.
+func (a *App) deleteManualEvent(c *gin.Context) {
 	user, ok := authUserFromContext(c)
 	if !ok {
 		writeError(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
-	babyID := strings.TrimSpace(c.Query("baby_id"))
-	if babyID == "" {
-		writeError(c, http.StatusBadRequest, "baby_id is required")
+	eventID := strings.TrimSpace(c.Param("event_id"))
+	if eventID == "" {
+		writeError(c, http.StatusBadRequest, "event_id is required")
+		return
+	}
+
+	var eventBabyID string
+	err := a.db.QueryRow(
+		c.Request.Context(),
+		`SELECT "babyId" FROM "Event" WHERE id = $1 AND "deletedAt" IS NULL`,
+		eventID,
+	).Scan(&eventBabyID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		writeError(c, http.StatusNotFound, "Event not found")
+		return
+	}
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load event")
+		return
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, eventBabyID, writeRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	tx, err := a.db.Begin(c.Request.Context())
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback(c.Request.Context())
+
+	var eventType string
+	var startTime time.Time
+	var existingEnd *time.Time
+	var metadataRaw []byte
+	err = tx.QueryRow(
+		c.Request.Context(),
+		`SELECT type, "startTime", "endTime", "metadataJson"
+		 FROM "Event"
+		 WHERE id = $1 AND "babyId" = $2
+		   AND "deletedAt" IS NULL
+		   AND `+eventVisibleToUserSQL("$3")+`
+		 FOR UPDATE`,
+		eventID,
+		baby.ID,
+		user.ID,
+	).Scan(&eventType, &startTime, &existingEnd, &metadataRaw)
+	if errors.Is(err, pgx.ErrNoRows) {
+		writeError(c, http.StatusNotFound, "Event not found")
+		return
+	}
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to lock event")
+		return
+	}
+	metadata := parseJSONStringMap(metadataRaw)
+	eventState := strings.ToUpper(strings.TrimSpace(toString(metadata["event_state"])))
+	entryMode := strings.ToLower(strings.TrimSpace(toString(metadata["entry_mode"])))
+	if existingEnd == nil && (eventState == "OPEN" || entryMode == "manual_start") {
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"detail":       "open events must be canceled before they can be deleted",
+			"event_id":     eventID,
+			"event_status": "OPEN",
+		})
+		return
+	}
+
+	var deletedAt time.Time
+	if err := tx.QueryRow(
+		c.Request.Context(),
+		`UPDATE "Event" SET "deletedAt" = NOW() WHERE id = $1 RETURNING "deletedAt"`,
+		eventID,
+	).Scan(&deletedAt); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to delete event")
+		return
+	}
+	restorableUntil := deletedAt.UTC().AddDate(0, 0, eventTrashRetentionDays)
+	// Private events are never projected, so there is no row to remove.
+	if toString(metadata["visibility"]) != eventVisibilityPrivate {
+		if err := deleteProjectedEvents(c.Request.Context(), tx, baby.ID, eventType, startTime); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to delete projected event")
+			return
+		}
+	}
+
+	if err := recordAuditLog(
+		c.Request.Context(),
+		tx,
+		baby.HouseholdID,
+		user.ID,
+		"EVENT_TRASHED",
+		"Event",
+		&eventID,
+		gin.H{
+			"baby_id":          baby.ID,
+			"type":             eventType,
+			"start_time":       startTime.UTC().Format(time.RFC3339),
+			"restorable_until": restorableUntil.Format(time.RFC3339),
+		},
+	); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to write audit log")
+		return
+	}
+
+	if err := tx.Commit(c.Request.Context()); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":           "TRASHED",
+		"event_id":         eventID,
+		"type":             eventType,
+		"deleted_at":       deletedAt.UTC().Format(time.RFC3339),
+		"restorable_until": restorableUntil.Format(time.RFC3339),
+	})
+}
+
+// restoreManualEvent takes an event back out of the trash and re-projects it.
+// Events trashed more than eventTrashRetentionDays ago are gone for good.
+func (a *App) restoreManualEvent(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventID := strings.TrimSpace(c.Param("event_id"))
+	if eventID == "" {
+		writeError(c, http.StatusBadRequest, "event_id is required")
+		return
+	}
+
+	var eventBabyID string
+	err := a.db.QueryRow(
+		c.Request.Context(),
+		`SELECT "babyId" FROM "Event" WHERE id = $1 AND "deletedAt" IS NOT NULL`,
+		eventID,
+	).Scan(&eventBabyID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		writeError(c, http.StatusNotFound, "Trashed event not found")
+		return
+	}
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load event")
+		return
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, eventBabyID, writeRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	tx, err := a.db.Begin(c.Request.Context())
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback(c.Request.Context())
+
+	var eventType string
+	var startTime, deletedAt time.Time
+	var endTime *time.Time
+	var valueRaw, metadataRaw []byte
+	err = tx.QueryRow(
+		c.Request.Context(),
+		`SELECT type, "startTime", "endTime", "valueJson", "metadataJson", "deletedAt"
+		 FROM "Event"
+		 WHERE id = $1 AND "babyId" = $2
+		   AND "deletedAt" IS NOT NULL
+		   AND `+eventVisibleToUserSQL("$3")+`
+		 FOR UPDATE`,
+		eventID,
+		baby.ID,
+		user.ID,
+	).Scan(&eventType, &startTime, &endTime, &valueRaw, &metadataRaw, &deletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		writeError(c, http.StatusNotFound, "Trashed event not found")
+		return
+	}
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to lock event")
+		return
+	}
+	if !eventRestorable(deletedAt, time.Now().UTC()) {
+		writeError(c, http.StatusGone, "Event has been in the trash too long to restore")
+		return
+	}
+	// A merged-away event's amount and duration already live on the kept
+	// event; restoring it would count them twice.
+	metadata := parseJSONStringMap(metadataRaw)
+	if mergedInto := strings.TrimSpace(toString(metadata["merged_into"])); mergedInto != "" {
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"detail":      "event was merged into another event and cannot be restored",
+			"event_id":    eventID,
+			"merged_into": mergedInto,
+		})
+		return
+	}
+
+	if _, err := tx.Exec(
+		c.Request.Context(),
+		`UPDATE "Event" SET "deletedAt" = NULL WHERE id = $1`,
+		eventID,
+	); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to restore event")
+		return
+	}
+	if toString(metadata["visibility"]) != eventVisibilityPrivate {
+		if err := a.projectEventToPRDTables(
+			c.Request.Context(),
+			tx,
+			baby.ID,
+			eventType,
+			startTime.UTC(),
+			endTime,
+			parseJSONStringMap(valueRaw),
+		); err != nil {
+			log.Printf(
+				"projectEventToPRDTables warning on restore event_id=%s baby_id=%s event_type=%s err=%v",
+				eventID,
+				baby.ID,
+				eventType,
+				err,
+			)
+		}
+	}
+
+	if err := recordAuditLog(
+		c.Request.Context(),
+		tx,
+		baby.HouseholdID,
+		user.ID,
+		"EVENT_RESTORED",
+		"Event",
+		&eventID,
+		gin.H{
+			"baby_id":    baby.ID,
+			"type":       eventType,
+			"start_time": startTime.UTC().Format(time.RFC3339),
+			"deleted_at": deletedAt.UTC().Format(time.RFC3339),
+		},
+	); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to write audit log")
+		return
+	}
+
+	if err := tx.Commit(c.Request.Context()); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "RESTORED",
+		"event_id": eventID,
+		"type":     eventType,
+	})
+}
+
+var (
+	eventMergeAmountKeys   = []string{"ml", "amount_ml", "volume_ml"}
+	eventMergeDurationKeys = []string{"duration_min", "duration_minutes", "minutes"}
+)
+
+// mergeEventValues folds duplicate event values into the kept value: amounts
+// are summed, durations take the max, and any other field the kept event
+// lacks is filled from the merged events in order.
+func mergeEventValues(kept map[string]any, others []map[string]any) map[string]any {
+	merged := mergeJSONMap(map[string]any{}, kept)
+	all := append([]map[string]any{kept}, others...)
+
+	amountKey := firstPresentKey(kept, eventMergeAmountKeys)
+	amountTotal := 0.0
+	for _, value := range all {
+		if key := firstPresentKey(value, eventMergeAmountKeys); key != "" {
+			if amountKey == "" {
+				amountKey = key
+			}
+			amountTotal += extractNumberFromMap(value, key)
+		}
+	}
+	if amountKey != "" {
+		merged[amountKey] = roundToOneDecimal(amountTotal)
+	}
+
+	durationKey := firstPresentKey(kept, eventMergeDurationKeys)
+	var durationMax *float64
+	for _, value := range all {
+		if key := firstPresentKey(value, eventMergeDurationKeys); key != "" {
+			if durationKey == "" {
+				durationKey = key
+			}
+			duration := extractNumberFromMap(value, key)
+			if durationMax == nil || duration > *durationMax {
+				durationMax = &duration
+			}
+		}
+	}
+	if durationMax != nil {
+		merged[durationKey] = roundToOneDecimal(*durationMax)
+	}
+
+	for _, value := range others {
+		for key, item := range value {
+			if _, exists := merged[key]; !exists {
+				merged[key] = item
+			}
+		}
+	}
+	return merged
+}
+
+func firstPresentKey(value map[string]any, keys []string) string {
+	for _, key := range keys {
+		if _, ok := value[key]; ok {
+			return key
+		}
+	}
+	return ""
+}
+
+func (a *App) mergeEvents(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var payload eventMergeRequest
+	if !mustJSON(c, &payload) {
+		return
+	}
+	keepEventID := strings.TrimSpace(payload.KeepEventID)
+	if keepEventID == "" {
+		writeError(c, http.StatusBadRequest, "keep_event_id is required")
+		return
+	}
+	mergeEventIDs := make([]string, 0, len(payload.MergeEventIDs))
+	seen := map[string]struct{}{}
+	for _, raw := range payload.MergeEventIDs {
+		eventID := strings.TrimSpace(raw)
+		if eventID == "" {
+			continue
+		}
+		if eventID == keepEventID {
+			writeError(c, http.StatusBadRequest, "merge_event_ids must not include keep_event_id")
+			return
+		}
+		if _, dup := seen[eventID]; dup {
+			continue
+		}
+		seen[eventID] = struct{}{}
+		mergeEventIDs = append(mergeEventIDs, eventID)
+	}
+	if len(mergeEventIDs) == 0 {
+		writeError(c, http.StatusBadRequest, "merge_event_ids is required")
+		return
+	}
+
+	var keepBabyID string
+	err := a.db.QueryRow(
+		c.Request.Context(),
+		`SELECT "babyId" FROM "Event" WHERE id = $1 AND "deletedAt" IS NULL`,
+		keepEventID,
+	).Scan(&keepBabyID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		writeError(c, http.StatusNotFound, "Event not found")
+		return
+	}
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load event")
+		return
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, keepBabyID, writeRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	tx, err := a.db.Begin(c.Request.Context())
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback(c.Request.Context())
+
+	type lockedEvent struct {
+		BabyID    string
+		Type      string
+		StartTime time.Time
+		EndTime   *time.Time
+		Value     map[string]any
+		Metadata  map[string]any
+	}
+	allIDs := append([]string{keepEventID}, mergeEventIDs...)
+	rows, err := tx.Query(
+		c.Request.Context(),
+		`SELECT id, "babyId", type, "startTime", "endTime", "valueJson", "metadataJson"
+		 FROM "Event"
+		 WHERE id = ANY($1)
+		   AND "deletedAt" IS NULL
+		   AND `+eventVisibleToUserSQL("$2")+`
+		 FOR UPDATE`,
+		allIDs,
+		user.ID,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to lock events")
+		return
+	}
+	locked := make(map[string]lockedEvent, len(allIDs))
+	for rows.Next() {
+		var eventID string
+		var item lockedEvent
+		var valueRaw []byte
+		var metadataRaw []byte
+		if err := rows.Scan(&eventID, &item.BabyID, &item.Type, &item.StartTime, &item.EndTime, &valueRaw, &metadataRaw); err != nil {
+			rows.Close()
+			writeError(c, http.StatusInternalServerError, "Failed to parse events")
+			return
+		}
+		item.Value = parseJSONStringMap(valueRaw)
+		item.Metadata = parseJSONStringMap(metadataRaw)
+		locked[eventID] = item
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse events")
+		return
+	}
+
+	kept, ok := locked[keepEventID]
+	if !ok {
+		writeError(c, http.StatusNotFound, "Event not found")
+		return
+	}
+	others := make([]map[string]any, 0, len(mergeEventIDs))
+	for _, eventID := range allIDs {
+		item, ok := locked[eventID]
+		if !ok {
+			writeError(c, http.StatusNotFound, "Event not found: "+eventID)
+			return
+		}
+		if item.BabyID != baby.ID {
+			writeError(c, http.StatusBadRequest, "events must belong to the same baby")
+			return
+		}
+		if item.Type != kept.Type {
+			writeError(c, http.StatusBadRequest, "events must have the same type")
+			return
+		}
+		eventState := strings.ToUpper(strings.TrimSpace(toString(item.Metadata["event_state"])))
+		if eventState == "CANCELED" {
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+				"detail":       "event is already canceled",
+				"event_id":     eventID,
+				"event_status": "CANCELED",
+			})
+			return
+		}
+		entryMode := strings.ToLower(strings.TrimSpace(toString(item.Metadata["entry_mode"])))
+		if item.EndTime == nil && (eventState == "OPEN" || entryMode == "manual_start") {
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+				"detail":       "only closed events can be merged",
+				"event_id":     eventID,
+				"event_status": "OPEN",
+			})
+			return
+		}
+		if eventID != keepEventID {
+			others = append(others, item.Value)
+		}
+	}
+
+	mergedValue := mergeEventValues(kept.Value, others)
+	keptMetadata := kept.Metadata
+	keptMetadata["merged_event_ids"] = mergeEventIDs
+	if _, err := tx.Exec(
+		c.Request.Context(),
+		`UPDATE "Event" SET "valueJson" = $2, "metadataJson" = $3 WHERE id = $1`,
+		keepEventID,
+		mustMarshalJSON(mergedValue),
+		mustMarshalJSON(keptMetadata),
+	); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to update event")
+		return
+	}
+
+	// Merged events go to the trash like a delete, so they can be restored
+	// within the retention window and their PRD rows go with them.
+	for _, eventID := range mergeEventIDs {
+		item := locked[eventID]
+		item.Metadata["merged_into"] = keepEventID
+		if _, err := tx.Exec(
+			c.Request.Context(),
+			`UPDATE "Event" SET "deletedAt" = NOW(), "metadataJson" = $2 WHERE id = $1`,
+			eventID,
+			mustMarshalJSON(item.Metadata),
+		); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to delete merged event")
+			return
+		}
+		// Private events are never projected, so there is no row to remove.
+		if toString(item.Metadata["visibility"]) != eventVisibilityPrivate {
+			if err := deleteProjectedEvents(c.Request.Context(), tx, baby.ID, item.Type, item.StartTime); err != nil {
+				writeError(c, http.StatusInternalServerError, "Failed to delete projected event")
+				return
+			}
+		}
+	}
+	// Replace the kept event's PRD row so it carries the merged amount and
+	// duration.
+	if toString(kept.Metadata["visibility"]) != eventVisibilityPrivate {
+		if err := deleteProjectedEvents(c.Request.Context(), tx, baby.ID, kept.Type, kept.StartTime); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to update projected event")
+			return
+		}
+		if err := a.projectEventToPRDTables(
+			c.Request.Context(),
+			tx,
+			baby.ID,
+			kept.Type,
+			kept.StartTime.UTC(),
+			kept.EndTime,
+			mergedValue,
+		); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to update projected event")
+			return
+		}
+	}
+
+	if err := recordAuditLog(
+		c.Request.Context(),
+		tx,
+		baby.HouseholdID,
+		user.ID,
+		"EVENT_MERGED",
+		"Event",
+		&keepEventID,
+		gin.H{
+			"baby_id":          baby.ID,
+			"type":             kept.Type,
+			"merged_event_ids": mergeEventIDs,
+		},
+	); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to write audit log")
+		return
+	}
+
+	if err := tx.Commit(c.Request.Context()); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":           "MERGED",
+		"event_id":         keepEventID,
+		"type":             kept.Type,
+		"value":            mergedValue,
+		"merged_event_ids": mergeEventIDs,
+	})
+}
+
+func (a *App) listOpenEvents(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	babyID := strings.TrimSpace(c.Query("baby_id"))
+	if babyID == "" {
+		writeError(c, http.StatusBadRequest, "baby_id is required")
 		return
 	}
 
@@ -1417,11 +2835,8 @@ func (a *App) listOpenEvents(c *gin.Context) {
 	rowsQuery := `SELECT id, type, "startTime", "valueJson", "metadataJson", "createdAt"
 		FROM "Event"
 		WHERE "babyId" = $1
-		  AND "endTime" IS NULL
-		  AND (
-		    COALESCE("metadataJson"->>'event_state', '') = 'OPEN'
-		    OR COALESCE("metadataJson"->>'entry_mode', '') = 'manual_start'
-		  )
+		  AND "deletedAt" IS NULL
+		  AND ` + openEventPredicateSQL + `
 		ORDER BY "startTime" DESC`
 	args := []any{baby.ID}
 	if queryType != "" {
@@ -1433,12 +2848,9 @@ func (a *App) listOpenEvents(c *gin.Context) {
 		rowsQuery = `SELECT id, type, "startTime", "valueJson", "metadataJson", "createdAt"
 			FROM "Event"
 			WHERE "babyId" = $1
-			  AND "endTime" IS NULL
+			  AND "deletedAt" IS NULL
 			  AND type = $2
-			  AND (
-			    COALESCE("metadataJson"->>'event_state', '') = 'OPEN'
-			    OR COALESCE("metadataJson"->>'entry_mode', '') = 'manual_start'
-			  )
+			  AND ` + openEventPredicateSQL + `
 			ORDER BY "startTime" DESC`
 		args = []any{baby.ID, eventType}
 	}
@@ -1483,3 +2895,120 @@ func (a *App) listOpenEvents(c *gin.Context) {
 		"reference_text": "Open events represent in-progress records awaiting completion.",
 	})
 }
+
+func (a *App) getStaleOpenEvents(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	babyID := strings.TrimSpace(c.Query("baby_id"))
+	if babyID == "" {
+		writeError(c, http.StatusBadRequest, "baby_id is required")
+		return
+	}
+
+	olderThanMin := staleOpenEventDefaultMin
+	if raw := strings.TrimSpace(c.Query("older_than_min")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 || parsed > staleOpenEventMaxMin {
+			writeError(c, http.StatusBadRequest, "older_than_min must be between 1 and "+strconv.Itoa(staleOpenEventMaxMin))
+			return
+		}
+		olderThanMin = parsed
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, babyID, readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	nowUTC := time.Now().UTC()
+	cutoff := nowUTC.Add(-time.Duration(olderThanMin) * time.Minute)
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT id, type, "startTime", "valueJson"
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND "startTime" <= $2
+		   AND `+openEventPredicateSQL+`
+		 ORDER BY "startTime" ASC`,
+		baby.ID,
+		cutoff,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load open events")
+		return
+	}
+	defer rows.Close()
+
+	events := make([]gin.H, 0)
+	for rows.Next() {
+		var eventID string
+		var eventType string
+		var startTime time.Time
+		var valueRaw []byte
+		if err := rows.Scan(&eventID, &eventType, &startTime, &valueRaw); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to parse open events")
+			return
+		}
+		elapsedMin := int(nowUTC.Sub(startTime.UTC()).Minutes())
+		if elapsedMin < 0 {
+			elapsedMin = 0
+		}
+		action, message := staleOpenEventSuggestion(eventType, elapsedMin)
+		events = append(events, gin.H{
+			"event_id":          eventID,
+			"type":              eventType,
+			"status":            "OPEN",
+			"start_time":        startTime.UTC().Format(time.RFC3339),
+			"elapsed_min":       elapsedMin,
+			"value":             parseJSONStringMap(valueRaw),
+			"suggested_action":  action,
+			"suggested_actions": []string{"complete", "cancel"},
+			"message":           message,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse open events")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"baby_id":        baby.ID,
+		"older_than_min": olderThanMin,
+		"stale_events":   events,
+		"stale_count":    len(events),
+		"reference_text": "Open events started more than older_than_min minutes ago. Nothing is changed automatically.",
+	})
+}
+
+// staleOpenEventSuggestion proposes completing an open event while its
+// elapsed time is still plausible for the type, and cancelling it once the
+// timer was most likely forgotten.
+func staleOpenEventSuggestion(eventType string, elapsedMin int) (string, string) {
+	plausibleMaxMin := 120
+	label := "record"
+	switch strings.ToUpper(strings.TrimSpace(eventType)) {
+	case "SLEEP":
+		plausibleMaxMin = 840
+		label = "sleep"
+	case "FORMULA", "BREASTFEED":
+		plausibleMaxMin = 60
+		label = "feeding"
+	case "PEE", "POO":
+		plausibleMaxMin = 30
+		label = "diaper"
+	case "MEDICATION":
+		plausibleMaxMin = 30
+		label = "medication"
+	}
+
+	if elapsedMin <= plausibleMaxMin {
+		return "complete", "The " + label + " timer is still running. Complete it when it ends."
+	}
+	return "cancel", "The " + label + " timer has been running for " + strconv.Itoa(elapsedMin) + " minutes. It was likely left open; complete it with the real end time or cancel it."
+}
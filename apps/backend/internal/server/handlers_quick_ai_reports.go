@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
@@ -33,7 +34,7 @@ func (a *App) quickLastPooTime(c *gin.Context) {
 	err = a.db.QueryRow(
 		c.Request.Context(),
 		`SELECT "startTime" FROM "Event"
-		 WHERE "babyId" = $1 AND type = 'POO'
+		 WHERE "babyId" = $1 AND "deletedAt" IS NULL AND type = 'POO'
 		 ORDER BY "startTime" DESC LIMIT 1`,
 		baby.ID,
 	).Scan(&lastPoo)
@@ -62,6 +63,195 @@ func (a *App) quickLastPooTime(c *gin.Context) {
 	})
 }
 
+// quickLastSymptom reports the latest closed SYMPTOM event with its symptom
+// name, severity and any temperature logged with it.
+func (a *App) quickLastSymptom(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	babyID := c.Query("baby_id")
+	tone := strings.TrimSpace(c.DefaultQuery("tone", "neutral"))
+	localZone, tzNormalized, err := parseTZOffset(c.Query("tz_offset"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, babyID, readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	var startedAt time.Time
+	var valueRaw []byte
+	err = a.db.QueryRow(
+		c.Request.Context(),
+		`SELECT "startTime", "valueJson" FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND type = 'SYMPTOM'
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') = 'CLOSED'
+		   AND `+eventVisibleToUserSQL("$2")+`
+		 ORDER BY "startTime" DESC LIMIT 1`,
+		baby.ID,
+		user.ID,
+	).Scan(&startedAt, &valueRaw)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusOK, gin.H{
+			"type":           "SYMPTOM",
+			"timestamp":      nil,
+			"local_time":     nil,
+			"tz_offset":      tzNormalized,
+			"reference_text": "No confirmed symptom events are stored yet.",
+			"message":        "No symptom records yet. Add one and I can answer immediately.",
+		})
+		return
+	}
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load symptom events")
+		return
+	}
+
+	value := parseJSONStringMap(valueRaw)
+	symptom := strings.TrimSpace(coalesceNonEmpty(toString(value["symptom"]), toString(value["name"])))
+	severity := strings.TrimSpace(toString(value["severity"]))
+	var temperatureC *float64
+	if tempC := extractNumberFromMap(value, "temp_c", "temperature_c", "temp"); tempC > 0 {
+		rounded := math.Round(tempC*10) / 10
+		temperatureC = &rounded
+	}
+
+	localTime := startedAt.In(localZone)
+	label := symptom
+	if label == "" {
+		label = "A symptom"
+	}
+	if severity != "" {
+		label += " (" + severity + ")"
+	}
+	if temperatureC != nil {
+		label += fmt.Sprintf(", %.1f°C", *temperatureC)
+	}
+	clock := localTime.Format("15:04")
+	c.JSON(http.StatusOK, gin.H{
+		"type":           "SYMPTOM",
+		"timestamp":      startedAt.UTC(),
+		"local_time":     localTime.Format(time.RFC3339),
+		"tz_offset":      tzNormalized,
+		"symptom":        nullableString(symptom),
+		"severity":       nullableString(severity),
+		"temperature_c":  temperatureC,
+		"reference_text": "Based on confirmed event logs for this baby.",
+		"message": toneWrap(
+			tone,
+			label+" was logged at "+clock+".",
+			"The latest recorded symptom is "+label+" at "+clock+".",
+			"Last symptom: "+label+" "+clock+".",
+		),
+	})
+}
+
+// quickLastFeeding reports the latest closed FORMULA or BREASTFEED event.
+// amount_ml stays in ml; amount echoes it in the baby's feeding_unit.
+func (a *App) quickLastFeeding(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	babyID := c.Query("baby_id")
+	tone := strings.TrimSpace(c.DefaultQuery("tone", "neutral"))
+	localZone, tzNormalized, err := parseTZOffset(c.Query("tz_offset"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	profile, statusCode, err := a.resolveBabyProfile(c.Request.Context(), user.ID, babyID, readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	var eventType string
+	var startedAt time.Time
+	var endedAt *time.Time
+	var valueRaw []byte
+	err = a.db.QueryRow(
+		c.Request.Context(),
+		`SELECT type, "startTime", "endTime", "valueJson" FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND type IN ('FORMULA', 'BREASTFEED')
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') = 'CLOSED'
+		   AND `+eventVisibleToUserSQL("$2")+`
+		 ORDER BY "startTime" DESC LIMIT 1`,
+		profile.BabyID,
+		user.ID,
+	).Scan(&eventType, &startedAt, &endedAt, &valueRaw)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusOK, gin.H{
+			"type":           nil,
+			"timestamp":      nil,
+			"local_time":     nil,
+			"tz_offset":      tzNormalized,
+			"feeding_unit":   profile.FeedingUnit,
+			"reference_text": "No confirmed feeding events are stored yet.",
+			"message":        "No feeding records yet. Add one and I can answer immediately.",
+		})
+		return
+	}
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load feeding events")
+		return
+	}
+
+	value := parseJSONStringMap(valueRaw)
+	var amountML *int
+	if amount := extractNumberFromMap(value, "ml", "amount_ml", "volume_ml"); amount > 0 {
+		rounded := int(math.Round(amount))
+		amountML = &rounded
+	}
+	var durationMin *int
+	if duration := extractDurationMinutes(value, startedAt, endedAt); duration != nil {
+		minutes := int(*duration)
+		durationMin = &minutes
+	}
+	amount := feedingAmountInUnit(amountML, profile.FeedingUnit)
+
+	label := "Formula"
+	if eventType == "BREASTFEED" {
+		label = "Breastfeed"
+	}
+	if amount != nil {
+		label += " " + strconv.FormatFloat(*amount, 'f', -1, 64) + " " + profile.FeedingUnit
+	} else if durationMin != nil {
+		label += " " + strconv.Itoa(*durationMin) + " min"
+	}
+	localTime := startedAt.In(localZone)
+	clock := localTime.Format("15:04")
+	c.JSON(http.StatusOK, gin.H{
+		"type":           eventType,
+		"timestamp":      startedAt.UTC(),
+		"local_time":     localTime.Format(time.RFC3339),
+		"tz_offset":      tzNormalized,
+		"amount_ml":      amountML,
+		"amount":         amount,
+		"feeding_unit":   profile.FeedingUnit,
+		"duration_min":   durationMin,
+		"reference_text": "Based on confirmed event logs for this baby.",
+		"message": toneWrap(
+			tone,
+			label+" was logged at "+clock+".",
+			"The latest recorded feeding is "+label+" at "+clock+".",
+			"Last feeding: "+label+" "+clock+".",
+		),
+	})
+}
+
 func (a *App) quickNextFeedingETA(c *gin.Context) {
 	user, ok := authUserFromContext(c)
 	if !ok {
@@ -70,6 +260,11 @@ func (a *App) quickNextFeedingETA(c *gin.Context) {
 	}
 	babyID := c.Query("baby_id")
 	tone := strings.TrimSpace(c.DefaultQuery("tone", "neutral"))
+	mode := strings.ToLower(strings.TrimSpace(c.DefaultQuery("mode", feedingETAModeMean)))
+	if mode != feedingETAModeMean && mode != feedingETAModeWeighted {
+		writeError(c, http.StatusBadRequest, "mode must be one of: mean, weighted")
+		return
+	}
 
 	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, babyID, readRoles)
 	if err != nil {
@@ -82,6 +277,7 @@ func (a *App) quickNextFeedingETA(c *gin.Context) {
 		c.Request.Context(),
 		`SELECT "startTime" FROM "Event"
 		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
 		   AND type IN ('FORMULA', 'BREASTFEED')
 		   AND "startTime" <= $2
 		 ORDER BY "startTime" DESC LIMIT 10`,
@@ -105,9 +301,13 @@ func (a *App) quickNextFeedingETA(c *gin.Context) {
 	}
 
 	result := calculateNextFeedingETA(times, nowUTC)
+	if mode == feedingETAModeWeighted {
+		result = calculateWeightedNextFeedingETA(times, nowUTC)
+	}
 	if result.ETAMinutes == nil || result.AverageIntervalMinutes == nil {
 		c.JSON(http.StatusOK, gin.H{
 			"eta_minutes":    nil,
+			"mode":           mode,
 			"unstable":       true,
 			"reference_text": "At least two feeding records are required.",
 			"message":        "Not enough feeding history yet. Add one or two more feeding events.",
@@ -119,6 +319,7 @@ func (a *App) quickNextFeedingETA(c *gin.Context) {
 	avgM := *result.AverageIntervalMinutes % 60
 	c.JSON(http.StatusOK, gin.H{
 		"eta_minutes":    *result.ETAMinutes,
+		"mode":           mode,
 		"unstable":       false,
 		"reference_text": "Computed from " + strconv.Itoa(len(times)) + " recent feeding events.",
 		"message": toneWrap(
@@ -137,6 +338,11 @@ func (a *App) quickTodaySummary(c *gin.Context) {
 		return
 	}
 	babyID := c.Query("baby_id")
+	localZone, tzNormalized, err := parseTZOffset(c.Query("tz_offset"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, babyID, readRoles)
 	if err != nil {
@@ -144,14 +350,15 @@ func (a *App) quickTodaySummary(c *gin.Context) {
 		return
 	}
 
-	start := startOfUTCDay(time.Now().UTC())
+	// "Today" runs from the caller's local midnight, not UTC midnight.
+	start := startOfScopeLocalDayUTC(time.Now().UTC(), localZone)
 	end := start.Add(24 * time.Hour)
 
 	rows, err := a.db.Query(
 		c.Request.Context(),
 		`SELECT type, "startTime", "endTime", "valueJson"
 		 FROM "Event"
-		 WHERE "babyId" = $1 AND "startTime" >= $2 AND "startTime" < $3`,
+		 WHERE "babyId" = $1 AND "deletedAt" IS NULL AND "startTime" >= $2 AND "startTime" < $3`,
 		baby.ID,
 		start,
 		end,
@@ -165,6 +372,7 @@ func (a *App) quickTodaySummary(c *gin.Context) {
 	counts := map[string]int{}
 	formulaTotal := 0.0
 	sleepMinutes := 0
+	feedings := feedingSplit{}
 	for rows.Next() {
 		var eventType string
 		var startedAt time.Time
@@ -176,6 +384,7 @@ func (a *App) quickTodaySummary(c *gin.Context) {
 		}
 		counts[eventType]++
 		valueJSON := parseJSONStringMap(valueRaw)
+		feedings.add(eventType, valueJSON, startedAt, endedAt)
 		if eventType == "FORMULA" {
 			formulaTotal += extractNumberFromMap(valueJSON, "ml", "amount_ml", "volume_ml")
 		}
@@ -193,12 +402,45 @@ func (a *App) quickTodaySummary(c *gin.Context) {
 		"Sleep logged: " + strconv.Itoa(sleepMinutes) + " minutes",
 		"Diaper events: pee " + strconv.Itoa(counts["PEE"]) + ", poo " + strconv.Itoa(counts["POO"]),
 	}
+	lines = append(lines, feedings.summaryLines()...)
 	c.JSON(http.StatusOK, gin.H{
 		"summary_lines":  lines,
+		"feeding_split":  feedings,
+		"tz_offset":      tzNormalized,
 		"reference_text": "Derived from today's confirmed events.",
 	})
 }
 
+// landingSnapshotWindow is the local date range a landing snapshot covers.
+type landingSnapshotWindow struct {
+	RangeKey     string
+	RangeLabel   string
+	RangeDays    int
+	TZOffset     string
+	WeekStartsOn time.Weekday
+	LocalNow     time.Time
+	LocalStart   time.Time
+	LocalEnd     time.Time
+}
+
+type landingSnapshotEvent struct {
+	ID        string
+	Type      string
+	StartTime time.Time
+	EndTime   *time.Time
+	Value     map[string]any
+	Metadata  map[string]any
+}
+
+// landingSnapshotCredits is the caller's AI credit state. It depends only on
+// the user and household, so a household snapshot loads it once.
+type landingSnapshotCredits struct {
+	Plan       *string
+	Balance    int
+	GraceUsed  int
+	GraceLimit int
+}
+
 func (a *App) quickLandingSnapshot(c *gin.Context) {
 	user, ok := authUserFromContext(c)
 	if !ok {
@@ -213,27 +455,225 @@ func (a *App) quickLandingSnapshot(c *gin.Context) {
 		return
 	}
 
-	localZone, tzNormalized, err := parseTZOffset(c.Query("tz_offset"))
+	nowUTC := time.Now().UTC()
+	window, statusCode, err := a.landingSnapshotWindowFromQuery(c, user.ID, nowUTC)
 	if err != nil {
-		writeError(c, http.StatusBadRequest, err.Error())
+		writeError(c, statusCode, err.Error())
 		return
 	}
-	rangeKey := strings.ToLower(strings.TrimSpace(c.DefaultQuery("range", "day")))
+	events, openEvents, err := a.loadLandingSnapshotEvents(
+		c.Request.Context(),
+		[]string{baby.ID},
+		user.ID,
+		window.LocalStart.UTC(),
+		window.LocalEnd.UTC(),
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load events")
+		return
+	}
+	credits, err := a.loadLandingSnapshotCredits(c.Request.Context(), user.ID, baby.HouseholdID, nowUTC)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	snapshot, err := a.buildLandingSnapshot(
+		c.Request.Context(),
+		user.ID,
+		baby.ID,
+		window,
+		credits,
+		events[baby.ID],
+		openEvents[baby.ID],
+		nowUTC,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, snapshot)
+}
+
+// quickHouseholdSnapshot returns the landing snapshot of every baby in a
+// household keyed by baby id, so a multi-baby home needs one call. Events for
+// all babies are loaded together rather than once per baby.
+func (a *App) quickHouseholdSnapshot(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	householdID := strings.TrimSpace(c.Query("household_id"))
+	if householdID == "" {
+		writeError(c, http.StatusBadRequest, "household_id is required")
+		return
+	}
+	if _, statusCode, err := a.assertHouseholdAccess(c.Request.Context(), user.ID, householdID, readRoles); err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
 	nowUTC := time.Now().UTC()
-	localNow := nowUTC.In(localZone)
-	localStart, localEnd, rangeDays, rangeLabel, err := quickRangeWindow(localNow, rangeKey)
+	window, statusCode, err := a.landingSnapshotWindowFromQuery(c, user.ID, nowUTC)
 	if err != nil {
-		writeError(c, http.StatusBadRequest, err.Error())
+		writeError(c, statusCode, err.Error())
 		return
 	}
-	start := localStart.UTC()
-	end := localEnd.UTC()
 
 	rows, err := a.db.Query(
 		c.Request.Context(),
-		`SELECT type, "startTime", "endTime", "valueJson", "metadataJson"
+		`SELECT id FROM "Baby" WHERE "householdId" = $1 ORDER BY "createdAt" ASC, id ASC`,
+		householdID,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load babies")
+		return
+	}
+	babyIDs := make([]string, 0, 2)
+	for rows.Next() {
+		var babyID string
+		if err := rows.Scan(&babyID); err != nil {
+			rows.Close()
+			writeError(c, http.StatusInternalServerError, "Failed to parse babies")
+			return
+		}
+		babyIDs = append(babyIDs, babyID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse babies")
+		return
+	}
+
+	events, openEvents, err := a.loadLandingSnapshotEvents(
+		c.Request.Context(),
+		babyIDs,
+		user.ID,
+		window.LocalStart.UTC(),
+		window.LocalEnd.UTC(),
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load events")
+		return
+	}
+	credits, err := a.loadLandingSnapshotCredits(c.Request.Context(), user.ID, householdID, nowUTC)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	snapshots := make(map[string]gin.H, len(babyIDs))
+	for _, babyID := range babyIDs {
+		snapshot, err := a.buildLandingSnapshot(
+			c.Request.Context(),
+			user.ID,
+			babyID,
+			window,
+			credits,
+			events[babyID],
+			openEvents[babyID],
+			nowUTC,
+		)
+		if err != nil {
+			writeError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		snapshots[babyID] = snapshot
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"household_id": householdID,
+		"baby_ids":     babyIDs,
+		"range":        window.RangeKey,
+		"tz_offset":    window.TZOffset,
+		"snapshots":    snapshots,
+	})
+}
+
+// landingSnapshotWindowFromQuery reads tz_offset, range and week_starts_on.
+func (a *App) landingSnapshotWindowFromQuery(c *gin.Context, userID string, nowUTC time.Time) (landingSnapshotWindow, int, error) {
+	localZone, tzNormalized, err := parseTZOffset(c.Query("tz_offset"))
+	if err != nil {
+		return landingSnapshotWindow{}, http.StatusBadRequest, err
+	}
+	rangeKey := strings.ToLower(strings.TrimSpace(c.DefaultQuery("range", "day")))
+	weekStartsOn, statusCode, err := a.resolveWeekStartsOn(c.Request.Context(), userID, c.Query("week_starts_on"))
+	if err != nil {
+		return landingSnapshotWindow{}, statusCode, err
+	}
+	localNow := nowUTC.In(localZone)
+	localStart, localEnd, rangeDays, rangeLabel, err := quickRangeWindow(localNow, rangeKey, weekStartsOn)
+	if err != nil {
+		return landingSnapshotWindow{}, http.StatusBadRequest, err
+	}
+	return landingSnapshotWindow{
+		RangeKey:     rangeKey,
+		RangeLabel:   rangeLabel,
+		RangeDays:    rangeDays,
+		TZOffset:     tzNormalized,
+		WeekStartsOn: weekStartsOn,
+		LocalNow:     localNow,
+		LocalStart:   localStart,
+		LocalEnd:     localEnd,
+	}, http.StatusOK, nil
+}
+
+// loadLandingSnapshotEvents loads, for every baby at once, the closed events
+// starting in [start, end) and the currently open events. Both are keyed by
+// baby id and ordered newest first.
+func (a *App) loadLandingSnapshotEvents(
+	ctx context.Context,
+	babyIDs []string,
+	userID string,
+	start, end time.Time,
+) (map[string][]landingSnapshotEvent, map[string][]landingSnapshotEvent, error) {
+	events, err := a.loadLandingSnapshotClosedEvents(ctx, babyIDs, userID, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	openRows, err := a.db.Query(
+		ctx,
+		`SELECT id, "babyId", type, "startTime", "endTime", "valueJson", "metadataJson"
 		 FROM "Event"
-		 WHERE "babyId" = $1
+		 WHERE "babyId" = ANY($1)
+		   AND "deletedAt" IS NULL
+		   AND "endTime" IS NULL
+		   AND (
+		     COALESCE("metadataJson"->>'event_state', '') = 'OPEN'
+		     OR COALESCE("metadataJson"->>'entry_mode', '') = 'manual_start'
+		   )
+		   AND type IN ('FORMULA', 'BREASTFEED', 'SLEEP', 'PEE', 'POO', 'MEDICATION', 'MEMO')
+		   AND `+eventVisibleToUserSQL("$2")+`
+		 ORDER BY "startTime" DESC`,
+		babyIDs,
+		userID,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	openEvents, err := collectLandingSnapshotEvents(openRows)
+	if err != nil {
+		return nil, nil, err
+	}
+	return events, openEvents, nil
+}
+
+// loadLandingSnapshotClosedEvents loads the closed, visible events starting in
+// [start, end) for every baby at once, keyed by baby id and newest first.
+func (a *App) loadLandingSnapshotClosedEvents(
+	ctx context.Context,
+	babyIDs []string,
+	userID string,
+	start, end time.Time,
+) (map[string][]landingSnapshotEvent, error) {
+	rows, err := a.db.Query(
+		ctx,
+		`SELECT id, "babyId", type, "startTime", "endTime", "valueJson", "metadataJson"
+		 FROM "Event"
+		 WHERE "babyId" = ANY($1)
+		   AND "deletedAt" IS NULL
 		   AND "startTime" >= $2
 		   AND "startTime" < $3
 		   AND NOT (
@@ -244,17 +684,103 @@ func (a *App) quickLandingSnapshot(c *gin.Context) {
 		     )
 		   )
 		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
-		   AND type IN ('FORMULA', 'BREASTFEED', 'SLEEP', 'PEE', 'POO', 'MEDICATION', 'MEMO')
+		   AND type IN ('FORMULA', 'BREASTFEED', 'SLEEP', 'PEE', 'POO', 'MEDICATION', 'MEMO', 'SYMPTOM', 'GROWTH')
+		   AND `+eventVisibleToUserSQL("$4")+`
 		 ORDER BY "startTime" DESC`,
-		baby.ID,
+		babyIDs,
 		start,
 		end,
+		userID,
 	)
 	if err != nil {
-		writeError(c, http.StatusInternalServerError, "Failed to load events")
-		return
+		return nil, err
 	}
+	return collectLandingSnapshotEvents(rows)
+}
+
+// landingFormulaAmountML is the whole-ml amount one FORMULA event adds to the
+// snapshot totals; negative amounts count as 0.
+func landingFormulaAmountML(value map[string]any) int {
+	amountML := int(extractNumberFromMap(value, "ml", "amount_ml", "volume_ml") + 0.5)
+	if amountML < 0 {
+		return 0
+	}
+	return amountML
+}
+
+func collectLandingSnapshotEvents(rows pgx.Rows) (map[string][]landingSnapshotEvent, error) {
 	defer rows.Close()
+	byBaby := map[string][]landingSnapshotEvent{}
+	for rows.Next() {
+		var babyID string
+		var event landingSnapshotEvent
+		var valueRaw, metadataRaw []byte
+		if err := rows.Scan(
+			&event.ID,
+			&babyID,
+			&event.Type,
+			&event.StartTime,
+			&event.EndTime,
+			&valueRaw,
+			&metadataRaw,
+		); err != nil {
+			return nil, err
+		}
+		event.Value = parseJSONStringMap(valueRaw)
+		event.Metadata = parseJSONStringMap(metadataRaw)
+		byBaby[babyID] = append(byBaby[babyID], event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return byBaby, nil
+}
+
+func (a *App) loadLandingSnapshotCredits(ctx context.Context, userID, householdID string, nowUTC time.Time) (landingSnapshotCredits, error) {
+	plan, err := a.ensureMonthlyGrant(ctx, a.db, userID, householdID, nowUTC)
+	if err != nil {
+		return landingSnapshotCredits{}, errors.New("Failed to resolve AI credit plan")
+	}
+	balance, err := a.getWalletBalance(ctx, a.db, userID)
+	if err != nil {
+		return landingSnapshotCredits{}, errors.New("Failed to load AI credit balance")
+	}
+	graceUsed, err := a.countGraceUsedToday(ctx, a.db, userID, nowUTC)
+	if err != nil {
+		return landingSnapshotCredits{}, errors.New("Failed to load AI grace usage")
+	}
+	limits, err := a.planLimitsForHousehold(ctx, householdID)
+	if err != nil {
+		return landingSnapshotCredits{}, errors.New("Failed to resolve AI credit plan")
+	}
+	return landingSnapshotCredits{
+		Plan:       plan,
+		Balance:    balance,
+		GraceUsed:  graceUsed,
+		GraceLimit: limits.GraceLimitPerDay,
+	}, nil
+}
+
+// buildLandingSnapshot computes one baby's snapshot from its preloaded
+// events. events and openEvents must be ordered newest first.
+func (a *App) buildLandingSnapshot(
+	ctx context.Context,
+	userID, babyID string,
+	window landingSnapshotWindow,
+	credits landingSnapshotCredits,
+	events, openEvents []landingSnapshotEvent,
+	nowUTC time.Time,
+) (gin.H, error) {
+	localZone := window.LocalNow.Location()
+	localNow := window.LocalNow
+	localStart := window.LocalStart
+	localEnd := window.LocalEnd
+	rangeDays := window.RangeDays
+
+	profile, _, err := a.resolveBabyProfile(ctx, userID, babyID, readRoles)
+	if err != nil {
+		return nil, errors.New("Failed to resolve baby profile")
+	}
 
 	formulaBands := map[string]int{
 		"night":     0,
@@ -294,23 +820,24 @@ func (a *App) quickLandingSnapshot(c *gin.Context) {
 	var lastMedicationTime *time.Time
 	var lastMedicationName *string
 	var lastFormulaAmountML *int
+	symptomCount := 0
+	var lastSymptomTime *time.Time
+	var lastSymptomName *string
+	var lastSymptomSeverity *string
+	// SYMPTOM and GROWTH have no start/complete flow, so they never appear
+	// among the open events below.
+	var latestGrowthMeasuredAt *time.Time
+	var latestGrowthWeightKg *float64
+	var latestGrowthHeightCm *float64
 	specialMemo := "No special memo in selected range."
 
-	for rows.Next() {
-		var eventType string
-		var startedAt time.Time
-		var endedAt *time.Time
-		var valueRaw []byte
-		var metadataRaw []byte
-		if err := rows.Scan(&eventType, &startedAt, &endedAt, &valueRaw, &metadataRaw); err != nil {
-			writeError(c, http.StatusInternalServerError, "Failed to parse events")
-			return
-		}
-
-		startedUTC := startedAt.UTC()
+	for _, event := range events {
+		eventType := event.Type
+		endedAt := event.EndTime
+		startedUTC := event.StartTime.UTC()
 		startedLocal := startedUTC.In(localZone)
-		valueMap := parseJSONStringMap(valueRaw)
-		metadataMap := parseJSONStringMap(metadataRaw)
+		valueMap := event.Value
+		metadataMap := event.Metadata
 
 		switch eventType {
 		case "FORMULA":
@@ -320,10 +847,7 @@ func (a *App) quickLandingSnapshot(c *gin.Context) {
 				lastFormulaTime = &startedUTC
 			}
 			formulaTimes = append(formulaTimes, startedUTC.Format(time.RFC3339))
-			amountML := int(extractNumberFromMap(valueMap, "ml", "amount_ml", "volume_ml") + 0.5)
-			if amountML < 0 {
-				amountML = 0
-			}
+			amountML := landingFormulaAmountML(valueMap)
 			if lastFormulaAmountML == nil {
 				amountCopy := amountML
 				lastFormulaAmountML = &amountCopy
@@ -345,6 +869,9 @@ func (a *App) quickLandingSnapshot(c *gin.Context) {
 			breastfeedTimes = append(breastfeedTimes, startedUTC.Format(time.RFC3339))
 
 		case "SLEEP":
+			if isZeroDurationSleep(eventType, valueMap, startedUTC, endedAt) {
+				continue
+			}
 			if recentSleepTime == nil {
 				recentSleepTime = &startedUTC
 			}
@@ -360,7 +887,7 @@ func (a *App) quickLandingSnapshot(c *gin.Context) {
 				}
 			}
 			sleepTotalMin += duration
-			if startedLocal.Hour() >= 6 && startedLocal.Hour() < 18 {
+			if sleepBandForEvent(valueMap, startedLocal, float64(duration), profile.NapStartHour, profile.NightStartHour) == "nap" {
 				sleepNapTotalMin += duration
 			} else {
 				sleepNightTotalMin += duration
@@ -394,25 +921,26 @@ func (a *App) quickLandingSnapshot(c *gin.Context) {
 			medicationCount++
 			if lastMedicationTime == nil {
 				lastMedicationTime = &startedUTC
-				medicationName := ""
-				for _, raw := range []any{
-					valueMap["name"],
-					valueMap["medication_name"],
-					valueMap["medication_type"],
-				} {
-					text := strings.TrimSpace(toString(raw))
-					if text == "" {
-						continue
-					}
-					medicationName = text
-					break
-				}
-				if medicationName != "" {
+				if medicationName := medicationNameFromValue(valueMap); medicationName != "" {
 					nameCopy := medicationName
 					lastMedicationName = &nameCopy
 				}
 			}
 
+		case "SYMPTOM":
+			symptomCount++
+			if lastSymptomTime == nil {
+				lastSymptomTime = &startedUTC
+				lastSymptomName = nullableString(coalesceNonEmpty(toString(valueMap["symptom"]), toString(valueMap["name"])))
+				lastSymptomSeverity = nullableString(toString(valueMap["severity"]))
+			}
+
+		case "GROWTH":
+			if latestGrowthMeasuredAt == nil {
+				latestGrowthMeasuredAt = &startedUTC
+				latestGrowthWeightKg, latestGrowthHeightCm = growthMeasurements(valueMap)
+			}
+
 		case "MEMO":
 			memoCount++
 			if isWeaningMemo(valueMap, metadataMap) {
@@ -430,10 +958,6 @@ func (a *App) quickLandingSnapshot(c *gin.Context) {
 			}
 		}
 	}
-	if err := rows.Err(); err != nil {
-		writeError(c, http.StatusInternalServerError, "Failed to parse events")
-		return
-	}
 
 	var openFormulaEventID *string
 	var openFormulaStartTime *time.Time
@@ -461,38 +985,12 @@ func (a *App) quickLandingSnapshot(c *gin.Context) {
 	var openMedicationValue map[string]any
 	var openMedicationMemo *string
 
-	openRows, err := a.db.Query(
-		c.Request.Context(),
-		`SELECT id, type, "startTime", "valueJson", "metadataJson"
-		 FROM "Event"
-		 WHERE "babyId" = $1
-		   AND "endTime" IS NULL
-		   AND (
-		     COALESCE("metadataJson"->>'event_state', '') = 'OPEN'
-		     OR COALESCE("metadataJson"->>'entry_mode', '') = 'manual_start'
-		   )
-		   AND type IN ('FORMULA', 'BREASTFEED', 'SLEEP', 'PEE', 'POO', 'MEDICATION', 'MEMO')
-		 ORDER BY "startTime" DESC`,
-		baby.ID,
-	)
-	if err != nil {
-		writeError(c, http.StatusInternalServerError, "Failed to load open events")
-		return
-	}
-	defer openRows.Close()
-
-	for openRows.Next() {
-		var eventID string
-		var eventType string
-		var startTime time.Time
-		var valueRaw []byte
-		var metadataRaw []byte
-		if err := openRows.Scan(&eventID, &eventType, &startTime, &valueRaw, &metadataRaw); err != nil {
-			writeError(c, http.StatusInternalServerError, "Failed to parse open events")
-			return
-		}
-		valueMap := parseJSONStringMap(valueRaw)
-		metadataMap := parseJSONStringMap(metadataRaw)
+	for _, event := range openEvents {
+		eventID := event.ID
+		eventType := event.Type
+		startTime := event.StartTime
+		valueMap := event.Value
+		metadataMap := event.Metadata
 		switch eventType {
 		case "FORMULA":
 			if openFormulaEventID == nil {
@@ -573,11 +1071,6 @@ func (a *App) quickLandingSnapshot(c *gin.Context) {
 			}
 		}
 	}
-	if err := openRows.Err(); err != nil {
-		writeError(c, http.StatusInternalServerError, "Failed to parse open events")
-		return
-	}
-
 	if recentSleepDurationMin == nil && lastSleepEndTime != nil && recentSleepTime != nil {
 		duration := int(lastSleepEndTime.UTC().Sub(recentSleepTime.UTC()).Minutes())
 		if duration < 0 {
@@ -612,7 +1105,7 @@ func (a *App) quickLandingSnapshot(c *gin.Context) {
 	graphLabels := make([]string, 0)
 	graphPoints := make([]float64, 0)
 	graphMode := ""
-	switch rangeKey {
+	switch window.RangeKey {
 	case "day":
 		graphMode = "feeding_by_session"
 		sort.Slice(formulaEvents, func(i, j int) bool {
@@ -644,49 +1137,28 @@ func (a *App) quickLandingSnapshot(c *gin.Context) {
 		graphPoints = []float64{0}
 	}
 
-	profile, _, err := a.resolveBabyProfile(c.Request.Context(), user.ID, baby.ID, readRoles)
-	if err != nil {
-		writeError(c, http.StatusInternalServerError, "Failed to resolve baby profile")
-		return
-	}
-	lastFeedingTime, err := a.latestFeedingTime(c.Request.Context(), baby.ID)
+	lastFeedingTime, err := a.latestFeedingTime(ctx, babyID)
 	if err != nil {
-		writeError(c, http.StatusInternalServerError, "Failed to load latest feeding event")
-		return
+		return nil, errors.New("Failed to load latest feeding event")
 	}
 	recommendation := calculateFeedingRecommendation(profile, lastFeedingTime, nowUTC)
 
-	plan, err := a.ensureMonthlyGrant(c.Request.Context(), a.db, user.ID, baby.HouseholdID, nowUTC)
-	if err != nil {
-		writeError(c, http.StatusInternalServerError, "Failed to resolve AI credit plan")
-		return
-	}
-	balance, err := a.getWalletBalance(c.Request.Context(), a.db, user.ID)
-	if err != nil {
-		writeError(c, http.StatusInternalServerError, "Failed to load AI credit balance")
-		return
-	}
-	graceUsed, err := a.countGraceUsedToday(c.Request.Context(), a.db, user.ID, nowUTC)
-	if err != nil {
-		writeError(c, http.StatusInternalServerError, "Failed to load AI grace usage")
-		return
-	}
-
 	rangeEndDate := localEnd.Add(-24 * time.Hour).Format("2006-01-02")
 	if rangeEndDate < localStart.Format("2006-01-02") {
 		rangeEndDate = localStart.Format("2006-01-02")
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	return gin.H{
 		"baby_name":                       profile.Name,
 		"baby_profile_photo_url":          profile.ProfilePhotoURL,
 		"date":                            localNow.Format("2006-01-02"),
-		"range":                           rangeKey,
-		"range_label":                     rangeLabel,
+		"range":                           window.RangeKey,
+		"range_label":                     window.RangeLabel,
 		"range_start_date":                localStart.Format("2006-01-02"),
 		"range_end_date":                  rangeEndDate,
-		"range_day_count":                 rangeDays,
-		"tz_offset":                       tzNormalized,
+		"range_day_count":                 window.RangeDays,
+		"week_starts_on":                  weekStartsOnLabel(window.WeekStartsOn),
+		"tz_offset":                       window.TZOffset,
 		"formula_count":                   formulaCount,
 		"formula_times":                   formulaTimes,
 		"feedings_count":                  feedingsCount,
@@ -696,6 +1168,8 @@ func (a *App) quickLandingSnapshot(c *gin.Context) {
 		"formula_amount_by_time_band_ml":  formulaBands,
 		"last_formula_time":               formatNullableTimeRFC3339(lastFormulaTime),
 		"last_formula_amount_ml":          lastFormulaAmountML,
+		"last_formula_amount":             feedingAmountInUnit(lastFormulaAmountML, profile.FeedingUnit),
+		"feeding_unit":                    profile.FeedingUnit,
 		"breastfeed_count":                breastfeedCount,
 		"breastfeed_times":                breastfeedTimes,
 		"last_breastfeed_time":            formatNullableTimeRFC3339(lastBreastfeedTime),
@@ -722,6 +1196,13 @@ func (a *App) quickLandingSnapshot(c *gin.Context) {
 		"medication_count":                medicationCount,
 		"last_medication_time":            formatNullableTimeRFC3339(lastMedicationTime),
 		"last_medication_name":            lastMedicationName,
+		"symptom_count":                   symptomCount,
+		"last_symptom_time":               formatNullableTimeRFC3339(lastSymptomTime),
+		"last_symptom_name":               lastSymptomName,
+		"last_symptom_severity":           lastSymptomSeverity,
+		"latest_growth_weight_kg":         latestGrowthWeightKg,
+		"latest_growth_height_cm":         latestGrowthHeightCm,
+		"latest_growth_measured_at":       formatNullableTimeRFC3339(latestGrowthMeasuredAt),
 		"special_memo":                    specialMemo,
 		"feeding_method":                  profile.FeedingMethod,
 		"formula_type":                    profile.FormulaType,
@@ -730,6 +1211,8 @@ func (a *App) quickLandingSnapshot(c *gin.Context) {
 		"formula_contains_starch":         profile.FormulaContainsStarch,
 		"formula_display_name":            formulaDisplayName(profile),
 		"baby_age_days":                   profile.AgeDays,
+		"baby_corrected_age_days":         profile.CorrectedAgeDays,
+		"baby_gestational_weeks":          profile.GestationalWeeks,
 		"baby_weight_kg":                  profile.WeightKg,
 		"recommended_formula_daily_ml":    recommendation.RecommendedFormulaDailyML,
 		"recommended_formula_per_feed_ml": recommendation.RecommendedFormulaPerFeedML,
@@ -741,10 +1224,10 @@ func (a *App) quickLandingSnapshot(c *gin.Context) {
 		"feeding_graph_mode":              graphMode,
 		"feeding_graph_labels":            graphLabels,
 		"feeding_graph_points":            graphPoints,
-		"ai_credit_balance":               balance,
-		"ai_grace_used_today":             graceUsed,
-		"ai_grace_limit":                  graceLimitPerDay,
-		"ai_plan":                         plan,
+		"ai_credit_balance":               credits.Balance,
+		"ai_grace_used_today":             credits.GraceUsed,
+		"ai_grace_limit":                  credits.GraceLimit,
+		"ai_plan":                         credits.Plan,
 		"open_formula_event_id":           openFormulaEventID,
 		"open_formula_start_time":         formatNullableTimeRFC3339(openFormulaStartTime),
 		"open_formula_value":              openFormulaValue,
@@ -771,10 +1254,10 @@ func (a *App) quickLandingSnapshot(c *gin.Context) {
 		"open_medication_value":           openMedicationValue,
 		"open_medication_memo":            openMedicationMemo,
 		"reference_text":                  "Derived from selected range confirmed events.",
-	})
+	}, nil
 }
 
-func quickRangeWindow(localNow time.Time, rangeKey string) (time.Time, time.Time, int, string, error) {
+func quickRangeWindow(localNow time.Time, rangeKey string, weekStartsOn time.Weekday) (time.Time, time.Time, int, string, error) {
 	location := localNow.Location()
 	year, month, day := localNow.Date()
 	dayStart := time.Date(year, month, day, 0, 0, 0, 0, location)
@@ -783,11 +1266,7 @@ func quickRangeWindow(localNow time.Time, rangeKey string) (time.Time, time.Time
 	case "day":
 		return dayStart, dayStart.Add(24 * time.Hour), 1, dayStart.Format("2006-01-02"), nil
 	case "week":
-		weekdayOffset := int(dayStart.Weekday() - time.Monday)
-		if weekdayOffset < 0 {
-			weekdayOffset = 6
-		}
-		weekStart := dayStart.AddDate(0, 0, -weekdayOffset)
+		weekStart := startOfLocalWeek(dayStart, weekStartsOn)
 		weekEnd := weekStart.AddDate(0, 0, 7)
 		label := fmt.Sprintf("%d/%d - %d/%d", weekStart.Month(), weekStart.Day(), weekStart.AddDate(0, 0, 6).Month(), weekStart.AddDate(0, 0, 6).Day())
 		return weekStart, weekEnd, 7, label, nil
@@ -805,6 +1284,55 @@ func quickRangeWindow(localNow time.Time, rangeKey string) (time.Time, time.Time
 	}
 }
 
+// startOfLocalWeek returns local midnight of the first day of the week that
+// contains value, where weeks begin on weekStartsOn.
+func startOfLocalWeek(value time.Time, weekStartsOn time.Weekday) time.Time {
+	year, month, day := value.Date()
+	dayStart := time.Date(year, month, day, 0, 0, 0, 0, value.Location())
+	offset := (int(dayStart.Weekday()) - int(weekStartsOn) + 7) % 7
+	return dayStart.AddDate(0, 0, -offset)
+}
+
+func parseWeekStartsOn(raw string) (time.Weekday, bool) {
+	normalized, valid := normalizeWeekStartsOn(raw)
+	if !valid {
+		return time.Monday, false
+	}
+	if normalized == "sunday" {
+		return time.Sunday, true
+	}
+	return time.Monday, true
+}
+
+func weekStartsOnLabel(weekStartsOn time.Weekday) string {
+	if weekStartsOn == time.Sunday {
+		return "sunday"
+	}
+	return "monday"
+}
+
+// resolveWeekStartsOn prefers an explicit week_starts_on query value and
+// falls back to the user's saved setting (Monday when unset).
+func (a *App) resolveWeekStartsOn(ctx context.Context, userID, raw string) (time.Weekday, int, error) {
+	if strings.TrimSpace(raw) != "" {
+		weekStartsOn, valid := parseWeekStartsOn(raw)
+		if !valid {
+			return time.Monday, http.StatusBadRequest, errors.New("week_starts_on must be one of: monday, sunday")
+		}
+		return weekStartsOn, http.StatusOK, nil
+	}
+
+	persona, err := loadPersonaSettings(ctx, a.db, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return time.Monday, http.StatusOK, nil
+		}
+		return time.Monday, http.StatusInternalServerError, errors.New("Failed to load settings")
+	}
+	weekStartsOn, _ := parseWeekStartsOn(resolveWeekStartsOn(persona))
+	return weekStartsOn, http.StatusOK, nil
+}
+
 func parseTZOffset(raw string) (*time.Location, string, error) {
 	trimmed := strings.TrimSpace(raw)
 	if trimmed == "" {
@@ -1004,6 +1532,7 @@ func (a *App) loadRecentEventCounts(ctx *gin.Context, babyID string, since time.
 		`SELECT type::text, COUNT(*)::int
 		 FROM "Event"
 		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
 		   AND "startTime" >= $2
 		   AND type::text = ANY($3::text[])
 		 GROUP BY type`,
@@ -1055,6 +1584,14 @@ func (a *App) buildMedicalGuidance(c *gin.Context, babyID string) (string, strin
 	return answer, referenceText, nil
 }
 
+// reportPeriodKey is the "periodStart" a stored Report is filed under: the
+// local calendar date at UTC midnight. Keying on the date rather than the
+// converted instant lets callers in any timezone find the same row, and it
+// matches what the UTC report job writes.
+func reportPeriodKey(localStart time.Time) time.Time {
+	return time.Date(localStart.Year(), localStart.Month(), localStart.Day(), 0, 0, 0, 0, time.UTC)
+}
+
 func (a *App) getDailyReport(c *gin.Context) {
 	user, ok := authUserFromContext(c)
 	if !ok {
@@ -1068,7 +1605,7 @@ func (a *App) getDailyReport(c *gin.Context) {
 		writeError(c, http.StatusBadRequest, "date must be YYYY-MM-DD")
 		return
 	}
-	localZone, _, err := parseTZOffset(c.Query("tz_offset"))
+	localZone, tzNormalized, err := parseTZOffset(c.Query("tz_offset"))
 	if err != nil {
 		writeError(c, http.StatusBadRequest, err.Error())
 		return
@@ -1088,7 +1625,7 @@ func (a *App) getDailyReport(c *gin.Context) {
 		 WHERE "babyId" = $1 AND "periodType" = 'DAILY' AND "periodStart" = $2
 		 ORDER BY "createdAt" DESC LIMIT 1`,
 		baby.ID,
-		targetDate,
+		reportPeriodKey(targetDate),
 	).Scan(&summaryText)
 	if err == nil {
 		summary = splitNonEmptyLines(summaryText)
@@ -1115,6 +1652,7 @@ func (a *App) getDailyReport(c *gin.Context) {
 		`SELECT id, type, "startTime", "endTime", "valueJson"
 		 FROM "Event"
 		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
 		   AND "startTime" >= $2
 		   AND "startTime" < $3
 		   AND NOT (
@@ -1125,10 +1663,12 @@ func (a *App) getDailyReport(c *gin.Context) {
 		     )
 		   )
 		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
+		   AND `+eventVisibleToUserSQL("$4")+`
 		 ORDER BY "startTime" ASC`,
 		baby.ID,
 		start,
 		end,
+		user.ID,
 	)
 	if err != nil {
 		writeError(c, http.StatusInternalServerError, "Failed to load events")
@@ -1139,6 +1679,7 @@ func (a *App) getDailyReport(c *gin.Context) {
 	counts := map[string]int{}
 	formulaTotal := 0.0
 	sleepMinutes := 0
+	feedings := feedingSplit{}
 	events := make([]gin.H, 0, 16)
 	for rows.Next() {
 		var eventID string
@@ -1164,6 +1705,7 @@ func (a *App) getDailyReport(c *gin.Context) {
 			eventItem["end_time"] = nil
 		}
 		events = append(events, eventItem)
+		feedings.add(eventType, valueMap, startedAt, endedAt)
 		if eventType == "FORMULA" {
 			formulaTotal += extractNumberFromMap(valueMap, "ml", "amount_ml", "volume_ml")
 		}
@@ -1186,14 +1728,17 @@ func (a *App) getDailyReport(c *gin.Context) {
 			"Sleep total: " + strconv.Itoa(sleepMinutes) + " minutes",
 			"Diaper events: pee " + strconv.Itoa(counts["PEE"]) + ", poo " + strconv.Itoa(counts["POO"]),
 		}
+		summary = append(summary, feedings.summaryLines()...)
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"baby_id": baby.ID,
-		"date":    targetDate.Format("2006-01-02"),
-		"summary": summary,
-		"events":  events,
-		"labels":  []string{"record_based"},
+		"baby_id":       baby.ID,
+		"date":          targetDate.Format("2006-01-02"),
+		"tz_offset":     tzNormalized,
+		"summary":       summary,
+		"feeding_split": feedings,
+		"events":        events,
+		"labels":        []string{"record_based"},
 	})
 }
 
@@ -1210,12 +1755,17 @@ func (a *App) getWeeklyReport(c *gin.Context) {
 		writeError(c, http.StatusBadRequest, "week_start must be YYYY-MM-DD")
 		return
 	}
-	localZone, _, err := parseTZOffset(c.Query("tz_offset"))
+	localZone, tzNormalized, err := parseTZOffset(c.Query("tz_offset"))
 	if err != nil {
 		writeError(c, http.StatusBadRequest, err.Error())
 		return
 	}
-	localStart := time.Date(
+	weekStartsOn, statusCode, err := a.resolveWeekStartsOn(c.Request.Context(), user.ID, c.Query("week_starts_on"))
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+	localStart := startOfLocalWeek(time.Date(
 		start.Year(),
 		start.Month(),
 		start.Day(),
@@ -1224,7 +1774,7 @@ func (a *App) getWeeklyReport(c *gin.Context) {
 		0,
 		0,
 		localZone,
-	)
+	), weekStartsOn)
 	startUTC := localStart.UTC()
 	endUTC := localStart.Add(7 * 24 * time.Hour).UTC()
 
@@ -1241,7 +1791,7 @@ func (a *App) getWeeklyReport(c *gin.Context) {
 		 WHERE "babyId" = $1 AND "periodType" = 'WEEKLY' AND "periodStart" = $2
 		 ORDER BY "createdAt" DESC LIMIT 1`,
 		baby.ID,
-		startUTC,
+		reportPeriodKey(localStart),
 	).Scan(&metricsRaw)
 	if err == nil {
 		metrics := parseJSONStringMap(metricsRaw)
@@ -1252,11 +1802,14 @@ func (a *App) getWeeklyReport(c *gin.Context) {
 			suggestions = append(suggestions, strings.TrimSpace(toString(item)))
 		}
 		c.JSON(http.StatusOK, gin.H{
-			"baby_id":     baby.ID,
-			"week_start":  localStart.Format("2006-01-02"),
-			"trend":       trend,
-			"suggestions": suggestions,
-			"labels":      []string{"record_based"},
+			"baby_id":        baby.ID,
+			"week_start":     localStart.Format("2006-01-02"),
+			"week_starts_on": weekStartsOnLabel(weekStartsOn),
+			"tz_offset":      tzNormalized,
+			"trend":          trend,
+			"feeding_split":  metrics["feeding_split"],
+			"suggestions":    suggestions,
+			"labels":         []string{"record_based"},
 		})
 		return
 	}
@@ -1265,39 +1818,54 @@ func (a *App) getWeeklyReport(c *gin.Context) {
 		return
 	}
 
-	currentMetrics, err := a.computeWeeklyMetrics(c, baby.ID, startUTC, endUTC)
+	currentMetrics, err := a.computeWeeklyMetrics(c.Request.Context(), baby.ID, startUTC, endUTC)
 	if err != nil {
 		writeError(c, http.StatusInternalServerError, "Failed to compute weekly metrics")
 		return
 	}
 	previousStart := localStart.Add(-7 * 24 * time.Hour).UTC()
-	previousMetrics, err := a.computeWeeklyMetrics(c, baby.ID, previousStart, startUTC)
+	previousMetrics, err := a.computeWeeklyMetrics(c.Request.Context(), baby.ID, previousStart, startUTC)
 	if err != nil {
 		writeError(c, http.StatusInternalServerError, "Failed to compute weekly metrics")
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"baby_id":    baby.ID,
-		"week_start": localStart.Format("2006-01-02"),
-		"trend": gin.H{
-			"feeding_total_ml": trendString(currentMetrics.FeedingML, previousMetrics.FeedingML),
-			"sleep_total_min":  trendString(float64(currentMetrics.SleepMinutes), float64(previousMetrics.SleepMinutes)),
-		},
-		"suggestions": []string{
-			"Keep logging feeding and sleep consistently to improve ETA quality.",
-			"If diaper events spike, review feeding intervals and hydration patterns.",
-		},
-		"labels": []string{"record_based"},
+		"baby_id":        baby.ID,
+		"week_start":     localStart.Format("2006-01-02"),
+		"week_starts_on": weekStartsOnLabel(weekStartsOn),
+		"tz_offset":      tzNormalized,
+		"trend":          weeklyTrend(currentMetrics, previousMetrics),
+		"feeding_split":  currentMetrics.Feedings,
+		"suggestions":    weeklyReportSuggestions(),
+		"labels":         []string{"record_based"},
 	})
 }
 
-func (a *App) computeWeeklyMetrics(c *gin.Context, babyID string, start, end time.Time) (weeklyMetrics, error) {
+func weeklyTrend(current, previous weeklyMetrics) map[string]any {
+	return map[string]any{
+		"feeding_total_ml":     trendString(current.FeedingML, previous.FeedingML),
+		"sleep_total_min":      trendString(float64(current.SleepMinutes), float64(previous.SleepMinutes)),
+		"formula_count":        trendString(float64(current.Feedings.FormulaCount), float64(previous.Feedings.FormulaCount)),
+		"breastfeed_count":     trendString(float64(current.Feedings.BreastfeedCount), float64(previous.Feedings.BreastfeedCount)),
+		"breastfeed_total_min": trendString(float64(current.Feedings.BreastfeedMinutes), float64(previous.Feedings.BreastfeedMinutes)),
+	}
+}
+
+func weeklyReportSuggestions() []string {
+	return []string{
+		"Keep logging feeding and sleep consistently to improve ETA quality.",
+		"If diaper events spike, review feeding intervals and hydration patterns.",
+	}
+}
+
+func (a *App) computeWeeklyMetrics(ctx context.Context, babyID string, start, end time.Time) (weeklyMetrics, error) {
 	rows, err := a.db.Query(
-		c.Request.Context(),
+		ctx,
 		`SELECT type, "startTime", "endTime", "valueJson"
 		 FROM "Event"
 		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
 		   AND "startTime" >= $2
 		   AND "startTime" < $3
 		   AND NOT (
@@ -1327,6 +1895,7 @@ func (a *App) computeWeeklyMetrics(c *gin.Context, babyID string, start, end tim
 			return weeklyMetrics{}, err
 		}
 		valueMap := parseJSONStringMap(valueRaw)
+		metrics.Feedings.add(eventType, valueMap, startedAt, endedAt)
 		if eventType == "FORMULA" {
 			metrics.FeedingML += extractNumberFromMap(valueMap, "ml", "amount_ml", "volume_ml")
 		}
@@ -1339,3 +1908,228 @@ func (a *App) computeWeeklyMetrics(c *gin.Context, babyID string, start, end tim
 	}
 	return metrics, nil
 }
+
+func (a *App) getMonthlyReport(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	babyID := c.Query("baby_id")
+	month, err := time.Parse("2006-01", strings.TrimSpace(c.Query("month")))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, "month must be YYYY-MM")
+		return
+	}
+	localZone, _, err := parseTZOffset(c.Query("tz_offset"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	localStart := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, localZone)
+	startUTC := localStart.UTC()
+	endUTC := localStart.AddDate(0, 1, 0).UTC()
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, babyID, readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	// Compare as text so databases created before MONTHLY joined the enum
+	// fall through to the computed report instead of failing the cast.
+	var metricsRaw []byte
+	err = a.db.QueryRow(
+		c.Request.Context(),
+		`SELECT "metricsJson" FROM "Report"
+		 WHERE "babyId" = $1 AND "periodType"::text = 'MONTHLY' AND "periodStart" = $2
+		 ORDER BY "createdAt" DESC LIMIT 1`,
+		baby.ID,
+		reportPeriodKey(localStart),
+	).Scan(&metricsRaw)
+	if err == nil {
+		metrics := parseJSONStringMap(metricsRaw)
+		totals, _ := metrics["metrics"].(map[string]any)
+		trend, _ := metrics["trend"].(map[string]any)
+		weeklyTrend, _ := metrics["weekly_trend"].([]any)
+		if weeklyTrend == nil {
+			weeklyTrend = []any{}
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"baby_id":       baby.ID,
+			"month":         localStart.Format("2006-01"),
+			"days_in_month": daysInMonth(localStart),
+			"metrics":       totals,
+			"trend":         trend,
+			"weekly_trend":  weeklyTrend,
+			"labels":        []string{"record_based"},
+		})
+		return
+	}
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		writeError(c, http.StatusInternalServerError, "Failed to load reports")
+		return
+	}
+
+	currentMetrics, err := a.computeMonthlyMetrics(c.Request.Context(), baby.ID, startUTC, endUTC)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to compute monthly metrics")
+		return
+	}
+	previousMetrics, err := a.computeMonthlyMetrics(c.Request.Context(), baby.ID, localStart.AddDate(0, -1, 0).UTC(), startUTC)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to compute monthly metrics")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"baby_id":       baby.ID,
+		"month":         localStart.Format("2006-01"),
+		"days_in_month": currentMetrics.Days,
+		"metrics":       monthlyMetricsMap(currentMetrics),
+		"trend":         monthlyTrend(currentMetrics, previousMetrics),
+		"weekly_trend":  monthlyWeeklyTrend(localStart, currentMetrics, previousMetrics),
+		"labels":        []string{"record_based"},
+	})
+}
+
+func daysInMonth(monthStart time.Time) int {
+	return time.Date(monthStart.Year(), monthStart.Month()+1, 0, 0, 0, 0, 0, monthStart.Location()).Day()
+}
+
+// perDay divides a monthly or weekly total by its day count so months of
+// different lengths can be compared.
+func perDay(total float64, days int) float64 {
+	if days <= 0 {
+		return 0
+	}
+	return total / float64(days)
+}
+
+func monthlyMetricsMap(metrics monthlyMetrics) map[string]any {
+	return map[string]any{
+		"feeding_count":        metrics.FeedingCount,
+		"feeding_total_ml":     roundToOneDecimal(metrics.FeedingML),
+		"avg_daily_formula_ml": roundToOneDecimal(perDay(metrics.FeedingML, metrics.Days)),
+		"sleep_total_min":      metrics.SleepMinutes,
+		"pee_count":            metrics.PeeCount,
+		"poo_count":            metrics.PooCount,
+		"diaper_count":         metrics.PeeCount + metrics.PooCount,
+	}
+}
+
+// monthlyTrend compares daily averages rather than raw totals, so a 31-day
+// month is not reported as growth over a 28-day one.
+func monthlyTrend(current, previous monthlyMetrics) map[string]any {
+	return map[string]any{
+		"feeding_total_ml": trendString(
+			perDay(current.FeedingML, current.Days),
+			perDay(previous.FeedingML, previous.Days),
+		),
+		"sleep_total_min": trendString(
+			perDay(float64(current.SleepMinutes), current.Days),
+			perDay(float64(previous.SleepMinutes), previous.Days),
+		),
+		"diaper_count": trendString(
+			perDay(float64(current.PeeCount+current.PooCount), current.Days),
+			perDay(float64(previous.PeeCount+previous.PooCount), previous.Days),
+		),
+	}
+}
+
+// monthlyWeeklyTrend compares each week's daily average against the prior
+// month's daily average. The trailing partial week uses its own day count.
+func monthlyWeeklyTrend(monthStart time.Time, current, previous monthlyMetrics) []map[string]any {
+	result := make([]map[string]any, 0, len(current.Weeks))
+	for index, week := range current.Weeks {
+		days := current.Days - index*7
+		if days > 7 {
+			days = 7
+		}
+		result = append(result, map[string]any{
+			"week":       index + 1,
+			"week_start": monthStart.AddDate(0, 0, index*7).Format("2006-01-02"),
+			"days":       days,
+			"feeding_total_ml": trendString(
+				perDay(week.FeedingML, days),
+				perDay(previous.FeedingML, previous.Days),
+			),
+			"sleep_total_min": trendString(
+				perDay(float64(week.SleepMinutes), days),
+				perDay(float64(previous.SleepMinutes), previous.Days),
+			),
+		})
+	}
+	return result
+}
+
+func (a *App) computeMonthlyMetrics(ctx context.Context, babyID string, start, end time.Time) (monthlyMetrics, error) {
+	days := int(end.Sub(start).Hours()/24 + 0.5)
+	metrics := monthlyMetrics{
+		Days:  days,
+		Weeks: make([]weeklyMetrics, (days+6)/7),
+	}
+
+	rows, err := a.db.Query(
+		ctx,
+		`SELECT type, "startTime", "endTime", "valueJson"
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND "startTime" >= $2
+		   AND "startTime" < $3
+		   AND NOT (
+		     "endTime" IS NULL
+		     AND (
+		       COALESCE("metadataJson"->>'event_state', '') = 'OPEN'
+		       OR COALESCE("metadataJson"->>'entry_mode', '') = 'manual_start'
+		     )
+		   )
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'`,
+		babyID,
+		start,
+		end,
+	)
+	if err != nil {
+		return monthlyMetrics{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var eventType string
+		var startedAt time.Time
+		var endedAt *time.Time
+		var valueRaw []byte
+		if err := rows.Scan(&eventType, &startedAt, &endedAt, &valueRaw); err != nil {
+			return monthlyMetrics{}, err
+		}
+		weekIndex := int(startedAt.UTC().Sub(start) / (7 * 24 * time.Hour))
+		if weekIndex < 0 || weekIndex >= len(metrics.Weeks) {
+			continue
+		}
+		week := &metrics.Weeks[weekIndex]
+		switch eventType {
+		case "FORMULA":
+			ml := extractNumberFromMap(parseJSONStringMap(valueRaw), "ml", "amount_ml", "volume_ml")
+			metrics.FeedingCount++
+			metrics.FeedingML += ml
+			week.FeedingML += ml
+		case "BREASTFEED":
+			metrics.FeedingCount++
+		case "SLEEP":
+			if endedAt == nil {
+				continue
+			}
+			duration := int(endedAt.UTC().Sub(startedAt.UTC()).Minutes())
+			if duration > 0 {
+				metrics.SleepMinutes += duration
+				week.SleepMinutes += duration
+			}
+		case "PEE":
+			metrics.PeeCount++
+		case "POO":
+			metrics.PooCount++
+		}
+	}
+	return metrics, rows.Err()
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	recentByTypeDefaultPerType = 3
+	recentByTypeMaxPerType     = 20
+)
+
+// getRecentByType returns the latest closed events for each requested type in
+// one round trip, for quick-edit lists. Open and canceled events are skipped.
+func (a *App) getRecentByType(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	types := make([]string, 0, 4)
+	seen := map[string]struct{}{}
+	for _, raw := range strings.Split(c.Query("types"), ",") {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		eventType, valid := normalizeEventType(raw)
+		if !valid {
+			writeError(c, http.StatusBadRequest, "types contains an invalid type: "+strings.TrimSpace(raw))
+			return
+		}
+		if _, dup := seen[eventType]; dup {
+			continue
+		}
+		seen[eventType] = struct{}{}
+		types = append(types, eventType)
+	}
+	if len(types) == 0 {
+		writeError(c, http.StatusBadRequest, "types is required")
+		return
+	}
+
+	perType := recentByTypeDefaultPerType
+	if raw := strings.TrimSpace(c.Query("per_type")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 || parsed > recentByTypeMaxPerType {
+			writeError(c, http.StatusBadRequest, "per_type must be between 1 and "+strconv.Itoa(recentByTypeMaxPerType))
+			return
+		}
+		perType = parsed
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, c.Param("baby_id"), readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT id, type, "startTime", "endTime", "valueJson", "metadataJson"
+		 FROM (
+		   SELECT id, type::text AS type, "startTime", "endTime", "valueJson", "metadataJson",
+		          ROW_NUMBER() OVER (PARTITION BY type ORDER BY "startTime" DESC, id DESC) AS rn
+		   FROM "Event"
+		   WHERE "babyId" = $1
+		     AND "deletedAt" IS NULL
+		     AND type::text = ANY($2)
+		     AND NOT (`+openEventPredicateSQL+`)
+		     AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
+		     AND `+eventVisibleToUserSQL("$4")+`
+		 ) ranked
+		 WHERE rn <= $3
+		 ORDER BY type ASC, "startTime" DESC, id DESC`,
+		baby.ID,
+		types,
+		perType,
+		user.ID,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load recent events")
+		return
+	}
+	defer rows.Close()
+
+	eventsByType := make(map[string][]gin.H, len(types))
+	for _, eventType := range types {
+		eventsByType[eventType] = []gin.H{}
+	}
+	for rows.Next() {
+		var eventID, eventType string
+		var startTime time.Time
+		var endTime *time.Time
+		var valueRaw, metadataRaw []byte
+		if err := rows.Scan(&eventID, &eventType, &startTime, &endTime, &valueRaw, &metadataRaw); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to parse recent events")
+			return
+		}
+		eventsByType[eventType] = append(eventsByType[eventType], gin.H{
+			"event_id":   eventID,
+			"type":       eventType,
+			"start_time": startTime.UTC().Format(time.RFC3339),
+			"end_time":   formatNullableTimeRFC3339(endTime),
+			"value":      parseJSONStringMap(valueRaw),
+			"metadata":   parseJSONStringMap(metadataRaw),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse recent events")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"baby_id":        baby.ID,
+		"types":          types,
+		"per_type":       perType,
+		"events_by_type": eventsByType,
+	})
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxFormulaDailyGoalML = 3000
+
+type remainingFormulaPlan struct {
+	RemainingML             int
+	SuggestedRemainingFeeds *int
+}
+
+// planRemainingFormula clamps the remaining amount at 0 and spreads it over
+// per-feed sized bottles. Without a per-feed amount only the ml is returned.
+func planRemainingFormula(targetML int, consumedML int, perFeedML *int) remainingFormulaPlan {
+	remaining := targetML - consumedML
+	if remaining < 0 {
+		remaining = 0
+	}
+	plan := remainingFormulaPlan{RemainingML: remaining}
+	if perFeedML != nil && *perFeedML > 0 {
+		feeds := int(math.Ceil(float64(remaining) / float64(*perFeedML)))
+		plan.SuggestedRemainingFeeds = &feeds
+	}
+	return plan
+}
+
+func (a *App) getRemainingFormula(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	localZone, tzNormalized, err := parseTZOffset(c.Query("tz_offset"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	babyID := strings.TrimSpace(c.Param("baby_id"))
+	if babyID == "" {
+		writeError(c, http.StatusBadRequest, "baby_id is required")
+		return
+	}
+
+	profile, statusCode, err := a.resolveBabyProfile(c.Request.Context(), user.ID, babyID, readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+	lastFeeding, err := a.latestFeedingTime(c.Request.Context(), profile.BabyID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load latest feeding event")
+		return
+	}
+	nowUTC := time.Now().UTC()
+	recommendation := calculateFeedingRecommendation(profile, lastFeeding, nowUTC)
+
+	localNow := nowUTC.In(localZone)
+	dayStart := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, localZone)
+	// Same day window and filters as the landing snapshot's formula progress.
+	events, err := a.loadLandingSnapshotClosedEvents(c.Request.Context(), []string{profile.BabyID}, user.ID, dayStart.UTC(), dayStart.AddDate(0, 0, 1).UTC())
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load formula events")
+		return
+	}
+	consumedML := 0
+	feedCount := 0
+	for _, event := range events[profile.BabyID] {
+		if event.Type == "FORMULA" {
+			consumedML += landingFormulaAmountML(event.Value)
+			feedCount++
+		}
+	}
+
+	targetSource := "recommendation"
+	targetML := recommendation.RecommendedFormulaDailyML
+	if profile.FormulaDailyGoalML != nil {
+		targetSource = "goal"
+		targetML = profile.FormulaDailyGoalML
+	}
+
+	response := gin.H{
+		"baby_id":                         profile.BabyID,
+		"date":                            dayStart.Format("2006-01-02"),
+		"tz_offset":                       tzNormalized,
+		"feeding_method":                  profile.FeedingMethod,
+		"target_source":                   targetSource,
+		"daily_target_ml":                 targetML,
+		"recommended_formula_daily_ml":    recommendation.RecommendedFormulaDailyML,
+		"recommended_formula_per_feed_ml": recommendation.RecommendedFormulaPerFeedML,
+		"consumed_ml":                     consumedML,
+		"formula_feed_count":              feedCount,
+		"remaining_ml":                    nil,
+		"suggested_remaining_feeds":       nil,
+	}
+	if targetML == nil {
+		response["target_source"] = nil
+		response["note"] = "No formula target for breastmilk-only feeding; set formula_daily_goal_ml to track one."
+		c.JSON(http.StatusOK, response)
+		return
+	}
+	plan := planRemainingFormula(*targetML, consumedML, recommendation.RecommendedFormulaPerFeedML)
+	response["remaining_ml"] = plan.RemainingML
+	response["suggested_remaining_feeds"] = plan.SuggestedRemainingFeeds
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,279 @@
+package server
+
+import (
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	scheduleShiftRecentDays   = 3
+	scheduleShiftBaselineDays = 7
+	// Each window needs this many days with an anchor before a signal is
+	// compared at all.
+	scheduleShiftMinRecentDays   = 2
+	scheduleShiftMinBaselineDays = 3
+	// scheduleShiftMinMinutes is the smallest move in a signal that counts;
+	// ordinary day-to-day drift stays well under it.
+	scheduleShiftMinMinutes = 90
+)
+
+// scheduleShiftDay holds one local day's clock-time anchors in minutes since
+// local midnight: bedtime and wake of the longest sleep ending that day, and
+// the first feed after that wake.
+type scheduleShiftDay struct {
+	Date         string
+	BedtimeMin   *int
+	WakeMin      *int
+	FirstFeedMin *int
+}
+
+type scheduleShiftSignal struct {
+	Name        string `json:"name"`
+	BaselineMin *int   `json:"baseline_min"`
+	RecentMin   *int   `json:"recent_min"`
+	ShiftMin    *int   `json:"shift_min"`
+}
+
+type scheduleShiftResult struct {
+	Detected  bool
+	ShiftMin  int
+	Direction string
+	Signals   []scheduleShiftSignal
+}
+
+// circularMeanMinutes averages clock times on the 24h circle so 23:30 and
+// 00:30 average to midnight rather than noon.
+func circularMeanMinutes(values []int) (float64, bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+	sinSum, cosSum := 0.0, 0.0
+	for _, value := range values {
+		angle := float64(value) / 1440 * 2 * math.Pi
+		sinSum += math.Sin(angle)
+		cosSum += math.Cos(angle)
+	}
+	if math.Abs(sinSum) < 1e-9 && math.Abs(cosSum) < 1e-9 {
+		return 0, false
+	}
+	mean := math.Atan2(sinSum, cosSum) / (2 * math.Pi) * 1440
+	if mean < 0 {
+		mean += 1440
+	}
+	return mean, true
+}
+
+// clockDiffMinutes is the signed shortest move from one clock time to
+// another, in [-720, 720).
+func clockDiffMinutes(from, to float64) float64 {
+	diff := math.Mod(to-from+720, 1440)
+	if diff < 0 {
+		diff += 1440
+	}
+	return diff - 720
+}
+
+func scheduleShiftAnchors(days []scheduleShiftDay, pick func(scheduleShiftDay) *int) []int {
+	values := make([]int, 0, len(days))
+	for _, day := range days {
+		if value := pick(day); value != nil {
+			values = append(values, *value)
+		}
+	}
+	return values
+}
+
+// detectScheduleShift compares each anchor's recent average clock time with
+// the baseline. A shift is reported only when at least two signals moved by
+// scheduleShiftMinMinutes or more in the same direction; its size is their
+// average, rounded to 30 minutes.
+func detectScheduleShift(baseline, recent []scheduleShiftDay) scheduleShiftResult {
+	pickers := []struct {
+		name string
+		pick func(scheduleShiftDay) *int
+	}{
+		{"bedtime", func(day scheduleShiftDay) *int { return day.BedtimeMin }},
+		{"wake", func(day scheduleShiftDay) *int { return day.WakeMin }},
+		{"first_feed", func(day scheduleShiftDay) *int { return day.FirstFeedMin }},
+	}
+
+	result := scheduleShiftResult{Direction: "none", Signals: make([]scheduleShiftSignal, 0, len(pickers))}
+	later, earlier := []float64{}, []float64{}
+	for _, picker := range pickers {
+		signal := scheduleShiftSignal{Name: picker.name}
+		baselineValues := scheduleShiftAnchors(baseline, picker.pick)
+		recentValues := scheduleShiftAnchors(recent, picker.pick)
+		baselineMean, baselineOK := circularMeanMinutes(baselineValues)
+		recentMean, recentOK := circularMeanMinutes(recentValues)
+		if baselineOK && len(baselineValues) >= scheduleShiftMinBaselineDays {
+			rounded := int(math.Round(baselineMean)) % 1440
+			signal.BaselineMin = &rounded
+		}
+		if recentOK && len(recentValues) >= scheduleShiftMinRecentDays {
+			rounded := int(math.Round(recentMean)) % 1440
+			signal.RecentMin = &rounded
+		}
+		if signal.BaselineMin != nil && signal.RecentMin != nil {
+			diff := clockDiffMinutes(baselineMean, recentMean)
+			rounded := int(math.Round(diff))
+			signal.ShiftMin = &rounded
+			switch {
+			case diff >= scheduleShiftMinMinutes:
+				later = append(later, diff)
+			case diff <= -scheduleShiftMinMinutes:
+				earlier = append(earlier, diff)
+			}
+		}
+		result.Signals = append(result.Signals, signal)
+	}
+
+	if len(later) > 0 && len(earlier) > 0 {
+		return result
+	}
+	agreeing, direction := later, "later"
+	if len(earlier) > 0 {
+		agreeing, direction = earlier, "earlier"
+	}
+	if len(agreeing) < 2 {
+		return result
+	}
+	result.Detected = true
+	result.Direction = direction
+	result.ShiftMin = int(math.Round(averageFloat(agreeing)/30) * 30)
+	return result
+}
+
+func minutesSinceLocalMidnight(value time.Time) int {
+	return value.Hour()*60 + value.Minute()
+}
+
+func (a *App) getScheduleShift(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	localZone, tzNormalized, err := parseTZOffset(c.Query("tz_offset"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, c.Param("baby_id"), readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	localNow := time.Now().In(localZone)
+	today := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, localZone)
+	totalDays := scheduleShiftBaselineDays + scheduleShiftRecentDays
+	windowStart := today.AddDate(0, 0, -(totalDays - 1))
+	days := make([]scheduleShiftDay, totalDays)
+	dayIndex := make(map[string]int, totalDays)
+	for i := range days {
+		days[i].Date = windowStart.AddDate(0, 0, i).Format("2006-01-02")
+		dayIndex[days[i].Date] = i
+	}
+
+	// Sleeps are attributed to the day they end on, so a night that starts
+	// the evening before the window still counts; load one extra day.
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT type, "startTime", "endTime", "valueJson"
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND type IN ('SLEEP', 'FORMULA', 'BREASTFEED')
+		   AND "startTime" >= $2
+		   AND "startTime" < $3
+		   AND NOT (`+openEventPredicateSQL+`)
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
+		   AND `+eventVisibleToUserSQL("$4")+`
+		 ORDER BY "startTime" ASC, id ASC`,
+		baby.ID,
+		windowStart.AddDate(0, 0, -1).UTC(),
+		today.AddDate(0, 0, 1).UTC(),
+		user.ID,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load events")
+		return
+	}
+	defer rows.Close()
+
+	longestSleep := make([]time.Duration, totalDays)
+	wakeTimes := make([]*time.Time, totalDays)
+	feedTimes := make([][]time.Time, totalDays)
+	for rows.Next() {
+		var eventType string
+		var startTime time.Time
+		var endTime *time.Time
+		var valueRaw []byte
+		if err := rows.Scan(&eventType, &startTime, &endTime, &valueRaw); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to parse events")
+			return
+		}
+		startLocal := startTime.In(localZone)
+		if eventType != "SLEEP" {
+			if index, found := dayIndex[startLocal.Format("2006-01-02")]; found {
+				feedTimes[index] = append(feedTimes[index], startLocal)
+			}
+			continue
+		}
+		if endTime == nil || isZeroDurationSleep(eventType, parseJSONStringMap(valueRaw), startTime, endTime) {
+			continue
+		}
+		endLocal := endTime.In(localZone)
+		index, found := dayIndex[endLocal.Format("2006-01-02")]
+		if !found {
+			continue
+		}
+		if duration := endTime.Sub(startTime); duration > longestSleep[index] {
+			longestSleep[index] = duration
+			bedtime := minutesSinceLocalMidnight(startLocal)
+			wake := minutesSinceLocalMidnight(endLocal)
+			days[index].BedtimeMin = &bedtime
+			days[index].WakeMin = &wake
+			wakeTimes[index] = &endLocal
+		}
+	}
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse events")
+		return
+	}
+	for i := range days {
+		if wakeTimes[i] == nil {
+			continue
+		}
+		for _, feedTime := range feedTimes[i] {
+			if !feedTime.Before(*wakeTimes[i]) {
+				firstFeed := minutesSinceLocalMidnight(feedTime)
+				days[i].FirstFeedMin = &firstFeed
+				break
+			}
+		}
+	}
+
+	result := detectScheduleShift(days[:scheduleShiftBaselineDays], days[scheduleShiftBaselineDays:])
+	var shiftHours *float64
+	if result.Detected {
+		hours := float64(result.ShiftMin) / 60
+		shiftHours = &hours
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"baby_id":        baby.ID,
+		"tz_offset":      tzNormalized,
+		"baseline_from":  days[0].Date,
+		"recent_from":    days[scheduleShiftBaselineDays].Date,
+		"to":             days[totalDays-1].Date,
+		"shift_detected": result.Detected,
+		"shift_min":      result.ShiftMin,
+		"shift_hours":    shiftHours,
+		"direction":      result.Direction,
+		"signals":        result.Signals,
+	})
+}
@@ -195,6 +195,24 @@ func (a *App) upsertMySettings(c *gin.Context) {
 		appSettings["show_special_memo"] = *payload.ShowSpecialMemo
 	}
 
+	if payload.WeekStartsOn != nil {
+		weekStartsOn, valid := normalizeWeekStartsOn(*payload.WeekStartsOn)
+		if !valid {
+			writeError(c, http.StatusBadRequest, "week_starts_on must be one of: monday, sunday")
+			return
+		}
+		appSettings["week_starts_on"] = weekStartsOn
+	}
+
+	if payload.SmalltalkMaxLen != nil {
+		maxChars := *payload.SmalltalkMaxLen
+		if maxChars < smalltalkReplyRuneMinSetting || maxChars > smalltalkReplyRuneMaxSetting {
+			writeError(c, http.StatusBadRequest, "smalltalk_reply_max_chars must be between 40 and 400")
+			return
+		}
+		appSettings["smalltalk_reply_max_chars"] = maxChars
+	}
+
 	persona["app_settings"] = appSettings
 
 	if _, err := a.db.Exec(
@@ -229,18 +247,20 @@ func loadPersonaSettings(ctx context.Context, q dbQuerier, userID string) (map[s
 
 func buildSettingsResponse(persona map[string]any) gin.H {
 	return gin.H{
-		"theme_mode":          resolveThemeMode(persona),
-		"language":            resolveLanguage(persona),
-		"main_font":           resolveMainFont(persona),
-		"highlight_font":      resolveHighlightFont(persona),
-		"accent_tone":         resolveAccentTone(persona),
-		"report_color_tone":   resolveReportColorTone(persona),
-		"bottom_menu_enabled": resolveBottomMenuEnabled(persona),
-		"child_care_profile":  resolveChildCareProfile(persona),
-		"home_tiles":          resolveHomeTiles(persona),
-		"home_tile_columns":   resolveHomeTileColumns(persona),
-		"home_tile_order":     resolveHomeTileOrder(persona),
-		"show_special_memo":   resolveShowSpecialMemo(persona),
+		"theme_mode":                resolveThemeMode(persona),
+		"language":                  resolveLanguage(persona),
+		"main_font":                 resolveMainFont(persona),
+		"highlight_font":            resolveHighlightFont(persona),
+		"accent_tone":               resolveAccentTone(persona),
+		"report_color_tone":         resolveReportColorTone(persona),
+		"bottom_menu_enabled":       resolveBottomMenuEnabled(persona),
+		"child_care_profile":        resolveChildCareProfile(persona),
+		"home_tiles":                resolveHomeTiles(persona),
+		"home_tile_columns":         resolveHomeTileColumns(persona),
+		"home_tile_order":           resolveHomeTileOrder(persona),
+		"show_special_memo":         resolveShowSpecialMemo(persona),
+		"week_starts_on":            resolveWeekStartsOn(persona),
+		"smalltalk_reply_max_chars": resolveSmalltalkReplyMaxChars(persona),
 	}
 }
 
@@ -424,6 +444,27 @@ func resolveShowSpecialMemo(persona map[string]any) bool {
 	return true
 }
 
+func resolveWeekStartsOn(persona map[string]any) string {
+	if appSettings, ok := persona["app_settings"].(map[string]any); ok {
+		if value, valid := normalizeWeekStartsOn(toString(appSettings["week_starts_on"])); valid {
+			return value
+		}
+	}
+	return "monday"
+}
+
+// resolveSmalltalkReplyMaxChars is the rune cap for smalltalk chat replies.
+func resolveSmalltalkReplyMaxChars(persona map[string]any) int {
+	if appSettings, ok := persona["app_settings"].(map[string]any); ok {
+		if raw, ok := toInt(appSettings["smalltalk_reply_max_chars"]); ok {
+			if raw >= smalltalkReplyRuneMinSetting && raw <= smalltalkReplyRuneMaxSetting {
+				return raw
+			}
+		}
+	}
+	return smalltalkReplyRuneMax
+}
+
 func copyBoolMap(input map[string]bool) map[string]bool {
 	result := make(map[string]bool, len(input))
 	for key, value := range input {
@@ -547,3 +588,13 @@ func normalizeReportColorTone(input string) (string, bool) {
 		return "", false
 	}
 }
+
+func normalizeWeekStartsOn(input string) (string, bool) {
+	value := strings.ToLower(strings.TrimSpace(input))
+	switch value {
+	case "monday", "sunday":
+		return value, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const statsForDatesMaxDates = 31
+
+type statsForDatesRequest struct {
+	Dates    []string `json:"dates"`
+	TZOffset string   `json:"tz_offset"`
+}
+
+type dateStats struct {
+	Date            string  `json:"date"`
+	FeedingsCount   int     `json:"feedings_count"`
+	FormulaCount    int     `json:"formula_count"`
+	BreastfeedCount int     `json:"breastfeed_count"`
+	FormulaTotalML  float64 `json:"formula_total_ml"`
+	SleepTotalMin   int     `json:"sleep_total_min"`
+	PeeCount        int     `json:"pee_count"`
+	PooCount        int     `json:"poo_count"`
+	EventCount      int     `json:"event_count"`
+}
+
+// normalizeStatsDates parses, dedupes and sorts the requested dates. The cap
+// applies after dedupe so repeated days do not count against it.
+func normalizeStatsDates(raw []string) ([]time.Time, error) {
+	seen := make(map[string]struct{}, len(raw))
+	dates := make([]time.Time, 0, len(raw))
+	for i, value := range raw {
+		parsed, err := parseDate(value)
+		if err != nil {
+			return nil, fmt.Errorf("dates[%d] must be YYYY-MM-DD", i)
+		}
+		key := parsed.Format("2006-01-02")
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		dates = append(dates, parsed)
+	}
+	if len(dates) == 0 {
+		return nil, errors.New("dates is required")
+	}
+	if len(dates) > statsForDatesMaxDates {
+		return nil, fmt.Errorf("dates must contain at most %d distinct days", statsForDatesMaxDates)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	return dates, nil
+}
+
+// getStatsForDates returns the daily aggregate for each requested local date
+// in one query, for comparison views that pick non-contiguous days.
+func (a *App) getStatsForDates(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var payload statsForDatesRequest
+	if !mustJSON(c, &payload) {
+		return
+	}
+	localZone, tzNormalized, err := parseTZOffset(payload.TZOffset)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	dates, err := normalizeStatsDates(payload.Dates)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, c.Param("baby_id"), readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	stats := make([]dateStats, len(dates))
+	statsByDate := make(map[string]*dateStats, len(dates))
+	windowStarts := make([]time.Time, len(dates))
+	windowEnds := make([]time.Time, len(dates))
+	for i, date := range dates {
+		localStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, localZone)
+		windowStarts[i] = localStart.UTC()
+		windowEnds[i] = localStart.AddDate(0, 0, 1).UTC()
+		stats[i] = dateStats{Date: date.Format("2006-01-02")}
+		statsByDate[stats[i].Date] = &stats[i]
+	}
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT type, "startTime", "endTime", "valueJson"
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND EXISTS (
+		     SELECT 1
+		     FROM unnest($2::timestamp[], $3::timestamp[]) AS window_range(range_start, range_end)
+		     WHERE "startTime" >= window_range.range_start
+		       AND "startTime" < window_range.range_end
+		   )
+		   AND NOT (`+openEventPredicateSQL+`)
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
+		   AND `+eventVisibleToUserSQL("$4"),
+		baby.ID,
+		windowStarts,
+		windowEnds,
+		user.ID,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load events")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var eventType string
+		var startTime time.Time
+		var endTime *time.Time
+		var valueRaw []byte
+		if err := rows.Scan(&eventType, &startTime, &endTime, &valueRaw); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to parse events")
+			return
+		}
+		day, found := statsByDate[startTime.In(localZone).Format("2006-01-02")]
+		if !found {
+			continue
+		}
+		value := parseJSONStringMap(valueRaw)
+		if isZeroDurationSleep(eventType, value, startTime, endTime) {
+			continue
+		}
+		day.EventCount++
+		switch eventType {
+		case "FORMULA":
+			day.FeedingsCount++
+			day.FormulaCount++
+			day.FormulaTotalML += extractNumberFromMap(value, "ml", "amount_ml", "volume_ml")
+		case "BREASTFEED":
+			day.FeedingsCount++
+			day.BreastfeedCount++
+		case "SLEEP":
+			if duration := extractDurationMinutes(value, startTime, endTime); duration != nil {
+				day.SleepTotalMin += int(*duration + 0.5)
+			}
+		case "PEE":
+			day.PeeCount++
+		case "POO":
+			day.PooCount++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse events")
+		return
+	}
+	for i := range stats {
+		stats[i].FormulaTotalML = roundToOneDecimal(stats[i].FormulaTotalML)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"baby_id":   baby.ID,
+		"tz_offset": tzNormalized,
+		"days":      stats,
+	})
+}
@@ -0,0 +1,263 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	timelineDefaultPageDays  = 7
+	timelineMaxPageDays      = 31
+	timelineDefaultRangeDays = 30
+	timelineMaxRangeDays     = 366
+	timelineMemoPreviewRunes = 40
+)
+
+type timelineEventItem struct {
+	EventID      string  `json:"event_id"`
+	Type         string  `json:"type"`
+	IconKey      string  `json:"icon_key"`
+	State        string  `json:"state"`
+	LocalTime    string  `json:"local_time"`
+	LocalEndTime *string `json:"local_end_time"`
+	StartTime    string  `json:"start_time"`
+	EndTime      *string `json:"end_time"`
+	ValueText    string  `json:"value_text"`
+}
+
+type timelineDay struct {
+	Date   string              `json:"date"`
+	Events []timelineEventItem `json:"events"`
+}
+
+// getTimeline pages through local days newest first. A page always covers
+// `days` calendar days, so next_cursor stays predictable even when some of
+// those days have no events; empty days are left out of the response.
+func (a *App) getTimeline(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	localZone, tzNormalized, err := parseTZOffset(c.Query("tz_offset"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	pageDays := timelineDefaultPageDays
+	if raw := strings.TrimSpace(c.Query("days")); raw != "" {
+		parsed, parseErr := strconv.Atoi(raw)
+		if parseErr != nil || parsed <= 0 || parsed > timelineMaxPageDays {
+			writeError(c, http.StatusBadRequest, fmt.Sprintf("days must be between 1 and %d", timelineMaxPageDays))
+			return
+		}
+		pageDays = parsed
+	}
+
+	localNow := time.Now().In(localZone)
+	toDate := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, localZone)
+	if raw := strings.TrimSpace(c.Query("to")); raw != "" {
+		parsed, parseErr := parseDate(raw)
+		if parseErr != nil {
+			writeError(c, http.StatusBadRequest, "to must be YYYY-MM-DD")
+			return
+		}
+		toDate = time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, localZone)
+	}
+	fromDate := toDate.AddDate(0, 0, -(timelineDefaultRangeDays - 1))
+	if raw := strings.TrimSpace(c.Query("from")); raw != "" {
+		parsed, parseErr := parseDate(raw)
+		if parseErr != nil {
+			writeError(c, http.StatusBadRequest, "from must be YYYY-MM-DD")
+			return
+		}
+		fromDate = time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, localZone)
+	}
+	if fromDate.After(toDate) {
+		writeError(c, http.StatusBadRequest, "from must be on or before to")
+		return
+	}
+	if toDate.Sub(fromDate) >= timelineMaxRangeDays*24*time.Hour {
+		writeError(c, http.StatusBadRequest, fmt.Sprintf("from/to range must be at most %d days", timelineMaxRangeDays))
+		return
+	}
+
+	// The cursor is the oldest date of the previous page; this page starts
+	// the day before it.
+	pageEndDate := toDate
+	if raw := strings.TrimSpace(c.Query("cursor")); raw != "" {
+		parsed, parseErr := parseDate(raw)
+		if parseErr != nil {
+			writeError(c, http.StatusBadRequest, "cursor must be YYYY-MM-DD")
+			return
+		}
+		cursorDate := time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, localZone)
+		if cursorDate.AddDate(0, 0, -1).Before(pageEndDate) {
+			pageEndDate = cursorDate.AddDate(0, 0, -1)
+		}
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, c.Param("baby_id"), readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	days := []timelineDay{}
+	if pageEndDate.Before(fromDate) {
+		c.JSON(http.StatusOK, gin.H{
+			"baby_id":     baby.ID,
+			"tz_offset":   tzNormalized,
+			"from":        fromDate.Format("2006-01-02"),
+			"to":          toDate.Format("2006-01-02"),
+			"days":        days,
+			"next_cursor": nil,
+		})
+		return
+	}
+	pageStartDate := pageEndDate.AddDate(0, 0, -(pageDays - 1))
+	if pageStartDate.Before(fromDate) {
+		pageStartDate = fromDate
+	}
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT id, type::text, "startTime", "endTime", "valueJson", COALESCE("metadataJson", '{}'::jsonb)
+		 FROM "Event"
+		 WHERE "babyId" = $1
+		   AND "deletedAt" IS NULL
+		   AND "startTime" >= $2
+		   AND "startTime" < $3
+		   AND COALESCE("metadataJson"->>'event_state', 'CLOSED') <> 'CANCELED'
+		   AND `+eventVisibleToUserSQL("$4")+`
+		 ORDER BY "startTime" DESC, id DESC`,
+		baby.ID,
+		pageStartDate.UTC(),
+		pageEndDate.AddDate(0, 0, 1).UTC(),
+		user.ID,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load events")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var eventID, eventType string
+		var startTime time.Time
+		var endTime *time.Time
+		var valueRaw, metadataRaw []byte
+		if err := rows.Scan(&eventID, &eventType, &startTime, &endTime, &valueRaw, &metadataRaw); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to parse events")
+			return
+		}
+		metadata := parseJSONStringMap(metadataRaw)
+		item := timelineEventItem{
+			EventID:   eventID,
+			Type:      eventType,
+			IconKey:   strings.ToLower(eventType),
+			State:     strings.ToUpper(strings.TrimSpace(toString(metadata["event_state"]))),
+			LocalTime: startTime.In(localZone).Format("15:04"),
+			StartTime: startTime.UTC().Format(time.RFC3339),
+			EndTime:   formatNullableTimeRFC3339(endTime),
+			ValueText: timelineValueText(eventType, parseJSONStringMap(valueRaw), startTime, endTime),
+		}
+		if item.State == "" {
+			item.State = "CLOSED"
+		}
+		if endTime != nil {
+			localEnd := endTime.In(localZone).Format("15:04")
+			item.LocalEndTime = &localEnd
+		}
+
+		localDate := startTime.In(localZone).Format("2006-01-02")
+		if len(days) == 0 || days[len(days)-1].Date != localDate {
+			days = append(days, timelineDay{Date: localDate, Events: []timelineEventItem{}})
+		}
+		days[len(days)-1].Events = append(days[len(days)-1].Events, item)
+	}
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse events")
+		return
+	}
+
+	var nextCursor *string
+	if pageStartDate.After(fromDate) {
+		cursor := pageStartDate.Format("2006-01-02")
+		nextCursor = &cursor
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"baby_id":     baby.ID,
+		"tz_offset":   tzNormalized,
+		"from":        fromDate.Format("2006-01-02"),
+		"to":          toDate.Format("2006-01-02"),
+		"page_start":  pageStartDate.Format("2006-01-02"),
+		"page_end":    pageEndDate.Format("2006-01-02"),
+		"days":        days,
+		"next_cursor": nextCursor,
+	})
+}
+
+// timelineValueText renders the short value shown next to an event row.
+// Event types without a meaningful value return an empty string.
+func timelineValueText(eventType string, value map[string]any, startTime time.Time, endTime *time.Time) string {
+	switch eventType {
+	case "FORMULA":
+		if ml := extractNumberFromMap(value, "ml", "amount_ml", "volume_ml"); ml > 0 {
+			return fmt.Sprintf("%d ml", int(ml+0.5))
+		}
+	case "BREASTFEED":
+		if ml := extractNumberFromMap(value, "ml", "amount_ml", "volume_ml"); ml > 0 {
+			return fmt.Sprintf("%d ml", int(ml+0.5))
+		}
+		if duration := extractDurationMinutes(value, startTime, endTime); duration != nil && *duration > 0 {
+			return timelineDurationText(*duration)
+		}
+	case "SLEEP":
+		if duration := extractDurationMinutes(value, startTime, endTime); duration != nil && *duration > 0 {
+			return timelineDurationText(*duration)
+		}
+	case "GROWTH":
+		parts := make([]string, 0, 2)
+		if weight := extractNumberFromMap(value, "weight_kg", "weight"); weight > 0 {
+			parts = append(parts, fmt.Sprintf("%.1f kg", weight))
+		}
+		if height := extractNumberFromMap(value, "height_cm", "length_cm", "height"); height > 0 {
+			parts = append(parts, fmt.Sprintf("%.1f cm", height))
+		}
+		return strings.Join(parts, " / ")
+	case "SYMPTOM":
+		if tempC := extractNumberFromMap(value, "temp_c", "temperature_c", "temp"); tempC > 0 {
+			return fmt.Sprintf("%.1f°C", tempC)
+		}
+		return strings.TrimSpace(coalesceNonEmpty(toString(value["symptom"]), toString(value["name"])))
+	case "MEDICATION":
+		name := medicationNameFromValue(value)
+		dose := strings.TrimSpace(coalesceNonEmpty(toString(value["dose_text"]), toString(value["dose"])))
+		return strings.TrimSpace(name + " " + dose)
+	case "MEMO":
+		memo := []rune(extractMemoText(value))
+		if len(memo) > timelineMemoPreviewRunes {
+			return string(memo[:timelineMemoPreviewRunes]) + "…"
+		}
+		return string(memo)
+	}
+	return ""
+}
+
+func timelineDurationText(minutes float64) string {
+	total := int(minutes + 0.5)
+	if total < 60 {
+		return fmt.Sprintf("%d min", total)
+	}
+	if total%60 == 0 {
+		return fmt.Sprintf("%dh", total/60)
+	}
+	return fmt.Sprintf("%dh %dm", total/60, total%60)
+}
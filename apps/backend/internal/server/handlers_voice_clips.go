@@ -0,0 +1,428 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+const voiceClipListMax = 100
+
+// listVoiceClips returns a baby's most recent voice clips, newest first, so
+// parents can find one to confirm or retry.
+func (a *App) listVoiceClips(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	babyID := strings.TrimSpace(c.Query("baby_id"))
+	if babyID == "" {
+		writeError(c, http.StatusBadRequest, "baby_id is required")
+		return
+	}
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, babyID, readRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	rows, err := a.db.Query(
+		c.Request.Context(),
+		`SELECT id, status::text, COALESCE(transcript, ''), "createdAt",
+		        CASE WHEN jsonb_typeof("parsedEventsJson") = 'array'
+		             THEN jsonb_array_length("parsedEventsJson") ELSE 0 END
+		 FROM "VoiceClip"
+		 WHERE "babyId" = $1
+		 ORDER BY "createdAt" DESC, id DESC
+		 LIMIT $2`,
+		baby.ID,
+		voiceClipListMax,
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load voice clips")
+		return
+	}
+	defer rows.Close()
+
+	clips := make([]gin.H, 0)
+	for rows.Next() {
+		var clipID, status, transcript string
+		var createdAt time.Time
+		var parsedEventCount int
+		if err := rows.Scan(&clipID, &status, &transcript, &createdAt, &parsedEventCount); err != nil {
+			writeError(c, http.StatusInternalServerError, "Failed to parse voice clips")
+			return
+		}
+		clips = append(clips, gin.H{
+			"clip_id":            clipID,
+			"status":             status,
+			"transcript":         transcript,
+			"created_at":         createdAt.UTC().Format(time.RFC3339),
+			"parsed_event_count": parsedEventCount,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to parse voice clips")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"baby_id": baby.ID, "clips": clips})
+}
+
+// reparseVoiceClip runs extraction again on the stored transcript, e.g. after
+// a FAILED parse. Confirmed and partially confirmed clips already produced
+// events and stay as they are.
+func (a *App) reparseVoiceClip(c *gin.Context) {
+	user, ok := authUserFromContext(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	clipID := strings.TrimSpace(c.Param("clip_id"))
+
+	var babyID, status string
+	var transcript *string
+	err := a.db.QueryRow(
+		c.Request.Context(),
+		`SELECT "babyId", status::text, transcript FROM "VoiceClip" WHERE id = $1`,
+		clipID,
+	).Scan(&babyID, &status, &transcript)
+	if errors.Is(err, pgx.ErrNoRows) {
+		writeError(c, http.StatusNotFound, "Voice clip not found")
+		return
+	}
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load voice clip")
+		return
+	}
+
+	baby, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, babyID, writeRoles)
+	if err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+	if status == voiceClipStatusConfirmed || status == voiceClipStatusPartiallyConfirmed {
+		writeError(c, http.StatusConflict, "Voice clip is already confirmed")
+		return
+	}
+	if transcript == nil || strings.TrimSpace(*transcript) == "" {
+		writeError(c, http.StatusUnprocessableEntity, "Voice clip has no transcript to reparse")
+		return
+	}
+
+	events, err := a.extractVoiceEvents(c.Request.Context(), strings.TrimSpace(*transcript), baby.ID, time.Now().UTC())
+	if err != nil {
+		a.writeChatExecutionError(c, err)
+		return
+	}
+
+	tx, err := a.db.Begin(c.Request.Context())
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback(c.Request.Context())
+
+	// The status guard covers a confirm that landed while extraction ran.
+	result, err := tx.Exec(
+		c.Request.Context(),
+		`UPDATE "VoiceClip"
+		 SET "parsedEventsJson" = $2, "confidenceJson" = $3, status = 'PARSED'
+		 WHERE id = $1 AND status::text NOT IN ('CONFIRMED', 'PARTIALLY_CONFIRMED')`,
+		clipID,
+		mustMarshalJSON(events),
+		mustMarshalJSON(voiceEventConfidences(events)),
+	)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to update voice clip")
+		return
+	}
+	if result.RowsAffected() == 0 {
+		writeError(c, http.StatusConflict, "Voice clip is already confirmed")
+		return
+	}
+
+	if err := recordAuditLog(
+		c.Request.Context(),
+		tx,
+		baby.HouseholdID,
+		user.ID,
+		"VOICE_CLIP_REPARSED",
+		"VoiceClip",
+		&clipID,
+		gin.H{"baby_id": baby.ID, "previous_status": status, "parsed_event_count": len(events)},
+	); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to write audit log")
+		return
+	}
+
+	if err := tx.Commit(c.Request.Context()); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	c.JSON(http.StatusOK, voiceParseResponse{
+		ClipID:       clipID,
+		Transcript:   strings.TrimSpace(*transcript),
+		ParsedEvents: events,
+		Status:       "PARSED",
+	})
+}
+
+const (
+	voiceClipStatusConfirmed          = "CONFIRMED"
+	voiceClipStatusPartiallyConfirmed = "PARTIALLY_CONFIRMED"
+	voiceClipResolutionConfirmed      = "CONFIRMED"
+	voiceClipResolutionRejected       = "REJECTED"
+)
+
+// voiceClipResolution records what happened to one parsed event of a clip.
+// EventID is set for confirmed events.
+type voiceClipResolution struct {
+	Index   int    `json:"index"`
+	Status  string `json:"status"`
+	EventID string `json:"event_id,omitempty"`
+}
+
+type voiceClipConfirmState struct {
+	HouseholdID  string
+	BabyID       string
+	Status       string
+	ParsedEvents []eventItem
+	Resolutions  []voiceClipResolution
+}
+
+// loadVoiceClipForConfirm reads a clip with its parsed events and the
+// resolutions recorded so far. Inside a transaction it locks the row.
+func (a *App) loadVoiceClipForConfirm(ctx context.Context, q dbQuerier, clipID string, forUpdate bool) (voiceClipConfirmState, error) {
+	query := `SELECT "householdId", "babyId", status::text,
+	        COALESCE("parsedEventsJson", '[]'::jsonb), COALESCE("resolutionsJson", '[]'::jsonb)
+	 FROM "VoiceClip"
+	 WHERE id = $1`
+	if forUpdate {
+		query += ` FOR UPDATE`
+	}
+	var state voiceClipConfirmState
+	var parsedRaw, resolutionsRaw []byte
+	if err := q.QueryRow(ctx, query, clipID).Scan(&state.HouseholdID, &state.BabyID, &state.Status, &parsedRaw, &resolutionsRaw); err != nil {
+		return voiceClipConfirmState{}, err
+	}
+	// Clips parsed before a shape change may not decode; they then have no
+	// parsed events to resolve by index.
+	_ = json.Unmarshal(parsedRaw, &state.ParsedEvents)
+	_ = json.Unmarshal(resolutionsRaw, &state.Resolutions)
+	return state, nil
+}
+
+// validateVoiceClipIndices checks that confirm and reject indices are
+// non-negative, unique and disjoint. Range checks need the stored clip.
+func validateVoiceClipIndices(confirmIndices, rejectIndices []int) error {
+	if len(confirmIndices) == 0 && len(rejectIndices) == 0 {
+		return errors.New("confirm_indices or reject_indices must not be empty")
+	}
+	seen := map[int]struct{}{}
+	for _, indices := range [][]int{confirmIndices, rejectIndices} {
+		for _, index := range indices {
+			if index < 0 {
+				return errors.New("indices must not be negative")
+			}
+			if _, dup := seen[index]; dup {
+				return fmt.Errorf("index %d is listed more than once", index)
+			}
+			seen[index] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// pendingVoiceClipIndices lists parsed event indices without a resolution.
+func pendingVoiceClipIndices(parsedCount int, resolutions []voiceClipResolution) []int {
+	resolved := make(map[int]struct{}, len(resolutions))
+	for _, resolution := range resolutions {
+		resolved[resolution.Index] = struct{}{}
+	}
+	pending := make([]int, 0)
+	for index := 0; index < parsedCount; index++ {
+		if _, ok := resolved[index]; !ok {
+			pending = append(pending, index)
+		}
+	}
+	return pending
+}
+
+// confirmVoiceClipSubset saves the parsed events named by confirm_indices and
+// marks reject_indices as discarded. The clip stays PARTIALLY_CONFIRMED until
+// every parsed event is resolved one way or the other.
+func (a *App) confirmVoiceClipSubset(c *gin.Context, user AuthUser, idempotencyKey string, payload confirmEventsRequest) {
+	if err := validateVoiceClipIndices(payload.ConfirmIndices, payload.RejectIndices); err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(payload.Events) > 0 && len(payload.Events) != len(payload.ConfirmIndices) {
+		writeError(c, http.StatusBadRequest, "events must match confirm_indices one to one")
+		return
+	}
+
+	clip, err := a.loadVoiceClipForConfirm(c.Request.Context(), a.db, payload.ClipID, false)
+	if errors.Is(err, pgx.ErrNoRows) {
+		writeError(c, http.StatusNotFound, "Voice clip not found")
+		return
+	}
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load voice clip")
+		return
+	}
+	if _, statusCode, err := a.getBabyWithAccess(c.Request.Context(), user.ID, clip.BabyID, writeRoles); err != nil {
+		writeError(c, statusCode, err.Error())
+		return
+	}
+
+	tx, err := a.db.Begin(c.Request.Context())
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback(c.Request.Context())
+
+	// Re-read under a row lock so two reviewers cannot resolve the same
+	// parsed event twice.
+	clip, err = a.loadVoiceClipForConfirm(c.Request.Context(), tx, payload.ClipID, true)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to load voice clip")
+		return
+	}
+	if clip.Status == voiceClipStatusConfirmed {
+		writeError(c, http.StatusConflict, "Voice clip is already confirmed")
+		return
+	}
+	resolved := make(map[int]struct{}, len(clip.Resolutions))
+	for _, resolution := range clip.Resolutions {
+		resolved[resolution.Index] = struct{}{}
+	}
+	for _, index := range append(append([]int{}, payload.ConfirmIndices...), payload.RejectIndices...) {
+		if index >= len(clip.ParsedEvents) {
+			writeError(c, http.StatusBadRequest, fmt.Sprintf("index %d is out of range; the clip has %d parsed events", index, len(clip.ParsedEvents)))
+			return
+		}
+		if _, done := resolved[index]; done {
+			writeError(c, http.StatusConflict, fmt.Sprintf("parsed event %d is already resolved", index))
+			return
+		}
+	}
+
+	events := payload.Events
+	if len(events) == 0 {
+		events = make([]eventItem, 0, len(payload.ConfirmIndices))
+		for _, index := range payload.ConfirmIndices {
+			events = append(events, clip.ParsedEvents[index])
+		}
+	}
+	if err := normalizeConfirmEvents(events, payload.ConfirmIndices); err != nil {
+		writeError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	eventIDs := make([]string, 0, len(events))
+	resolutions := append([]voiceClipResolution{}, clip.Resolutions...)
+	parsedEvents := append([]eventItem{}, clip.ParsedEvents...)
+	for i, event := range events {
+		eventID, err := a.saveVoiceConfirmedEvent(c.Request.Context(), tx, payload.ClipID, clip.BabyID, user.ID, event)
+		if err != nil {
+			writeVoiceConfirmSaveError(c, err)
+			return
+		}
+		index := payload.ConfirmIndices[i]
+		parsedEvents[index] = event
+		eventIDs = append(eventIDs, eventID)
+		resolutions = append(resolutions, voiceClipResolution{Index: index, Status: voiceClipResolutionConfirmed, EventID: eventID})
+	}
+	for _, index := range payload.RejectIndices {
+		resolutions = append(resolutions, voiceClipResolution{Index: index, Status: voiceClipResolutionRejected})
+	}
+	sort.Slice(resolutions, func(i, j int) bool { return resolutions[i].Index < resolutions[j].Index })
+
+	pending := pendingVoiceClipIndices(len(parsedEvents), resolutions)
+	status := voiceClipStatusPartiallyConfirmed
+	auditAction := "VOICE_CLIP_PARTIALLY_CONFIRMED"
+	if len(pending) == 0 {
+		status = voiceClipStatusConfirmed
+		auditAction = "VOICE_CLIP_CONFIRMED"
+	}
+	if _, err := tx.Exec(
+		c.Request.Context(),
+		`UPDATE "VoiceClip"
+		 SET status = $2::"VoiceClipStatus", "parsedEventsJson" = $3, "resolutionsJson" = $4
+		 WHERE id = $1`,
+		payload.ClipID,
+		status,
+		mustMarshalJSON(parsedEvents),
+		mustMarshalJSON(resolutions),
+	); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to update voice clip")
+		return
+	}
+
+	if err := recordAuditLog(
+		c.Request.Context(),
+		tx,
+		clip.HouseholdID,
+		user.ID,
+		auditAction,
+		"VoiceClip",
+		&payload.ClipID,
+		gin.H{
+			"saved_event_count": len(eventIDs),
+			"confirmed_indices": payload.ConfirmIndices,
+			"rejected_indices":  payload.RejectIndices,
+			"pending_indices":   pending,
+		},
+	); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to write audit log")
+		return
+	}
+
+	response := gin.H{
+		"status":            status,
+		"clip_id":           payload.ClipID,
+		"saved_event_count": len(eventIDs),
+		"event_ids":         eventIDs,
+		"resolutions":       resolutions,
+		"pending_indices":   pending,
+	}
+	if !a.storeIdempotentResponse(c, tx, user.ID, idempotencyKey, idempotencyEndpointConfirm, response) {
+		return
+	}
+	if err := tx.Commit(c.Request.Context()); err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// EnsureVoiceClipResolutionSchema adds the resolution column and the
+// PARTIALLY_CONFIRMED status to databases created before partial confirms.
+// It runs once at startup: the enum value cannot be added inside the confirm
+// transaction that first writes it, and each step is a no-op once applied.
+func (a *App) EnsureVoiceClipResolutionSchema(ctx context.Context) error {
+	statements := []string{
+		`ALTER TYPE "VoiceClipStatus" ADD VALUE IF NOT EXISTS 'PARTIALLY_CONFIRMED'`,
+		`ALTER TABLE "VoiceClip" ADD COLUMN IF NOT EXISTS "resolutionsJson" JSONB`,
+	}
+	for _, stmt := range statements {
+		if _, err := a.db.Exec(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,10 +1,16 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
+
+	"babyai/apps/backend/internal/config"
 )
 
 func TestClaimHasAudience(t *testing.T) {
@@ -173,6 +179,31 @@ func TestAgeMonthsFromBirthDate(t *testing.T) {
 	}
 }
 
+func TestAgeMonthsFromBirthDateEndOfMonth(t *testing.T) {
+	cases := []struct {
+		name  string
+		birth time.Time
+		now   time.Time
+		want  int
+	}{
+		{"jan31 on feb27", time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 27, 0, 0, 0, 0, time.UTC), 0},
+		{"jan31 on feb28", time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), 1},
+		{"jan31 on leap feb29", time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), 1},
+		{"jan31 on mar30", time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 30, 0, 0, 0, 0, time.UTC), 1},
+		{"jan31 on mar31", time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), 2},
+		{"jan31 on apr30", time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC), 3},
+		{"feb29 on mar28", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 28, 0, 0, 0, 0, time.UTC), 0},
+		{"feb29 on mar29", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC), 1},
+		{"feb29 on next feb28", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), 12},
+		{"feb29 on next feb27", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 27, 0, 0, 0, 0, time.UTC), 11},
+	}
+	for _, tc := range cases {
+		if got := ageMonthsFromBirthDate(tc.birth, tc.now); got != tc.want {
+			t.Fatalf("%s: expected %d months, got %d", tc.name, tc.want, got)
+		}
+	}
+}
+
 func TestChatModelForIntent(t *testing.T) {
 	if got := chatModelForIntent(aiIntentSmalltalk); got != chatDailyModel {
 		t.Fatalf("expected smalltalk to use %q, got %q", chatDailyModel, got)
@@ -334,7 +365,8 @@ func TestEnforceAnswerEvidenceGuideInlineHeadingsAndTilde(t *testing.T) {
 
 func TestBuildOnboardingDummySeedEvents(t *testing.T) {
 	now := time.Date(2026, 2, 19, 18, 0, 0, 0, time.UTC)
-	events := buildOnboardingDummySeedEvents(now)
+	kst := time.FixedZone("KST", 9*60*60)
+	events := buildOnboardingDummySeedEvents(now, kst, onboardingSeedPresetSixMo)
 	if len(events) < 10 {
 		t.Fatalf("expected enough seeded events, got %d", len(events))
 	}
@@ -374,3 +406,1774 @@ func TestBuildOnboardingDummySeedEvents(t *testing.T) {
 		)
 	}
 }
+
+func TestBuildOnboardingDummySeedEventsPresets(t *testing.T) {
+	maxFeedMl := func(events []onboardingDummySeedEvent) float64 {
+		maxMl := 0.0
+		for _, item := range events {
+			if item.Type == "FORMULA" && extractNumberFromMap(item.Value, "ml") > maxMl {
+				maxMl = extractNumberFromMap(item.Value, "ml")
+			}
+		}
+		return maxMl
+	}
+
+	// 20:00 in New York is late enough to seed the same local day.
+	newYork := time.FixedZone("EST", -5*60*60)
+	now := time.Date(2026, 2, 20, 1, 0, 0, 0, time.UTC)
+	newborn := buildOnboardingDummySeedEvents(now, newYork, onboardingSeedPresetNewborn)
+	toddler := buildOnboardingDummySeedEvents(now, newYork, onboardingSeedPresetToddler)
+	if len(newborn) == 0 || len(toddler) == 0 {
+		t.Fatalf("expected seeded events newborn=%d toddler=%d", len(newborn), len(toddler))
+	}
+	if got := newborn[0].StartTime.In(newYork).Day(); got != 19 {
+		t.Fatalf("expected newborn seed on local 19th, got %d", got)
+	}
+	if maxFeedMl(newborn) >= maxFeedMl(toddler) {
+		t.Fatalf("expected newborn feeds smaller than toddler feeds, got %v >= %v", maxFeedMl(newborn), maxFeedMl(toddler))
+	}
+	for _, item := range append(newborn, toddler...) {
+		if item.EndTime != nil && item.EndTime.After(now) {
+			t.Fatalf("seed event end must be <= now, got %s", item.EndTime.Format(time.RFC3339))
+		}
+	}
+
+	// 09:00 local is before the last preset entry, so yesterday is used.
+	early := time.Date(2026, 2, 19, 14, 0, 0, 0, time.UTC)
+	shifted := buildOnboardingDummySeedEvents(early, newYork, onboardingSeedPresetToddler)
+	if got := shifted[0].StartTime.In(newYork).Day(); got != 18 {
+		t.Fatalf("expected early-day seed shifted to local 18th, got %d", got)
+	}
+
+	if got := normalizeOnboardingSeedPreset("Toddler"); got != onboardingSeedPresetToddler {
+		t.Fatalf("expected toddler preset, got %q", got)
+	}
+	if got := normalizeOnboardingSeedPreset("teen"); got != "" {
+		t.Fatalf("expected unknown preset to be rejected, got %q", got)
+	}
+	if got := onboardingSeedPresetForAge(200); got != onboardingSeedPresetSixMo {
+		t.Fatalf("expected 6mo preset for 200 days, got %q", got)
+	}
+}
+
+func TestQuickRangeWindowWeekStartsOn(t *testing.T) {
+	// Wednesday 2026-02-11 in KST.
+	localNow := time.Date(2026, 2, 11, 15, 30, 0, 0, time.FixedZone("KST", 9*60*60))
+
+	mondayStart, mondayEnd, mondayDays, _, err := quickRangeWindow(localNow, "week", time.Monday)
+	if err != nil {
+		t.Fatalf("expected monday week window, got error: %v", err)
+	}
+	sundayStart, sundayEnd, sundayDays, _, err := quickRangeWindow(localNow, "week", time.Sunday)
+	if err != nil {
+		t.Fatalf("expected sunday week window, got error: %v", err)
+	}
+
+	if mondayDays != 7 || sundayDays != 7 {
+		t.Fatalf("expected 7-day windows, got monday=%d sunday=%d", mondayDays, sundayDays)
+	}
+	if got := mondayStart.Format("2006-01-02"); got != "2026-02-09" {
+		t.Fatalf("expected monday-start week to begin 2026-02-09, got %s", got)
+	}
+	if got := mondayEnd.Format("2006-01-02"); got != "2026-02-16" {
+		t.Fatalf("expected monday-start week to end 2026-02-16, got %s", got)
+	}
+	if got := sundayStart.Format("2006-01-02"); got != "2026-02-08" {
+		t.Fatalf("expected sunday-start week to begin 2026-02-08, got %s", got)
+	}
+	if got := sundayEnd.Format("2006-01-02"); got != "2026-02-15" {
+		t.Fatalf("expected sunday-start week to end 2026-02-15, got %s", got)
+	}
+
+	// A Sunday belongs to the week it opens when weeks start on Sunday,
+	// but closes the previous week when weeks start on Monday.
+	sunday := time.Date(2026, 2, 15, 8, 0, 0, 0, time.UTC)
+	if got := startOfLocalWeek(sunday, time.Sunday).Format("2006-01-02"); got != "2026-02-15" {
+		t.Fatalf("expected sunday to start its own week, got %s", got)
+	}
+	if got := startOfLocalWeek(sunday, time.Monday).Format("2006-01-02"); got != "2026-02-09" {
+		t.Fatalf("expected sunday to close the monday-start week, got %s", got)
+	}
+}
+
+func TestParseWeekStartsOn(t *testing.T) {
+	if got, ok := parseWeekStartsOn(" Sunday "); !ok || got != time.Sunday {
+		t.Fatalf("expected sunday, got %v ok=%v", got, ok)
+	}
+	if got, ok := parseWeekStartsOn("monday"); !ok || got != time.Monday {
+		t.Fatalf("expected monday, got %v ok=%v", got, ok)
+	}
+	if _, ok := parseWeekStartsOn("friday"); ok {
+		t.Fatalf("expected unsupported week start to fail")
+	}
+}
+
+func TestModelPricingChargesDifferByModel(t *testing.T) {
+	app := &App{modelPricing: parseModelPricingTable([]string{"model-cheap=0.5:1", "model-premium=2:8", "broken-entry", "model-bad=x:1"})}
+	usage := AIUsage{PromptTokens: 1500, CompletionTokens: 500, TotalTokens: 2000}
+
+	cheap := creditBreakdownForUsage("model-cheap", app.pricingForModel("model-cheap"), usage)
+	premium := creditBreakdownForUsage("model-premium", app.pricingForModel("model-premium"), usage)
+	if cheap.Charged != 2 {
+		t.Fatalf("expected cheap charge 2 (0.75+0.5), got %d", cheap.Charged)
+	}
+	if premium.Charged != 7 {
+		t.Fatalf("expected premium charge 7 (3+4), got %d", premium.Charged)
+	}
+	if premium.PromptCredits != 3 || premium.CompletionCredits != 4 {
+		t.Fatalf("unexpected premium breakdown: %+v", premium)
+	}
+
+	if got := app.pricingForModel("model-bad"); got != fallbackModelPricing {
+		t.Fatalf("expected malformed entry to fall back, got %+v", got)
+	}
+	if got := app.pricingForModel(chatCoreModel); got != defaultModelPricing[chatCoreModel] {
+		t.Fatalf("expected default pricing for %s, got %+v", chatCoreModel, got)
+	}
+}
+
+func TestReserveCreditsFollowModelPricing(t *testing.T) {
+	app := &App{modelPricing: parseModelPricingTable([]string{"model-cheap=0.5:1", "model-premium=2:8"})}
+	if got := reserveCreditsForPricing(app.pricingForModel(chatCoreModel)); got != 2 {
+		t.Fatalf("expected default reservation of 2 credits, got %d", got)
+	}
+	for _, model := range []string{"model-cheap", "model-premium"} {
+		pricing := app.pricingForModel(model)
+		expected := creditBreakdownForUsage(model, pricing, AIUsage{
+			PromptTokens:     reservePromptTokens,
+			CompletionTokens: reserveCompletionTokens,
+		}).Charged
+		if got := reserveCreditsForPricing(pricing); got != expected {
+			t.Fatalf("expected %s reservation %d, got %d", model, expected, got)
+		}
+	}
+	if reserveCreditsForPricing(app.pricingForModel("model-premium")) <= reserveCreditsForPricing(app.pricingForModel("model-cheap")) {
+		t.Fatalf("expected premium reservation to exceed cheap reservation")
+	}
+}
+
+func TestSelectableAIModelsOnlyOffersPricedModels(t *testing.T) {
+	app := &App{modelPricing: parseModelPricingTable([]string{"model-premium=2:8"})}
+	if got := strings.Join(app.selectableAIModels(), ","); got != strings.Join([]string{chatCoreModel, chatDailyModel, "model-premium"}, ",") {
+		t.Fatalf("expected every priced model without an allowlist, got %s", got)
+	}
+
+	app.cfg.AIModelAllowlist = []string{"model-premium", "model-unpriced", chatCoreModel}
+	if got := strings.Join(app.selectableAIModels(), ","); got != chatCoreModel+",model-premium" {
+		t.Fatalf("expected allowlisted priced models only, got %s", got)
+	}
+	if app.isSelectableAIModel("model-unpriced") || app.isSelectableAIModel(chatDailyModel) {
+		t.Fatalf("expected unpriced and unlisted models to be rejected")
+	}
+
+	if got := chatModelFor("", aiIntentSmalltalk); got != chatDailyModel {
+		t.Fatalf("expected intent default without a household model, got %s", got)
+	}
+	if got := chatModelFor("model-premium", aiIntentSmalltalk); got != "model-premium" {
+		t.Fatalf("expected household model to win, got %s", got)
+	}
+}
+
+func TestLimitsForPlan(t *testing.T) {
+	if got := limitsForPlan("AI_PHOTO", "ACTIVE"); got.GraceLimitPerDay <= graceLimitPerDay || got.ConversationTurnLimit <= chatConversationTurnLimit {
+		t.Fatalf("expected AI_PHOTO to raise both limits, got %+v", got)
+	}
+	for _, tc := range []struct{ plan, status string }{
+		{"AI_ONLY", "ACTIVE"},
+		{"AI_PHOTO", "CANCELED"},
+		{"", ""},
+	} {
+		if got := limitsForPlan(tc.plan, tc.status); got != defaultPlanLimits {
+			t.Fatalf("expected default limits for %s/%s, got %+v", tc.plan, tc.status, got)
+		}
+	}
+}
+
+func TestScoreDataCompletenessWeightsCoverageAndConsistency(t *testing.T) {
+	steady := scoreDataCompleteness(map[string][]int{
+		"feeding": {5, 6, 4, 5, 7, 4, 5},
+		"sleep":   {2, 3, 2, 2, 3, 2, 1},
+		"diaper":  {4, 5, 3, 6, 4, 3, 5},
+	}, 7)
+	if steady.Score != 100 || len(steady.UnderLogged) != 0 {
+		t.Fatalf("expected fully logged week to score 100, got %+v", steady)
+	}
+
+	// Same feeding total as a steady week, but crammed into a single day.
+	burst := scoreDataCompleteness(map[string][]int{
+		"feeding": {36, 0, 0, 0, 0, 0, 0},
+		"sleep":   {2, 3, 2, 2, 3, 2, 1},
+		"diaper":  {4, 5, 3, 6, 4, 3, 5},
+	}, 7)
+	if burst.Score >= steady.Score {
+		t.Fatalf("expected burst logging to score lower, got %d vs %d", burst.Score, steady.Score)
+	}
+	if len(burst.UnderLogged) != 1 || burst.UnderLogged[0] != "feeding" {
+		t.Fatalf("expected feeding to be under-logged, got %v", burst.UnderLogged)
+	}
+	if burst.Categories[0].TotalEvents != 36 || burst.Categories[0].DaysLogged != 1 {
+		t.Fatalf("unexpected feeding breakdown: %+v", burst.Categories[0])
+	}
+
+	empty := scoreDataCompleteness(map[string][]int{}, 7)
+	if empty.Score != 0 || len(empty.UnderLogged) != 3 {
+		t.Fatalf("expected empty week to score 0 with all categories under-logged, got %+v", empty)
+	}
+}
+
+func TestApplyFocalEventContextInjectsEventDetails(t *testing.T) {
+	startAt := time.Date(2026, 3, 4, 13, 0, 0, 0, time.UTC)
+	endAt := startAt.Add(35 * time.Minute)
+	focal := normalizeEvidenceRow("evt-nap", "SLEEP", startAt, &endAt, `{"memo":"short nap"}`, `{}`)
+
+	base := chatContextResult{
+		Meta: map[string]any{
+			"time_range":         "last_3d_raw",
+			"evidence_event_ids": []string{"evt-other", "evt-nap"},
+			"has_missing_data":   false,
+		},
+		Summary: "window summary",
+	}
+	result := applyFocalEventContext(base, focal)
+
+	if result.Meta["focal_event_id"] != "evt-nap" {
+		t.Fatalf("expected focal_event_id in meta, got %v", result.Meta["focal_event_id"])
+	}
+	ids, _ := result.Meta["evidence_event_ids"].([]string)
+	if len(ids) != 2 || ids[0] != "evt-nap" || ids[1] != "evt-other" {
+		t.Fatalf("expected focal event first without duplicates, got %v", ids)
+	}
+	if _, ok := base.Meta["focal_event_id"]; ok {
+		t.Fatalf("expected base meta to stay untouched")
+	}
+	for _, expected := range []string{"초점 이벤트", "evt-nap", "SLEEP", focal.Start, focal.End, "window summary"} {
+		if !strings.Contains(result.Summary, expected) {
+			t.Fatalf("expected summary to contain %q, got %s", expected, result.Summary)
+		}
+	}
+	if strings.Index(result.Summary, "evt-nap") > strings.Index(result.Summary, "window summary") {
+		t.Fatalf("expected focal event ahead of the window summary: %s", result.Summary)
+	}
+
+	emptyWindow := chatContextResult{Meta: map[string]any{"has_missing_data": true}}
+	result = applyFocalEventContext(emptyWindow, focal)
+	if result.Meta["has_missing_data"] != true {
+		t.Fatalf("expected an empty window to stay flagged as missing data, got %v", result.Meta["has_missing_data"])
+	}
+	if ids, _ := result.Meta["evidence_event_ids"].([]string); len(ids) != 1 || ids[0] != "evt-nap" {
+		t.Fatalf("expected the focal event as the only evidence, got %v", result.Meta["evidence_event_ids"])
+	}
+}
+
+type intentRouterStubAIClient struct {
+	answer string
+	err    error
+}
+
+func (s intentRouterStubAIClient) Query(_ context.Context, req AIModelRequest) (AIModelResponse, error) {
+	if s.err != nil {
+		return AIModelResponse{}, s.err
+	}
+	return AIModelResponse{Answer: s.answer, Model: req.Model}, nil
+}
+
+func TestResolveSessionIntentReportsSource(t *testing.T) {
+	router := &App{ai: intentRouterStubAIClient{answer: `{"intent":"data_query","confidence":0.9}`}}
+	brokenRouter := &App{ai: intentRouterStubAIClient{err: errors.New("router down")}}
+
+	cases := []struct {
+		name           string
+		app            *App
+		question       string
+		fixedIntent    aiIntent
+		forcedIntent   aiIntent
+		expectedIntent aiIntent
+		expectedSource chatIntentSource
+	}{
+		{
+			name:           "forced override wins over persisted intent",
+			app:            router,
+			question:       "how many feeds today?",
+			fixedIntent:    aiIntentSmalltalk,
+			forcedIntent:   aiIntentMedicalRelated,
+			expectedIntent: aiIntentMedicalRelated,
+			expectedSource: chatIntentSourceForcedOverride,
+		},
+		{
+			name:           "persisted first message intent",
+			app:            router,
+			question:       "how many feeds today?",
+			fixedIntent:    aiIntentCareRoutine,
+			expectedIntent: aiIntentCareRoutine,
+			expectedSource: chatIntentSourcePersistedFirstMessage,
+		},
+		{
+			name:           "caregiver self talk guard",
+			app:            router,
+			question:       "I am so tired today",
+			expectedIntent: aiIntentSmalltalk,
+			expectedSource: chatIntentSourceCaregiverSelfTalk,
+		},
+		{
+			name:           "ai router",
+			app:            router,
+			question:       "how many feeds today?",
+			expectedIntent: aiIntentDataQuery,
+			expectedSource: chatIntentSourceAIRouter,
+		},
+		{
+			name:           "heuristic when router fails",
+			app:            brokenRouter,
+			question:       "how many feeds today?",
+			expectedIntent: resolveAIIntentWithSession("how many feeds today?", nil),
+			expectedSource: chatIntentSourceHeuristic,
+		},
+	}
+
+	for _, tc := range cases {
+		intent, source := tc.app.resolveSessionIntentFromFirstUserMessage(
+			context.Background(),
+			"session-1",
+			tc.question,
+			nil,
+			"",
+			"",
+			tc.fixedIntent,
+			tc.forcedIntent,
+		)
+		if intent != tc.expectedIntent || source != tc.expectedSource {
+			t.Fatalf("%s: expected %s/%s, got %s/%s", tc.name, tc.expectedIntent, tc.expectedSource, intent, source)
+		}
+	}
+}
+
+func TestPlanRemainingFormulaClampsAndCountsFeeds(t *testing.T) {
+	perFeed := 120
+	plan := planRemainingFormula(800, 330, &perFeed)
+	if plan.RemainingML != 470 {
+		t.Fatalf("expected 470 ml remaining, got %d", plan.RemainingML)
+	}
+	if plan.SuggestedRemainingFeeds == nil || *plan.SuggestedRemainingFeeds != 4 {
+		t.Fatalf("expected 4 remaining feeds, got %v", plan.SuggestedRemainingFeeds)
+	}
+
+	over := planRemainingFormula(800, 950, &perFeed)
+	if over.RemainingML != 0 || over.SuggestedRemainingFeeds == nil || *over.SuggestedRemainingFeeds != 0 {
+		t.Fatalf("expected remaining clamped at 0, got %+v", over)
+	}
+
+	noPerFeed := planRemainingFormula(500, 100, nil)
+	if noPerFeed.RemainingML != 400 || noPerFeed.SuggestedRemainingFeeds != nil {
+		t.Fatalf("expected ml only without per-feed amount, got %+v", noPerFeed)
+	}
+}
+
+func TestSoftenInternalJargonCleansJSONMentions(t *testing.T) {
+	answer, found := softenInternalJargon("오늘 JSON을 보면 분유는 4회였어요.", defaultJargonTerms)
+	if strings.Contains(answer, "JSON") {
+		t.Fatalf("expected JSON to be removed, got %q", answer)
+	}
+	if answer != "오늘 기록을 보면 분유는 4회였어요." {
+		t.Fatalf("unexpected softened answer: %q", answer)
+	}
+	if len(found) != 1 || found[0] != "JSON" {
+		t.Fatalf("expected JSON to be flagged, got %v", found)
+	}
+
+	untouched, found := softenInternalJargon("Use the tokenizer-free view.", defaultJargonTerms)
+	if untouched != "Use the tokenizer-free view." || len(found) != 0 {
+		t.Fatalf("expected substrings to be left alone, got %q %v", untouched, found)
+	}
+
+	app := &App{jargonTerms: parseJargonTerms([]string{"Event table=your logs", "json"})}
+	custom, _ := softenInternalJargon("Based on your Event table json, sleep was short.", app.answerJargonTerms())
+	if custom != "Based on your your logs, sleep was short." {
+		t.Fatalf("unexpected custom softened answer: %q", custom)
+	}
+}
+
+func TestChatStreamSanitizerCleansDeltasBySentence(t *testing.T) {
+	deltas := make([]string, 0, 4)
+	sanitizer := newChatStreamSanitizer(defaultJargonTerms, func(text string) {
+		deltas = append(deltas, text)
+	})
+	fragments := []string{"Last feed was at 2026-01-01T09:", "00:00Z (UTC). Based on the ", "JSON, she ate 120 ml.\n", "- nap"}
+	for _, fragment := range fragments {
+		sanitizer.Write(fragment)
+	}
+	if len(deltas) != 2 {
+		t.Fatalf("expected the trailing fragment to stay buffered, got %q", deltas)
+	}
+	sanitizer.Flush()
+
+	streamed := strings.Join(deltas, "")
+	for _, raw := range []string{"T09:00:00Z", "UTC", "JSON"} {
+		if strings.Contains(streamed, raw) {
+			t.Fatalf("expected %q to be cleaned from the stream, got %q", raw, streamed)
+		}
+	}
+	want, _ := softenInternalJargon(sanitizeUserFacingAnswer(strings.Join(fragments, "")), defaultJargonTerms)
+	if streamed != want {
+		t.Fatalf("expected the stream to match the non-streamed cleanup %q, got %q", want, streamed)
+	}
+}
+
+func TestTimelineValueTextFormatsByType(t *testing.T) {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(45 * time.Minute)
+	cases := []struct {
+		eventType string
+		value     map[string]any
+		end       *time.Time
+		expected  string
+	}{
+		{eventType: "FORMULA", value: map[string]any{"ml": 150.0}, expected: "150 ml"},
+		{eventType: "BREASTFEED", value: map[string]any{}, end: &end, expected: "45 min"},
+		{eventType: "SLEEP", value: map[string]any{"duration_min": 120.0}, expected: "2h"},
+		{eventType: "GROWTH", value: map[string]any{"weight_kg": 6.25, "height_cm": 61.0}, expected: "6.2 kg / 61.0 cm"},
+		{eventType: "SYMPTOM", value: map[string]any{"temp_c": 38.2}, expected: "38.2°C"},
+		{eventType: "MEDICATION", value: map[string]any{"name": "acetaminophen", "dose_text": "2.5ml"}, expected: "acetaminophen 2.5ml"},
+		{eventType: "PEE", value: map[string]any{}, expected: ""},
+	}
+	for _, tc := range cases {
+		if got := timelineValueText(tc.eventType, tc.value, start, tc.end); got != tc.expected {
+			t.Fatalf("%s: expected %q, got %q", tc.eventType, tc.expected, got)
+		}
+	}
+}
+
+func TestTranslateChatAnswerUsesTargetLanguage(t *testing.T) {
+	app := &App{ai: intentRouterStubAIClient{answer: " Slept 11 hours today. "}}
+	translated, _, err := app.translateChatAnswer(context.Background(), "gpt-5-mini", "오늘 11시간 잤어요.", "en")
+	if err != nil {
+		t.Fatalf("translate: %v", err)
+	}
+	if translated != "Slept 11 hours today." {
+		t.Fatalf("unexpected translation: %q", translated)
+	}
+	if normalizeTranslateTarget("EN-us") != "en" || normalizeTranslateTarget("ko") != "" {
+		t.Fatalf("unexpected translate_to normalization")
+	}
+	if !strings.Contains(buildTranslationSystemPrompt("Japanese"), "into Japanese") {
+		t.Fatalf("expected target language in translation prompt")
+	}
+
+	empty := &App{ai: intentRouterStubAIClient{answer: "  "}}
+	if _, _, err := empty.translateChatAnswer(context.Background(), "gpt-5-mini", "답변", "ja"); err == nil {
+		t.Fatalf("expected empty translation to fail")
+	}
+}
+
+func TestValidateManualEventPayloadSplitsErrorsAndWarnings(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	_, errs, _ := validateManualEventPayload(manualEventCreateRequest{Type: "nope"}, now)
+	if len(errs) != 3 || errs[0].Field != "baby_id" || errs[1].Field != "type" || errs[2].Field != "start_time" {
+		t.Fatalf("expected baby_id/type/start_time errors, got %+v", errs)
+	}
+
+	end := now.Add(20 * time.Hour)
+	event, errs, warnings := validateManualEventPayload(manualEventCreateRequest{
+		BabyID:    "baby-1",
+		Type:      "sleep",
+		StartTime: now.Add(time.Hour),
+		EndTime:   &end,
+	}, now)
+	if len(errs) != 0 || event.Type != "SLEEP" {
+		t.Fatalf("expected valid SLEEP event, got %+v %+v", event, errs)
+	}
+	codes := make([]string, 0, len(warnings))
+	for _, warning := range warnings {
+		codes = append(codes, warning.Code)
+	}
+	if strings.Join(codes, ",") != "long_duration,future_start" {
+		t.Fatalf("unexpected warnings: %v", codes)
+	}
+}
+
+func TestEventDuplicateWindowUsesConfigThenDefaults(t *testing.T) {
+	windows := parseEventDuplicateWindows([]string{"formula=120", "MEMO=30", "POO=0", "NOPE=5", "SLEEP=-1", "PEE"})
+	if len(windows) != 3 || windows["FORMULA"] != 2*time.Minute || windows["MEMO"] != 30*time.Second || windows["POO"] != 0 {
+		t.Fatalf("unexpected windows: %v", windows)
+	}
+
+	app := &App{cfg: config.Config{EventDuplicateWindows: []string{"POO=0"}}}
+	if got := app.eventDuplicateWindow("POO"); got != 0 {
+		t.Fatalf("expected configured POO window to be off, got %v", got)
+	}
+	if got := app.eventDuplicateWindow("FORMULA"); got != defaultEventDuplicateWindow {
+		t.Fatalf("expected default FORMULA window, got %v", got)
+	}
+	if got := app.eventDuplicateWindow("MEMO"); got != 0 {
+		t.Fatalf("expected MEMO to be off by default, got %v", got)
+	}
+}
+
+func TestValidateEventValueEnforcesPerTypeRules(t *testing.T) {
+	cases := []struct {
+		eventType string
+		value     map[string]any
+		field     string
+	}{
+		{"FORMULA", map[string]any{}, "value.ml"},
+		{"FORMULA", map[string]any{"ml": 0}, "value.ml"},
+		{"FORMULA", map[string]any{"amount_ml": "120"}, ""},
+		{"SLEEP", map[string]any{}, ""},
+		{"SLEEP", map[string]any{"duration_min": 2000}, "value.duration_min"},
+		{"GROWTH", map[string]any{"note": "garbage"}, "value"},
+		{"GROWTH", map[string]any{"weight_kg": 6.2, "height_cm": -1}, "value"},
+		{"GROWTH", map[string]any{"height_cm": 61.5}, ""},
+		{"MEDICATION", map[string]any{"dose": "2.5ml"}, "value.name"},
+		{"MEDICATION", map[string]any{"med_name": "acetaminophen"}, ""},
+		{"MEDICATION", map[string]any{"medication_name": "acetaminophen"}, ""},
+		{"MEDICATION", map[string]any{"medication_type": "fever reducer"}, ""},
+		{"MEMO", map[string]any{}, ""},
+	}
+	for _, tc := range cases {
+		issue := validateEventValue(tc.eventType, tc.value)
+		if tc.field == "" && issue != nil {
+			t.Fatalf("expected %s %v to pass, got %+v", tc.eventType, tc.value, issue)
+		}
+		if tc.field != "" && (issue == nil || issue.Field != tc.field) {
+			t.Fatalf("expected %s %v to fail on %s, got %+v", tc.eventType, tc.value, tc.field, issue)
+		}
+	}
+}
+
+func TestSmalltalkToneSignalsDriveStyleHint(t *testing.T) {
+	turns := []ChatTurn{
+		{Role: "user", Content: "오늘 너무 피곤해 ㅠㅠ 해줘"},
+		{Role: "assistant", Content: "고생 많으셨어요."},
+		{Role: "user", Content: "ㅋㅋ 고마워"},
+	}
+	signals := computeSmalltalkToneSignals(collectUserToneSamples(turns, "", 8))
+	if signals.SampleCount != 2 || signals.CasualScore != 2 || signals.FormalScore != 0 || !signals.HasHangulText {
+		t.Fatalf("unexpected tone signals: %+v", signals)
+	}
+	if signals.EmojiScore != 2 {
+		t.Fatalf("expected emoji-like markers in both samples, got %d", signals.EmojiScore)
+	}
+	hint := smalltalkStyleHintFromSignals(signals)
+	if hint != deriveSmalltalkStyleHint(turns, "") {
+		t.Fatalf("expected signal-based hint to match deriveSmalltalkStyleHint")
+	}
+	if !strings.Contains(hint, "구어체") {
+		t.Fatalf("expected casual Korean hint, got %q", hint)
+	}
+	if smalltalkStyleHintFromSignals(smalltalkToneSignals{}) != "" {
+		t.Fatalf("expected empty hint without samples")
+	}
+}
+
+func TestSanitizeSmalltalkAnswerCutsAtCleanBoundary(t *testing.T) {
+	kept := "오늘도 정말 고생 많으셨어요. 아기가 잘 자고 있다니 다행이에요!"
+	answer := "- " + kept + "\n- 저녁에는 따뜻한 차 한 잔 하면서 쉬어 보세요."
+	maxRunes := utf8.RuneCountInString(kept) + 5
+	if got := sanitizeSmalltalkAnswer(answer, maxRunes); got != kept {
+		t.Fatalf("expected reply to end after the last full sentence, got %q", got)
+	}
+
+	unpunctuated := "오늘도 정말 고생 많으셨어요 아기가 잘 자고 있다니 정말 다행이에요"
+	got := sanitizeSmalltalkAnswer(unpunctuated, 20)
+	if !utf8.ValidString(got) || !strings.HasSuffix(got, "...") {
+		t.Fatalf("expected valid UTF-8 ending in an ellipsis, got %q", got)
+	}
+	prefix := strings.TrimSuffix(got, "...")
+	if !strings.HasPrefix(unpunctuated, prefix) || unpunctuated[len(prefix)] != ' ' {
+		t.Fatalf("expected cut at a word boundary, got %q", got)
+	}
+	if utf8.RuneCountInString(prefix) > 20 {
+		t.Fatalf("expected at most 20 runes before the ellipsis, got %q", prefix)
+	}
+
+	if got := sanitizeSmalltalkAnswer(kept, smalltalkReplyRuneMax); got != kept {
+		t.Fatalf("expected short reply unchanged, got %q", got)
+	}
+}
+
+func TestResolveSmalltalkReplyMaxCharsFallsBackToDefault(t *testing.T) {
+	if got := resolveSmalltalkReplyMaxChars(nil); got != smalltalkReplyRuneMax {
+		t.Fatalf("expected default %d, got %d", smalltalkReplyRuneMax, got)
+	}
+	persona := map[string]any{"app_settings": map[string]any{"smalltalk_reply_max_chars": float64(160)}}
+	if got := resolveSmalltalkReplyMaxChars(persona); got != 160 {
+		t.Fatalf("expected 160, got %d", got)
+	}
+	persona = map[string]any{"app_settings": map[string]any{"smalltalk_reply_max_chars": float64(5)}}
+	if got := resolveSmalltalkReplyMaxChars(persona); got != smalltalkReplyRuneMax {
+		t.Fatalf("expected out-of-range value to fall back to %d, got %d", smalltalkReplyRuneMax, got)
+	}
+}
+
+func TestResolveDurationOverrideBoundsToInterval(t *testing.T) {
+	start := time.Date(2026, 3, 1, 1, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Hour)
+
+	if got, err := resolveDurationOverride(90, start, end); err != nil || got != 90 {
+		t.Fatalf("expected shorter override to be accepted, got %v %v", got, err)
+	}
+	if got, err := resolveDurationOverride(200, start, end); err != nil || got != 200 {
+		t.Fatalf("expected override within slack to be accepted, got %v %v", got, err)
+	}
+	if _, err := resolveDurationOverride(0, start, end); err == nil {
+		t.Fatalf("expected non-positive override to fail")
+	}
+	if _, err := resolveDurationOverride(241, start, end); err == nil {
+		t.Fatalf("expected override past the slack to fail")
+	}
+}
+
+func TestResolveRequestedRawWindowValidatesAndSelectsRange(t *testing.T) {
+	now := time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC)
+	start, end, err := resolveRequestedRawWindow("2026-02-01", "2026-02-28", "+09:00", now)
+	if err != nil {
+		t.Fatalf("expected valid window, got %v", err)
+	}
+	if !start.Equal(time.Date(2026, 1, 31, 15, 0, 0, 0, time.UTC)) || !end.Equal(time.Date(2026, 2, 28, 15, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected local-day window, got %s - %s", start, end)
+	}
+	if _, clamped, err := resolveRequestedRawWindow("2026-03-10", "2026-03-20", "", now); err != nil || !clamped.Equal(now) {
+		t.Fatalf("expected window end clamped to now, got %s err=%v", clamped, err)
+	}
+	if start, end, err := resolveRequestedRawWindow("", "", "", now); err != nil || !start.IsZero() || !end.IsZero() {
+		t.Fatalf("expected no window without from/to, got %s - %s err=%v", start, end, err)
+	}
+	for _, tc := range [][2]string{
+		{"2026-02-01", ""},
+		{"2026-02-28", "2026-02-01"},
+		{"2025-11-01", "2026-02-28"},
+		{"2026-04-01", "2026-04-02"},
+		{"02/01/2026", "2026-02-28"},
+	} {
+		if _, _, err := resolveRequestedRawWindow(tc[0], tc[1], "", now); err == nil {
+			t.Fatalf("expected from=%q to=%q to be rejected", tc[0], tc[1])
+		}
+	}
+
+	scope := chatScopeOverride{RawStart: start, RawEnd: end}
+	selection := resolveChatContextSelection("지난달 수유 요약해줘", aiIntentDataQuery, now, scope)
+	if selection.Mode != chatContextModeRequestedRangeRaw || !selection.RawStart.Equal(start) || !selection.RawEnd.Equal(end) {
+		t.Fatalf("expected requested range to win for data_query, got %+v", selection)
+	}
+	if medical := resolveChatContextSelection("지난달 수유 요약해줘", aiIntentMedicalRelated, now, scope); medical.Mode == chatContextModeRequestedRangeRaw {
+		t.Fatalf("expected requested range to apply to data_query only")
+	}
+}
+
+func TestSplitChatFactsBlockParsesAndStripsBlock(t *testing.T) {
+	answer := "## 답변\n총 **3회** 먹었어요.\n```json\n{\"facts\":[{\"metric\":\"feed_count\",\"value\":\"3\",\"unit\":\"회\",\"period\":\"2026-02-15\"},{\"metric\":\"\",\"value\":1}]}\n```"
+	prose, facts, ok := splitChatFactsBlock(answer)
+	if !ok || prose != "## 답변\n총 **3회** 먹었어요." {
+		t.Fatalf("unexpected split: ok=%v prose=%q", ok, prose)
+	}
+	if len(facts) != 1 || facts[0] != (chatFact{Metric: "feed_count", Value: 3, Unit: "회", Period: "2026-02-15"}) {
+		t.Fatalf("unexpected facts: %+v", facts)
+	}
+
+	prose, facts, ok = splitChatFactsBlock("답변입니다.\n```json\n{\"facts\": oops}\n```")
+	if ok || facts != nil || prose != "답변입니다." {
+		t.Fatalf("expected a broken block to be dropped, got ok=%v facts=%v prose=%q", ok, facts, prose)
+	}
+	if prose, _, ok := splitChatFactsBlock("블록 없는 답변"); ok || prose != "블록 없는 답변" {
+		t.Fatalf("expected prose-only answer untouched, got %q", prose)
+	}
+}
+
+func TestCompactRawContextLinesFoldsOlderEventsByType(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	events := make([]rawContextEvent, 0, 200)
+	for idx := 0; idx < 200; idx++ {
+		start := base.Add(-time.Duration(idx) * 30 * time.Minute)
+		if idx%2 == 0 {
+			events = append(events, newRawContextEvent(fmt.Sprintf("f%d", idx), "FORMULA", start, nil, `{"ml":100}`, `{}`))
+		} else {
+			end := start.Add(20 * time.Minute)
+			events = append(events, newRawContextEvent(fmt.Sprintf("s%d", idx), "SLEEP", start, &end, `{}`, `{}`))
+		}
+	}
+
+	lines, summarized := compactRawContextLines(events, 50)
+	if !summarized || len(lines) > 50 {
+		t.Fatalf("expected at most 50 summarized lines, got %d (summarized=%v)", len(lines), summarized)
+	}
+	if !strings.HasSuffix(lines[0], "| f0") {
+		t.Fatalf("expected the newest event first and verbatim, got %q", lines[0])
+	}
+	// 50 lines = 47 verbatim + note + FORMULA and SLEEP summaries.
+	if !strings.Contains(lines[47], "153건") {
+		t.Fatalf("expected a note for the 153 folded events, got %q", lines[47])
+	}
+	if !strings.Contains(lines[48], "| FORMULA | 76건, 총 7600ml") || !strings.Contains(lines[49], "| SLEEP | 77건, 총 1540분") {
+		t.Fatalf("unexpected summary lines: %q / %q", lines[48], lines[49])
+	}
+
+	if lines, summarized := compactRawContextLines(events[:10], 50); summarized || len(lines) != 10 {
+		t.Fatalf("expected short windows untouched, got %d lines (summarized=%v)", len(lines), summarized)
+	}
+	if lines, summarized := compactRawContextLines(events, 0); summarized || len(lines) != 200 {
+		t.Fatalf("expected a zero cap to keep every line, got %d", len(lines))
+	}
+}
+
+func TestFutureRequestedDateIsFlaggedWithoutQuerying(t *testing.T) {
+	now := time.Date(2026, 2, 20, 9, 0, 0, 0, time.UTC)
+	selection := resolveChatContextSelection("2027-01-01에 무슨 일 있었어?", aiIntentDataQuery, now, chatScopeOverride{})
+	if selection.Mode != chatContextModeRequestedDateFuture {
+		t.Fatalf("expected requested_date_future, got %q", selection.Mode)
+	}
+	if today := resolveChatContextSelection("오늘 수유 몇 번 했어?", aiIntentDataQuery, now, chatScopeOverride{}); today.Mode != chatContextModeRequestedDateRaw {
+		t.Fatalf("expected today to stay requested_date_raw, got %q", today.Mode)
+	}
+	tomorrow := time.Date(2026, 2, 21, 0, 0, 0, 0, time.UTC)
+	scoped := resolveChatContextSelection("ignored question", aiIntentDataQuery, now, chatScopeOverride{Mode: "day", AnchorDate: &tomorrow})
+	if scoped.Mode != chatContextModeRequestedDateFuture {
+		t.Fatalf("expected future day anchor to be flagged, got %q", scoped.Mode)
+	}
+
+	// An App without a pool panics on any query, so reaching the result
+	// proves the future branch never touches events.
+	app := &App{}
+	result, err := app.buildChatContextForSelection(context.Background(), "user-1", "child-1", "2027-01-01", aiIntentDataQuery, now, selection, childProfileSnapshot{Name: "하늘"}, "2025-12-01")
+	if err != nil {
+		t.Fatalf("build future context: %v", err)
+	}
+	if inFuture, _ := result.Meta["requested_date_in_future"].(bool); !inFuture {
+		t.Fatalf("expected requested_date_in_future flag, got %#v", result.Meta["requested_date_in_future"])
+	}
+	if got := result.Meta["requested_date_utc"]; got != "2027-01-01" {
+		t.Fatalf("unexpected requested_date_utc: %#v", got)
+	}
+	if ids, _ := result.Meta["evidence_event_ids"].([]string); len(ids) != 0 {
+		t.Fatalf("expected no evidence events, got %v", ids)
+	}
+
+	prompt := buildChatSystemPrompt(aiIntentDataQuery, "neutral", result, true, "", "", "")
+	if !strings.Contains(prompt, "아직 오지 않은 미래 날짜") {
+		t.Fatalf("expected future-date directive in system prompt")
+	}
+}
+
+func TestResolveFieldSeriesKeysAcceptsAliases(t *testing.T) {
+	field, keys, ok := resolveFieldSeriesKeys(" Temp_C ")
+	if !ok || field != "temperature_c" || len(keys) != 3 {
+		t.Fatalf("expected temp_c to resolve to temperature_c, got %q %v %v", field, keys, ok)
+	}
+	if field, keys, ok := resolveFieldSeriesKeys("spo2"); !ok || field != "spo2" || len(keys) != 1 {
+		t.Fatalf("expected unknown field to be read as-is, got %q %v %v", field, keys, ok)
+	}
+	if _, _, ok := resolveFieldSeriesKeys("ml; drop"); ok {
+		t.Fatalf("expected malformed field to be rejected")
+	}
+
+	start := time.Date(2026, 2, 20, 9, 0, 0, 0, time.UTC)
+	if _, found := fieldSeriesValue("temperature_c", []string{"temperature_c", "temp_c"}, map[string]any{"symptom": "cough"}, start, nil); found {
+		t.Fatalf("expected missing field to produce no point")
+	}
+	end := start.Add(45 * time.Minute)
+	if value, found := fieldSeriesValue("duration_min", []string{"duration_min"}, map[string]any{}, start, &end); !found || value != 45 {
+		t.Fatalf("expected duration from interval, got %v %v", value, found)
+	}
+}
+
+type countingStubAIClient struct {
+	answer string
+	calls  *int
+}
+
+func (s countingStubAIClient) Query(_ context.Context, req AIModelRequest) (AIModelResponse, error) {
+	*s.calls++
+	return AIModelResponse{Answer: s.answer, Model: req.Model}, nil
+}
+
+func TestSessionMemoryCompressionOnlyPastThreshold(t *testing.T) {
+	calls := 0
+	app := &App{
+		cfg: config.Config{ChatMemoryCompressEvery: 10},
+		ai:  countingStubAIClient{answer: "- 보호자가 밤잠 패턴을 걱정함", calls: &calls},
+	}
+
+	short := "- User: 오늘 분유 120ml 먹었어"
+	if summary, compressedCount := app.maybeCompressSessionMemory(context.Background(), "s1", short, 30, 0); summary != short || compressedCount != 0 || calls != 0 {
+		t.Fatalf("expected short summary to be left alone, got %q count=%d calls=%d", summary, compressedCount, calls)
+	}
+
+	long := strings.Repeat("- User: 밤에 자주 깨요\n", chatMemoryCompressTriggerChars/10)
+	summary, compressedCount := app.maybeCompressSessionMemory(context.Background(), "s1", long, 40, 0)
+	if calls != 1 || summary != "- 보호자가 밤잠 패턴을 걱정함" || compressedCount != 40 {
+		t.Fatalf("expected compression past threshold, got %q count=%d calls=%d", summary, compressedCount, calls)
+	}
+
+	if _, compressedCount := app.maybeCompressSessionMemory(context.Background(), "s1", long, 45, 40); compressedCount != 40 || calls != 1 {
+		t.Fatalf("expected cadence to skip compression, got count=%d calls=%d", compressedCount, calls)
+	}
+	if _, compressedCount := app.maybeCompressSessionMemory(context.Background(), "s1", long, 50, 40); compressedCount != 50 || calls != 2 {
+		t.Fatalf("expected compression once cadence elapsed, got count=%d calls=%d", compressedCount, calls)
+	}
+
+	app.cfg.ChatMemoryCompressEvery = 0
+	app.maybeCompressSessionMemory(context.Background(), "s1", long, 90, 50)
+	if calls != 2 {
+		t.Fatalf("expected compression disabled at 0, got calls=%d", calls)
+	}
+}
+
+func TestZeroDurationSleepIssueFollowsConfiguredMode(t *testing.T) {
+	start := time.Date(2026, 2, 20, 9, 0, 0, 0, time.UTC)
+	tap := start.Add(30 * time.Second)
+	nap := start.Add(40 * time.Minute)
+
+	app := &App{cfg: config.Config{SleepZeroDurationMode: "reject"}}
+	if issue, rejected := app.zeroDurationSleepIssue("SLEEP", nil, start, &tap); issue == nil || !rejected {
+		t.Fatalf("expected sub-minute sleep to be rejected, got %v %v", issue, rejected)
+	}
+	if issue, _ := app.zeroDurationSleepIssue("SLEEP", nil, start, &nap); issue != nil {
+		t.Fatalf("expected a real nap to pass, got %v", issue)
+	}
+	if issue, _ := app.zeroDurationSleepIssue("FORMULA", nil, start, &tap); issue != nil {
+		t.Fatalf("expected non-sleep events to pass, got %v", issue)
+	}
+	if issue, _ := app.zeroDurationSleepIssue("SLEEP", map[string]any{"duration_min": 5}, start, &tap); issue != nil {
+		t.Fatalf("expected stored duration_min to win over end-start, got %v", issue)
+	}
+
+	app.cfg.SleepZeroDurationMode = "flag"
+	issue, rejected := app.zeroDurationSleepIssue("SLEEP", nil, start, &start)
+	if issue == nil || rejected || issue.Code != "zero_duration" {
+		t.Fatalf("expected flag mode to warn instead of reject, got %v %v", issue, rejected)
+	}
+	metadata := map[string]any{}
+	markZeroDurationSleep(metadata, true)
+	if metadata["zero_duration_sleep"] != true {
+		t.Fatalf("expected zero_duration_sleep flag, got %v", metadata)
+	}
+}
+
+func TestFeedingEfficiencySkipsIncompleteEventsAndWeightsByDuration(t *testing.T) {
+	start := time.Date(2026, 2, 20, 9, 0, 0, 0, time.UTC)
+	end := start.Add(20 * time.Minute)
+	if _, _, ok := feedingEfficiencySample(map[string]any{}, start, &end); ok {
+		t.Fatalf("expected event without ml to be skipped")
+	}
+	if _, _, ok := feedingEfficiencySample(map[string]any{"ml": 60}, start, nil); ok {
+		t.Fatalf("expected event without duration to be skipped")
+	}
+	ml, duration, ok := feedingEfficiencySample(map[string]any{"amount_ml": 60}, start, &end)
+	if !ok || ml != 60 || duration != 20 {
+		t.Fatalf("expected 60ml over 20min, got %v %v %v", ml, duration, ok)
+	}
+
+	summary := summarizeFeedingEfficiency([]feedingEfficiencyPoint{
+		{ML: 60, DurationMin: 20},
+		{ML: 10, DurationMin: 2},
+	})
+	if summary.SampleCount != 2 || summary.AvgMLPerMin == nil || *summary.AvgMLPerMin != 3.2 {
+		t.Fatalf("expected weighted 70ml/22min=3.2, got %+v", summary)
+	}
+	if empty := summarizeFeedingEfficiency(nil); empty.AvgMLPerMin != nil {
+		t.Fatalf("expected no average without samples, got %v", *empty.AvgMLPerMin)
+	}
+}
+
+func TestNormalizeStatsDatesDedupesSortsAndCaps(t *testing.T) {
+	dates, err := normalizeStatsDates([]string{"2026-02-17", "2026-02-10", "2026-02-17"})
+	if err != nil {
+		t.Fatalf("normalize dates: %v", err)
+	}
+	if len(dates) != 2 || dates[0].Format("2006-01-02") != "2026-02-10" {
+		t.Fatalf("expected two sorted dates, got %v", dates)
+	}
+	if _, err := normalizeStatsDates([]string{"2026-02-10", "Feb 17"}); err == nil || !strings.Contains(err.Error(), "dates[1]") {
+		t.Fatalf("expected index in parse error, got %v", err)
+	}
+
+	tooMany := make([]string, 0, statsForDatesMaxDates+1)
+	for i := 0; i <= statsForDatesMaxDates; i++ {
+		tooMany = append(tooMany, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, i).Format("2006-01-02"))
+	}
+	if _, err := normalizeStatsDates(tooMany); err == nil {
+		t.Fatalf("expected more than %d dates to be rejected", statsForDatesMaxDates)
+	}
+}
+
+func TestNapNightConsolidationTrend(t *testing.T) {
+	ratioDays := func(ratios ...float64) []napNightDay {
+		days := make([]napNightDay, 0, len(ratios)+1)
+		days = append(days, napNightDay{Date: "no-night-sleep"})
+		for _, ratio := range ratios {
+			value := ratio
+			days = append(days, napNightDay{Ratio: &value})
+		}
+		return days
+	}
+
+	if trend, _, _ := napNightConsolidationTrend(ratioDays(0.5, 0.4, 0.3)); trend != "insufficient_data" {
+		t.Fatalf("expected insufficient_data below %d ratio days, got %q", napNightMinTrendDays, trend)
+	}
+	trend, earlier, recent := napNightConsolidationTrend(ratioDays(0.6, 0.6, 0.4, 0.3))
+	if trend != "consolidating" || earlier == nil || *earlier != 0.6 || recent == nil || *recent != 0.35 {
+		t.Fatalf("expected consolidating 0.6 -> 0.35, got %q %v %v", trend, earlier, recent)
+	}
+	if trend, _, _ := napNightConsolidationTrend(ratioDays(0.3, 0.3, 0.31, 0.3)); trend != "stable" {
+		t.Fatalf("expected stable, got %q", trend)
+	}
+	if trend, _, _ := napNightConsolidationTrend(ratioDays(0.3, 0.3, 0.5, 0.5)); trend != "fragmenting" {
+		t.Fatalf("expected fragmenting, got %q", trend)
+	}
+
+	afternoon := time.Date(2026, 2, 20, 14, 0, 0, 0, time.UTC)
+	if band := sleepBandForEvent(map[string]any{}, afternoon, 60, defaultNapStartHour, defaultNightStartHour); band != "nap" {
+		t.Fatalf("expected daytime sleep to be a nap, got %q", band)
+	}
+	if band := sleepBandForEvent(map[string]any{"sleep_type": "night"}, afternoon, 60, defaultNapStartHour, defaultNightStartHour); band != "night" {
+		t.Fatalf("expected explicit sleep_type to win, got %q", band)
+	}
+}
+
+func TestSleepBandForEventUsesMajorityOfInterval(t *testing.T) {
+	lateNap := time.Date(2026, 2, 20, 17, 30, 0, 0, time.UTC)
+	if band := sleepBandForEvent(map[string]any{}, lateNap, 120, defaultNapStartHour, defaultNightStartHour); band != "night" {
+		t.Fatalf("expected 17:30-19:30 sleep to count as night, got %q", band)
+	}
+	if band := sleepBandForEvent(map[string]any{}, lateNap, 120, defaultNapStartHour, 20); band != "nap" {
+		t.Fatalf("expected a 20:00 night start to make it a nap, got %q", band)
+	}
+
+	earlyWake := time.Date(2026, 2, 20, 4, 0, 0, 0, time.UTC)
+	if band := sleepBandForEvent(map[string]any{}, earlyWake, 180, defaultNapStartHour, defaultNightStartHour); band != "night" {
+		t.Fatalf("expected 04:00-07:00 sleep to count as night, got %q", band)
+	}
+	// A tie falls back to the start hour.
+	if band := sleepBandForEvent(map[string]any{}, time.Date(2026, 2, 20, 17, 0, 0, 0, time.UTC), 120, defaultNapStartHour, defaultNightStartHour); band != "nap" {
+		t.Fatalf("expected a 17:00-19:00 tie to follow the start hour, got %q", band)
+	}
+
+	nap, night := napNightHoursFromSettings(map[string]any{"nap_start_hour": float64(7), "night_start_hour": float64(19)})
+	if nap != 7 || night != 19 {
+		t.Fatalf("expected 7/19 from settings, got %d/%d", nap, night)
+	}
+	if nap, night := napNightHoursFromSettings(map[string]any{"nap_start_hour": float64(20)}); nap != defaultNapStartHour || night != defaultNightStartHour {
+		t.Fatalf("expected invalid settings to fall back to defaults, got %d/%d", nap, night)
+	}
+}
+
+func TestAICapabilitiesFallbackRendersFromCapabilityList(t *testing.T) {
+	for _, language := range []string{"ko", "en"} {
+		items := localizedAICapabilities(language)
+		if len(items) != len(aiCapabilities) {
+			t.Fatalf("%s: expected %d capabilities, got %d", language, len(aiCapabilities), len(items))
+		}
+		fallback := aiCapabilitiesFallbackAnswer(language)
+		for _, item := range items {
+			examples, _ := item["examples"].([]string)
+			if item["title"] == "" || len(examples) == 0 {
+				t.Fatalf("%s: capability %v is missing text", language, item["key"])
+			}
+			if !strings.Contains(fallback, item["title"].(string)) {
+				t.Fatalf("%s: fallback %q does not mention %q", language, fallback, item["title"])
+			}
+		}
+	}
+	if normalizeCapabilityLanguage("es") != "en" || normalizeCapabilityLanguage("") != "ko" {
+		t.Fatalf("unexpected language normalization")
+	}
+}
+
+func TestMaxOutputTokensForIntentVariesByIntent(t *testing.T) {
+	app := &App{}
+	smalltalk := app.maxOutputTokensForIntent(aiIntentSmalltalk)
+	dataQuery := app.maxOutputTokensForIntent(aiIntentDataQuery)
+	if smalltalk <= 0 || dataQuery <= smalltalk {
+		t.Fatalf("expected a small smalltalk cap and a larger data_query cap, got %d and %d", smalltalk, dataQuery)
+	}
+	if got := app.maxOutputTokensForIntent(aiIntentMedicalRelated); got != 0 {
+		t.Fatalf("expected unlisted intents to use the client default, got %d", got)
+	}
+
+	app.cfg.AIIntentMaxOutputTokens = []string{"smalltalk=250", "medical_related=900", "unknown=10", "data_query=x"}
+	if got := app.maxOutputTokensForIntent(aiIntentSmalltalk); got != 250 {
+		t.Fatalf("expected configured smalltalk cap 250, got %d", got)
+	}
+	if got := app.maxOutputTokensForIntent(aiIntentMedicalRelated); got != 900 {
+		t.Fatalf("expected configured medical_related cap 900, got %d", got)
+	}
+	if got := app.maxOutputTokensForIntent(aiIntentDataQuery); got != dataQuery {
+		t.Fatalf("expected malformed entry to keep the built-in data_query cap, got %d", got)
+	}
+}
+
+func TestFormulaPrepUsesMostSpecificRatioAndWholeScoops(t *testing.T) {
+	ratio, source := resolveFormulaPrepRatio(" Similac ", "Pro-Advance", "standard")
+	if source != "brand" || ratio.WaterMLPerScoop != 60 {
+		t.Fatalf("expected brand ratio of 60 ml per scoop, got %v from %s", ratio.WaterMLPerScoop, source)
+	}
+	if _, source := resolveFormulaPrepRatio("", "", "AR"); source != "formula_type" {
+		t.Fatalf("expected thickened formula_type ratio, got %s", source)
+	}
+	ratio, source = resolveFormulaPrepRatio("Unknown Brand", "", "standard")
+	if source != "generic_standard" || ratio != formulaPrepGenericRatio {
+		t.Fatalf("expected generic fallback, got %+v from %s", ratio, source)
+	}
+
+	plan := planFormulaPrep(100, ratio)
+	if plan.Scoops != 4 || plan.WaterML != 120 || plan.PowderG != 17.6 {
+		t.Fatalf("expected 100 ml to round up to 4 scoops / 120 ml / 17.6 g, got %+v", plan)
+	}
+}
+
+func TestDetectScheduleShiftNeedsTwoAgreeingSignals(t *testing.T) {
+	day := func(bedtime, wake, firstFeed int) scheduleShiftDay {
+		return scheduleShiftDay{BedtimeMin: &bedtime, WakeMin: &wake, FirstFeedMin: &firstFeed}
+	}
+	baseline := []scheduleShiftDay{
+		day(20*60, 6*60, 6*60+30),
+		day(20*60+15, 6*60+15, 6*60+40),
+		day(19*60+45, 5*60+50, 6*60+20),
+	}
+
+	// Bedtime crosses midnight: 20:00 -> 23:00 is +3h, not -21h.
+	travel := []scheduleShiftDay{day(23*60, 9*60, 9*60+30), day(23*60+10, 9*60+5, 9*60+35)}
+	result := detectScheduleShift(baseline, travel)
+	if !result.Detected || result.Direction != "later" || result.ShiftMin != 180 {
+		t.Fatalf("expected a 180 min later shift, got %+v", result)
+	}
+
+	drift := []scheduleShiftDay{day(20*60+30, 6*60+20, 6*60+50), day(20*60+20, 6*60+30, 7*60)}
+	if result := detectScheduleShift(baseline, drift); result.Detected || result.Direction != "none" {
+		t.Fatalf("expected normal drift to stay undetected, got %+v", result)
+	}
+
+	onlyBedtime := []scheduleShiftDay{day(22*60+30, 6*60, 6*60+30), day(22*60+30, 6*60, 6*60+30)}
+	if result := detectScheduleShift(baseline, onlyBedtime); result.Detected {
+		t.Fatalf("expected a single moved signal to stay undetected, got %+v", result)
+	}
+
+	if result := detectScheduleShift(baseline, travel[:1]); result.Detected || result.Signals[0].ShiftMin != nil {
+		t.Fatalf("expected too few recent days to skip the comparison, got %+v", result)
+	}
+}
+
+func TestMonthlyRollupTooSparseChecksEventsAndDays(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	app := &App{cfg: config.Config{ChatMonthlyMinEvents: 20, ChatMonthlyMinHistoryDays: 7}}
+	twoDaysAgo := now.AddDate(0, 0, -2)
+	monthAgo := now.AddDate(0, -1, 0)
+	if !app.monthlyRollupTooSparse(50, &twoDaysAgo, now) {
+		t.Fatalf("expected two days of history to be too sparse")
+	}
+	if !app.monthlyRollupTooSparse(5, &monthAgo, now) {
+		t.Fatalf("expected five events to be too sparse")
+	}
+	if !app.monthlyRollupTooSparse(0, nil, now) {
+		t.Fatalf("expected no history to be too sparse")
+	}
+	if app.monthlyRollupTooSparse(50, &monthAgo, now) {
+		t.Fatalf("expected a month of regular records to keep the rollup")
+	}
+	if (&App{}).monthlyRollupTooSparse(0, nil, now) {
+		t.Fatalf("expected zero thresholds to disable the check")
+	}
+}
+
+func TestBabyMilestonesUsesKoreanDayCountAndMonthEndClamping(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	recent, today, upcoming := babyMilestones(date(2026, 1, 31), date(2026, 2, 28), 40)
+	if len(recent) != 0 || len(today) != 1 || today[0].Key != "1_months" {
+		t.Fatalf("expected the clamped 1 month milestone today, got recent=%+v today=%+v", recent, today)
+	}
+	if len(upcoming) != 1 || upcoming[0].Key != "2_months" || upcoming[0].Date != "2026-03-31" || upcoming[0].DaysFromToday != 31 {
+		t.Fatalf("expected 2 months on 2026-03-31, got %+v", upcoming)
+	}
+
+	recent, _, _ = babyMilestones(date(2025, 3, 1), date(2025, 6, 10), 30)
+	if len(recent) != 2 || recent[0].Key != "100_days" || recent[0].Date != "2025-06-08" || recent[0].LabelKo != "백일" {
+		t.Fatalf("expected 백일 on 2025-06-08 counting the birth day as day 1, got %+v", recent)
+	}
+	if recent[1].Key != "3_months" || recent[1].DaysFromToday != -9 {
+		t.Fatalf("expected 3 months 9 days ago, got %+v", recent[1])
+	}
+
+	_, _, upcoming = babyMilestones(date(2024, 2, 29), date(2025, 2, 20), 30)
+	if len(upcoming) != 1 || upcoming[0].Key != "birthday_1" || upcoming[0].LabelKo != "돌" || upcoming[0].Date != "2025-02-28" {
+		t.Fatalf("expected 돌 on 2025-02-28 for a leap-day birth, got %+v", upcoming)
+	}
+}
+
+func TestComputeNextNapWindowBlendsAgeRangeAndObservedGaps(t *testing.T) {
+	lastSleepEnd := time.Date(2026, 4, 2, 1, 0, 0, 0, time.UTC)
+
+	// 4 months: age range 75-120 min. A 300 min gap is a missed log.
+	result := computeNextNapWindow(120, lastSleepEnd, []float64{100, 110, 105, 300})
+	if result.Unstable || result.ObservedGapCount != 3 || result.TargetWakeMin != 105 {
+		t.Fatalf("expected a stable 105 min target from three gaps, got %+v", result)
+	}
+	if !result.Earliest.Equal(lastSleepEnd.Add(90*time.Minute)) || !result.Latest.Equal(lastSleepEnd.Add(120*time.Minute)) {
+		t.Fatalf("expected a 90-120 min window, got %s - %s", result.Earliest, result.Latest)
+	}
+
+	result = computeNextNapWindow(120, lastSleepEnd, []float64{119, 119, 119})
+	if result.TargetWakeMin != 119 || !result.Latest.Equal(lastSleepEnd.Add(120*time.Minute)) {
+		t.Fatalf("expected the window to stay inside the age range, got %+v", result)
+	}
+
+	result = computeNextNapWindow(20, lastSleepEnd, []float64{50})
+	if !result.Unstable || result.TargetWakeMin != 52 || result.ObservedAvgMin == nil || *result.ObservedAvgMin != 50 {
+		t.Fatalf("expected thin data to fall back to the newborn age range, got %+v", result)
+	}
+}
+
+func TestAIHealthCountersClassifyAndWindowOutcomes(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 30, 0, time.UTC)
+	counters := newAIHealthCounters()
+	app := &App{aiHealth: counters}
+
+	counters.record(classifyAIProviderError(nil), now.Add(-2*time.Hour))
+	counters.record(classifyAIProviderError(nil), now.Add(-10*time.Minute))
+	counters.record(classifyAIProviderError(errors.New("openai request failed: context deadline exceeded")), now)
+	counters.record(classifyAIProviderError(errors.New("openai response answer is empty")), now)
+
+	counts := counters.counts(time.Hour, now)
+	if counts[aiOutcomeSuccess] != 1 || counts[aiOutcomeTimeout] != 1 || counts[aiOutcomeEmptyAnswer] != 1 {
+		t.Fatalf("expected one success, timeout and empty answer in the last hour, got %v", counts)
+	}
+	if counts := counters.counts(3*time.Hour, now); counts[aiOutcomeSuccess] != 2 {
+		t.Fatalf("expected the older success inside a 3h window, got %v", counts)
+	}
+	if classifyAIProviderError(errors.New("boom")) != aiOutcomeUnclassified {
+		t.Fatalf("expected unknown errors to be unclassified")
+	}
+
+	// Stub-built apps without counters must not panic.
+	(&App{}).recordAIOutcome(errors.New("openai responses error status=500"))
+	app.recordAIOutcome(errors.New("openai responses error status=500"))
+	if counts := counters.counts(time.Minute, time.Now()); counts[aiOutcomeProviderError] != 1 {
+		t.Fatalf("expected recordAIOutcome to count a provider error, got %v", counts)
+	}
+}
+
+func TestSummarizeSessionTurnsFallsBackToLines(t *testing.T) {
+	turns := []ChatTurn{
+		{Role: "user", Content: "어젯밤에 3번 깼어"},
+		{Role: "assistant", Content: "깬 시간을 기록해 두면 패턴을 볼 수 있어요."},
+	}
+	mechanical := buildSessionMemorySummary("", turns)
+
+	off := &App{ai: intentRouterStubAIClient{answer: "요약"}}
+	if summary, response := off.summarizeSessionTurns(context.Background(), "s1", "", turns); summary != mechanical || response != nil {
+		t.Fatalf("expected line summary when disabled, got %q response=%v", summary, response)
+	}
+
+	broken := &App{
+		cfg: config.Config{ChatMemoryAISummary: true},
+		ai:  intentRouterStubAIClient{err: errors.New("provider down")},
+	}
+	if summary, response := broken.summarizeSessionTurns(context.Background(), "s1", "", turns); summary != mechanical || response != nil {
+		t.Fatalf("expected line summary when the AI call fails, got %q response=%v", summary, response)
+	}
+
+	on := &App{
+		cfg: config.Config{ChatMemoryAISummary: true},
+		ai:  intentRouterStubAIClient{answer: " 아이가 어젯밤 3번 깼고, 깬 시간을 기록하기로 했다. "},
+	}
+	summary, response := on.summarizeSessionTurns(context.Background(), "s1", "", turns)
+	if summary != "아이가 어젯밤 3번 깼고, 깬 시간을 기록하기로 했다." || response == nil || response.Model != chatDailyModel {
+		t.Fatalf("expected AI paragraph summary, got %q response=%v", summary, response)
+	}
+}
+
+func TestChatSystemPromptFollowsPreferredLanguage(t *testing.T) {
+	defaultPrompt := buildChatSystemPrompt(aiIntentSmalltalk, "neutral", chatContextResult{}, false, "", "", "")
+	if !strings.Contains(defaultPrompt, "기본 언어는 한국어다") {
+		t.Fatalf("expected Korean default language line")
+	}
+	if korean := buildChatSystemPrompt(aiIntentSmalltalk, "neutral", chatContextResult{}, false, "", "", "ko"); korean != defaultPrompt {
+		t.Fatalf("expected a Korean preference to keep the default prompt")
+	}
+	english := buildChatSystemPrompt(aiIntentSmalltalk, "neutral", chatContextResult{}, false, "", "", "en")
+	if strings.Contains(english, "기본 언어는 한국어다") || !strings.Contains(english, "모든 답변을 English로 작성한다") {
+		t.Fatalf("expected an English language line, got %s", english)
+	}
+}
+
+func TestParseAIIntentRouterResultReadsConfidence(t *testing.T) {
+	intent, confidence, ok := parseAIIntentRouterResult("```json\n{\"intent\":\"medical_related\",\"confidence\":0.82}\n```")
+	if !ok || intent != aiIntentMedicalRelated || confidence == nil || *confidence != 0.82 {
+		t.Fatalf("expected medical_related at 0.82, got %q %v %v", intent, confidence, ok)
+	}
+	if _, confidence, ok := parseAIIntentRouterResult(`{"intent":"smalltalk","confidence":7}`); !ok || confidence != nil {
+		t.Fatalf("expected out-of-range confidence to be dropped, got %v %v", confidence, ok)
+	}
+	if _, _, ok := parseAIIntentRouterResult(`{"intent":"weather"}`); ok {
+		t.Fatalf("expected unknown intent to be rejected")
+	}
+}
+
+func TestCharRatioTokenEstimatorCountsPromptAndCap(t *testing.T) {
+	usage := charRatioTokenEstimator{}.EstimateUsage(AIModelRequest{
+		SystemPrompt: "abcdefgh",
+		Conversation: []ChatTurn{{Role: "user", Content: "수유"}},
+		UserPrompt:   "잠 abc",
+	}, 400)
+	// 8 ASCII chars -> 2, "수유" -> 2, "잠 abc" -> 1 rune + 4 ASCII chars -> 2.
+	if usage.PromptTokens != 6 || usage.CompletionTokens != 400 || usage.TotalTokens != 406 {
+		t.Fatalf("unexpected estimate: %+v", usage)
+	}
+}
+
+func TestCalculateWeightedNextFeedingETADropsOvernightGap(t *testing.T) {
+	now := time.Date(2026, 2, 15, 13, 0, 0, 0, time.UTC)
+	feedings := []time.Time{
+		time.Date(2026, 2, 14, 22, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 15, 5, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 15, 8, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 15, 10, 30, 0, 0, time.UTC),
+		time.Date(2026, 2, 15, 12, 30, 0, 0, time.UTC),
+	}
+
+	mean := calculateNextFeedingETA(feedings, now)
+	if mean.AverageIntervalMinutes == nil || *mean.AverageIntervalMinutes != 218 {
+		t.Fatalf("expected mean interval 218, got %+v", mean.AverageIntervalMinutes)
+	}
+
+	// 420 is dropped; (120*1 + 150*0.7 + 180*0.49) / 2.19 = 143.
+	weighted := calculateWeightedNextFeedingETA(feedings, now)
+	if weighted.Unstable || weighted.AverageIntervalMinutes == nil || *weighted.AverageIntervalMinutes != 143 {
+		t.Fatalf("expected weighted interval 143, got %+v", weighted.AverageIntervalMinutes)
+	}
+	if weighted.ETAMinutes == nil || *weighted.ETAMinutes != 113 {
+		t.Fatalf("expected weighted eta 113, got %+v", weighted.ETAMinutes)
+	}
+
+	if unstable := calculateWeightedNextFeedingETA(feedings[:1], now); !unstable.Unstable {
+		t.Fatalf("expected unstable result when fewer than 2 feedings")
+	}
+}
+
+func TestCalculateFeedingIntervalStats(t *testing.T) {
+	now := time.Date(2026, 2, 15, 13, 0, 0, 0, time.UTC)
+	feedings := []time.Time{
+		time.Date(2026, 2, 15, 12, 30, 0, 0, time.UTC),
+		time.Date(2026, 2, 14, 22, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 15, 5, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 15, 8, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 15, 10, 30, 0, 0, time.UTC),
+		time.Date(2026, 2, 15, 14, 0, 0, 0, time.UTC),
+	}
+
+	stats := calculateFeedingIntervalStats(feedings, now)
+	if stats.Count != 4 || stats.Unstable {
+		t.Fatalf("expected 4 stable intervals, got %+v", stats)
+	}
+	if stats.MinMinutes == nil || *stats.MinMinutes != 120 || stats.MaxMinutes == nil || *stats.MaxMinutes != 420 {
+		t.Fatalf("unexpected min/max: %+v %+v", stats.MinMinutes, stats.MaxMinutes)
+	}
+	if stats.MedianMinutes == nil || *stats.MedianMinutes != 165 {
+		t.Fatalf("expected median 165, got %+v", stats.MedianMinutes)
+	}
+	if stats.MeanMinutes == nil || *stats.MeanMinutes != 217.5 {
+		t.Fatalf("expected mean 217.5, got %+v", stats.MeanMinutes)
+	}
+	if stats.CoefficientOfVariation == nil || *stats.CoefficientOfVariation != 0.55 {
+		t.Fatalf("expected cv 0.55, got %+v", stats.CoefficientOfVariation)
+	}
+
+	sparse := calculateFeedingIntervalStats(feedings[:2], now)
+	if !sparse.Unstable || sparse.Count != 1 {
+		t.Fatalf("expected a single unstable interval, got %+v", sparse)
+	}
+	if empty := calculateFeedingIntervalStats(nil, now); !empty.Unstable || empty.MeanMinutes != nil {
+		t.Fatalf("expected empty unstable stats, got %+v", empty)
+	}
+}
+
+func TestFeedingSplitSeparatesFormulaAndBreastfeed(t *testing.T) {
+	start := time.Date(2026, 2, 15, 8, 0, 0, 0, time.UTC)
+	end := start.Add(20 * time.Minute)
+	split := feedingSplit{}
+	split.add("FORMULA", map[string]any{"ml": 120}, start, nil)
+	split.add("FORMULA", map[string]any{"amount_ml": 90}, start, nil)
+	split.add("BREASTFEED", map[string]any{}, start, &end)
+	split.add("BREASTFEED", map[string]any{"duration_min": 12}, start, nil)
+	split.add("SLEEP", map[string]any{"duration_min": 60}, start, nil)
+
+	if split.FormulaCount != 2 || split.FormulaML != 210 {
+		t.Fatalf("unexpected formula tally: %+v", split)
+	}
+	if split.BreastfeedCount != 2 || split.BreastfeedMinutes != 32 {
+		t.Fatalf("unexpected breastfeed tally: %+v", split)
+	}
+	want := []string{
+		"Feedings combined: 4 (formula 2, breastfeed 2)",
+		"Formula: 2 feeds, 210 ml",
+		"Breastfeed: 2 sessions, 32 minutes",
+	}
+	if got := split.summaryLines(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected summary lines: %v", got)
+	}
+}
+
+func TestVerifyBillingWebhookSignature(t *testing.T) {
+	secret := "whsec-test-secret"
+	body := []byte(`{"id":"evt_1","external_id":"ext_1","status":"ACTIVE"}`)
+	now := time.Date(2026, 2, 15, 12, 0, 0, 0, time.UTC)
+	header := func(at time.Time, signature string) string {
+		return fmt.Sprintf("t=%d,v1=%s", at.Unix(), signature)
+	}
+
+	valid := signBillingWebhookPayload(secret, now.Unix(), body)
+	signedAt, err := verifyBillingWebhookSignature(secret, header(now, valid), body, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("expected valid signature, got %v", err)
+	}
+	if !signedAt.Equal(now) {
+		t.Fatalf("expected signed time %s, got %s", now, signedAt)
+	}
+	// A rotated secret may send several v1 entries.
+	if _, err := verifyBillingWebhookSignature(secret, header(now, "deadbeef")+",v1="+valid, body, now); err != nil {
+		t.Fatalf("expected any matching v1 to pass, got %v", err)
+	}
+	if _, err := verifyBillingWebhookSignature(secret, "", body, now); err != errBillingWebhookSignature {
+		t.Fatalf("expected missing header to fail, got %v", err)
+	}
+	if _, err := verifyBillingWebhookSignature(secret, header(now, valid), []byte(`{"id":"evt_2"}`), now); err != errBillingWebhookSignature {
+		t.Fatalf("expected tampered body to fail, got %v", err)
+	}
+	if _, err := verifyBillingWebhookSignature("other-secret", header(now, valid), body, now); err != errBillingWebhookSignature {
+		t.Fatalf("expected wrong secret to fail, got %v", err)
+	}
+	if _, err := verifyBillingWebhookSignature(secret, header(now, valid), body, now.Add(billingWebhookTolerance+time.Second)); err != errBillingWebhookStale {
+		t.Fatalf("expected replayed timestamp to fail, got %v", err)
+	}
+}
+
+func TestMonthlyTrendComparesDailyAverages(t *testing.T) {
+	january := monthlyMetrics{Days: 31, FeedingML: 3100, SleepMinutes: 31 * 600}
+	february := monthlyMetrics{
+		Days:         28,
+		FeedingML:    2800,
+		SleepMinutes: 28 * 660,
+		Weeks: []weeklyMetrics{
+			{FeedingML: 700, SleepMinutes: 7 * 660},
+			{FeedingML: 770, SleepMinutes: 7 * 660},
+			{FeedingML: 700, SleepMinutes: 7 * 660},
+			{FeedingML: 630, SleepMinutes: 7 * 660},
+		},
+	}
+
+	trend := monthlyTrend(february, january)
+	if trend["feeding_total_ml"] != "+0%" || trend["sleep_total_min"] != "+10%" {
+		t.Fatalf("unexpected monthly trend: %v", trend)
+	}
+	if avg := monthlyMetricsMap(february)["avg_daily_formula_ml"]; avg != 100.0 {
+		t.Fatalf("expected avg_daily_formula_ml 100, got %v", avg)
+	}
+
+	weeks := monthlyWeeklyTrend(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), february, january)
+	if len(weeks) != 4 {
+		t.Fatalf("expected 4 weeks for a 28-day month, got %d", len(weeks))
+	}
+	if weeks[0]["feeding_total_ml"] != "+0%" || weeks[1]["feeding_total_ml"] != "+10%" {
+		t.Fatalf("unexpected weekly feeding trend: %v", weeks)
+	}
+
+	march := monthlyMetrics{Days: 31, Weeks: make([]weeklyMetrics, 5)}
+	marchWeeks := monthlyWeeklyTrend(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), march, february)
+	if len(marchWeeks) != 5 || marchWeeks[4]["days"] != 3 || marchWeeks[4]["week_start"] != "2026-03-29" {
+		t.Fatalf("unexpected trailing week for a 31-day month: %v", marchWeeks[len(marchWeeks)-1])
+	}
+}
+
+func TestWHOPercentileUsesSexAndAgeRow(t *testing.T) {
+	median := whoPercentile(whoWeightForAgeLMS, "female", 12, 8.9481)
+	if median == nil || *median != 50 {
+		t.Fatalf("expected median weight at 50th percentile, got %v", median)
+	}
+	// +2 SD on the newborn boy length curve is about the 98th percentile.
+	tall := whoPercentile(whoLengthForAgeLMS, "male", 0, 49.8842*(1+2*0.03795))
+	if tall == nil || *tall != 98 {
+		t.Fatalf("expected 98th percentile, got %v", tall)
+	}
+	if got := whoPercentile(whoWeightForAgeLMS, "unknown", 12, 9); got != nil {
+		t.Fatalf("expected nil percentile for unknown sex, got %d", *got)
+	}
+	if got := whoPercentile(whoWeightForAgeLMS, "male", 25, 12); got != nil {
+		t.Fatalf("expected nil percentile past the table, got %d", *got)
+	}
+}
+
+func TestEventStateFromRecord(t *testing.T) {
+	end := time.Date(2026, 2, 15, 10, 0, 0, 0, time.UTC)
+	cases := []struct {
+		end      *time.Time
+		metadata map[string]any
+		want     string
+	}{
+		{nil, map[string]any{"event_state": "OPEN"}, "OPEN"},
+		{nil, map[string]any{"entry_mode": "manual_start"}, "OPEN"},
+		{&end, map[string]any{"event_state": "OPEN"}, "CLOSED"},
+		{nil, map[string]any{"entry_mode": "manual_form"}, "CLOSED"},
+		{&end, map[string]any{"event_state": "canceled"}, "CANCELED"},
+	}
+	for _, tc := range cases {
+		if got := eventStateFromRecord(tc.end, tc.metadata); got != tc.want {
+			t.Fatalf("eventStateFromRecord(%v, %v) = %q, want %q", tc.end, tc.metadata, got, tc.want)
+		}
+	}
+}
+
+func TestNormalizeFeedingAmountUnitConvertsOunces(t *testing.T) {
+	value := map[string]any{"amount_oz": 4.0}
+	metadata := map[string]any{}
+	if err := normalizeFeedingAmountUnit("FORMULA", value, metadata); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value["ml"] != 118 || value["amount_oz"] != nil {
+		t.Fatalf("expected 4 oz stored as 118 ml, got %v", value)
+	}
+	if metadata["original_unit"] != "oz" || metadata["original_amount"] != 4.0 {
+		t.Fatalf("expected original unit in metadata, got %v", metadata)
+	}
+
+	value = map[string]any{"ml": 3, "unit": "OZ"}
+	if err := normalizeFeedingAmountUnit("FORMULA", value, map[string]any{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value["ml"] != 89 || value["unit"] != nil {
+		t.Fatalf("expected unit=oz amount converted to 89 ml, got %v", value)
+	}
+
+	value = map[string]any{"ml": 120}
+	if err := normalizeFeedingAmountUnit("FORMULA", value, map[string]any{}); err != nil || value["ml"] != 120 {
+		t.Fatalf("expected ml amount unchanged, got %v err=%v", value, err)
+	}
+	if err := normalizeFeedingAmountUnit("FORMULA", map[string]any{"ml": 2, "unit": "cup"}, map[string]any{}); err == nil {
+		t.Fatalf("expected error for unknown unit")
+	}
+
+	ml := 118
+	if got := feedingAmountInUnit(&ml, feedingUnitOz); got == nil || *got != 4.0 {
+		t.Fatalf("expected 4.0 oz, got %v", got)
+	}
+}
+
+func TestNormalizeFeedingValuePatchConvertsOuncesOnEdit(t *testing.T) {
+	patch := map[string]any{"amount_oz": 4.0}
+	normalized, unitMetadata, err := normalizeFeedingValuePatch("FORMULA", patch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if normalized["ml"] != 118 || normalized["amount_oz"] != nil {
+		t.Fatalf("expected 4 oz patch stored as 118 ml, got %v", normalized)
+	}
+	if patch["amount_oz"] != 4.0 {
+		t.Fatalf("expected caller patch left untouched, got %v", patch)
+	}
+	metadata := mergeFeedingUnitMetadata(map[string]any{"note": "x"}, normalized, unitMetadata)
+	if metadata["original_unit"] != "oz" || metadata["note"] != "x" {
+		t.Fatalf("expected original unit merged into metadata, got %v", metadata)
+	}
+
+	normalized, unitMetadata, err = normalizeFeedingValuePatch("FORMULA", map[string]any{"ml": 120})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	metadata = mergeFeedingUnitMetadata(map[string]any{"original_unit": "oz", "original_amount": 4.0}, normalized, unitMetadata)
+	if metadata["original_unit"] != nil || metadata["original_amount"] != nil {
+		t.Fatalf("expected stale ounce stamp cleared by ml patch, got %v", metadata)
+	}
+
+	metadata = mergeFeedingUnitMetadata(map[string]any{"original_unit": "oz"}, map[string]any{"note": "y"}, map[string]any{})
+	if metadata["original_unit"] != "oz" {
+		t.Fatalf("expected ounce stamp kept when amount is not patched, got %v", metadata)
+	}
+	if _, _, err := normalizeFeedingValuePatch("FORMULA", map[string]any{"ml": 2, "unit": "cup"}); err == nil {
+		t.Fatalf("expected error for unknown unit")
+	}
+}
+
+func TestExtractVoiceEventsUsesAIAnswerAndFallsBack(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	app := &App{ai: intentRouterStubAIClient{answer: "```json\n" +
+		`{"events":[` +
+		`{"type":"formula","minutes_ago":20,"value":{"ml":4,"unit":"oz"},"confidence":{"type":0.95,"start_time":0.7,"ml":1.4}},` +
+		`{"type":"POO","minutes_ago":5,"value":{"count":1},"confidence":{"type":0.9}},` +
+		`{"type":"??","text":"and the thing","confidence":{"type":0.8}}` +
+		`]}` +
+		"\n```"}}
+	events, err := app.extractVoiceEvents(context.Background(), "four ounces of formula then a poo and the thing", "baby-1", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %+v", events)
+	}
+	feed := events[0]
+	if feed.Type != "FORMULA" || !feed.StartTime.Equal(now.Add(-20*time.Minute)) || feed.NeedsReview {
+		t.Fatalf("unexpected feed event: %+v", feed)
+	}
+	if feed.Value["ml"] != 118 || feed.Metadata["extraction"] != "ai" {
+		t.Fatalf("expected 4 oz stored as 118 ml from ai extraction, got %v %v", feed.Value, feed.Metadata)
+	}
+	if feed.Confidence["type"] != 0.95 || feed.Confidence["ml"] != 1 {
+		t.Fatalf("expected model confidence clamped to [0,1], got %v", feed.Confidence)
+	}
+	if events[1].Type != "POO" || !events[1].StartTime.Equal(now.Add(-5*time.Minute)) {
+		t.Fatalf("unexpected poo event: %+v", events[1])
+	}
+	unknown := events[2]
+	if unknown.Type != "MEMO" || unknown.Value["text"] != "and the thing" || !unknown.NeedsReview || unknown.Confidence["type"] > 0.3 {
+		t.Fatalf("expected unknown segment kept as low-confidence MEMO, got %+v", unknown)
+	}
+
+	fallback := &App{ai: intentRouterStubAIClient{answer: "Sorry, I cannot help."}}
+	events, err = fallback.extractVoiceEvents(context.Background(), "pee 15 minutes ago", "baby-1", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 1 || events[0].Type != "PEE" || events[0].Metadata["extraction"] != "keyword" || !events[0].StartTime.Equal(now.Add(-15*time.Minute)) {
+		t.Fatalf("expected keyword PEE 15 minutes ago, got %+v", events)
+	}
+
+	notConfigured := &App{ai: intentRouterStubAIClient{err: errors.New("OPENAI_API_KEY is not configured")}}
+	if _, err := notConfigured.extractVoiceEvents(context.Background(), "pee", "baby-1", now); err == nil {
+		t.Fatalf("expected not configured error")
+	}
+}
+
+func TestKeywordVoiceEventsSplitsSegments(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	events := keywordVoiceEvents("fed 120ml then changed a poo diaper, grandma visited", "baby-1", now)
+	if len(events) != 3 {
+		t.Fatalf("expected 3 segments, got %+v", events)
+	}
+	if events[0].Type != "FORMULA" || events[0].Value["ml"] != 120.0 || !events[0].NeedsReview {
+		t.Fatalf("expected bare feed as FORMULA needing review, got %+v", events[0])
+	}
+	if events[1].Type != "POO" || events[1].NeedsReview {
+		t.Fatalf("expected POO, got %+v", events[1])
+	}
+	if events[2].Type != "MEMO" || events[2].Value["text"] != "grandma visited" || !events[2].NeedsReview {
+		t.Fatalf("expected MEMO needing review, got %+v", events[2])
+	}
+
+	events = keywordVoiceEvents("분유 120ml 먹었어", "baby-1", now)
+	if len(events) != 1 || events[0].Type != "FORMULA" || events[0].NeedsReview {
+		t.Fatalf("expected one FORMULA, got %+v", events)
+	}
+}
+
+func TestOnboardingBabiesFromRequestNamesFieldsPerShape(t *testing.T) {
+	legacy, err := onboardingBabiesFromRequest(parentOnboardingRequest{BabyName: "Mina", BabyBirthDate: "2024-01-02"})
+	if err != nil || len(legacy) != 1 || legacy[0].Sex != "unknown" || legacy[0].FeedingMethod != "mixed" {
+		t.Fatalf("expected one defaulted legacy baby, got %+v err=%v", legacy, err)
+	}
+	if _, err := onboardingBabiesFromRequest(parentOnboardingRequest{BabyBirthDate: "2024-01-02"}); err == nil || err.Error() != "baby_name is required" {
+		t.Fatalf("expected legacy field name in error, got %v", err)
+	}
+
+	twins, err := onboardingBabiesFromRequest(parentOnboardingRequest{
+		BabyName: "ignored",
+		Babies: []parentOnboardingBaby{
+			{Name: "Mina", BirthDate: "2024-01-02", Sex: "female"},
+			{Name: "Minu", BirthDate: "2024-01-02", FeedingMethod: "formula"},
+		},
+	})
+	if err != nil || len(twins) != 2 || twins[0].Name != "Mina" || twins[1].FeedingMethod != "formula" {
+		t.Fatalf("expected two babies from the array, got %+v err=%v", twins, err)
+	}
+	_, err = onboardingBabiesFromRequest(parentOnboardingRequest{
+		Babies: []parentOnboardingBaby{{Name: "Mina", BirthDate: "2024-01-02"}, {Name: "Minu", BirthDate: "01/02/2024"}},
+	})
+	if err == nil || err.Error() != "babies[1].birth_date must be YYYY-MM-DD" {
+		t.Fatalf("expected indexed field name in error, got %v", err)
+	}
+}
+
+func TestCorrectedBirthDateOnlyShiftsPretermBabies(t *testing.T) {
+	birth := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	weeks := func(value int) *int { return &value }
+	if got := correctedBirthDate(birth, weeks(32)); !got.Equal(birth.AddDate(0, 0, 56)) {
+		t.Fatalf("expected 32 weeks to shift by 8 weeks, got %s", got)
+	}
+	for _, value := range []*int{nil, weeks(37), weeks(41)} {
+		if got := correctedBirthDate(birth, value); !got.Equal(birth) {
+			t.Fatalf("expected birth date unchanged for %v, got %s", value, got)
+		}
+	}
+
+	term := profileAgeText(childProfileSnapshot{AgeDays: 100, AgeMonths: 3, CorrectedAgeDays: 100, CorrectedAgeMonths: 3})
+	if strings.Contains(term, "교정연령") {
+		t.Fatalf("expected no corrected age for term baby, got %q", term)
+	}
+	preterm := profileAgeText(childProfileSnapshot{AgeDays: 100, AgeMonths: 3, CorrectedAgeDays: 44, CorrectedAgeMonths: 1, GestationalWeeks: weeks(32)})
+	if !strings.Contains(preterm, "나이=100일") || !strings.Contains(preterm, "교정연령=44일") {
+		t.Fatalf("expected chronological and corrected age, got %q", preterm)
+	}
+}
+
+func TestShouldSendFeedingReminderRespectsLeadAndQuietHours(t *testing.T) {
+	seoul := time.FixedZone("UTC+09:00", 9*60*60)
+	dueAt := time.Date(2026, 3, 11, 3, 0, 0, 0, time.UTC) // 12:00 in Seoul
+	if shouldSendFeedingReminder(dueAt, dueAt.Add(-11*time.Minute), 10, seoul, false) {
+		t.Fatalf("expected no reminder before the lead window")
+	}
+	if !shouldSendFeedingReminder(dueAt, dueAt.Add(-5*time.Minute), 10, seoul, false) {
+		t.Fatalf("expected a reminder inside the lead window")
+	}
+	if shouldSendFeedingReminder(dueAt, dueAt.Add(time.Minute), 10, seoul, false) {
+		t.Fatalf("expected no reminder once the feed is overdue")
+	}
+
+	nightDue := time.Date(2026, 3, 11, 15, 0, 0, 0, time.UTC) // 00:00 in Seoul
+	if shouldSendFeedingReminder(nightDue, nightDue.Add(-5*time.Minute), 10, seoul, false) {
+		t.Fatalf("expected overnight reminder to be held without opt-in")
+	}
+	if !shouldSendFeedingReminder(nightDue, nightDue.Add(-5*time.Minute), 10, seoul, true) {
+		t.Fatalf("expected overnight reminder with allow_overnight")
+	}
+	if !shouldSendFeedingReminder(nightDue, nightDue.Add(-5*time.Minute), 10, time.UTC, false) {
+		t.Fatalf("expected 15:00 UTC to be outside quiet hours")
+	}
+}
+
+func TestNextFeedingDueAtDoesNotRollForward(t *testing.T) {
+	last := time.Date(2026, 3, 11, 9, 0, 0, 0, time.UTC)
+	feedings := []time.Time{last.Add(-4 * time.Hour), last.Add(-2 * time.Hour), last}
+	gotLast, dueAt, ok := nextFeedingDueAt(feedings, last.Add(5*time.Hour))
+	if !ok || !gotLast.Equal(last) || !dueAt.Equal(last.Add(2*time.Hour)) {
+		t.Fatalf("expected due two hours after the last feed, got last=%s due=%s ok=%v", gotLast, dueAt, ok)
+	}
+	if _, _, ok := nextFeedingDueAt(feedings[:1], last); ok {
+		t.Fatalf("expected no due time from a single feeding")
+	}
+}
+
+func TestChatSearchSnippetTrimsAroundMatch(t *testing.T) {
+	content := strings.Repeat("a", 100) + " Fever " + strings.Repeat("b", 100) + " fever"
+	snippet, highlights := chatSearchSnippet(content, "fever")
+	if !strings.HasPrefix(snippet, "…") || !strings.HasSuffix(snippet, "…") {
+		t.Fatalf("expected snippet trimmed on both sides, got %q", snippet)
+	}
+	if len(highlights) != 1 {
+		t.Fatalf("expected only the match inside the snippet, got %v", highlights)
+	}
+	runes := []rune(snippet)
+	if got := string(runes[highlights[0].Start:highlights[0].End]); got != "Fever" {
+		t.Fatalf("expected highlight on the match, got %q", got)
+	}
+
+	short, shortHighlights := chatSearchSnippet("열이 나요, 열", "열")
+	if short != "열이 나요, 열" || len(shortHighlights) != 2 || shortHighlights[1].Start != 7 {
+		t.Fatalf("unexpected short snippet %q %v", short, shortHighlights)
+	}
+}
+
+func TestRedactPIIMasksContactDetailsInKoreanAndEnglish(t *testing.T) {
+	cases := map[string]string{
+		"제 번호는010-1234-5678이에요":                        "제 번호는[phone]이에요",
+		"연락처 010 1234 5678 로 주세요":                      "연락처 [phone] 로 주세요",
+		"병원 02-123-4567, 메일 mom.kim@example.co.kr":     "병원 [phone], 메일 [email]",
+		"주민번호 900101-1234567":                          "주민번호 [number]",
+		"Call me at (555) 123-4567 or +1 555-123-4567": "Call me at [phone] or [phone]",
+		"card 4111111111111111 expires soon":           "card [number] expires soon",
+		"분유 120 150 1600ml, 2026-03-15 14:30 체온 38.2":  "분유 120 150 1600ml, 2026-03-15 14:30 체온 38.2",
+	}
+	for input, want := range cases {
+		if got := redactPII(input); got != want {
+			t.Fatalf("redactPII(%q) = %q, want %q", input, got, want)
+		}
+	}
+
+	summary := buildSessionMemorySummary("", []ChatTurn{{Role: "user", Content: "소아과 번호 01098765432 알려줄게"}})
+	if strings.Contains(summary, "98765432") || !strings.Contains(summary, "[phone]") {
+		t.Fatalf("expected memory summary to be redacted, got %q", summary)
+	}
+}
+
+func TestValidateVoiceClipIndicesAndPending(t *testing.T) {
+	if err := validateVoiceClipIndices(nil, []int{}); err == nil {
+		t.Fatalf("expected empty indices to be rejected")
+	}
+	if err := validateVoiceClipIndices([]int{0, 2}, []int{2}); err == nil {
+		t.Fatalf("expected an index both confirmed and rejected to be rejected")
+	}
+	if err := validateVoiceClipIndices([]int{-1}, nil); err == nil {
+		t.Fatalf("expected a negative index to be rejected")
+	}
+	if err := validateVoiceClipIndices([]int{0}, []int{1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pending := pendingVoiceClipIndices(4, []voiceClipResolution{
+		{Index: 0, Status: voiceClipResolutionConfirmed},
+		{Index: 2, Status: voiceClipResolutionRejected},
+	})
+	if len(pending) != 2 || pending[0] != 1 || pending[1] != 3 {
+		t.Fatalf("expected pending [1 3], got %v", pending)
+	}
+}
+
+func TestReportPeriodKeyUsesLocalCalendarDate(t *testing.T) {
+	kst := time.FixedZone("UTC+09:00", 9*60*60)
+	localStart := time.Date(2026, 2, 9, 0, 0, 0, 0, kst)
+	want := time.Date(2026, 2, 9, 0, 0, 0, 0, time.UTC)
+	if got := reportPeriodKey(localStart); !got.Equal(want) {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	if got := reportPeriodKey(want); !got.Equal(want) {
+		t.Fatalf("expected UTC midnight to map to itself, got %s", got)
+	}
+}
+
+func TestFeedingReminderLocationFollowsHouseholdDST(t *testing.T) {
+	loc, err := feedingReminderLocation("America/New_York", "+09:00")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 11:30 UTC is 06:30 EST in January but 07:30 EDT in July.
+	winter := time.Date(2026, 1, 15, 11, 30, 0, 0, time.UTC)
+	summer := time.Date(2026, 7, 15, 11, 30, 0, 0, time.UTC)
+	if !isFeedingReminderQuietHour(winter, loc) {
+		t.Fatalf("expected 06:30 EST to be a quiet hour")
+	}
+	if isFeedingReminderQuietHour(summer, loc) {
+		t.Fatalf("expected 07:30 EDT to be outside quiet hours")
+	}
+
+	fallback, err := feedingReminderLocation("", "+09:00")
+	if err != nil {
+		t.Fatalf("unexpected fallback error: %v", err)
+	}
+	if _, offset := winter.In(fallback).Zone(); offset != 9*60*60 {
+		t.Fatalf("expected tz_offset fallback, got offset %d", offset)
+	}
+	if _, err := parseHouseholdTimezone("Mars/Olympus"); err == nil {
+		t.Fatalf("expected an unknown zone to be rejected")
+	}
+}
+
+func TestSoftenInternalJargonKeepsFormattingAndAnswerLanguage(t *testing.T) {
+	answer := "Based on the JSON, feeds were regular.\n\n- 4 feeds  \n- 3 naps\n\n    code  block"
+	softened, found := softenInternalJargon(answer, defaultJargonTerms)
+	want := "Based on the records, feeds were regular.\n\n- 4 feeds  \n- 3 naps\n\n    code  block"
+	if softened != want || len(found) != 1 {
+		t.Fatalf("expected English replacement with formatting kept, got %q %v", softened, found)
+	}
+
+	removed, _ := softenInternalJargon("Check the system prompt. Also  keep this.", defaultJargonTerms)
+	if removed != "Check the. Also  keep this." {
+		t.Fatalf("expected only the removed term's spaces to be tidied, got %q", removed)
+	}
+
+	plain := "Seek prompt medical attention if she refuses a token amount of milk."
+	if kept, found := softenInternalJargon(plain, defaultJargonTerms); kept != plain || len(found) != 0 {
+		t.Fatalf("expected ordinary English to be left alone, got %q %v", kept, found)
+	}
+
+	korean, _ := softenInternalJargon("민준이의 JSON 기준으로 수유는 4회였어요.", defaultJargonTerms)
+	if korean != "민준이의 기록 기준으로 수유는 4회였어요." {
+		t.Fatalf("expected Korean replacement in a Korean answer, got %q", korean)
+	}
+}
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	idempotencyKeyHeader    = "Idempotency-Key"
+	idempotencyReplayHeader = "Idempotent-Replayed"
+	idempotencyKeyMaxLength = 255
+
+	idempotencyEndpointManualEvent = "events/manual"
+	idempotencyEndpointBulkEvents  = "events/bulk"
+	idempotencyEndpointConfirm     = "events/confirm"
+)
+
+// errIdempotencyKeyReused means the key was first used on another endpoint, so
+// its stored response does not answer this request.
+var errIdempotencyKeyReused = errors.New("Idempotency-Key was already used for a different request")
+
+// idempotencyKeyFromRequest reads the optional Idempotency-Key header. An
+// empty key disables replay for the request.
+func idempotencyKeyFromRequest(c *gin.Context) (string, error) {
+	key := strings.TrimSpace(c.GetHeader(idempotencyKeyHeader))
+	if len(key) > idempotencyKeyMaxLength {
+		return "", errors.New("Idempotency-Key must be at most 255 characters")
+	}
+	return key, nil
+}
+
+// lookupIdempotentResponse returns the stored response for a key the user
+// sent within the last 24 hours. Keys are scoped per user.
+func (a *App) lookupIdempotentResponse(ctx context.Context, userID, key, endpoint string) ([]byte, bool, error) {
+	if key == "" {
+		return nil, false, nil
+	}
+	query := `SELECT endpoint, "responseJson"
+		 FROM "IdempotencyKey"
+		 WHERE "userId" = $1 AND key = $2 AND "createdAt" > NOW() - INTERVAL '24 hours'`
+	var storedEndpoint string
+	var response []byte
+	err := a.db.QueryRow(ctx, query, userID, key).Scan(&storedEndpoint, &response)
+	if err != nil && isMissingIdempotencyTableErr(err) {
+		if ensureErr := a.ensureIdempotencyKeyTable(ctx); ensureErr != nil {
+			return nil, false, ensureErr
+		}
+		return nil, false, nil
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if storedEndpoint != endpoint {
+		return nil, false, errIdempotencyKeyReused
+	}
+	return response, true, nil
+}
+
+// saveIdempotentResponse stores the response in the same transaction as the
+// writes it describes. It reports false when a concurrent request with the
+// same key committed first; the caller should roll back and replay that one.
+func saveIdempotentResponse(ctx context.Context, tx pgx.Tx, userID, key, endpoint string, response any) (bool, error) {
+	if key == "" {
+		return true, nil
+	}
+	// Expired keys are pruned per user on write so the table stays small
+	// without a background job, and an expired key can be reused.
+	if _, err := tx.Exec(
+		ctx,
+		`DELETE FROM "IdempotencyKey" WHERE "userId" = $1 AND "createdAt" <= NOW() - INTERVAL '24 hours'`,
+		userID,
+	); err != nil {
+		return false, err
+	}
+	result, err := tx.Exec(
+		ctx,
+		`INSERT INTO "IdempotencyKey" (id, "userId", key, endpoint, "responseJson", "createdAt")
+		 VALUES ($1, $2, $3, $4, $5, NOW())
+		 ON CONFLICT ("userId", key) DO NOTHING`,
+		uuid.NewString(),
+		userID,
+		key,
+		endpoint,
+		mustMarshalJSON(response),
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// storeIdempotentResponse saves the response before the caller commits. When
+// a concurrent duplicate won, it rolls tx back, answers with that request's
+// response and returns false.
+func (a *App) storeIdempotentResponse(c *gin.Context, tx pgx.Tx, userID, key, endpoint string, response any) bool {
+	saved, err := saveIdempotentResponse(c.Request.Context(), tx, userID, key, endpoint, response)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to save Idempotency-Key")
+		return false
+	}
+	if saved {
+		return true
+	}
+	_ = tx.Rollback(c.Request.Context())
+	if !a.replayIdempotentResponse(c, userID, key, endpoint) {
+		writeError(c, http.StatusConflict, "A request with this Idempotency-Key is already in progress")
+	}
+	return false
+}
+
+// replayIdempotentResponse answers a repeated request. It returns false when
+// there is nothing to replay and the handler should go on.
+func (a *App) replayIdempotentResponse(c *gin.Context, userID, key, endpoint string) bool {
+	response, found, err := a.lookupIdempotentResponse(c.Request.Context(), userID, key, endpoint)
+	if errors.Is(err, errIdempotencyKeyReused) {
+		writeError(c, http.StatusUnprocessableEntity, err.Error())
+		return true
+	}
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, "Failed to check Idempotency-Key")
+		return true
+	}
+	if !found {
+		return false
+	}
+	c.Header(idempotencyReplayHeader, "true")
+	c.Data(http.StatusOK, "application/json; charset=utf-8", response)
+	return true
+}
+
+func (a *App) ensureIdempotencyKeyTable(ctx context.Context) error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS "IdempotencyKey" (
+			id TEXT PRIMARY KEY,
+			"userId" TEXT NOT NULL REFERENCES "User"(id) ON DELETE CASCADE ON UPDATE CASCADE,
+			key TEXT NOT NULL,
+			endpoint TEXT NOT NULL,
+			"responseJson" JSONB NOT NULL,
+			"createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS "IdempotencyKey_userId_key_key" ON "IdempotencyKey"("userId", key)`,
+		`CREATE INDEX IF NOT EXISTS "IdempotencyKey_createdAt_idx" ON "IdempotencyKey"("createdAt")`,
+	}
+	for _, stmt := range statements {
+		if _, err := a.db.Exec(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isMissingIdempotencyTableErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	lowered := strings.ToLower(err.Error())
+	return strings.Contains(lowered, "relation") && strings.Contains(lowered, "idempotencykey")
+}
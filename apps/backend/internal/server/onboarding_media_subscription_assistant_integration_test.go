@@ -2,6 +2,8 @@ package server
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"strings"
 	"testing"
@@ -68,6 +70,109 @@ func TestOnboardingParentCreatesHouseholdAndBaby(t *testing.T) {
 	}
 }
 
+func TestOnboardingParentCreatesTwinsInOneHousehold(t *testing.T) {
+	resetDatabase(t)
+	userID := seedUser(t, "")
+	router := newTestRouter(t)
+	token := signToken(t, userID, nil)
+
+	rec := performRequest(
+		t,
+		router,
+		http.MethodPost,
+		"/api/v1/onboarding/parent",
+		token,
+		map[string]any{
+			"provider": "google",
+			"babies": []map[string]any{
+				{"name": "Mina", "birth_date": "2024-01-02", "sex": "female", "feeding_method": "breastmilk"},
+				{"name": "Minu", "birth_date": "2024-01-02", "sex": "male", "feeding_method": "formula"},
+			},
+			"required_consents": []string{"terms", "privacy"},
+		},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	babyIDs := decodeStringList(t, body["baby_ids"])
+	if len(babyIDs) != 2 || body["baby_id"] != babyIDs[0] {
+		t.Fatalf("expected two baby_ids with the first as baby_id, got %v", body)
+	}
+	householdID, _ := body["household_id"].(string)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var babyCount, householdCount int
+	if err := testPool.QueryRow(ctx, `SELECT COUNT(*) FROM "Baby" WHERE "householdId" = $1`, householdID).Scan(&babyCount); err != nil {
+		t.Fatalf("count babies: %v", err)
+	}
+	if err := testPool.QueryRow(ctx, `SELECT COUNT(*) FROM "Household" WHERE "ownerUserId" = $1`, userID).Scan(&householdCount); err != nil {
+		t.Fatalf("count households: %v", err)
+	}
+	if babyCount != 2 || householdCount != 1 {
+		t.Fatalf("expected 2 babies in 1 household, got %d babies and %d households", babyCount, householdCount)
+	}
+
+	primaryID, err := New(baseTestConfig, testPool).resolvePrimaryChildForHousehold(ctx, householdID)
+	if err != nil || primaryID != babyIDs[0] {
+		t.Fatalf("expected first baby as primary child, got %q err=%v", primaryID, err)
+	}
+
+	profileRec := performRequest(t, router, http.MethodGet, "/api/v1/babies/profile?baby_id="+babyIDs[1], token, nil, nil)
+	if profileRec.Code != http.StatusOK {
+		t.Fatalf("expected 200 for second baby profile, got %d body=%s", profileRec.Code, profileRec.Body.String())
+	}
+	if profile := decodeJSONMap(t, profileRec); profile["feeding_method"] != "formula" {
+		t.Fatalf("expected per-baby feeding settings, got %v", profile["feeding_method"])
+	}
+}
+
+func TestOnboardingParentStoresGestationalWeeksForCorrectedAge(t *testing.T) {
+	resetDatabase(t)
+	userID := seedUser(t, "")
+	router := newTestRouter(t)
+	token := signToken(t, userID, nil)
+	birthDate := time.Now().UTC().AddDate(0, 0, -100).Format("2006-01-02")
+
+	rec := performRequest(
+		t,
+		router,
+		http.MethodPost,
+		"/api/v1/onboarding/parent",
+		token,
+		map[string]any{"baby_name": "Mina", "baby_birth_date": birthDate, "gestational_weeks": 32},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	babyID, _ := decodeJSONMap(t, rec)["baby_id"].(string)
+
+	profileRec := performRequest(t, router, http.MethodGet, "/api/v1/babies/profile?baby_id="+babyID, token, nil, nil)
+	profile := decodeJSONMap(t, profileRec)
+	if profile["gestational_weeks"] != float64(32) {
+		t.Fatalf("expected gestational_weeks 32, got %v", profile["gestational_weeks"])
+	}
+	if profile["age_days"] != float64(100) || profile["corrected_age_days"] != float64(44) {
+		t.Fatalf("expected 100 chronological and 44 corrected days, got %v and %v", profile["age_days"], profile["corrected_age_days"])
+	}
+
+	invalidRec := performRequest(
+		t,
+		router,
+		http.MethodPost,
+		"/api/v1/onboarding/parent",
+		token,
+		map[string]any{"baby_name": "Mina", "baby_birth_date": birthDate, "gestational_weeks": 12},
+		nil,
+	)
+	if detail := responseDetail(t, invalidRec); detail != "gestational_weeks must be between 22 and 44" {
+		t.Fatalf("unexpected detail: %q", detail)
+	}
+}
+
 func TestOnboardingParentRejectsInvalidConsent(t *testing.T) {
 	resetDatabase(t)
 	userID := seedUser(t, "")
@@ -280,6 +385,140 @@ func TestCheckoutSubscriptionCreatesTrialingSubscription(t *testing.T) {
 	}
 }
 
+func TestBillingWebhookActivatesSubscriptionOnce(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	cfg := baseTestConfig
+	cfg.BillingWebhookSecret = "whsec-test-secret"
+	router := New(cfg, testPool).Router()
+
+	checkout := performRequest(t, router, http.MethodPost, "/api/v1/subscription/checkout", signToken(t, fixture.UserID, nil), map[string]any{
+		"household_id": fixture.HouseholdID,
+		"plan":         "AI_ONLY",
+	}, nil)
+	if checkout.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", checkout.Code, checkout.Body.String())
+	}
+	externalID, _ := decodeJSONMap(t, checkout)["external_id"].(string)
+	if externalID == "" {
+		t.Fatalf("expected checkout to return external_id, got %s", checkout.Body.String())
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"id":          "evt_paid_1",
+		"type":        "invoice.paid",
+		"external_id": externalID,
+		"status":      "ACTIVE",
+	})
+	if err != nil {
+		t.Fatalf("marshal webhook: %v", err)
+	}
+	sign := func(at time.Time) map[string]string {
+		signature := signBillingWebhookPayload(cfg.BillingWebhookSecret, at.Unix(), body)
+		return map[string]string{billingWebhookSignatureHeader: fmt.Sprintf("t=%d,v1=%s", at.Unix(), signature)}
+	}
+
+	rec := performRequest(t, router, http.MethodPost, "/api/v1/billing/webhook", "", json.RawMessage(body), nil)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected unsigned webhook to get 401, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	rec = performRequest(t, router, http.MethodPost, "/api/v1/billing/webhook", "", json.RawMessage(body), sign(time.Now().Add(-time.Hour)))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected stale webhook to get 401, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = performRequest(t, router, http.MethodPost, "/api/v1/billing/webhook", "", json.RawMessage(body), sign(time.Now()))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if result := decodeJSONMap(t, rec); result["status"] != "ACTIVE" || result["duplicate"] != false {
+		t.Fatalf("unexpected webhook result: %v", result)
+	}
+
+	rec = performRequest(t, router, http.MethodPost, "/api/v1/billing/webhook", "", json.RawMessage(body), sign(time.Now()))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if result := decodeJSONMap(t, rec); result["duplicate"] != true {
+		t.Fatalf("expected redelivery to be a duplicate, got %v", result)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var status string
+	if err := testPool.QueryRow(ctx, `SELECT status::text FROM "Subscription" WHERE "householdId" = $1`, fixture.HouseholdID).Scan(&status); err != nil {
+		t.Fatalf("load subscription: %v", err)
+	}
+	if status != "ACTIVE" {
+		t.Fatalf("expected ACTIVE subscription, got %s", status)
+	}
+	var auditCount int
+	if err := testPool.QueryRow(ctx, `SELECT COUNT(*) FROM "AuditLog" WHERE action = 'SUBSCRIPTION_STATUS_WEBHOOK'`).Scan(&auditCount); err != nil {
+		t.Fatalf("count audit logs: %v", err)
+	}
+	if auditCount != 1 {
+		t.Fatalf("expected one audit log, got %d", auditCount)
+	}
+}
+
+func TestBillingWebhookIgnoresEventsOlderThanTheLastApplied(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	cfg := baseTestConfig
+	cfg.BillingWebhookSecret = "whsec-test-secret"
+	router := New(cfg, testPool).Router()
+
+	checkout := performRequest(t, router, http.MethodPost, "/api/v1/subscription/checkout", signToken(t, fixture.UserID, nil), map[string]any{
+		"household_id": fixture.HouseholdID,
+		"plan":         "AI_ONLY",
+	}, nil)
+	if checkout.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", checkout.Code, checkout.Body.String())
+	}
+	externalID, _ := decodeJSONMap(t, checkout)["external_id"].(string)
+
+	deliver := func(id, status string, createdAt time.Time) map[string]any {
+		t.Helper()
+		body, err := json.Marshal(map[string]any{
+			"id":          id,
+			"type":        "subscription.updated",
+			"external_id": externalID,
+			"status":      status,
+			"created_at":  createdAt.UTC().Format(time.RFC3339),
+		})
+		if err != nil {
+			t.Fatalf("marshal webhook: %v", err)
+		}
+		now := time.Now()
+		signature := signBillingWebhookPayload(cfg.BillingWebhookSecret, now.Unix(), body)
+		headers := map[string]string{billingWebhookSignatureHeader: fmt.Sprintf("t=%d,v1=%s", now.Unix(), signature)}
+		rec := performRequest(t, router, http.MethodPost, "/api/v1/billing/webhook", "", json.RawMessage(body), headers)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+		}
+		return decodeJSONMap(t, rec)
+	}
+
+	raised := time.Now().Add(-10 * time.Minute)
+	if result := deliver("evt_active", "ACTIVE", raised); result["status"] != "ACTIVE" || result["stale"] != false {
+		t.Fatalf("unexpected webhook result: %v", result)
+	}
+	// The provider raised PAST_DUE before ACTIVE but it arrives later.
+	if result := deliver("evt_past_due", "PAST_DUE", raised.Add(-time.Minute)); result["status"] != "ACTIVE" || result["stale"] != true {
+		t.Fatalf("expected the older event to be ignored, got %v", result)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var status string
+	if err := testPool.QueryRow(ctx, `SELECT status::text FROM "Subscription" WHERE "householdId" = $1`, fixture.HouseholdID).Scan(&status); err != nil {
+		t.Fatalf("load subscription: %v", err)
+	}
+	if status != "ACTIVE" {
+		t.Fatalf("expected ACTIVE subscription, got %s", status)
+	}
+}
+
 func TestCheckoutSubscriptionBuildsFormulaPreanalysisMetadata(t *testing.T) {
 	resetDatabase(t)
 	fixture := seedOwnerFixture(t)
@@ -472,6 +711,43 @@ func TestBixbyQueryFallsBackToTodaySummaryIntent(t *testing.T) {
 	}
 }
 
+func TestBixbyNextFeedingReturnsRecommendationFields(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	now := time.Now().UTC()
+	seedEvent(t, "", fixture.BabyID, "FORMULA", now.Add(-5*time.Hour), nil, map[string]any{"ml": 150}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "FORMULA", now.Add(-2*time.Hour), nil, map[string]any{"ml": 150}, fixture.UserID)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/assistants/bixby/query",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"capsule_action": "GetNextFeedingEta",
+			"baby_id":        fixture.BabyID,
+		},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	if answer, _ := body["answer"].(string); !strings.Contains(answer, "minutes") {
+		t.Fatalf("expected the ETA sentence to stay in answer, got %q", answer)
+	}
+	if interval, ok := body["recommended_feed_interval_min"].(float64); !ok || interval <= 0 {
+		t.Fatalf("expected recommended_feed_interval_min, got %v", body["recommended_feed_interval_min"])
+	}
+	if _, ok := body["recommended_formula_per_feed_ml"].(float64); !ok {
+		t.Fatalf("expected recommended_formula_per_feed_ml, got %v", body["recommended_formula_per_feed_ml"])
+	}
+	if nextTime, _ := body["recommended_next_feeding_time"].(string); nextTime == "" {
+		t.Fatalf("expected recommended_next_feeding_time, got %v", body["recommended_next_feeding_time"])
+	}
+}
+
 func TestBixbyQueryRejectsMissingRequiredFields(t *testing.T) {
 	resetDatabase(t)
 	fixture := seedOwnerFixture(t)
@@ -495,6 +771,61 @@ func TestBixbyQueryRejectsMissingRequiredFields(t *testing.T) {
 	}
 }
 
+func TestAlexaQueryReturnsResponseEnvelope(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/assistants/alexa",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"version": "1.0",
+			"request": map[string]any{
+				"type": "IntentRequest",
+				"intent": map[string]any{
+					"name": "AMAZON.FallbackIntent",
+					"slots": map[string]any{
+						"baby_id": map[string]any{"name": "baby_id", "value": fixture.BabyID},
+					},
+				},
+			},
+		},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	response, _ := body["response"].(map[string]any)
+	speech, _ := response["outputSpeech"].(map[string]any)
+	if text, _ := speech["text"].(string); !strings.Contains(text, "Today:") {
+		t.Fatalf("expected unknown intent to fall back to today's summary, got %v", speech)
+	}
+	reprompt, _ := response["reprompt"].(map[string]any)
+	if repromptSpeech, _ := reprompt["outputSpeech"].(map[string]any); repromptSpeech["text"] == "" || repromptSpeech["text"] == nil {
+		t.Fatalf("expected a reprompt, got %v", response["reprompt"])
+	}
+	if response["shouldEndSession"] != false {
+		t.Fatalf("expected shouldEndSession=false, got %v", response["shouldEndSession"])
+	}
+
+	rec = performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/assistants/alexa",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{"request": map[string]any{"type": "LaunchRequest"}},
+		nil,
+	)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without baby_id, got %d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
 func TestGetMySubscriptionReturnsExistingPlan(t *testing.T) {
 	resetDatabase(t)
 	fixture := seedOwnerFixture(t)
@@ -2,7 +2,9 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -300,6 +302,58 @@ func TestQuickNextFeedingETAReturnsEstimateFromRecentFeedings(t *testing.T) {
 	}
 }
 
+func TestQuickFeedingIntervalsReportsStatsWithinWindow(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	now := time.Now().UTC()
+	seedEvent(t, "", fixture.BabyID, "FORMULA", now.AddDate(0, 0, -20), nil, map[string]any{"ml": 120}, fixture.UserID)
+	for _, hoursAgo := range []int{9, 6, 3, 1} {
+		seedEvent(t, "", fixture.BabyID, "FORMULA", now.Add(-time.Duration(hoursAgo)*time.Hour), nil, map[string]any{"ml": 120}, fixture.UserID)
+	}
+
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	rec := performRequest(
+		t,
+		router,
+		http.MethodGet,
+		"/api/v1/quick/feeding-intervals?baby_id="+fixture.BabyID+"&days=3&tz_offset=%2B09:00",
+		token,
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	if body["tz_offset"] != "+09:00" {
+		t.Fatalf("unexpected tz_offset: %v", body["tz_offset"])
+	}
+	if body["feeding_count"] != float64(4) {
+		t.Fatalf("expected the 20-day-old feeding to be outside the window, got %v", body["feeding_count"])
+	}
+	intervals, _ := body["intervals"].(map[string]any)
+	if intervals["count"] != float64(3) || intervals["unstable"] != false {
+		t.Fatalf("unexpected intervals: %v", intervals)
+	}
+	if intervals["min_minutes"] != float64(120) || intervals["max_minutes"] != float64(180) {
+		t.Fatalf("unexpected min/max: %v", intervals)
+	}
+
+	rec = performRequest(
+		t,
+		router,
+		http.MethodGet,
+		"/api/v1/quick/feeding-intervals?baby_id="+fixture.BabyID+"&days=0",
+		token,
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
 func TestQuickTodaySummaryBuildsExpectedLines(t *testing.T) {
 	resetDatabase(t)
 	fixture := seedOwnerFixture(t)
@@ -336,6 +390,81 @@ func TestQuickTodaySummaryBuildsExpectedLines(t *testing.T) {
 	}
 }
 
+func TestQuickTodaySummarySplitsFormulaAndBreastfeed(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	start := startOfUTCDay(time.Now().UTC()).Add(time.Hour)
+	breastfeedEnd := start.Add(75 * time.Minute)
+
+	seedEvent(t, "", fixture.BabyID, "FORMULA", start, nil, map[string]any{"ml": 120}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "BREASTFEED", start.Add(time.Hour), &breastfeedEnd, map[string]any{}, fixture.UserID)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/quick/today-summary?baby_id="+fixture.BabyID,
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	lines := decodeStringList(t, body["summary_lines"])
+	if !containsString(lines, "Feedings: 2") || !containsString(lines, "Breastfeed: 1 sessions, 15 minutes") {
+		t.Fatalf("expected combined and split feeding lines, got %v", lines)
+	}
+	split, _ := body["feeding_split"].(map[string]any)
+	if split["formula_count"] != float64(1) || split["formula_ml"] != float64(120) || split["breastfeed_min"] != float64(15) {
+		t.Fatalf("unexpected feeding_split: %v", split)
+	}
+}
+
+func TestQuickTodaySummaryUsesLocalDayBoundary(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	kst := time.FixedZone("KST", 9*60*60)
+	localMidnight := startOfScopeLocalDayUTC(time.Now().UTC(), kst)
+
+	seedEvent(t, "", fixture.BabyID, "FORMULA", localMidnight.Add(-30*time.Minute), nil, map[string]any{"ml": 90}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "FORMULA", localMidnight.Add(30*time.Minute), nil, map[string]any{"ml": 150}, fixture.UserID)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/quick/today-summary?baby_id="+fixture.BabyID+"&tz_offset=%2B09:00",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	lines := decodeStringList(t, decodeJSONMap(t, rec)["summary_lines"])
+	if !containsString(lines, "Feedings: 1") || !containsString(lines, "Formula total: 150 ml") {
+		t.Fatalf("expected only the feeding after local midnight, got %v", lines)
+	}
+
+	invalid := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/quick/today-summary?baby_id="+fixture.BabyID+"&tz_offset=0900",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if invalid.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d body=%s", invalid.Code, invalid.Body.String())
+	}
+	if detail := responseDetail(t, invalid); detail != "tz_offset must be in +/-HH:MM format" {
+		t.Fatalf("unexpected detail: %q", detail)
+	}
+}
+
 func TestQuickLandingSnapshotReturnsStructuredDashboardData(t *testing.T) {
 	resetDatabase(t)
 	fixture := seedOwnerFixture(t)
@@ -352,6 +481,10 @@ func TestQuickLandingSnapshotReturnsStructuredDashboardData(t *testing.T) {
 	seedEvent(t, "", fixture.BabyID, "POO", base.Add(17*time.Hour+5*time.Minute), nil, map[string]any{}, fixture.UserID)
 	seedEvent(t, "", fixture.BabyID, "MEDICATION", base.Add(18*time.Hour), nil, map[string]any{"name": "vitamin-d"}, fixture.UserID)
 	seedEvent(t, "", fixture.BabyID, "MEMO", base.Add(20*time.Hour), nil, map[string]any{"text": "Needs vitamin D after lunch"}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "SYMPTOM", base.Add(9*time.Hour), nil, map[string]any{"symptom": "cough", "severity": "mild"}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "SYMPTOM", base.Add(15*time.Hour), nil, map[string]any{"symptom": "fever", "severity": "moderate"}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "GROWTH", base.Add(7*time.Hour), nil, map[string]any{"weight_kg": 6.0}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "GROWTH", base.Add(10*time.Hour), nil, map[string]any{"weight_kg": 6.2, "height_cm": 61.5}, fixture.UserID)
 
 	rec := performRequest(
 		t,
@@ -434,6 +567,21 @@ func TestQuickLandingSnapshotReturnsStructuredDashboardData(t *testing.T) {
 	if body["last_medication_time"] == nil {
 		t.Fatalf("expected last_medication_time, got nil")
 	}
+	if symptomCount, ok := body["symptom_count"].(float64); !ok || int(symptomCount) != 2 {
+		t.Fatalf("expected symptom_count=2, got %v", body["symptom_count"])
+	}
+	if body["last_symptom_time"] != base.Add(15*time.Hour).Format(time.RFC3339) {
+		t.Fatalf("unexpected last_symptom_time: %v", body["last_symptom_time"])
+	}
+	if body["last_symptom_name"] != "fever" || body["last_symptom_severity"] != "moderate" {
+		t.Fatalf("expected latest symptom fever/moderate, got %v/%v", body["last_symptom_name"], body["last_symptom_severity"])
+	}
+	if body["latest_growth_weight_kg"] != 6.2 || body["latest_growth_height_cm"] != 61.5 {
+		t.Fatalf("expected latest growth 6.2kg/61.5cm, got %v/%v", body["latest_growth_weight_kg"], body["latest_growth_height_cm"])
+	}
+	if body["latest_growth_measured_at"] != base.Add(10*time.Hour).Format(time.RFC3339) {
+		t.Fatalf("unexpected latest_growth_measured_at: %v", body["latest_growth_measured_at"])
+	}
 
 	bands, ok := body["formula_amount_by_time_band_ml"].(map[string]any)
 	if !ok {
@@ -967,6 +1115,92 @@ func TestDailyReportRespectsTZOffsetLocalDayBoundary(t *testing.T) {
 	}
 }
 
+func TestDailyReportPlacesLateEveningEventOnLocalDay(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	est := time.FixedZone("UTC-05:00", -5*60*60)
+	// 23:30 on Feb 19 in UTC-05:00 is 04:30 UTC on Feb 20.
+	lateEvening := time.Date(2026, 2, 19, 23, 30, 0, 0, est)
+	seedEvent(t, "", fixture.BabyID, "FORMULA", lateEvening.UTC(), nil, map[string]any{"ml": 110}, fixture.UserID)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+
+	dailyEvents := func(query string) (map[string]any, []any) {
+		t.Helper()
+		rec := performRequest(t, router, http.MethodGet, "/api/v1/reports/daily?baby_id="+fixture.BabyID+"&"+query, token, nil, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200 for %s, got %d body=%s", query, rec.Code, rec.Body.String())
+		}
+		body := decodeJSONMap(t, rec)
+		events, ok := body["events"].([]any)
+		if !ok {
+			t.Fatalf("expected events array, got %T", body["events"])
+		}
+		return body, events
+	}
+
+	body, events := dailyEvents("date=2026-02-19&tz_offset=-05:00")
+	if len(events) != 1 {
+		t.Fatalf("expected the 23:30 event on local Feb 19, got %d events", len(events))
+	}
+	if body["tz_offset"] != "-05:00" {
+		t.Fatalf("unexpected tz_offset: %v", body["tz_offset"])
+	}
+	if _, events := dailyEvents("date=2026-02-20&tz_offset=-05:00"); len(events) != 0 {
+		t.Fatalf("expected no events on local Feb 20, got %d", len(events))
+	}
+
+	// Without tz_offset the window stays on UTC days.
+	body, events = dailyEvents("date=2026-02-20")
+	if len(events) != 1 {
+		t.Fatalf("expected the event on UTC Feb 20, got %d events", len(events))
+	}
+	if body["tz_offset"] != "+00:00" {
+		t.Fatalf("unexpected default tz_offset: %v", body["tz_offset"])
+	}
+}
+
+func TestWeeklyReportFindsPrecomputedRowByLocalWeekStart(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	weekStart := time.Date(2026, 2, 9, 0, 0, 0, 0, time.UTC)
+	seedReport(
+		t,
+		"",
+		fixture.HouseholdID,
+		fixture.BabyID,
+		"WEEKLY",
+		weekStart,
+		weekStart.Add(7*24*time.Hour),
+		map[string]any{
+			"trend":       map[string]any{"feeding_total_ml": "+20%"},
+			"suggestions": []string{"Keep logs consistent"},
+		},
+		"weekly summary",
+	)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/reports/weekly?baby_id="+fixture.BabyID+"&week_start=2026-02-09&week_starts_on=monday&tz_offset=%2B09:00",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	trend, ok := body["trend"].(map[string]any)
+	if !ok || trend["feeding_total_ml"] != "+20%" {
+		t.Fatalf("expected the stored report for local week 2026-02-09, got %v", body["trend"])
+	}
+	if body["week_start"] != "2026-02-09" || body["tz_offset"] != "+09:00" {
+		t.Fatalf("unexpected week_start/tz_offset: %v %v", body["week_start"], body["tz_offset"])
+	}
+}
+
 func TestWeeklyReportReturnsPrecomputedMetrics(t *testing.T) {
 	resetDatabase(t)
 	fixture := seedOwnerFixture(t)
@@ -1038,6 +1272,28 @@ func TestWeeklyReportRejectsInvalidWeekStart(t *testing.T) {
 	}
 }
 
+func TestWeeklyReportSnapsWeekStartToTheWeekStartDay(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/reports/weekly?baby_id="+fixture.BabyID+"&week_start=2026-02-11&week_starts_on=sunday",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	if body["week_start"] != "2026-02-08" || body["week_starts_on"] != "sunday" {
+		t.Fatalf("expected the Wednesday snapped back to Sunday 2026-02-08, got %v / %v", body["week_start"], body["week_starts_on"])
+	}
+}
+
 func TestWeeklyReportReturnsComputedTrendWhenNoPrecomputedMetrics(t *testing.T) {
 	resetDatabase(t)
 	fixture := seedOwnerFixture(t)
@@ -1080,11 +1336,1153 @@ func TestWeeklyReportReturnsComputedTrendWhenNoPrecomputedMetrics(t *testing.T)
 	}
 }
 
-func containsString(items []string, target string) bool {
-	for _, item := range items {
-		if item == target {
-			return true
-		}
+func TestWeeklyReportJobStoresPriorWeekOnce(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	weekStart := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	previousWeekStart := weekStart.Add(-7 * 24 * time.Hour)
+	sleepEnd := weekStart.Add(25 * time.Hour)
+
+	seedEvent(t, "", fixture.BabyID, "FORMULA", previousWeekStart.Add(24*time.Hour), nil, map[string]any{"ml": 200}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "FORMULA", weekStart.Add(24*time.Hour), nil, map[string]any{"ml": 300}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "SLEEP", weekStart.Add(24*time.Hour), &sleepEnd, map[string]any{}, fixture.UserID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	app := New(baseTestConfig, testPool)
+	now := time.Date(2026, 3, 11, 9, 0, 0, 0, time.UTC)
+
+	written, err := app.runWeeklyReportJob(ctx, now)
+	if err != nil {
+		t.Fatalf("run weekly report job: %v", err)
+	}
+	if written != 2 {
+		t.Fatalf("expected Monday- and Sunday-start reports written, got %d", written)
+	}
+
+	var metricsRaw []byte
+	if err := testPool.QueryRow(
+		ctx,
+		`SELECT "metricsJson" FROM "Report" WHERE "babyId" = $1 AND "periodType" = 'WEEKLY' AND "periodStart" = $2`,
+		fixture.BabyID,
+		weekStart,
+	).Scan(&metricsRaw); err != nil {
+		t.Fatalf("load stored Monday-start weekly report: %v", err)
+	}
+	var sundayRows int
+	if err := testPool.QueryRow(
+		ctx,
+		`SELECT COUNT(*) FROM "Report" WHERE "babyId" = $1 AND "periodType" = 'WEEKLY' AND "periodStart" = $2`,
+		fixture.BabyID,
+		weekStart.AddDate(0, 0, -1),
+	).Scan(&sundayRows); err != nil || sundayRows != 1 {
+		t.Fatalf("expected one Sunday-start weekly report, got %d err=%v", sundayRows, err)
+	}
+	trend, _ := parseJSONStringMap(metricsRaw)["trend"].(map[string]any)
+	if trend["feeding_total_ml"] != "+50%" {
+		t.Fatalf("unexpected stored feeding trend: %v", trend["feeding_total_ml"])
+	}
+	if trend["sleep_total_min"] != "new" {
+		t.Fatalf("unexpected stored sleep trend: %v", trend["sleep_total_min"])
+	}
+
+	written, err = app.runWeeklyReportJob(ctx, now)
+	if err != nil {
+		t.Fatalf("rerun weekly report job: %v", err)
+	}
+	if written != 0 {
+		t.Fatalf("expected stored week to be skipped, got %d written", written)
+	}
+	var reportCount int
+	if err := testPool.QueryRow(ctx, `SELECT COUNT(*) FROM "Report"`).Scan(&reportCount); err != nil {
+		t.Fatalf("query report count: %v", err)
+	}
+	if reportCount != 2 {
+		t.Fatalf("expected one stored report per week start, got %d", reportCount)
+	}
+}
+
+func TestWeeklyReportJobUsesHouseholdTimezone(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := testPool.Exec(ctx, `UPDATE "Household" SET timezone = 'Asia/Seoul' WHERE id = $1`, fixture.HouseholdID); err != nil {
+		t.Fatalf("set household timezone: %v", err)
+	}
+	// 08:00 KST on Monday 2026-03-02 is still Sunday in UTC.
+	seedEvent(t, "", fixture.BabyID, "FORMULA", time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC), nil, map[string]any{"ml": 100}, fixture.UserID)
+
+	app := New(baseTestConfig, testPool)
+	if _, err := app.runWeeklyReportJob(ctx, time.Date(2026, 3, 11, 9, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("run weekly report job: %v", err)
+	}
+	// Running again must not add duplicate rows.
+	if _, err := app.runWeeklyReportJob(ctx, time.Date(2026, 3, 11, 9, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("rerun weekly report job: %v", err)
+	}
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/reports/weekly?baby_id="+fixture.BabyID+"&week_start=2026-03-02&week_starts_on=monday&tz_offset=%2B09:00",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	split, _ := decodeJSONMap(t, rec)["feeding_split"].(map[string]any)
+	if split["formula_count"] != float64(1) {
+		t.Fatalf("expected the stored KST week to include the Monday-morning feed, got %v", split)
+	}
+
+	var reportCount int
+	if err := testPool.QueryRow(ctx, `SELECT COUNT(*) FROM "Report"`).Scan(&reportCount); err != nil {
+		t.Fatalf("query report count: %v", err)
+	}
+	if reportCount != 2 {
+		t.Fatalf("expected one stored report per week start, got %d", reportCount)
+	}
+}
+
+type recordingPushNotifier struct {
+	messages []pushMessage
+}
+
+func (n *recordingPushNotifier) Send(_ context.Context, message pushMessage) error {
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+func TestFeedingReminderJobSendsOncePerFeeding(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	token := signToken(t, fixture.UserID, nil)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/reminders/feeding/subscribe",
+		token,
+		map[string]any{"household_id": fixture.HouseholdID, "webhook_url": "https://169.254.169.254/latest", "tz_offset": "+09:00"},
+		nil,
+	)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without a device token, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/reminders/feeding/subscribe",
+		token,
+		map[string]any{"household_id": fixture.HouseholdID, "device_token": "device-1", "timezone": "Asia/Seoul"},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if body := decodeJSONMap(t, rec); body["lead_minutes"] != float64(10) || body["allow_overnight"] != false || body["timezone"] != "Asia/Seoul" {
+		t.Fatalf("unexpected subscription defaults: %v", body)
+	}
+
+	// Feeds every 3h at 03:00, 06:00 and 09:00 KST put the next one at 12:00 KST.
+	lastFeeding := time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)
+	for _, offset := range []int{-6, -3, 0} {
+		seedEvent(t, "", fixture.BabyID, "FORMULA", lastFeeding.Add(time.Duration(offset)*time.Hour), nil, map[string]any{"ml": 120}, fixture.UserID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	notifier := &recordingPushNotifier{}
+	app := New(baseTestConfig, testPool)
+	app.push = notifier
+
+	early := lastFeeding.Add(2*time.Hour + 30*time.Minute)
+	if sent, err := app.runFeedingReminderJob(ctx, early); err != nil || sent != 0 {
+		t.Fatalf("expected no reminder before the lead window, sent=%d err=%v", sent, err)
+	}
+	dueSoon := lastFeeding.Add(2*time.Hour + 55*time.Minute)
+	if sent, err := app.runFeedingReminderJob(ctx, dueSoon); err != nil || sent != 1 {
+		t.Fatalf("expected one reminder in the lead window, sent=%d err=%v", sent, err)
+	}
+	if sent, err := app.runFeedingReminderJob(ctx, dueSoon.Add(time.Minute)); err != nil || sent != 0 {
+		t.Fatalf("expected the reminder not to repeat, sent=%d err=%v", sent, err)
+	}
+	if len(notifier.messages) != 1 {
+		t.Fatalf("expected one pushed message, got %d", len(notifier.messages))
+	}
+	message := notifier.messages[0]
+	if message.DeviceToken != "device-1" || message.BabyID != fixture.BabyID || !strings.Contains(message.Body, "12:00") {
+		t.Fatalf("unexpected reminder: %+v", message)
+	}
+
+	rec = performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/reminders/feeding/unsubscribe",
+		token,
+		map[string]any{"household_id": fixture.HouseholdID},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	rec = performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/reminders/feeding/unsubscribe",
+		token,
+		map[string]any{"household_id": fixture.HouseholdID},
+		nil,
+	)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 after unsubscribing, got %d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestPrivateMemoHiddenFromOtherHouseholdMembers(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	memberID := seedUser(t, "")
+	seedHouseholdMember(t, "", fixture.HouseholdID, memberID, "CAREGIVER", "ACTIVE")
+
+	createRec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/events/manual",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"baby_id":    fixture.BabyID,
+			"type":       "MEMO",
+			"start_time": time.Now().UTC().Add(-30 * time.Minute).Format(time.RFC3339),
+			"value":      map[string]any{"memo": "owner private note"},
+			"visibility": "private",
+		},
+		nil,
+	)
+	if createRec.Code != http.StatusOK {
+		t.Fatalf("create private memo failed: %d body=%s", createRec.Code, createRec.Body.String())
+	}
+
+	snapshotMemo := func(userID string) any {
+		rec := performRequest(
+			t,
+			newTestRouter(t),
+			http.MethodGet,
+			"/api/v1/quick/landing-snapshot?baby_id="+fixture.BabyID+"&tz_offset=%2B00:00",
+			signToken(t, userID, nil),
+			nil,
+			nil,
+		)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+		}
+		return decodeJSONMap(t, rec)["special_memo"]
+	}
+
+	if memo := snapshotMemo(fixture.UserID); memo != "owner private note" {
+		t.Fatalf("expected author to see private memo, got %v", memo)
+	}
+	if memo := snapshotMemo(memberID); memo == "owner private note" {
+		t.Fatalf("expected private memo to be hidden from other member")
+	}
+}
+
+func TestPrivateVisibilityRejectedForNonMemoEvents(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/events/manual",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"baby_id":    fixture.BabyID,
+			"type":       "FORMULA",
+			"start_time": time.Now().UTC().Add(-30 * time.Minute).Format(time.RFC3339),
+			"value":      map[string]any{"ml": 120},
+			"visibility": "private",
+		},
+		nil,
+	)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestTimelineGroupsEventsByLocalDayNewestFirst(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+
+	// 23:30 UTC is already the next day at +09:00.
+	today := startOfUTCDay(time.Now().UTC())
+	lateYesterday := today.Add(-30 * time.Minute)
+	sleepEnd := today.Add(-2*time.Hour + 90*time.Minute)
+	seedEvent(t, "", fixture.BabyID, "FORMULA", lateYesterday, nil, map[string]any{"ml": 120}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "SLEEP", today.Add(-2*time.Hour), &sleepEnd, nil, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "PEE", today.Add(-30*time.Hour), nil, nil, fixture.UserID)
+
+	localToday := today.Add(9 * time.Hour).Format("2006-01-02")
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/babies/"+fixture.BabyID+"/timeline?tz_offset=%2B09:00&to="+localToday+"&days=2",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	days, ok := body["days"].([]any)
+	if !ok || len(days) != 2 {
+		t.Fatalf("expected two local days, got %v", body["days"])
+	}
+	firstDay := days[0].(map[string]any)
+	if firstDay["date"] != localToday {
+		t.Fatalf("expected newest day %s first, got %v", localToday, firstDay["date"])
+	}
+	firstEvents := firstDay["events"].([]any)
+	if len(firstEvents) != 2 {
+		t.Fatalf("expected formula and sleep on %s, got %v", localToday, firstEvents)
+	}
+	formula := firstEvents[0].(map[string]any)
+	if formula["type"] != "FORMULA" || formula["value_text"] != "120 ml" || formula["local_time"] != "08:30" {
+		t.Fatalf("unexpected formula row: %v", formula)
+	}
+	if sleep := firstEvents[1].(map[string]any); sleep["value_text"] != "1h 30m" {
+		t.Fatalf("unexpected sleep row: %v", sleep)
+	}
+	if body["next_cursor"] == nil {
+		t.Fatalf("expected next_cursor for older days")
+	}
+}
+
+func TestHouseholdDashboardSummarizesEveryBaby(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	twinID := seedBaby(t, "", fixture.HouseholdID, "Twin", time.Now().UTC().AddDate(0, -2, 0))
+	outsiderID := seedUser(t, "")
+
+	now := time.Now().UTC()
+	seedEvent(t, "", fixture.BabyID, "FORMULA", now.Add(-time.Minute), nil, map[string]any{"ml": 120}, fixture.UserID)
+	seedEvent(t, "", twinID, "FORMULA", now.Add(-2*time.Minute), nil, map[string]any{"ml": 90}, fixture.UserID)
+	startRec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/events/start",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{
+			"baby_id":    twinID,
+			"type":       "SLEEP",
+			"start_time": now.Add(-time.Minute).Format(time.RFC3339),
+		},
+		nil,
+	)
+	if startRec.Code != http.StatusOK {
+		t.Fatalf("start sleep failed: %d body=%s", startRec.Code, startRec.Body.String())
+	}
+
+	path := "/api/v1/households/" + fixture.HouseholdID + "/dashboard?tz_offset=%2B00:00"
+	forbidden := performRequest(t, newTestRouter(t), http.MethodGet, path, signToken(t, outsiderID, nil), nil, nil)
+	if forbidden.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for non-member, got %d", forbidden.Code)
+	}
+
+	rec := performRequest(t, newTestRouter(t), http.MethodGet, path, signToken(t, fixture.UserID, nil), nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	babies, _ := decodeJSONMap(t, rec)["babies"].([]any)
+	if len(babies) != 2 {
+		t.Fatalf("expected two babies, got %v", babies)
+	}
+	byID := map[string]map[string]any{}
+	for _, raw := range babies {
+		baby := raw.(map[string]any)
+		byID[baby["baby_id"].(string)] = baby
+	}
+	if byID[fixture.BabyID]["formula_total_ml"] != float64(120) || byID[twinID]["formula_total_ml"] != float64(90) {
+		t.Fatalf("unexpected formula totals: %v", babies)
+	}
+	if byID[fixture.BabyID]["last_feeding_time"] == nil {
+		t.Fatalf("expected last_feeding_time for %s", fixture.BabyID)
+	}
+	openEvents, _ := byID[twinID]["open_events"].([]any)
+	if len(openEvents) != 1 || openEvents[0].(map[string]any)["type"] != "SLEEP" {
+		t.Fatalf("expected twin's open SLEEP, got %v", byID[twinID]["open_events"])
+	}
+}
+
+func TestQuickHouseholdSnapshotReturnsEachBabySnapshot(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	twinID := seedBaby(t, "", fixture.HouseholdID, "Twin", time.Now().UTC().AddDate(0, -2, 0))
+	outsiderID := seedUser(t, "")
+	router := newTestRouter(t)
+
+	now := time.Now().UTC()
+	seedEvent(t, "", fixture.BabyID, "FORMULA", now.Add(-time.Minute), nil, map[string]any{"ml": 120}, fixture.UserID)
+	seedEvent(t, "", twinID, "FORMULA", now.Add(-2*time.Minute), nil, map[string]any{"ml": 90}, fixture.UserID)
+	seedEvent(t, "", twinID, "PEE", now.Add(-3*time.Minute), nil, map[string]any{}, fixture.UserID)
+
+	path := "/api/v1/quick/household-snapshot?household_id=" + fixture.HouseholdID + "&range=day&tz_offset=%2B00:00"
+	forbidden := performRequest(t, router, http.MethodGet, path, signToken(t, outsiderID, nil), nil, nil)
+	if forbidden.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for non-member, got %d", forbidden.Code)
+	}
+
+	rec := performRequest(t, router, http.MethodGet, path, signToken(t, fixture.UserID, nil), nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	snapshots, _ := body["snapshots"].(map[string]any)
+	if len(snapshots) != 2 {
+		t.Fatalf("expected two snapshots, got %v", body["snapshots"])
+	}
+	first, _ := snapshots[fixture.BabyID].(map[string]any)
+	twin, _ := snapshots[twinID].(map[string]any)
+	if first["formula_total_ml"] != float64(120) || twin["formula_total_ml"] != float64(90) {
+		t.Fatalf("unexpected formula totals: first=%v twin=%v", first["formula_total_ml"], twin["formula_total_ml"])
+	}
+	if first["diaper_pee_count"] != float64(0) || twin["diaper_pee_count"] != float64(1) {
+		t.Fatalf("unexpected pee counts: first=%v twin=%v", first["diaper_pee_count"], twin["diaper_pee_count"])
+	}
+	if twin["baby_name"] != "Twin" || twin["recommended_feed_interval_min"] == nil {
+		t.Fatalf("expected twin profile and recommendation fields, got %v", twin)
+	}
+}
+
+func TestHouseholdOpenEventsListsTimersAcrossBabies(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	twinID := seedBaby(t, "", fixture.HouseholdID, "Twin", time.Now().UTC().AddDate(0, -2, 0))
+	outsiderID := seedUser(t, "")
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	starts := []struct {
+		babyID    string
+		eventType string
+		start     time.Time
+	}{
+		{twinID, "SLEEP", now.Add(-30 * time.Minute)},
+		{fixture.BabyID, "FORMULA", now.Add(-10 * time.Minute)},
+	}
+	for _, item := range starts {
+		rec := performRequest(t, router, http.MethodPost, "/api/v1/events/start", token, map[string]any{
+			"baby_id":    item.babyID,
+			"type":       item.eventType,
+			"start_time": item.start.Format(time.RFC3339),
+		}, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("start %s failed: %d body=%s", item.eventType, rec.Code, rec.Body.String())
+		}
+	}
+	seedEvent(t, "", fixture.BabyID, "PEE", now.Add(-5*time.Minute), nil, nil, fixture.UserID)
+
+	path := "/api/v1/households/" + fixture.HouseholdID + "/open-events"
+	forbidden := performRequest(t, router, http.MethodGet, path, signToken(t, outsiderID, nil), nil, nil)
+	if forbidden.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for non-member, got %d", forbidden.Code)
+	}
+
+	rec := performRequest(t, router, http.MethodGet, path, token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	events, _ := decodeJSONMap(t, rec)["open_events"].([]any)
+	if len(events) != 2 {
+		t.Fatalf("expected two open events, got %v", events)
+	}
+	first := events[0].(map[string]any)
+	second := events[1].(map[string]any)
+	if first["baby_id"] != twinID || first["baby_name"] != "Twin" || first["type"] != "SLEEP" {
+		t.Fatalf("expected the twin's older sleep first, got %v", first)
+	}
+	if second["baby_id"] != fixture.BabyID || second["type"] != "FORMULA" {
+		t.Fatalf("expected the formula timer second, got %v", second)
+	}
+}
+
+func TestFieldSeriesReturnsAliasedNumericPoints(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+
+	now := time.Now().UTC()
+	seedEvent(t, "", fixture.BabyID, "SYMPTOM", now.Add(-3*time.Hour), nil, map[string]any{"temperature_c": 38.2}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "SYMPTOM", now.Add(-2*time.Hour), nil, map[string]any{"temp_c": 37.6}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "SYMPTOM", now.Add(-time.Hour), nil, map[string]any{"symptom": "cough"}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "FORMULA", now.Add(-time.Hour), nil, map[string]any{"ml": 120}, fixture.UserID)
+
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	rec := performRequest(t, router, http.MethodGet, "/api/v1/babies/"+fixture.BabyID+"/field-series?type=symptom&field=temp_c", token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	if body["field"] != "temperature_c" || body["type"] != "SYMPTOM" {
+		t.Fatalf("expected canonical field and type, got field=%v type=%v", body["field"], body["type"])
+	}
+	points, ok := body["points"].([]any)
+	if !ok || len(points) != 2 {
+		t.Fatalf("expected two temperature points, got %v", body["points"])
+	}
+	if first := points[0].(map[string]any); first["value"] != 38.2 {
+		t.Fatalf("expected oldest point first, got %v", first)
+	}
+	if second := points[1].(map[string]any); second["value"] != 37.6 {
+		t.Fatalf("expected temp_c alias to be read, got %v", second)
+	}
+
+	badRec := performRequest(t, router, http.MethodGet, "/api/v1/babies/"+fixture.BabyID+"/field-series?type=NOPE&field=ml", token, nil, nil)
+	if badRec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid type, got %d", badRec.Code)
+	}
+}
+
+func TestRecentByTypeReturnsLatestClosedEventsPerType(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+
+	now := time.Now().UTC()
+	oldestFormulaID := seedEvent(t, "", fixture.BabyID, "FORMULA", now.Add(-5*time.Hour), nil, map[string]any{"ml": 90}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "FORMULA", now.Add(-3*time.Hour), nil, map[string]any{"ml": 100}, fixture.UserID)
+	latestFormulaID := seedEvent(t, "", fixture.BabyID, "FORMULA", now.Add(-time.Hour), nil, map[string]any{"ml": 120}, fixture.UserID)
+	sleepEnd := now.Add(-90 * time.Minute)
+	seedEvent(t, "", fixture.BabyID, "SLEEP", now.Add(-3*time.Hour), &sleepEnd, nil, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "PEE", now.Add(-10*time.Minute), nil, nil, fixture.UserID)
+
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	rec := performRequest(t, router, http.MethodGet, "/api/v1/babies/"+fixture.BabyID+"/recent?types=formula,SLEEP,MEMO&per_type=2", token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	byType, ok := decodeJSONMap(t, rec)["events_by_type"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected events_by_type map")
+	}
+	formula := byType["FORMULA"].([]any)
+	if len(formula) != 2 {
+		t.Fatalf("expected per_type to cap FORMULA at 2, got %v", formula)
+	}
+	if first := formula[0].(map[string]any); first["event_id"] != latestFormulaID {
+		t.Fatalf("expected newest formula first, got %v", first)
+	}
+	for _, item := range formula {
+		if item.(map[string]any)["event_id"] == oldestFormulaID {
+			t.Fatalf("expected oldest formula to be cut by per_type")
+		}
+	}
+	if sleep := byType["SLEEP"].([]any); len(sleep) != 1 {
+		t.Fatalf("expected one SLEEP event, got %v", sleep)
+	}
+	if memo := byType["MEMO"].([]any); len(memo) != 0 {
+		t.Fatalf("expected empty MEMO list, got %v", memo)
+	}
+	if _, present := byType["PEE"]; present {
+		t.Fatalf("expected unrequested types to be left out")
+	}
+
+	badRec := performRequest(t, router, http.MethodGet, "/api/v1/babies/"+fixture.BabyID+"/recent?types=FORMULA&per_type=50", token, nil, nil)
+	if badRec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for per_type over cap, got %d", badRec.Code)
+	}
+}
+
+func TestLandingSnapshotExcludesZeroDurationSleep(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	napStart := now.Add(-3 * time.Hour)
+	napEnd := napStart.Add(45 * time.Minute)
+	seedEvent(t, "", fixture.BabyID, "SLEEP", napStart, &napEnd, nil, fixture.UserID)
+	// A double tap stored before zero-duration sleeps were rejected.
+	tapStart := now.Add(-time.Hour)
+	seedEvent(t, "", fixture.BabyID, "SLEEP", tapStart, &tapStart, nil, fixture.UserID)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/quick/landing-snapshot?baby_id="+fixture.BabyID+"&tz_offset=%2B00:00",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	if body["sleep_total_min"] != float64(45) {
+		t.Fatalf("expected only the nap in sleep_total_min, got %v", body["sleep_total_min"])
+	}
+	if body["recent_sleep_time"] != napStart.Format(time.RFC3339) {
+		t.Fatalf("expected recent sleep to skip the zero-duration row, got %v", body["recent_sleep_time"])
+	}
+}
+
+func TestStatsForDatesAggregatesEachRequestedDay(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+
+	today := startOfUTCDay(time.Now().UTC())
+	twoDaysAgo := today.AddDate(0, 0, -2)
+	seedEvent(t, "", fixture.BabyID, "FORMULA", today.Add(8*time.Hour), nil, map[string]any{"ml": 120}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "FORMULA", today.Add(11*time.Hour), nil, map[string]any{"ml": 90}, fixture.UserID)
+	sleepEnd := twoDaysAgo.Add(14 * time.Hour)
+	seedEvent(t, "", fixture.BabyID, "SLEEP", twoDaysAgo.Add(13*time.Hour), &sleepEnd, nil, fixture.UserID)
+	// Yesterday is not requested and must not leak into either day.
+	seedEvent(t, "", fixture.BabyID, "PEE", today.AddDate(0, 0, -1).Add(9*time.Hour), nil, nil, fixture.UserID)
+
+	todayText := today.Format("2006-01-02")
+	twoDaysAgoText := twoDaysAgo.Format("2006-01-02")
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodPost,
+		"/api/v1/babies/"+fixture.BabyID+"/stats/dates",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{"dates": []string{todayText, twoDaysAgoText, todayText}, "tz_offset": "+00:00"},
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	days, ok := decodeJSONMap(t, rec)["days"].([]any)
+	if !ok || len(days) != 2 {
+		t.Fatalf("expected two deduped days, got %v", days)
+	}
+	first := days[0].(map[string]any)
+	if first["date"] != twoDaysAgoText || first["sleep_total_min"] != float64(60) || first["event_count"] != float64(1) {
+		t.Fatalf("unexpected first day: %v", first)
+	}
+	second := days[1].(map[string]any)
+	if second["date"] != todayText || second["feedings_count"] != float64(2) || second["formula_total_ml"] != float64(210) || second["pee_count"] != float64(0) {
+		t.Fatalf("unexpected second day: %v", second)
+	}
+}
+
+func TestMonthlyChatContextIsSkippedForSparseHistory(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	now := time.Now().UTC()
+	seedEvent(t, "", fixture.BabyID, "FORMULA", now.Add(-26*time.Hour), nil, map[string]any{"ml": 120}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "PEE", now.Add(-2*time.Hour), nil, nil, fixture.UserID)
+
+	cfg := baseTestConfig
+	cfg.ChatMonthlyMinEvents = 20
+	cfg.ChatMonthlyMinHistoryDays = 7
+	app := New(cfg, testPool)
+
+	selection := resolveChatContextSelection("이번달 요약해줘", aiIntentDataQuery, now, chatScopeOverride{})
+	if selection.Mode != chatContextModeMonthlyParentingRollup {
+		t.Fatalf("expected a monthly selection, got %s", selection.Mode)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := app.buildChatContextForSelection(ctx, fixture.UserID, fixture.BabyID, "이번달 요약해줘", aiIntentDataQuery, now, selection, childProfileSnapshot{Name: "Baby"}, "2026-01-01")
+	if err != nil {
+		t.Fatalf("build chat context: %v", err)
+	}
+	if result.Meta["time_range"] == chatContextModeMonthlyParentingRollup || result.Meta["monthly_rollup_skipped"] != true {
+		t.Fatalf("expected the monthly rollup to be skipped, got meta %v", result.Meta)
+	}
+	if strings.Contains(result.Summary, "월간 육아 롤업") || strings.Contains(result.Summary, "주간 롤업") {
+		t.Fatalf("expected no monthly block in the summary, got %q", result.Summary)
+	}
+
+	cfg.ChatMonthlyMinEvents = 0
+	cfg.ChatMonthlyMinHistoryDays = 0
+	result, err = New(cfg, testPool).buildChatContextForSelection(ctx, fixture.UserID, fixture.BabyID, "이번달 요약해줘", aiIntentDataQuery, now, selection, childProfileSnapshot{Name: "Baby"}, "2026-01-01")
+	if err != nil {
+		t.Fatalf("build chat context: %v", err)
+	}
+	if result.Meta["time_range"] != chatContextModeMonthlyParentingRollup {
+		t.Fatalf("expected the monthly rollup with the checks disabled, got %v", result.Meta["time_range"])
+	}
+}
+
+func TestRawChatContextSummarizesOlderEventsPastLineCap(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	now := time.Now().UTC().Truncate(time.Minute)
+	for idx := 0; idx < 200; idx++ {
+		eventType, value := "FORMULA", map[string]any{"ml": 100}
+		if idx%2 == 1 {
+			eventType, value = "PEE", map[string]any{}
+		}
+		seedEvent(t, "", fixture.BabyID, eventType, now.Add(-time.Duration(idx*20+5)*time.Minute), nil, value, fixture.UserID)
+	}
+
+	cfg := baseTestConfig
+	cfg.ChatRawContextMaxLines = 120
+	app := New(cfg, testPool)
+	question := "수유랑 기저귀 기록 알려줘"
+	selection := resolveChatContextSelection(question, aiIntentDataQuery, now, chatScopeOverride{})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := app.buildChatContextForSelection(ctx, fixture.UserID, fixture.BabyID, question, aiIntentDataQuery, now, selection, childProfileSnapshot{Name: "Baby"}, "2026-01-01")
+	if err != nil {
+		t.Fatalf("build chat context: %v", err)
+	}
+
+	_, table, found := strings.Cut(result.Summary, "evidence_event_id):\n")
+	if !found {
+		t.Fatalf("expected an evidence table, got %q", result.Summary)
+	}
+	if lines := strings.Split(table, "\n"); len(lines) > 120 {
+		t.Fatalf("expected at most 120 event lines, got %d", len(lines))
+	}
+	if !strings.Contains(table, "| FORMULA |") || !strings.Contains(table, "- 요약 |") {
+		t.Fatalf("expected recent lines and per-type summary lines, got %q", table)
+	}
+	if ids, _ := result.Meta["evidence_event_ids"].([]string); len(ids) != 200 {
+		t.Fatalf("expected all 200 evidence ids, got %d", len(ids))
+	}
+	if result.Meta["raw_lines_summarized"] != true {
+		t.Fatalf("expected raw_lines_summarized, got %v", result.Meta["raw_lines_summarized"])
+	}
+}
+
+func containsString(items []string, target string) bool {
+	for _, item := range items {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
+
+func TestQuickLastSymptomReturnsSymptomAndTemperature(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	base := time.Date(2026, 2, 17, 3, 0, 0, 0, time.UTC)
+	seedEvent(t, "", fixture.BabyID, "SYMPTOM", base.Add(-3*time.Hour), nil, map[string]any{"symptom": "cough"}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "SYMPTOM", base, nil, map[string]any{"symptom": "fever", "severity": "mild", "temp_c": 38.2}, fixture.UserID)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/quick/last-symptom?baby_id="+fixture.BabyID+"&tz_offset=%2B09:00",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	if body["symptom"] != "fever" || body["severity"] != "mild" {
+		t.Fatalf("expected the latest fever symptom, got %v", body)
+	}
+	if tempC, ok := body["temperature_c"].(float64); !ok || tempC != 38.2 {
+		t.Fatalf("expected temperature_c=38.2, got %v", body["temperature_c"])
+	}
+	if body["local_time"] != "2026-02-17T12:00:00+09:00" {
+		t.Fatalf("unexpected local_time: %v", body["local_time"])
+	}
+}
+
+func TestQuickLastSymptomWithoutEventsReturnsNoData(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/quick/last-symptom?baby_id="+fixture.BabyID,
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if body := decodeJSONMap(t, rec); body["timestamp"] != nil {
+		t.Fatalf("expected timestamp=nil, got %v", body["timestamp"])
+	}
+}
+
+func TestMonthlyReportReturnsPrecomputedMetrics(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	monthStart := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	seedReport(
+		t,
+		"",
+		fixture.HouseholdID,
+		fixture.BabyID,
+		"MONTHLY",
+		monthStart,
+		monthStart.AddDate(0, 1, 0),
+		map[string]any{
+			"metrics": map[string]any{"feeding_total_ml": 2800},
+			"trend":   map[string]any{"feeding_total_ml": "+5%"},
+		},
+		"monthly summary",
+	)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/reports/monthly?baby_id="+fixture.BabyID+"&month=2026-02",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	trend, ok := body["trend"].(map[string]any)
+	if !ok || trend["feeding_total_ml"] != "+5%" {
+		t.Fatalf("unexpected trend: %v", body["trend"])
+	}
+	if body["days_in_month"] != float64(28) {
+		t.Fatalf("expected days_in_month=28, got %v", body["days_in_month"])
+	}
+}
+
+func TestMonthlyReportComputesMetricsWhenNoPrecomputedMetrics(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	monthStart := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	januaryStart := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	seedEvent(t, "", fixture.BabyID, "FORMULA", januaryStart.Add(24*time.Hour), nil, map[string]any{"ml": 310}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "FORMULA", monthStart.Add(24*time.Hour), nil, map[string]any{"ml": 140}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "FORMULA", monthStart.Add(20*24*time.Hour), nil, map[string]any{"ml": 140}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "PEE", monthStart.Add(2*24*time.Hour), nil, map[string]any{}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "POO", monthStart.Add(3*24*time.Hour), nil, map[string]any{}, fixture.UserID)
+	sleepEnd := monthStart.Add(4*24*time.Hour + 90*time.Minute)
+	seedEvent(t, "", fixture.BabyID, "SLEEP", monthStart.Add(4*24*time.Hour), &sleepEnd, map[string]any{}, fixture.UserID)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/reports/monthly?baby_id="+fixture.BabyID+"&month=2026-02",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	metrics, ok := body["metrics"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected metrics object, got %T", body["metrics"])
+	}
+	if metrics["feeding_total_ml"] != float64(280) || metrics["avg_daily_formula_ml"] != float64(10) {
+		t.Fatalf("unexpected feeding metrics: %v", metrics)
+	}
+	if metrics["diaper_count"] != float64(2) || metrics["sleep_total_min"] != float64(90) {
+		t.Fatalf("unexpected diaper/sleep metrics: %v", metrics)
+	}
+	// January averaged 10 ml/day over 31 days, February 10 ml/day over 28.
+	trend, _ := body["trend"].(map[string]any)
+	if trend["feeding_total_ml"] != "+0%" {
+		t.Fatalf("expected flat feeding trend, got %v", trend["feeding_total_ml"])
+	}
+	weeks, ok := body["weekly_trend"].([]any)
+	if !ok || len(weeks) != 4 {
+		t.Fatalf("expected 4 weekly trends, got %v", body["weekly_trend"])
+	}
+}
+
+func TestMonthlyReportRejectsInvalidMonth(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/reports/monthly?baby_id="+fixture.BabyID+"&month=2026-13",
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if detail := responseDetail(t, rec); detail != "month must be YYYY-MM" {
+		t.Fatalf("unexpected detail: %q", detail)
+	}
+}
+
+func TestGrowthReportReturnsPercentilesForLatestMeasurement(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := testPool.Exec(ctx, `UPDATE "Baby" SET sex = 'female' WHERE id = $1`, fixture.BabyID); err != nil {
+		t.Fatalf("set baby sex: %v", err)
+	}
+	now := time.Now().UTC()
+	seedEvent(t, "", fixture.BabyID, "GROWTH", now.Add(-48*time.Hour), nil, map[string]any{"weight_kg": 7.0}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "GROWTH", now.Add(-time.Hour), nil, map[string]any{"weight_kg": 8.9481, "height_cm": 74.0150}, fixture.UserID)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/reports/growth?baby_id="+fixture.BabyID,
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	if body["age_months"] != float64(11) && body["age_months"] != float64(12) {
+		t.Fatalf("unexpected age_months: %v", body["age_months"])
+	}
+	if body["weight_kg"] != 8.9 || body["measured_at"] == nil {
+		t.Fatalf("expected latest measurement, got weight=%v measured_at=%v", body["weight_kg"], body["measured_at"])
+	}
+	if body["weight_percentile"] == nil || body["height_percentile"] == nil {
+		t.Fatalf("expected percentiles, got %v", body)
+	}
+}
+
+func TestGrowthReportReturnsNullPercentilesWhenSexUnknown(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	seedEvent(t, "", fixture.BabyID, "GROWTH", time.Now().UTC().Add(-time.Hour), nil, map[string]any{"weight_kg": 9.0}, fixture.UserID)
+
+	rec := performRequest(
+		t,
+		newTestRouter(t),
+		http.MethodGet,
+		"/api/v1/reports/growth?baby_id="+fixture.BabyID,
+		signToken(t, fixture.UserID, nil),
+		nil,
+		nil,
+	)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	if body["sex"] != "unknown" || body["weight_percentile"] != nil {
+		t.Fatalf("expected null percentile for unknown sex, got %v", body)
+	}
+	if strings.TrimSpace(toString(body["reference_text"])) == "" {
+		t.Fatalf("expected reference_text")
+	}
+}
+
+func TestGrowthSeriesListsMeasurementsOldestFirst(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	seriesPath := "/api/v1/reports/growth-series?baby_id=" + fixture.BabyID
+
+	rec := performRequest(t, router, http.MethodGet, seriesPath, token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if series, ok := decodeJSONMap(t, rec)["series"].([]any); !ok || len(series) != 0 {
+		t.Fatalf("expected an empty series, got %v", rec.Body.String())
+	}
+
+	now := time.Now().UTC()
+	seedEvent(t, "", fixture.BabyID, "GROWTH", now.Add(-time.Hour), nil, map[string]any{"weight": 8.94, "length_cm": 74.0}, fixture.UserID)
+	seedEvent(t, "", fixture.BabyID, "GROWTH", now.AddDate(0, 0, -30), nil, map[string]any{"weight_kg": 8.1}, fixture.UserID)
+
+	rec = performRequest(t, router, http.MethodGet, seriesPath, token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	series, _ := decodeJSONMap(t, rec)["series"].([]any)
+	if len(series) != 2 {
+		t.Fatalf("expected 2 measurements, got %v", series)
+	}
+	first, _ := series[0].(map[string]any)
+	latest, _ := series[1].(map[string]any)
+	if first["weight_kg"] != 8.1 || first["height_cm"] != nil {
+		t.Fatalf("expected the older weight-only measurement first, got %v", first)
+	}
+	if latest["weight_kg"] != 8.9 || latest["height_cm"] != float64(74) {
+		t.Fatalf("expected aliased keys to be read, got %v", latest)
+	}
+	if first["age_days"].(float64) >= latest["age_days"].(float64) || latest["age_months"] == nil {
+		t.Fatalf("expected ages at each measurement, got %v then %v", first, latest)
+	}
+
+	from := now.AddDate(0, 0, -7).Format("2006-01-02")
+	rec = performRequest(t, router, http.MethodGet, seriesPath+"&from="+from, token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if series, _ := decodeJSONMap(t, rec)["series"].([]any); len(series) != 1 {
+		t.Fatalf("expected from to drop the older measurement, got %v", series)
+	}
+}
+
+func TestHouseholdInviteAddsActiveMemberOnce(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	router := newTestRouter(t)
+	ownerToken := signToken(t, fixture.UserID, nil)
+	inviteeID := seedUser(t, "")
+	inviteeToken := signToken(t, inviteeID, nil)
+	invitePath := "/api/v1/households/" + fixture.HouseholdID + "/invites"
+
+	createRec := performRequest(t, router, http.MethodPost, invitePath, ownerToken, map[string]any{"role": "caregiver"}, nil)
+	if createRec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", createRec.Code, createRec.Body.String())
+	}
+	created := decodeJSONMap(t, createRec)
+	token, _ := created["token"].(string)
+	if token == "" || created["role"] != "CAREGIVER" {
+		t.Fatalf("expected CAREGIVER invite token, got %v", created)
+	}
+
+	acceptPath := "/api/v1/households/invites/" + token + "/accept"
+	acceptRec := performRequest(t, router, http.MethodPost, acceptPath, inviteeToken, nil, nil)
+	if acceptRec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", acceptRec.Code, acceptRec.Body.String())
+	}
+	if reused := performRequest(t, router, http.MethodPost, acceptPath, inviteeToken, nil, nil); reused.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for reused invite, got %d body=%s", reused.Code, reused.Body.String())
+	}
+
+	dashboardRec := performRequest(t, router, http.MethodGet, "/api/v1/households/"+fixture.HouseholdID+"/dashboard", inviteeToken, nil, nil)
+	if dashboardRec.Code != http.StatusOK {
+		t.Fatalf("expected new member to read the household, got %d body=%s", dashboardRec.Code, dashboardRec.Body.String())
+	}
+	// CAREGIVER is not a billing role, so the new member cannot invite others.
+	if forbidden := performRequest(t, router, http.MethodPost, invitePath, inviteeToken, map[string]any{}, nil); forbidden.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for caregiver invite, got %d body=%s", forbidden.Code, forbidden.Body.String())
+	}
+
+	secondRec := performRequest(t, router, http.MethodPost, invitePath, ownerToken, map[string]any{}, nil)
+	secondToken, _ := decodeJSONMap(t, secondRec)["token"].(string)
+	duplicateRec := performRequest(t, router, http.MethodPost, "/api/v1/households/invites/"+secondToken+"/accept", inviteeToken, nil, nil)
+	if duplicateRec.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for existing member, got %d body=%s", duplicateRec.Code, duplicateRec.Body.String())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var auditCount int
+	if err := testPool.QueryRow(
+		ctx,
+		`SELECT COUNT(*) FROM "AuditLog" WHERE action IN ('HOUSEHOLD_INVITE_CREATED', 'HOUSEHOLD_INVITE_ACCEPTED') AND "householdId" = $1`,
+		fixture.HouseholdID,
+	).Scan(&auditCount); err != nil {
+		t.Fatalf("query audit log: %v", err)
+	}
+	if auditCount != 3 {
+		t.Fatalf("expected 2 created and 1 accepted audit rows, got %d", auditCount)
+	}
+}
+
+func TestHouseholdInviteRejectsExpiredToken(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+	router := newTestRouter(t)
+
+	createRec := performRequest(
+		t,
+		router,
+		http.MethodPost,
+		"/api/v1/households/"+fixture.HouseholdID+"/invites",
+		signToken(t, fixture.UserID, nil),
+		map[string]any{"expires_in_hours": 1},
+		nil,
+	)
+	created := decodeJSONMap(t, createRec)
+	token, _ := created["token"].(string)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := testPool.Exec(ctx, `UPDATE "Invite" SET "expiresAt" = NOW() - INTERVAL '1 minute' WHERE token = $1`, token); err != nil {
+		t.Fatalf("expire invite: %v", err)
+	}
+
+	rec := performRequest(t, router, http.MethodPost, "/api/v1/households/invites/"+token+"/accept", signToken(t, seedUser(t, ""), nil), nil, nil)
+	if rec.Code != http.StatusGone {
+		t.Fatalf("expected 410, got %d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestLandingSnapshotSplitsStraddlingSleepByBabyBoundaryHours(t *testing.T) {
+	resetDatabase(t)
+	fixture := seedOwnerFixture(t)
+
+	// Pick the offset that makes it about 21:00 locally, so a 17:30-19:30
+	// sleep today has already ended.
+	nowUTC := time.Now().UTC()
+	offsetHours := (21 - nowUTC.Hour() + 24) % 24
+	if offsetHours > 12 {
+		offsetHours -= 24
+	}
+	localZone := time.FixedZone("test", offsetHours*60*60)
+	localNow := nowUTC.In(localZone)
+	sleepStart := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 17, 30, 0, 0, localZone)
+	sleepEnd := sleepStart.Add(2 * time.Hour)
+	seedEvent(t, "", fixture.BabyID, "SLEEP", sleepStart, &sleepEnd, nil, fixture.UserID)
+
+	router := newTestRouter(t)
+	token := signToken(t, fixture.UserID, nil)
+	snapshotPath := fmt.Sprintf(
+		"/api/v1/quick/landing-snapshot?baby_id=%s&tz_offset=%s",
+		fixture.BabyID,
+		url.QueryEscape(localNow.Format("-07:00")),
+	)
+
+	rec := performRequest(t, router, http.MethodGet, snapshotPath, token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body := decodeJSONMap(t, rec)
+	if body["sleep_night_total_min"] != float64(120) || body["sleep_day_total_min"] != float64(0) {
+		t.Fatalf("expected 17:30-19:30 to count as night, got day=%v night=%v", body["sleep_day_total_min"], body["sleep_night_total_min"])
+	}
+
+	rec = performRequest(t, router, http.MethodPatch, "/api/v1/babies/profile", token, map[string]any{
+		"baby_id":          fixture.BabyID,
+		"night_start_hour": 20,
+	}, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = performRequest(t, router, http.MethodGet, snapshotPath, token, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	body = decodeJSONMap(t, rec)
+	if body["sleep_day_total_min"] != float64(120) || body["sleep_night_total_min"] != float64(0) {
+		t.Fatalf("expected a 20:00 night start to make it a nap, got day=%v night=%v", body["sleep_day_total_min"], body["sleep_night_total_min"])
 	}
-	return false
 }
@@ -0,0 +1,30 @@
+package server
+
+import "regexp"
+
+// The patterns run in order, so a phone number is labelled as one before the
+// digit-run rule can swallow it. Go's \b is ASCII-only, which makes Hangul
+// next to a number count as a boundary ("번호는010-1234-5678이에요").
+var (
+	redactEmailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	// Korean mobile numbers, with or without separators.
+	redactKoreanMobilePattern = regexp.MustCompile(`\b01[016-9][\s.-]?\d{3,4}[\s.-]?\d{4}\b`)
+	// Landlines and international numbers. Bare numbers need "-" or "."
+	// between groups so feeding amounts like "120 150 1600" are left alone.
+	redactPhonePattern = regexp.MustCompile(`(?:\+\d{1,3}[\s.-]?)?(?:\(\d{2,4}\)\s?|\b\d{2,4}[.-])\d{3,4}[.-]\d{4}\b`)
+	// Nine or more digits, optionally hyphenated: resident registration,
+	// card and account numbers. Dates such as 2026-03-15 stay below the bar.
+	redactDigitRunPattern = regexp.MustCompile(`\b\d(?:-?\d){8,}\b`)
+)
+
+// redactPII masks emails, phone numbers and long digit runs in free text
+// before it is stored outside the message itself or written to logs.
+func redactPII(value string) string {
+	if value == "" {
+		return value
+	}
+	value = redactEmailPattern.ReplaceAllString(value, "[email]")
+	value = redactKoreanMobilePattern.ReplaceAllString(value, "[phone]")
+	value = redactPhonePattern.ReplaceAllString(value, "[phone]")
+	return redactDigitRunPattern.ReplaceAllString(value, "[number]")
+}
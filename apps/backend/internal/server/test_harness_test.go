@@ -64,6 +64,33 @@ func TestMain(m *testing.M) {
 		os.Exit(1)
 	}
 
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	err = New(baseTestConfig, pool).EnsureEventTrashSchema(ctx)
+	cancel()
+	if err != nil {
+		pool.Close()
+		fmt.Fprintf(os.Stderr, "integration test setup failed: event schema update failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	err = New(baseTestConfig, pool).EnsureUsageLogPricingSchema(ctx)
+	cancel()
+	if err != nil {
+		pool.Close()
+		fmt.Fprintf(os.Stderr, "integration test setup failed: usage log schema update failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	err = New(baseTestConfig, pool).EnsureVoiceClipResolutionSchema(ctx)
+	cancel()
+	if err != nil {
+		pool.Close()
+		fmt.Fprintf(os.Stderr, "integration test setup failed: voice clip schema update failed: %v\n", err)
+		os.Exit(1)
+	}
+
 	testPool = pool
 	integrationDBReady = true
 
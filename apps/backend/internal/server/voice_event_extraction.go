@@ -0,0 +1,276 @@
+package server
+
+import (
+	"context"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	voiceExtractionMaxOutputTokens = 800
+	// voiceNeedsReviewConfidence is the type confidence below which a parsed
+	// event is flagged for the parent to check.
+	voiceNeedsReviewConfidence = 0.5
+)
+
+var (
+	voiceAmountPattern       = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*(ml|cc|oz|ounces?|밀리)`)
+	voiceMinutesAgoPattern   = regexp.MustCompile(`(?i)(\d+)\s*(minutes?|mins?|분)\s*(ago|전)`)
+	voiceSegmentSplitPattern = regexp.MustCompile(`(?i)[,;]|\.(?:\s|$)|\band then\b|\bthen\b|그리고|다음에`)
+)
+
+type voiceExtractedEvent struct {
+	Type        string             `json:"type"`
+	MinutesAgo  *float64           `json:"minutes_ago"`
+	DurationMin *float64           `json:"duration_min"`
+	Value       map[string]any     `json:"value"`
+	Confidence  map[string]float64 `json:"confidence"`
+	NeedsReview bool               `json:"needs_review"`
+}
+
+func buildVoiceExtractionSystemPrompt() string {
+	return strings.Join([]string{
+		"You turn a parent's spoken baby-care log into structured events, one per thing that happened.",
+		"Return JSON only, no prose, shaped as:",
+		`{"events":[{"type":"FORMULA|BREASTFEED|SLEEP|PEE|POO|GROWTH|MEMO|SYMPTOM|MEDICATION","minutes_ago":number,"duration_min":number|null,"value":{...},"confidence":{"type":0-1,"start_time":0-1,"<value key>":0-1},"needs_review":bool}]}`,
+		"Keep events in the order they were said. minutes_ago is how long before now each event started; when only the order is clear, space earlier events further back and lower start_time confidence. Use 0 when no time is mentioned.",
+		"value keys: FORMULA/BREASTFEED ml (add unit \"oz\" when ounces were said); PEE/POO count; GROWTH weight_kg or height_cm; MEDICATION name and dose; SYMPTOM and MEMO text.",
+		"Never drop part of the transcript: a segment you cannot place becomes a MEMO with that segment as text, a low type confidence and needs_review true.",
+		"Confidence is how sure you are each field was actually said, not a guess.",
+	}, "\n")
+}
+
+// extractVoiceEvents asks the AI to split the transcript into events. Unusable
+// answers fall back to keyword matching with low confidence so the clip can
+// still be reviewed; only an unconfigured provider is returned as an error.
+func (a *App) extractVoiceEvents(ctx context.Context, transcript, babyID string, now time.Time) ([]eventItem, error) {
+	resp, err := a.ai.Query(ctx, AIModelRequest{
+		SystemPrompt:    buildVoiceExtractionSystemPrompt(),
+		UserPrompt:      "Transcript: " + transcript,
+		MaxOutputTokens: voiceExtractionMaxOutputTokens,
+	})
+	if err != nil {
+		if classifyAIProviderError(err) == aiOutcomeNotConfigured {
+			return nil, err
+		}
+		log.Printf("voice extraction failed, using keyword fallback baby_id=%s err=%v", babyID, err)
+		return keywordVoiceEvents(transcript, babyID, now), nil
+	}
+
+	extracted, ok := parseVoiceExtraction(resp.Answer)
+	if !ok {
+		return keywordVoiceEvents(transcript, babyID, now), nil
+	}
+	events := make([]eventItem, 0, len(extracted))
+	for _, item := range extracted {
+		events = append(events, voiceEventFromExtraction(item, babyID, now))
+	}
+	return events, nil
+}
+
+// parseVoiceExtraction reads the {"events":[...]} answer; a bare event object
+// is accepted too. Items with an unknown type are kept as MEMOs needing review
+// instead of being dropped.
+func parseVoiceExtraction(answer string) ([]voiceExtractedEvent, bool) {
+	candidate := strings.TrimSpace(answer)
+	start := strings.Index(candidate, "{")
+	end := strings.LastIndex(candidate, "}")
+	if start < 0 || end <= start {
+		return nil, false
+	}
+	parsed := parseJSONStringMap([]byte(candidate[start : end+1]))
+	rawItems, ok := parsed["events"].([]any)
+	if !ok {
+		if _, hasType := parsed["type"]; !hasType {
+			return nil, false
+		}
+		rawItems = []any{parsed}
+	}
+
+	extracted := make([]voiceExtractedEvent, 0, len(rawItems))
+	for _, rawItem := range rawItems {
+		item, ok := rawItem.(map[string]any)
+		if !ok {
+			continue
+		}
+		extracted = append(extracted, parseVoiceExtractedItem(item))
+	}
+	if len(extracted) == 0 {
+		return nil, false
+	}
+	return extracted, true
+}
+
+func parseVoiceExtractedItem(item map[string]any) voiceExtractedEvent {
+	extracted := voiceExtractedEvent{Value: map[string]any{}, Confidence: map[string]float64{}}
+	if minutes, ok := item["minutes_ago"].(float64); ok && minutes >= 0 {
+		extracted.MinutesAgo = &minutes
+	}
+	if duration, ok := item["duration_min"].(float64); ok && duration > 0 {
+		extracted.DurationMin = &duration
+	}
+	if value, ok := item["value"].(map[string]any); ok {
+		extracted.Value = value
+	}
+	if confidence, ok := item["confidence"].(map[string]any); ok {
+		for key, raw := range confidence {
+			score, ok := raw.(float64)
+			if !ok {
+				continue
+			}
+			if score < 0 {
+				score = 0
+			}
+			if score > 1 {
+				score = 1
+			}
+			extracted.Confidence[key] = score
+		}
+	}
+	extracted.NeedsReview, _ = item["needs_review"].(bool)
+
+	eventType, valid := normalizeEventType(toString(item["type"]))
+	if !valid {
+		text := strings.TrimSpace(toString(extracted.Value["text"]))
+		if text == "" {
+			text = strings.TrimSpace(toString(item["text"]))
+		}
+		eventType = "MEMO"
+		extracted.Value = map[string]any{"text": text}
+		extracted.Confidence = map[string]float64{"type": 0.1}
+		extracted.NeedsReview = true
+	}
+	extracted.Type = eventType
+	return extracted
+}
+
+func voiceEventFromExtraction(extracted voiceExtractedEvent, babyID string, now time.Time) eventItem {
+	startTime := now
+	if extracted.MinutesAgo != nil {
+		startTime = now.Add(-time.Duration(*extracted.MinutesAgo * float64(time.Minute)))
+	}
+	metadata := map[string]any{"baby_id": babyID, "source": "voice", "extraction": "ai"}
+	if err := normalizeFeedingAmountUnit(extracted.Type, extracted.Value, metadata); err != nil {
+		extracted.Confidence["ml"] = 0
+	}
+	event := eventItem{
+		Type:       extracted.Type,
+		StartTime:  startTime.UTC(),
+		Value:      extracted.Value,
+		Metadata:   metadata,
+		Confidence: extracted.Confidence,
+	}
+	if extracted.DurationMin != nil {
+		endTime := event.StartTime.Add(time.Duration(*extracted.DurationMin * float64(time.Minute)))
+		event.EndTime = &endTime
+	}
+	if _, ok := event.Confidence["type"]; !ok {
+		event.Confidence["type"] = 0.5
+	}
+	if _, ok := event.Confidence["start_time"]; !ok {
+		event.Confidence["start_time"] = 0.3
+	}
+	event.NeedsReview = extracted.NeedsReview || event.Confidence["type"] < voiceNeedsReviewConfidence
+	return event
+}
+
+// keywordVoiceEvents is the fallback when the AI answer cannot be used. It
+// splits the transcript on commas, sentence ends and "then", and matches each
+// segment on its own.
+func keywordVoiceEvents(transcript, babyID string, now time.Time) []eventItem {
+	events := make([]eventItem, 0)
+	for _, segment := range voiceSegmentSplitPattern.Split(transcript, -1) {
+		segment = strings.TrimSpace(segment)
+		if segment == "" {
+			continue
+		}
+		events = append(events, keywordVoiceEvent(segment, babyID, now))
+	}
+	if len(events) == 0 {
+		events = append(events, keywordVoiceEvent(strings.TrimSpace(transcript), babyID, now))
+	}
+	return events
+}
+
+// keywordVoiceEvent only recognises the event type, an amount and "N minutes
+// ago"; anything else is a MEMO that needs review.
+func keywordVoiceEvent(transcript, babyID string, now time.Time) eventItem {
+	lowered := strings.ToLower(transcript)
+	eventType := "MEMO"
+	switch {
+	case containsAnyKeyword(lowered, []string{"poo", "poop", "stool", "대변", "응가", "똥"}):
+		eventType = "POO"
+	case containsAnyKeyword(lowered, []string{"pee", "wet diaper", "urine", "소변"}):
+		eventType = "PEE"
+	case containsAnyKeyword(lowered, []string{"formula", "bottle", "분유"}):
+		eventType = "FORMULA"
+	case containsAnyKeyword(lowered, []string{"breastfe", "nursed", "nursing", "모유"}):
+		eventType = "BREASTFEED"
+	case containsAnyKeyword(lowered, []string{"fed", "feed", "수유"}):
+		// A feed without formula or breast words is most often a bottle, but
+		// the parent should check.
+		eventType = "FORMULA"
+	case containsAnyKeyword(lowered, []string{"sleep", "slept", "nap", "낮잠", "잠들"}):
+		eventType = "SLEEP"
+	case containsAnyKeyword(lowered, []string{"medicine", "medication", "tylenol", "약 먹"}):
+		eventType = "MEDICATION"
+	case containsAnyKeyword(lowered, []string{"fever", "cough", "vomit", "rash", "열", "기침", "구토"}):
+		eventType = "SYMPTOM"
+	}
+
+	confidence := map[string]float64{"type": 0.6, "start_time": 0.3}
+	if eventType == "FORMULA" && !containsAnyKeyword(lowered, []string{"formula", "bottle", "분유"}) {
+		confidence["type"] = 0.4
+	}
+	value := map[string]any{}
+	metadata := map[string]any{"baby_id": babyID, "source": "voice", "extraction": "keyword"}
+	switch eventType {
+	case "POO", "PEE":
+		value["count"] = 1
+		confidence["count"] = 0.5
+	case "FORMULA", "BREASTFEED":
+		if match := voiceAmountPattern.FindStringSubmatch(transcript); match != nil {
+			amount, _ := strconv.ParseFloat(match[1], 64)
+			value["ml"] = amount
+			if normalizeFeedingUnit(match[2]) == feedingUnitOz {
+				value["unit"] = feedingUnitOz
+			}
+			if err := normalizeFeedingAmountUnit(eventType, value, metadata); err == nil {
+				confidence["ml"] = 0.5
+			}
+		}
+	case "MEMO", "SYMPTOM":
+		value["text"] = transcript
+		if eventType == "MEMO" {
+			confidence["type"] = 0.2
+		}
+	}
+
+	startTime := now
+	if match := voiceMinutesAgoPattern.FindStringSubmatch(transcript); match != nil {
+		minutes, _ := strconv.Atoi(match[1])
+		startTime = now.Add(-time.Duration(minutes) * time.Minute)
+		confidence["start_time"] = 0.6
+	}
+	return eventItem{
+		Type:        eventType,
+		StartTime:   startTime.UTC(),
+		Value:       value,
+		Metadata:    metadata,
+		Confidence:  confidence,
+		NeedsReview: confidence["type"] < voiceNeedsReviewConfidence,
+	}
+}
+
+// voiceEventConfidences is what VoiceClip.confidenceJson stores: one
+// confidence map per parsed event, in the same order.
+func voiceEventConfidences(events []eventItem) []map[string]float64 {
+	confidences := make([]map[string]float64, 0, len(events))
+	for _, event := range events {
+		confidences = append(confidences, event.Confidence)
+	}
+	return confidences
+}
@@ -0,0 +1,173 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"babyai/apps/backend/internal/config"
+)
+
+const (
+	voiceAudioMaxBytes   = 25 << 20
+	defaultSTTModel      = "gpt-4o-mini-transcribe"
+	voiceAudioFetchLimit = 30 * time.Second
+)
+
+var errSTTNotConfigured = errors.New("speech-to-text provider is not configured")
+
+type voiceAudio struct {
+	Data     []byte
+	Filename string
+}
+
+// SpeechToTextClient turns an uploaded voice clip into a transcript.
+type SpeechToTextClient interface {
+	Transcribe(ctx context.Context, audio voiceAudio) (string, error)
+}
+
+type OpenAITranscriptionClient struct {
+	apiKey     string
+	baseURL    string
+	model      string
+	httpClient *http.Client
+}
+
+// MockSpeechToTextClient reads the uploaded bytes back as the transcript so
+// tests can drive the voice flow with plain text "audio".
+type MockSpeechToTextClient struct{}
+
+func (MockSpeechToTextClient) Transcribe(_ context.Context, audio voiceAudio) (string, error) {
+	return strings.TrimSpace(string(audio.Data)), nil
+}
+
+// newSpeechToTextClient returns nil when STT_PROVIDER is none, which leaves
+// transcript_hint as the only way to use the voice endpoint.
+func newSpeechToTextClient(cfg config.Config) SpeechToTextClient {
+	switch strings.ToLower(strings.TrimSpace(cfg.STTProvider)) {
+	case "", "none":
+		return nil
+	default:
+		return NewOpenAITranscriptionClient(cfg)
+	}
+}
+
+func NewOpenAITranscriptionClient(cfg config.Config) *OpenAITranscriptionClient {
+	timeoutSeconds := cfg.AITimeoutSeconds
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = defaultAITimeoutSeconds
+	}
+	model := strings.TrimSpace(cfg.STTModel)
+	if model == "" {
+		model = defaultSTTModel
+	}
+	return &OpenAITranscriptionClient{
+		apiKey:  strings.TrimSpace(cfg.OpenAIAPIKey),
+		baseURL: strings.TrimRight(strings.TrimSpace(cfg.OpenAIBaseURL), "/"),
+		model:   model,
+		httpClient: &http.Client{
+			Timeout: time.Duration(timeoutSeconds) * time.Second,
+		},
+	}
+}
+
+func (c *OpenAITranscriptionClient) Transcribe(ctx context.Context, audio voiceAudio) (string, error) {
+	if c.apiKey == "" || c.baseURL == "" {
+		return "", errSTTNotConfigured
+	}
+	if len(audio.Data) == 0 {
+		return "", errors.New("audio is empty")
+	}
+	filename := strings.TrimSpace(audio.Filename)
+	if filename == "" {
+		filename = "voice.m4a"
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("model", c.model); err != nil {
+		return "", err
+	}
+	if err := writer.WriteField("response_format", "json"); err != nil {
+		return "", err
+	}
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return "", err
+	}
+	if _, err := part.Write(audio.Data); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/audio/transcriptions", &body)
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Authorization", "Bearer "+c.apiKey)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", err
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return "", fmt.Errorf("openai transcription error (%d): %s", response.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+
+	var parsed struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(responseBody, &parsed); err != nil {
+		return "", fmt.Errorf("openai transcription response is invalid: %w", err)
+	}
+	return strings.TrimSpace(parsed.Text), nil
+}
+
+// fetchVoiceAudio loads a clip the client already put in the voice upload
+// bucket. Keys are resolved against VOICE_AUDIO_BASE_URL, which is expected
+// to be readable by the API (pre-signed or private network).
+func fetchVoiceAudio(ctx context.Context, baseURL, objectKey string) (voiceAudio, error) {
+	fetchCtx, cancel := context.WithTimeout(ctx, voiceAudioFetchLimit)
+	defer cancel()
+
+	url := strings.TrimRight(baseURL, "/") + "/" + strings.TrimLeft(objectKey, "/")
+	request, err := http.NewRequestWithContext(fetchCtx, http.MethodGet, url, nil)
+	if err != nil {
+		return voiceAudio{}, err
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return voiceAudio{}, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return voiceAudio{}, fmt.Errorf("voice audio fetch returned %d", response.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(response.Body, voiceAudioMaxBytes+1))
+	if err != nil {
+		return voiceAudio{}, err
+	}
+	if len(data) > voiceAudioMaxBytes {
+		return voiceAudio{}, errors.New("voice audio is too large")
+	}
+	filename := objectKey
+	if idx := strings.LastIndex(filename, "/"); idx >= 0 {
+		filename = filename[idx+1:]
+	}
+	return voiceAudio{Data: data, Filename: filename}, nil
+}
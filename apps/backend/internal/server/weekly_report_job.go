@@ -0,0 +1,282 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const weeklyReportJobModelVersion = "weekly-job-v1"
+
+// weeklyReportJobWeekStarts are the week start days users can pick. The job
+// stores a row for each so getWeeklyReport finds one whichever the caller
+// uses.
+var weeklyReportJobWeekStarts = []time.Weekday{time.Monday, time.Sunday}
+
+type weeklyReportCandidate struct {
+	BabyID      string
+	HouseholdID string
+	Timezone    string
+}
+
+// weeklyReportPeriod is one completed week and the week before it. Key is
+// the "periodStart" getWeeklyReport looks the row up by.
+type weeklyReportPeriod struct {
+	PreviousStart time.Time
+	WeekStart     time.Time
+	WeekEnd       time.Time
+	Key           time.Time
+}
+
+// lastCompletedWeeklyReportPeriods returns the last completed week in loc
+// for every week start day users can pick.
+func lastCompletedWeeklyReportPeriods(now time.Time, loc *time.Location) []weeklyReportPeriod {
+	periods := make([]weeklyReportPeriod, 0, len(weeklyReportJobWeekStarts))
+	for _, weekStartsOn := range weeklyReportJobWeekStarts {
+		thisWeekStart := startOfLocalWeek(now.In(loc), weekStartsOn)
+		weekStart := thisWeekStart.AddDate(0, 0, -7)
+		periods = append(periods, weeklyReportPeriod{
+			PreviousStart: weekStart.AddDate(0, 0, -7).UTC(),
+			WeekStart:     weekStart.UTC(),
+			WeekEnd:       thisWeekStart.UTC(),
+			Key:           reportPeriodKey(weekStart),
+		})
+	}
+	return periods
+}
+
+// householdLocation resolves a stored household timezone, using UTC for
+// households that have not set one.
+func householdLocation(timezone string) *time.Location {
+	if loc, err := parseHouseholdTimezone(timezone); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// RunWeeklyReportScheduler stores last week's WEEKLY Report on a fixed
+// interval until ctx is canceled. Each pass only writes weeks that are not
+// stored yet, so the interval can be much shorter than a week.
+func (a *App) RunWeeklyReportScheduler(ctx context.Context) {
+	intervalMin := a.cfg.WeeklyReportJobIntervalMin
+	if intervalMin <= 0 {
+		intervalMin = 360
+	}
+	ticker := time.NewTicker(time.Duration(intervalMin) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		written, err := a.runWeeklyReportJob(ctx, time.Now().UTC())
+		if err != nil && ctx.Err() == nil {
+			log.Printf("weekly report job failed: %v", err)
+		} else if written > 0 {
+			log.Printf("weekly report job stored %d report(s)", written)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runWeeklyReportJob computes the WEEKLY Report for the last completed
+// Monday- and Sunday-start weeks in each household's timezone, which are the
+// rows getWeeklyReport reads. Weeks already stored, and babies without events
+// in the week or the one before, are skipped.
+func (a *App) runWeeklyReportJob(ctx context.Context, now time.Time) (int, error) {
+	candidates, err := a.loadWeeklyReportCandidates(ctx, now)
+	if err != nil && isMissingHouseholdTimezoneColumnErr(err) {
+		if ensureErr := a.ensureHouseholdTimezoneColumn(ctx); ensureErr != nil {
+			return 0, ensureErr
+		}
+		candidates, err = a.loadWeeklyReportCandidates(ctx, now)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	// One failing baby should not hold back the rest; the first error is
+	// reported and the baby is retried on the next pass.
+	written := 0
+	var firstErr error
+	for _, candidate := range candidates {
+		for _, period := range lastCompletedWeeklyReportPeriods(now, householdLocation(candidate.Timezone)) {
+			if err := ctx.Err(); err != nil {
+				return written, err
+			}
+			stored, err := a.storeWeeklyReport(ctx, candidate, period)
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("baby %s: %w", candidate.BabyID, err)
+				}
+				continue
+			}
+			if stored {
+				written++
+			}
+		}
+	}
+	return written, firstErr
+}
+
+// loadWeeklyReportCandidates returns babies with events in the last three
+// weeks, a window wide enough for any timezone and week start.
+func (a *App) loadWeeklyReportCandidates(ctx context.Context, now time.Time) ([]weeklyReportCandidate, error) {
+	rows, err := a.db.Query(
+		ctx,
+		`SELECT b.id, b."householdId", COALESCE(h.timezone, '')
+		 FROM "Baby" b
+		 JOIN "Household" h ON h.id = b."householdId"
+		 WHERE EXISTS (
+		     SELECT 1 FROM "Event" e
+		     WHERE e."babyId" = b.id
+		       AND e."deletedAt" IS NULL
+		       AND e."startTime" >= $1
+		       AND e."startTime" < $2
+		   )
+		 ORDER BY b.id`,
+		now.UTC().AddDate(0, 0, -22),
+		now.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	candidates := make([]weeklyReportCandidate, 0, 16)
+	for rows.Next() {
+		var candidate weeklyReportCandidate
+		if err := rows.Scan(&candidate.BabyID, &candidate.HouseholdID, &candidate.Timezone); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, rows.Err()
+}
+
+// storeWeeklyReport upserts one week's report. It reports false when the week
+// is already stored or the baby has no events in it or the week before.
+func (a *App) storeWeeklyReport(ctx context.Context, candidate weeklyReportCandidate, period weeklyReportPeriod) (bool, error) {
+	var alreadyStored, hasEvents bool
+	if err := a.db.QueryRow(
+		ctx,
+		`SELECT
+		   EXISTS (
+		     SELECT 1 FROM "Report"
+		     WHERE "babyId" = $1 AND "periodType" = 'WEEKLY' AND "periodStart" = $2
+		   ),
+		   EXISTS (
+		     SELECT 1 FROM "Event"
+		     WHERE "babyId" = $1
+		       AND "deletedAt" IS NULL
+		       AND "startTime" >= $3
+		       AND "startTime" < $4
+		   )`,
+		candidate.BabyID,
+		period.Key,
+		period.PreviousStart,
+		period.WeekEnd,
+	).Scan(&alreadyStored, &hasEvents); err != nil {
+		return false, err
+	}
+	if alreadyStored || !hasEvents {
+		return false, nil
+	}
+
+	currentMetrics, err := a.computeWeeklyMetrics(ctx, candidate.BabyID, period.WeekStart, period.WeekEnd)
+	if err != nil {
+		return false, err
+	}
+	previousMetrics, err := a.computeWeeklyMetrics(ctx, candidate.BabyID, period.PreviousStart, period.WeekStart)
+	if err != nil {
+		return false, err
+	}
+
+	trend := weeklyTrend(currentMetrics, previousMetrics)
+	metrics := map[string]any{
+		"trend":            trend,
+		"suggestions":      weeklyReportSuggestions(),
+		"feeding_total_ml": roundToOneDecimal(currentMetrics.FeedingML),
+		"sleep_total_min":  currentMetrics.SleepMinutes,
+		"feeding_split":    currentMetrics.Feedings,
+	}
+	summaryText := "Feeding total: " + strconv.Itoa(int(currentMetrics.FeedingML)) + " ml (" + toString(trend["feeding_total_ml"]) + ")\n" +
+		"Sleep total: " + strconv.Itoa(currentMetrics.SleepMinutes) + " minutes (" + toString(trend["sleep_total_min"]) + ")"
+
+	// Two API instances, or a pass that overlaps a slow one, may compute the
+	// same week; the unique key keeps a single row with the latest numbers.
+	upsert := func() error {
+		_, err := a.db.Exec(
+			ctx,
+			`INSERT INTO "Report" (
+				id, "householdId", "babyId", "periodType", "periodStart", "periodEnd", "metricsJson", "summaryText", "modelVersion", "createdAt"
+			) VALUES ($1, $2, $3, 'WEEKLY', $4, $5, $6, $7, $8, NOW())
+			ON CONFLICT ("babyId", "periodType", "periodStart") DO UPDATE SET
+				"periodEnd" = EXCLUDED."periodEnd",
+				"metricsJson" = EXCLUDED."metricsJson",
+				"summaryText" = EXCLUDED."summaryText",
+				"modelVersion" = EXCLUDED."modelVersion",
+				"createdAt" = NOW()`,
+			uuid.NewString(),
+			candidate.HouseholdID,
+			candidate.BabyID,
+			period.Key,
+			period.Key.AddDate(0, 0, 7),
+			mustMarshalJSON(metrics),
+			summaryText,
+			weeklyReportJobModelVersion,
+		)
+		return err
+	}
+	err = upsert()
+	if err != nil && isMissingReportPeriodUniqueIndexErr(err) {
+		if ensureErr := a.ensureReportPeriodUniqueIndex(ctx); ensureErr != nil {
+			return false, ensureErr
+		}
+		err = upsert()
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ensureReportPeriodUniqueIndex keeps the newest row of any duplicated
+// period, which is the one the report handlers already read, and adds the
+// unique key the job upserts on.
+func (a *App) ensureReportPeriodUniqueIndex(ctx context.Context) error {
+	tx, err := a.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	statements := []string{
+		`DELETE FROM "Report" r
+		 USING "Report" newer
+		 WHERE r."babyId" = newer."babyId"
+		   AND r."periodType" = newer."periodType"
+		   AND r."periodStart" = newer."periodStart"
+		   AND (r."createdAt", r.id) < (newer."createdAt", newer.id)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS "Report_babyId_periodType_periodStart_key"
+		 ON "Report"("babyId", "periodType", "periodStart")`,
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func isMissingReportPeriodUniqueIndexErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(err.Error()), "no unique or exclusion constraint")
+}